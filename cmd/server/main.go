@@ -3,23 +3,33 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 	"time"
+	// Fuseaux horaires des sondages de planification, absents de l'image alpine
+	_ "time/tzdata"
 
 	_ "microservice-go-gin/docs"
 	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/delivery/http/middleware"
 	"microservice-go-gin/internal/delivery/http/route"
+	"microservice-go-gin/internal/delivery/http/static"
+	"microservice-go-gin/internal/infrastructure/cache"
 	"microservice-go-gin/internal/infrastructure/database"
+	"microservice-go-gin/internal/infrastructure/logger"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // @title           QuickPoll API
@@ -42,24 +52,88 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+
 func main() {
+	// Sous-commandes : quickpoll migrate up|down [n]|status, quickpoll retention [--dry-run]
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(runMigrate(os.Args[2:]))
+		case "retention":
+			os.Exit(runRetention(os.Args[2:]))
+		}
+	}
+
 	// Charger la configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	// Configurer le logger structuré (JSON en production, texte en développement)
+	slog.SetDefault(logger.New(cfg.App))
+
+	slog.Info("starting QuickPoll API server", "environment", cfg.App.Environment)
+
+	// Initialiser le tracing OpenTelemetry
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.App)
+	if err != nil {
+		fatal("failed to set up tracing", err)
 	}
 
-	log.Println("Starting QuickPoll API server...")
+	// Initialiser la base de données (avec nouvelles tentatives tant qu'elle n'est pas joignable)
+	connectCtx, stopConnect := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	db, err := database.Connect(connectCtx, &cfg.Database)
+	stopConnect()
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+
+	// Exécuter les migrations, ou vérifier seulement que le schéma n'est pas plus récent que le binaire
+	if cfg.Database.AutoMigrate {
+		err = database.Migrate(db)
+	} else {
+		err = database.CheckSchema(context.Background(), db)
+	}
+	if err != nil {
+		fatal("failed to run migrations", err)
+	}
 
-	// Initialiser la base de données
-	db, err := database.NewConnection(&cfg.Database)
+	// Ouvrir les réplicas en lecture et suivre leur santé
+	replicas, err := database.OpenReplicas(&cfg.Database)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("failed to open read replicas", err)
 	}
+	reads := database.NewResolver(db, replicas...)
+	replicaCtx, stopReplicaChecks := context.WithCancel(context.Background())
+	go reads.Run(replicaCtx, cfg.Database.ReplicaCheckInterval)
 
-	// Exécuter les migrations
-	if err := database.Migrate(db); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+	// Politiques de rétention planifiées
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	if cfg.Retention.Enabled {
+		go newPurgeUseCase(db, cfg).Run(retentionCtx, cfg.Retention.Interval)
+	}
+
+	// Initialiser Redis si configuré, sinon un cache en mémoire
+	var store cache.Cache
+	if cfg.Redis.Enabled {
+		store, err = cache.NewRedisClient(&cfg.Redis)
+		if err != nil {
+			fatal("failed to connect to Redis", err)
+		}
+	} else {
+		store = cache.NewMemoryCache()
+	}
+
+	// Exposer les statistiques du pool de connexions
+	if sqlDB, err := db.DB(); err == nil {
+		if err := metrics.RegisterDBStats(sqlDB, cfg.Database.Name); err != nil {
+			slog.Warn("failed to register database metrics", "error", err)
+		}
 	}
 
 	// Configurer Gin
@@ -71,21 +145,25 @@ func main() {
 	r := gin.New()
 
 	// Middlewares globaux
-	r.Use(gin.Logger())
-	r.Use(gin.Recovery())
+	r.Use(otelgin.Middleware(cfg.App.Name))
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Logger())
+	r.Use(middleware.Recovery())
+	r.Use(middleware.Metrics())
 	r.Use(func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
-		
+
 		// Toujours définir les headers CORS de base
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, Idempotency-Key, Accept-Language")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Idempotent-Replayed, Content-Language")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		
+
 		// Gérer les origines autorisées
 		if cfg.App.Environment == "production" {
 			allowedOrigins := []string{cfg.Server.FrontendURL}
 			allowed := false
-			
+
 			// Vérifier les origines exactes et les domaines Vercel
 			for _, allowedOrigin := range allowedOrigins {
 				if origin == allowedOrigin || strings.HasSuffix(origin, ".vercel.app") {
@@ -93,18 +171,18 @@ func main() {
 					break
 				}
 			}
-			
+
 			if allowed {
 				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-				log.Printf("CORS: Authorized origin %s", origin)
+				slog.DebugContext(c.Request.Context(), "CORS origin authorized", "origin", origin)
 			} else {
-				log.Printf("CORS: Rejected origin %s (expected: %s)", origin, cfg.Server.FrontendURL)
+				slog.WarnContext(c.Request.Context(), "CORS origin rejected", "origin", origin, "expected", cfg.Server.FrontendURL)
 			}
 		} else {
 			// En développement, autoriser toutes les origines
 			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		}
-		
+
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
 			return
@@ -123,7 +201,13 @@ func main() {
 	if baseURL == "" {
 		baseURL = fmt.Sprintf("http://localhost:%d", cfg.Server.Port)
 	}
-	route.SetupRoutes(r, db, baseURL, cfg)
+	services := route.SetupRoutes(r, reads, baseURL, cfg, store)
+
+	// Frontend servi par l'API (mode standalone ou SERVER_STATIC_DIR)
+	if assets := frontendAssets(cfg); assets != nil {
+		static.Register(r, assets)
+		slog.Info("serving frontend", "url", baseURL)
+	}
 
 	// Créer le serveur HTTP
 	srv := &http.Server{
@@ -137,10 +221,10 @@ func main() {
 
 	// Démarrer le serveur dans une goroutine
 	go func() {
-		log.Printf("Server starting on port %d", cfg.Server.Port)
-		log.Printf("Swagger documentation: http://localhost:%d/swagger/index.html", cfg.Server.Port)
+		slog.Info("server starting", "port", cfg.Server.Port,
+			"swagger", fmt.Sprintf("http://localhost:%d/swagger/index.html", cfg.Server.Port))
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+			fatal("failed to start server", err)
 		}
 	}()
 
@@ -149,7 +233,11 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Server shutting down...")
+	slog.Info("server shutting down")
+
+	// Passer en non-ready pour que l'orchestrateur retire le pod avant le drain
+	services.Health.MarkShuttingDown()
+	time.Sleep(cfg.Server.ShutdownDelay)
 
 	// Graceful shutdown avec timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -157,14 +245,45 @@ func main() {
 
 	// Arrêter le serveur
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		fatal("server forced to shutdown", err)
 	}
 
-	// Fermer la connexion à la base de données
+	// Exporter les spans restants
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Warn("failed to flush traces", "error", err)
+	}
+
+	// Fermer le cache et la base de données
+	store.Close()
+	stopReplicaChecks()
+	stopRetention()
+	reads.Close()
 	sqlDB, err := db.DB()
 	if err == nil {
 		sqlDB.Close()
 	}
 
-	log.Println("Server exited")
+	slog.Info("server exited")
+}
+
+// frontendAssets returns the frontend build to serve, or nil when the
+// frontend is deployed separately
+func frontendAssets(cfg *config.Config) fs.FS {
+	if cfg.Server.StaticDir != "" {
+		return os.DirFS(cfg.Server.StaticDir)
+	}
+	if !cfg.App.Standalone {
+		return nil
+	}
+	assets := static.Embedded()
+	if assets == nil {
+		slog.Warn("standalone mode without embedded frontend, build with -tags embedfrontend or set SERVER_STATIC_DIR")
+	}
+	return assets
+}
+
+// fatal logs err and terminates the process
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
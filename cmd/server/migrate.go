@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/database"
+	"microservice-go-gin/internal/infrastructure/logger"
+)
+
+const migrateUsage = "usage: quickpoll migrate up | down [steps] | status"
+
+// runMigrate exécute la sous-commande migrate et renvoie le code de sortie
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	_, db, closeDB, err := openForCommand()
+	if err != nil {
+		return 1
+	}
+	defer closeDB()
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		slog.Error("failed to load migrations", "error", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			slog.Error("migration failed", "error", err)
+			return 1
+		}
+		fmt.Printf("%d migration(s) applied\n", len(applied))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				fmt.Fprintln(os.Stderr, migrateUsage)
+				return 2
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			slog.Error("rollback failed", "error", err)
+			return 1
+		}
+		fmt.Printf("%d migration(s) reverted\n", len(reverted))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			slog.Error("failed to read migration status", "error", err)
+			return 1
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		w.Flush()
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+	return 0
+}
+
+// openForCommand charge la configuration et ouvre la base pour une sous-commande.
+// Les erreurs sont déjà journalisées.
+func openForCommand() (*config.Config, *gorm.DB, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		return nil, nil, nil, err
+	}
+	slog.SetDefault(logger.New(cfg.App))
+
+	db, err := database.Connect(context.Background(), &cfg.Database)
+	if err != nil {
+		slog.Error("failed to connect to database", "error", err)
+		return nil, nil, nil, err
+	}
+	closeDB := func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+	return cfg, db, closeDB, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/database"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/retention"
+)
+
+// runRetention applique les politiques de rétention une fois, ou affiche leur effet avec --dry-run
+func runRetention(args []string) int {
+	flags := flag.NewFlagSet("retention", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report what would be purged without changing data")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, db, closeDB, err := openForCommand()
+	if err != nil {
+		return 1
+	}
+	defer closeDB()
+
+	report, err := newPurgeUseCase(db, cfg).Execute(context.Background(), retention.PurgeInput{DryRun: *dryRun})
+	if err != nil {
+		slog.Error("retention failed", "error", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	if report.DryRun {
+		fmt.Fprintln(w, "DRY RUN: no data was changed")
+	}
+	fmt.Fprintln(w, "POLICY\tCUTOFF\tROWS")
+	printPolicy(w, "soft-deleted polls", cfg.Retention.DeletedPollDays, report.DeletedPollsBefore, fmt.Sprintf("%d polls", report.DeletedPolls))
+	printPolicy(w, "voter data ("+cfg.Retention.VoterDataAction+")", cfg.Retention.VoterDataDays, report.VoterDataBefore, fmt.Sprintf("%d rows", report.AnonymizedBallots))
+	printPolicy(w, "closed polls archive", cfg.Retention.ArchiveClosedDays, report.ClosedBefore, fmt.Sprintf("%d polls, %d ballots", report.ArchivedPolls, report.ArchivedBallots))
+	w.Flush()
+	return 0
+}
+
+func printPolicy(w *tabwriter.Writer, name string, days int, cutoff time.Time, rows string) {
+	if days <= 0 {
+		fmt.Fprintf(w, "%s\tdisabled\t-\n", name)
+		return
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\n", name, cutoff.Format("2006-01-02 15:04"), rows)
+}
+
+// newPurgeUseCase construit le use case de rétention à partir de la configuration
+func newPurgeUseCase(db *gorm.DB, cfg *config.Config) *retention.PurgeUseCase {
+	retentionRepo := database.NewRetentionRepository(db, privacy.NewIPHasher(cfg.Privacy))
+	return retention.NewPurgeUseCase(retentionRepo, retention.Policy{
+		DeletedPollDays:   cfg.Retention.DeletedPollDays,
+		VoterDataDays:     cfg.Retention.VoterDataDays,
+		VoterDataAction:   repository.VoterDataAction(cfg.Retention.VoterDataAction),
+		ArchiveClosedDays: cfg.Retention.ArchiveClosedDays,
+		BatchSize:         cfg.Retention.BatchSize,
+	})
+}
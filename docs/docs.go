@@ -24,9 +24,62 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/polls": {
+        "/api/v1/admin/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Keys are listed with their prefix only, revoked and expired keys included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List API keys",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.APIKey"
+                            }
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Create a new poll with options",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The key is returned once and only its hash is stored. Scopes: polls:write, votes:read, export.",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,17 +87,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "polls"
+                    "admin"
                 ],
-                "summary": "Create a new poll",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "description": "Poll data",
-                        "name": "poll",
+                        "description": "API key",
+                        "name": "key",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/poll.CreatePollInput"
+                            "$ref": "#/definitions/admin.CreateAPIKeyInput"
                         }
                     }
                 ],
@@ -52,133 +105,313 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/poll.CreatePollOutput"
+                            "$ref": "#/definitions/admin.CreatedAPIKey"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/polls/{id}": {
-            "get": {
-                "description": "Get poll details with current results and vote counts",
-                "consumes": [
-                    "application/json"
+        "/api/v1/admin/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Requests carrying the key are refused from now on",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "polls"
+                    "admin"
                 ],
-                "summary": "Get poll details",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Poll ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/bans": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List bans",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Poll details with results",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Poll"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Ban"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Banned callers can no longer create polls or vote. IPv6 addresses are banned with their /64 network.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Ban an IP address or a user",
+                "parameters": [
+                    {
+                        "description": "Ban",
+                        "name": "ban",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.CreateBanInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Ban"
                         }
                     },
                     "400": {
-                        "description": "Invalid poll ID",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Poll not found",
+                    "409": {
+                        "description": "Already banned",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/polls/{id}/qr": {
-            "get": {
-                "description": "Generate QR code that links to the poll for easy sharing",
+        "/api/v1/admin/bans/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "produces": [
-                    "image/png"
+                    "application/json"
                 ],
                 "tags": [
-                    "polls"
+                    "admin"
                 ],
-                "summary": "Generate QR code for poll",
+                "summary": "Lift a ban",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Poll ID",
+                        "description": "Ban ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/polls": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List every poll, optionally filtered by status and by a text matching the title, the description or the ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List and search polls",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "closed",
+                            "deleted",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "open, closed, deleted or all",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "QR code image",
+                        "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/admin.PollPage"
                         }
                     },
                     "400": {
-                        "description": "Invalid poll ID",
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Failed to generate QR code",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/polls/{id}/vote": {
-            "post": {
-                "description": "Submit a vote for one or more options in a poll",
-                "consumes": [
-                    "application/json"
+        "/api/v1/admin/polls/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Soft-delete a poll; it is purged by the retention job",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "votes"
+                    "admin"
                 ],
-                "summary": "Submit a vote",
+                "summary": "Delete a poll",
                 "parameters": [
                     {
                         "type": "string",
@@ -187,210 +420,4504 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Vote data with option IDs",
-                        "name": "vote",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.VoteRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Vote submitted successfully",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handler.VoteResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request or voting error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.Option": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-01-15T10:00:00Z"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440001"
-                },
-                "order": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "poll_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "text": {
-                    "type": "string",
-                    "example": "Go"
-                },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2024-01-15T10:00:00Z"
+        },
+        "/api/v1/admin/polls/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Close a poll immediately; ballots already cast are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Force-close a poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Poll already closed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/polls/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Every recorded action on the poll, oldest first, deleted polls included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Audit history of a poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/poll.HistoryPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/polls/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Tallies per option, the number of responses of a text poll or of estimates of a numeric poll, unique voters and first/last ballot dates, deleted polls included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Vote statistics of a poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.PollStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/system": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "WebSocket hub rooms and clients, database pool usage, replicas and record counts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "System statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SystemStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/data": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export every ballot cast and every poll, survey and quiz created by the current voter. An API key or a JWT is required: an IP address alone may be shared by many people. Ballots are found by the IP address of the caller and exported without IP address or user agent; polls are found by principal and by IP address.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Export my data",
+                "responses": {
+                    "200": {
+                        "description": "Data of the current voter",
+                        "schema": {
+                            "$ref": "#/definitions/datasubject.Export"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Export failed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the ballots of the current voter in closed polls and finished quizzes and detach the polls, surveys and quizzes they created. Results of closed polls are preserved. Ballots of polls and quizzes still open are anonymized instead: they keep counting and still prevent a second vote. An API key or a JWT is required: an IP address alone may be shared by many people.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "privacy"
+                ],
+                "summary": "Erase my data",
+                "responses": {
+                    "200": {
+                        "description": "Records affected",
+                        "schema": {
+                            "$ref": "#/definitions/repository.ErasureResult"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erasure failed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new poll with options. Services authenticate with an API key (Authorization: Bearer qp_...) holding the polls:write scope; the poll is then attributed to the key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Create a new poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Poll data",
+                        "name": "poll",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/poll.CreatePollInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/poll.CreatePollOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}": {
+            "get": {
+                "description": "Get poll details with current results and vote counts. Multilingual polls are rendered in the locale requested by lang, else by Accept-Language, else in their own language; option IDs and counts are shared by every locale.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Get poll details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale of the content, e.g. fr",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Poll details with results",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Poll"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid poll ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the title, description, expiry or options of an open poll. Reserved to its creator; options can only change before the first vote.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Edit a poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "poll",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/poll.UpdatePollInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Poll"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Options locked by votes",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Poll closed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/availability": {
+            "post": {
+                "description": "Answer yes, if_need_be or no to every time slot of the poll under a display name, which is published with the results. The slots ranked again are pushed on the websocket of the poll.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Answer a scheduling poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Availability",
+                        "name": "availability",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/vote.SubmitAvailabilityInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Availability"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or unknown time slot, or not a scheduling poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already answered, or Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Poll expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/calendar.ics": {
+            "get": {
+                "description": "iCalendar file with one event for the time slot the most participants are available for, if need be.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Export the winning slot of a scheduling poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Not a scheduling poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found, or nobody available yet",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/estimates": {
+            "post": {
+                "description": "Submit a numeric estimate between the min and max of the poll, on one of its steps. The updated results are pushed on the websocket of the poll; while a planning poker poll hides its estimates, only their count is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "estimates"
+                ],
+                "summary": "Answer a numeric poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Estimate",
+                        "name": "estimate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/vote.CreateEstimateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Estimate"
+                        }
+                    },
+                    "400": {
+                        "description": "Estimate out of range, or not a numeric poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already estimated, or Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Poll expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "CSV with one line per option and its vote count. Reserved to the creator; API keys need the export scope.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Export the results of a poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/has-voted": {
+            "get": {
+                "description": "Check if the current user (by IP) has already voted in this poll",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "votes"
+                ],
+                "summary": "Check if user has voted",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Voting status",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HasVotedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid poll ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/history": {
+            "get": {
+                "description": "Who created, edited, closed or deleted the poll and when, oldest first. Reserved to its creator.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Audit history of a poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/poll.HistoryPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/options/{option_id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a pending write-in option, which is then published on the websocket of the poll, or reject it: the option and its ballots are deleted. Reserved to the creator of the poll.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Moderate a write-in option",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Option ID",
+                        "name": "option_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "moderation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/poll.ModerateOptionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Option"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll or pending option not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/qr": {
+            "get": {
+                "description": "Generate QR code that links to the poll for easy sharing",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Generate QR code for poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid poll ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate QR code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/responses": {
+            "get": {
+                "description": "Approved responses of a text poll, newest first. Pending and rejected responses are reserved to the creator of the poll.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "responses"
+                ],
+                "summary": "Text responses of a poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "approved",
+                            "pending",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "approved",
+                        "description": "Status of the responses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/poll.ResponsePage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Submit a free-text response of at most 500 characters, stripped of markup and control characters. Responses to a moderated poll stay pending until its creator approves them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "responses"
+                ],
+                "summary": "Answer a text poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Response text",
+                        "name": "response",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/vote.CreateResponseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TextResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid text, or not a text poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already answered, or Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Poll expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/responses/{response_id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve or reject a response of a text poll. Reserved to its creator; approved responses are published on the websocket of the poll.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "responses"
+                ],
+                "summary": "Moderate a text response",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Response ID",
+                        "name": "response_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "moderation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/poll.ModerateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TextResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll or response not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/reveal": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publish the distribution of the estimates hidden until now, also pushed on the websocket of the poll. Reserved to the creator of the poll.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "estimates"
+                ],
+                "summary": "Reveal the estimates of a planning poker poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.EstimateResults"
+                        }
+                    },
+                    "400": {
+                        "description": "Not a numeric poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Estimates already revealed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/vote": {
+            "post": {
+                "description": "Submit a vote for one or more options in a poll. Polls with allow_write_in also accept a write_in text: it votes for the option with the same text regardless of case, or adds it, pending the approval of the creator when the poll is moderated. On a sign-up sheet, a vote for a full option is rejected, or put on the waitlist of the option when the poll has one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "votes"
+                ],
+                "summary": "Submit a vote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Vote data with option IDs",
+                        "name": "vote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.VoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vote submitted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.VoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or option",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already voted, option limit reached, write-in awaiting moderation, option full, or Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Poll expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Withdraw the vote of the current user (by IP) from a sign-up sheet, and their places in waitlists. The freed places go to the first waitlisted voters.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "votes"
+                ],
+                "summary": "Withdraw a vote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vote withdrawn successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.WithdrawVoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid poll ID or survey question",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll or vote not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Not a sign-up sheet",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Poll expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/votes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Every ballot with its option and date, without voter identity. Reserved to the creator; API keys need the votes:read scope.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Ballots of a poll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/poll.Ballot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/polls/{id}/write-ins": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Options written in by voters on a moderated poll, with their votes so far. Reserved to the creator of the poll.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "polls"
+                ],
+                "summary": "Write-in options awaiting approval",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Poll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Option"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the poll",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Poll not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quizzes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a quiz of timed questions, each with its correct options (by position) and a time limit of 5 to 300 seconds, 20 by default. A question with several correct options expects all of them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Create a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Quiz data",
+                        "name": "quiz",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/quiz.CreateQuizInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/quiz.CreateQuizOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quizzes/{id}": {
+            "get": {
+                "description": "Get the state of a quiz and the questions started so far. The correct options of the current question stay hidden until it is over.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Get quiz details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quiz ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quizzes/{id}/answers": {
+            "post": {
+                "description": "Answer the question being played, once per player. The faster a correct answer, the more points: 1000 at once down to 500 at the time limit. Answers after the time limit or once the question is closed are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Answer the current question of a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player ID, question ID and chosen options",
+                        "name": "answer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/quiz.AnswerQuestionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.QuizAnswer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid options",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz or player not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already answered, question not started, or quiz over",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Time to answer is over",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quizzes/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End the current question before its time limit and publish its results and the leaderboard. Reserved to the creator of the quiz.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Close the current question of a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/quiz.QuizStep"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quiz ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the quiz",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "No question started, or quiz over",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Question already closed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quizzes/{id}/leaderboard": {
+            "get": {
+                "description": "Rank the players by score; players with the same score share their rank. The answers to the current question only count once it is over.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Get the leaderboard of a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Leaderboard"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quiz ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quizzes/{id}/next": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close the current question if still open, then start the next one, or finish the quiz after the last one. Every step is pushed on the websocket of the quiz. Reserved to the creator of the quiz.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Move a quiz to its next question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/quiz.QuizStep"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quiz ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the quiz",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Quiz over, or moved on by a concurrent request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quizzes/{id}/players": {
+            "post": {
+                "description": "Join a quiz under a nickname, unique within the quiz whatever its case. The returned player ID is needed to answer: keep it private.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Join a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nickname",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/quiz.JoinQuizInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.QuizPlayer"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or too long nickname",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Quiz not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Nickname taken, or quiz over",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quizzes/{id}/qr": {
+            "get": {
+                "description": "Generate QR code that links to the quiz, for the players to join from the room",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Generate QR code for quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quiz ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate QR code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/surveys": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a survey of ordered questions, each with its own options and multi-choice setting. Questions may be optional or only asked when an earlier answer matches (show_if, by position).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "surveys"
+                ],
+                "summary": "Create a survey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Survey data",
+                        "name": "survey",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/survey.CreateSurveyInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/survey.CreateSurveyOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/surveys/{id}": {
+            "get": {
+                "description": "Get the questions of a survey in order, with the results of each question",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "surveys"
+                ],
+                "summary": "Get survey details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Survey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Survey"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid survey ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Survey not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/surveys/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "CSV with one line per option of every question and its vote count. Reserved to the creator; API keys need the export scope.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "surveys"
+                ],
+                "summary": "Export the results of a survey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Survey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the creator of the survey",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Survey not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/surveys/{id}/has-responded": {
+            "get": {
+                "description": "Check if the current user (by IP) has already answered this survey",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "surveys"
+                ],
+                "summary": "Check if user has answered a survey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Survey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HasRespondedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid survey ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Survey not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/surveys/{id}/qr": {
+            "get": {
+                "description": "Generate QR code that links to the survey, all questions included",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "surveys"
+                ],
+                "summary": "Generate QR code for survey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Survey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid survey ID",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate QR code",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/surveys/{id}/responses": {
+            "post": {
+                "description": "Submit the answers to every question at once: when one answer is rejected, none is recorded. Required questions must be answered unless hidden by their condition.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "surveys"
+                ],
+                "summary": "Answer a survey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Survey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Option IDs keyed by question ID",
+                        "name": "response",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SurveyResponseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SurveyResponseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing, hidden or invalid answers",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Survey not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already answered, or Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "Survey expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Kept for backward compatibility, equivalent to /livez",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Legacy health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/livez": {
+            "get": {
+                "description": "Reports that the process is running. Dependencies are not checked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks the SQL pool, Redis, the WebSocket hub and the schema version",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "admin.CreateAPIKeyInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 525600
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "sprint-retro-bot"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "polls:write",
+                        "votes:read"
+                    ]
+                }
+            }
+        },
+        "admin.CreateBanInput": {
+            "type": "object",
+            "required": [
+                "kind",
+                "value"
+            ],
+            "properties": {
+                "expires_in": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1440
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "ip",
+                        "user"
+                    ],
+                    "example": "ip"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "vote flooding"
+                },
+                "value": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "203.0.113.7"
+                }
+            }
+        },
+        "admin.CreatedAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "api-key:ops"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-15T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "qp_Zk3v9QaB..."
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "sprint-retro-bot"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "qp_Zk3v9QaB"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "polls:write",
+                        "votes:read"
+                    ]
+                }
+            }
+        },
+        "admin.PollPage": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "polls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Poll"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "apperror.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "title"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "title must be at least 3 characters long"
+                },
+                "param": {
+                    "description": "Param is the parameter of the rule, e.g. the minimum length",
+                    "type": "string",
+                    "example": "3"
+                },
+                "rule": {
+                    "description": "Rule is the failed rule, e.g. \"required\", \"min\", \"duplicate\"",
+                    "type": "string",
+                    "example": "min"
+                }
+            }
+        },
+        "database.ReplicaStatus": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "datasubject.Export": {
+            "type": "object",
+            "properties": {
+                "availabilities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datasubject.ExportedAvailability"
+                    }
+                },
+                "estimates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datasubject.ExportedEstimate"
+                    }
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "polls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Poll"
+                    }
+                },
+                "quiz_players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datasubject.ExportedQuizPlayer"
+                    }
+                },
+                "quizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Quiz"
+                    }
+                },
+                "responses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datasubject.ExportedResponse"
+                    }
+                },
+                "surveys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Survey"
+                    }
+                },
+                "voter_id": {
+                    "type": "string"
+                },
+                "votes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datasubject.ExportedVote"
+                    }
+                },
+                "waitlist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datasubject.ExportedWaitlistEntry"
+                    }
+                }
+            }
+        },
+        "datasubject.ExportedAvailability": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "option_id": {
+                    "type": "string"
+                },
+                "poll_id": {
+                    "type": "string"
+                },
+                "poll_title": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "string"
+                }
+            }
+        },
+        "datasubject.ExportedEstimate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "poll_id": {
+                    "type": "string"
+                },
+                "poll_title": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "datasubject.ExportedQuizPlayer": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "quiz_id": {
+                    "type": "string"
+                },
+                "quiz_title": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "datasubject.ExportedResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "poll_id": {
+                    "type": "string"
+                },
+                "poll_title": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "datasubject.ExportedVote": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "option_id": {
+                    "type": "string"
+                },
+                "option_text": {
+                    "type": "string"
+                },
+                "poll_id": {
+                    "type": "string"
+                },
+                "poll_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "datasubject.ExportedWaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "option_id": {
+                    "type": "string"
+                },
+                "option_text": {
+                    "type": "string"
+                },
+                "poll_id": {
+                    "type": "string"
+                },
+                "poll_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "api-key:ops"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-15T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "sprint-retro-bot"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "qp_Zk3v9QaB"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "polls:write",
+                        "votes:read"
+                    ]
+                }
+            }
+        },
+        "entity.Availability": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string",
+                    "example": "yes"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440005"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Alice"
+                },
+                "option_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "poll_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "entity.Ban": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "api-key:ops"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-16T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "ip"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "vote flooding"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                }
+            }
+        },
+        "entity.Bucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "from": {
+                    "type": "number",
+                    "example": 0
+                },
+                "to": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "entity.Condition": {
+            "type": "object",
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "question": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "entity.Distribution": {
+            "type": "object",
+            "properties": {
+                "histogram": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Bucket"
+                    }
+                },
+                "max": {
+                    "type": "number",
+                    "example": 13
+                },
+                "mean": {
+                    "type": "number",
+                    "example": 5.4
+                },
+                "median": {
+                    "type": "number",
+                    "example": 5
+                },
+                "min": {
+                    "type": "number",
+                    "example": 1
+                },
+                "q1": {
+                    "type": "number",
+                    "example": 3
+                },
+                "q3": {
+                    "type": "number",
+                    "example": 8
+                },
+                "std_dev": {
+                    "type": "number",
+                    "example": 3.2
+                }
+            }
+        },
+        "entity.Estimate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440004"
+                },
+                "poll_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 8
+                }
+            }
+        },
+        "entity.EstimateResults": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "hidden": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "stats": {
+                    "$ref": "#/definitions/entity.Distribution"
+                }
+            }
+        },
+        "entity.Leaderboard": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.LeaderboardEntry"
+                    }
+                },
+                "final": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "entity.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "correct": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Ada"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 2750
+                }
+            }
+        },
+        "entity.NumericSettings": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "hide_until_reveal": {
+                    "description": "HideUntilReveal keeps the estimates secret until the creator reveals\nthem, as in planning poker",
+                    "type": "boolean",
+                    "example": false
+                },
+                "max": {
+                    "type": "number",
+                    "example": 100
+                },
+                "min": {
+                    "type": "number",
+                    "example": 0
+                },
+                "step": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "entity.Option": {
+            "type": "object",
+            "required": [
+                "poll_id",
+                "text"
+            ],
+            "properties": {
+                "correct": {
+                    "description": "Correct marks the right answers of a quiz question",
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2024-01-15T11:00:00+01:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "max_votes": {
+                    "description": "MaxVotes caps the votes of an option of a sign-up sheet, unlimited when nil",
+                    "type": "integer",
+                    "example": 20
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "pending": {
+                    "description": "Pending write-in options wait for the approval of the creator of the poll",
+                    "type": "boolean",
+                    "example": false
+                },
+                "poll_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "remaining": {
+                    "description": "Remaining and Waitlisted are the places left and the voters waiting\nfor one, for options with MaxVotes",
+                    "type": "integer",
+                    "example": 3
+                },
+                "starts_at": {
+                    "description": "StartsAt and EndsAt bound the time slot of a scheduling poll",
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00+01:00"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "Go"
+                },
+                "translations": {
+                    "description": "Translations holds the text of the option keyed by locale",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "fr": "Go"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "vote_count": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "waitlisted": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "entity.OptionAnswers": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "option_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                }
+            }
+        },
+        "entity.Poll": {
+            "type": "object",
+            "required": [
+                "options",
+                "title"
+            ],
+            "properties": {
+                "allow_write_in": {
+                    "description": "AllowWriteIn lets voters add an option of their own when they vote",
+                    "type": "boolean",
+                    "example": false
+                },
+                "archived_at": {
+                    "type": "string",
+                    "example": "2024-02-15T10:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "user123"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Choose your preferred programming language"
+                },
+                "estimates": {
+                    "description": "Estimates are the results of a numeric poll",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.EstimateResults"
+                        }
+                    ]
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-16T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "language": {
+                    "description": "Language is the locale of the title, description and option texts,\nempty for polls without translations",
+                    "type": "string",
+                    "example": "en"
+                },
+                "locale": {
+                    "description": "Locale is the locale the poll is rendered in after Localize",
+                    "type": "string",
+                    "example": "fr"
+                },
+                "moderated": {
+                    "description": "Moderated polls only publish the text responses and the write-in\noptions approved by their creator",
+                    "type": "boolean",
+                    "example": false
+                },
+                "multi_choice": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "numeric": {
+                    "description": "Numeric holds the bounds of a numeric poll",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.NumericSettings"
+                        }
+                    ]
+                },
+                "optional": {
+                    "description": "Optional questions of a survey may be left unanswered",
+                    "type": "boolean",
+                    "example": false
+                },
+                "options": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/entity.Option"
+                    }
+                },
+                "position": {
+                    "description": "Position orders the questions of a survey or a quiz",
+                    "type": "integer",
+                    "example": 0
+                },
+                "quiz_id": {
+                    "description": "QuizID is set on the questions of a quiz, which are only answered\nthrough it while the presenter shows them",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "require_auth": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "response_count": {
+                    "description": "ResponseCount and Terms are the results of a text poll: published\nresponses and their most frequent terms, for word clouds",
+                    "type": "integer",
+                    "example": 12
+                },
+                "revealed_at": {
+                    "description": "RevealedAt is when the creator revealed hidden estimates",
+                    "type": "string",
+                    "example": "2024-01-15T11:00:00Z"
+                },
+                "show_if": {
+                    "description": "ShowIf asks the question only for some answers to an earlier question",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Condition"
+                        }
+                    ]
+                },
+                "slots": {
+                    "description": "Slots are the time slots of a scheduling poll, ranked by availability",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SlotResult"
+                    }
+                },
+                "survey_id": {
+                    "description": "SurveyID is set on the questions of a survey, which are only\nanswered through it",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Term"
+                    }
+                },
+                "time_limit": {
+                    "description": "TimeLimit is the number of seconds the players have to answer a\nquestion of a quiz",
+                    "type": "integer",
+                    "example": 20
+                },
+                "time_zone": {
+                    "description": "TimeZone is the IANA time zone the slots of a scheduling poll are\nshown in, UTC when empty",
+                    "type": "string",
+                    "example": "Europe/Paris"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "What's your favorite programming language?"
+                },
+                "translations": {
+                    "description": "Translations holds the title and description in other locales",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/entity.PollTranslation"
+                    }
+                },
+                "type": {
+                    "description": "Type is PollTypeChoice (options), PollTypeText (free-text responses),\nPollTypeNumeric (numeric estimates) or PollTypeSchedule (time slots)",
+                    "type": "string",
+                    "example": "choice"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "waitlist": {
+                    "description": "Waitlist queues the voters of full options instead of turning them\naway, for polls whose options have a capacity",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "entity.PollTranslation": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Choisissez votre langage préféré"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Quel est votre langage de programmation préféré ?"
+                }
+            }
+        },
+        "entity.QuestionResult": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "description": "Answers and CorrectAnswers count the players who answered, and\nanswered right",
+                    "type": "integer",
+                    "example": 24
+                },
+                "correct": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "correct_answers": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OptionAnswers"
+                    }
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "question_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "entity.QuestionStart": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:20Z"
+                },
+                "question": {
+                    "$ref": "#/definitions/entity.Poll"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "time_limit": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "entity.Quiz": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "user123"
+                },
+                "current_question": {
+                    "description": "CurrentQuestion is the position of the last question started, nil\nbefore the first one",
+                    "type": "integer",
+                    "example": 0
+                },
+                "description": {
+                    "type": "string",
+                    "example": "How well do you know our tooling?"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:15:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "question_closed_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:20Z"
+                },
+                "question_ends_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:20Z"
+                },
+                "question_started_at": {
+                    "description": "QuestionStartedAt and QuestionEndsAt bound the time given to answer\nthe current question; QuestionClosedAt is set once it is over",
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Poll"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Onboarding quiz"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                }
+            }
+        },
+        "entity.QuizAnswer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:04Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440007"
+                },
+                "option_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "question_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "response_time_ms": {
+                    "description": "ResponseTime is the number of milliseconds between the start of the\nquestion and the answer",
+                    "type": "integer",
+                    "example": 4200
+                }
+            }
+        },
+        "entity.QuizPlayer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440006"
+                },
+                "nickname": {
+                    "type": "string",
+                    "example": "Ada"
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                }
+            }
+        },
+        "entity.SlotResult": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2024-01-15T11:00:00+01:00"
+                },
+                "if_need_be": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "no": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "option_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00+01:00"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Mon 15 Jan 2024 10:00–11:00 CET"
+                },
+                "yes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.Survey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "user123"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Help us plan the next offsite"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-16T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Poll"
+                    }
+                },
+                "require_auth": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Team offsite"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                }
+            }
+        },
+        "entity.Term": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "term": {
+                    "type": "string",
+                    "example": "onboarding"
+                }
+            }
+        },
+        "entity.TextResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "moderated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T11:00:00Z"
+                },
+                "poll_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "approved"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Faster onboarding"
+                }
+            }
+        },
+        "entity.WaitlistEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440004"
+                },
+                "option_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "poll_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "rank": {
+                    "description": "Rank is the place of the entry in the waitlist of its option, from 1",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handler.CheckResult": {
+            "type": "object",
+            "properties": {
+                "details": {},
+                "error": {
+                    "type": "string",
+                    "example": "context deadline exceeded"
+                },
+                "latency_ms": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "handler.HasRespondedResponse": {
+            "type": "object",
+            "properties": {
+                "has_responded": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handler.HasVotedResponse": {
+            "type": "object",
+            "properties": {
+                "has_voted": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handler.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handler.CheckResult"
+                    }
+                },
+                "service": {
+                    "type": "string",
+                    "example": "QuickPoll API"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ready"
+                }
+            }
+        },
+        "handler.PoolStats": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "in_use": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "max_open_connections": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "open_connections": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "wait_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "wait_duration_ms": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "handler.SurveyResponseRequest": {
+            "type": "object",
+            "required": [
+                "answers"
+            ],
+            "properties": {
+                "answers": {
+                    "description": "Answers holds the chosen option IDs keyed by question ID",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "handler.SurveyResponseResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "survey response submitted successfully"
+                }
+            }
+        },
+        "handler.SystemStats": {
+            "type": "object",
+            "properties": {
+                "db_pool": {
+                    "$ref": "#/definitions/handler.PoolStats"
+                },
+                "goroutines": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "replicas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.ReplicaStatus"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/repository.Totals"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "websocket": {
+                    "$ref": "#/definitions/websocket.HubStats"
+                }
+            }
+        },
+        "handler.VoteRequest": {
+            "type": "object",
+            "properties": {
+                "option_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440001"
+                    ]
+                },
+                "write_in": {
+                    "description": "WriteIn adds an option of the voter's own when the poll allows it",
+                    "type": "string",
+                    "example": "Zig"
+                }
+            }
+        },
+        "handler.VoteResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "vote submitted successfully"
+                },
+                "waitlisted": {
+                    "description": "Waitlisted are the places of the voter in the waitlists of the full\noptions of a sign-up sheet",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WaitlistEntry"
+                    }
+                },
+                "write_in": {
+                    "description": "WriteIn is the option written in by the voter, pending when the poll is moderated",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Option"
+                        }
+                    ]
+                }
+            }
+        },
+        "handler.WithdrawVoteResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "vote withdrawn successfully"
+                },
+                "promoted": {
+                    "description": "Promoted is the number of waitlisted voters given the freed places",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "poll.Ballot": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "option_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "poll.CreatePollInput": {
+            "type": "object",
+            "required": [
+                "options",
+                "title"
+            ],
+            "properties": {
+                "allow_write_in": {
+                    "description": "AllowWriteIn lets voters add an option of their own, up to 10 options",
+                    "type": "boolean",
+                    "example": false
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Choose your preferred programming language"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 1,
+                    "example": 60
+                },
+                "language": {
+                    "description": "Language is the locale of the title, description and options,\nrequired to add translations",
+                    "type": "string",
+                    "example": "en"
+                },
+                "max_votes": {
+                    "description": "MaxVotes turns a choice poll into a sign-up sheet: the capacity of each\noption, in the order of options, 0 for no limit",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        20,
+                        20,
+                        0
+                    ]
+                },
+                "moderated": {
+                    "description": "Moderated holds the responses of a text poll, or the write-in\noptions, until the creator approves them",
+                    "type": "boolean",
+                    "example": false
+                },
+                "multi_choice": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "numeric": {
+                    "description": "Numeric sets the bounds, step and histogram buckets of a numeric poll",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.NumericSettings"
+                        }
+                    ]
+                },
+                "options": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 2,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Go",
+                        "Python",
+                        "JavaScript",
+                        "Rust"
+                    ]
+                },
+                "require_auth": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "slots": {
+                    "description": "Slots are the time slots of a scheduling poll, with their UTC offset",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/poll.SlotInput"
+                    }
+                },
+                "time_zone": {
+                    "description": "TimeZone is the IANA time zone the slots of a scheduling poll are\nshown in, UTC by default",
+                    "type": "string",
+                    "example": "Europe/Paris"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "What's your favorite programming language?"
+                },
+                "translations": {
+                    "description": "Translations of the poll keyed by locale, e.g. \"fr\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/poll.TranslationInput"
+                    }
+                },
+                "type": {
+                    "description": "Type is \"choice\", the default, \"text\" for free-text responses,\n\"numeric\" for estimates or \"schedule\" for time slots; only choice\npolls take options",
+                    "type": "string",
+                    "enum": [
+                        "choice",
+                        "text",
+                        "numeric",
+                        "schedule"
+                    ],
+                    "example": "choice"
+                },
+                "waitlist": {
+                    "description": "Waitlist queues the voters of full options instead of turning them away",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "poll.CreatePollOutput": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "qr_code_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/v1/polls/550e8400-e29b-41d4-a716-446655440000/qr"
+                },
+                "share_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/poll/550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "poll.HistoryEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "poll.updated"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "creator:sha256:4f1c..."
+                },
+                "changes": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "poll.HistoryPage": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/poll.HistoryEntry"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "poll.ModerateInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "approved"
+                }
+            }
+        },
+        "poll.ModerateOptionInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "approved"
+                }
+            }
+        },
+        "poll.ResponsePage": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "responses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TextResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "poll.SlotInput": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2024-01-15T11:00:00+01:00"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:00:00+01:00"
+                }
+            }
+        },
+        "poll.TranslationInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Choisissez votre langage préféré"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Go",
+                        "Python",
+                        "JavaScript",
+                        "Rust"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Quel est votre langage de programmation préféré ?"
+                }
+            }
+        },
+        "poll.UpdatePollInput": {
+            "type": "object",
+            "required": [
+                "options"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Choose your preferred programming language"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 1,
+                    "example": 60
+                },
+                "language": {
+                    "description": "Language and Translations replace the locales of the poll; translations\nmust be sent again when the options change",
+                    "type": "string",
+                    "example": "en"
+                },
+                "options": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 2,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Go",
+                        "Python",
+                        "JavaScript",
+                        "Rust"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "What's your favorite programming language?"
                 },
-                "vote_count": {
-                    "type": "integer",
-                    "example": 5
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/poll.TranslationInput"
+                    }
                 }
             }
         },
-        "entity.Poll": {
+        "problem.Problem": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "code": {
                     "type": "string",
-                    "example": "2024-01-15T10:00:00Z"
+                    "example": "poll_not_found"
                 },
-                "created_by": {
+                "detail": {
                     "type": "string",
-                    "example": "user123"
+                    "example": "poll not found"
                 },
-                "description": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apperror.FieldError"
+                    }
+                },
+                "instance": {
                     "type": "string",
-                    "example": "Choose your preferred programming language"
+                    "example": "/api/v1/polls/550e8400-e29b-41d4-a716-446655440000"
                 },
-                "expires_at": {
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
                     "type": "string",
-                    "example": "2024-01-16T10:00:00Z"
+                    "example": "Not Found"
                 },
-                "id": {
+                "type": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "https://quickpoll.example.com/problems/poll_not_found"
+                }
+            }
+        },
+        "quiz.AnswerQuestionInput": {
+            "type": "object",
+            "required": [
+                "player_id",
+                "question_id"
+            ],
+            "properties": {
+                "option_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440001"
+                    ]
                 },
-                "multi_choice": {
-                    "type": "boolean",
-                    "example": false
+                "player_id": {
+                    "description": "PlayerID is the ID returned to the player when they joined",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440006"
                 },
-                "options": {
+                "question_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "quiz.CreateQuizInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "How well do you know our tooling?"
+                },
+                "questions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Option"
+                        "$ref": "#/definitions/quiz.QuestionInput"
                     }
                 },
-                "require_auth": {
-                    "type": "boolean",
-                    "example": false
-                },
                 "title": {
                     "type": "string",
-                    "example": "What's your favorite programming language?"
+                    "example": "Onboarding quiz"
+                }
+            }
+        },
+        "quiz.CreateQuizOutput": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
                 },
-                "updated_at": {
+                "qr_code_url": {
                     "type": "string",
-                    "example": "2024-01-15T10:00:00Z"
+                    "example": "http://localhost:8080/api/v1/quizzes/550e8400-e29b-41d4-a716-446655440003/qr"
+                },
+                "share_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/quiz/550e8400-e29b-41d4-a716-446655440003"
                 }
             }
         },
-        "handler.VoteRequest": {
+        "quiz.JoinQuizInput": {
             "type": "object",
-            "required": [
-                "option_ids"
-            ],
             "properties": {
-                "option_ids": {
+                "nickname": {
+                    "type": "string",
+                    "example": "Ada"
+                }
+            }
+        },
+        "quiz.QuestionInput": {
+            "type": "object",
+            "properties": {
+                "correct": {
+                    "description": "Correct lists the positions of the correct options; with several,\nplayers must pick all of them",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        0
+                    ]
+                },
+                "options": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "550e8400-e29b-41d4-a716-446655440001"
+                        "gofmt",
+                        "go vet",
+                        "golint"
                     ]
+                },
+                "time_limit": {
+                    "description": "TimeLimit is the number of seconds to answer, 20 when omitted",
+                    "type": "integer",
+                    "example": 20
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Which command formats Go code?"
                 }
             }
         },
-        "handler.VoteResponse": {
+        "quiz.QuizStep": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "vote submitted successfully"
+                "ended": {
+                    "$ref": "#/definitions/entity.QuestionResult"
+                },
+                "leaderboard": {
+                    "$ref": "#/definitions/entity.Leaderboard"
+                },
+                "started": {
+                    "$ref": "#/definitions/entity.QuestionStart"
                 }
             }
         },
-        "poll.CreatePollInput": {
+        "repository.ErasureResult": {
+            "type": "object",
+            "properties": {
+                "anonymized_ballots": {
+                    "description": "AnonymizedBallots were cast in polls and quizzes still open: they keep\ncounting and block a second ballot, but lose their IP address, user agent\nand voter ID in clear",
+                    "type": "integer"
+                },
+                "anonymized_polls": {
+                    "description": "AnonymizedPolls were created by the subject and no longer reference them",
+                    "type": "integer"
+                },
+                "anonymized_quizzes": {
+                    "description": "AnonymizedQuizzes were created by the subject and no longer reference them",
+                    "type": "integer"
+                },
+                "anonymized_surveys": {
+                    "description": "AnonymizedSurveys were created by the subject and no longer reference them",
+                    "type": "integer"
+                },
+                "archived_votes": {
+                    "description": "ArchivedVotes were cast in closed polls: folded into the option totals, then deleted",
+                    "type": "integer"
+                },
+                "deleted_availabilities": {
+                    "description": "DeletedAvailabilities are the answers of the subject to time slots of closed polls",
+                    "type": "integer"
+                },
+                "deleted_estimates": {
+                    "description": "DeletedEstimates are the numeric estimates of the subject in closed polls",
+                    "type": "integer"
+                },
+                "deleted_quiz_players": {
+                    "description": "DeletedQuizPlayers are the players of finished quizzes the subject joined as, with their answers",
+                    "type": "integer"
+                },
+                "deleted_responses": {
+                    "description": "DeletedResponses are the free-text responses of the subject in closed polls, whatever their status",
+                    "type": "integer"
+                },
+                "deleted_waitlist_entries": {
+                    "description": "DeletedWaitlistEntries are the places of the subject in the waitlists of closed sign-up sheets",
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.OptionStats": {
+            "type": "object",
+            "properties": {
+                "option_id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "votes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.PollStats": {
+            "type": "object",
+            "properties": {
+                "archived_votes": {
+                    "type": "integer"
+                },
+                "estimates": {
+                    "type": "integer"
+                },
+                "first_vote_at": {
+                    "type": "string"
+                },
+                "last_vote_at": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.OptionStats"
+                    }
+                },
+                "poll_id": {
+                    "type": "string"
+                },
+                "responses": {
+                    "type": "integer"
+                },
+                "total_votes": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unique_voters": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.Totals": {
+            "type": "object",
+            "properties": {
+                "active_bans": {
+                    "type": "integer"
+                },
+                "deleted_polls": {
+                    "type": "integer"
+                },
+                "open_polls": {
+                    "type": "integer"
+                },
+                "polls": {
+                    "type": "integer"
+                },
+                "votes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "survey.CreateSurveyInput": {
             "type": "object",
-            "required": [
-                "options",
-                "title"
-            ],
             "properties": {
                 "description": {
                     "type": "string",
-                    "maxLength": 500,
-                    "example": "Choose your preferred programming language"
+                    "example": "Help us plan the next offsite"
                 },
                 "expires_in": {
                     "type": "integer",
-                    "example": 60
+                    "example": 1440
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/survey.QuestionInput"
+                    }
+                },
+                "require_auth": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Team offsite"
+                }
+            }
+        },
+        "survey.CreateSurveyOutput": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "qr_code_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/v1/surveys/550e8400-e29b-41d4-a716-446655440002/qr"
+                },
+                "share_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/survey/550e8400-e29b-41d4-a716-446655440002"
+                }
+            }
+        },
+        "survey.QuestionInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Pick every month that works"
                 },
                 "multi_choice": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "optional": {
+                    "description": "Optional questions may be left unanswered",
                     "type": "boolean",
                     "example": false
                 },
                 "options": {
                     "type": "array",
-                    "maxItems": 10,
-                    "minItems": 2,
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "Go",
-                        "Python",
-                        "JavaScript",
-                        "Rust"
+                        "June",
+                        "July",
+                        "September"
                     ]
                 },
-                "require_auth": {
-                    "type": "boolean",
-                    "example": false
+                "show_if": {
+                    "description": "ShowIf asks the question only when the answer to an earlier question\nincludes one of the given options, both referenced by position",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Condition"
+                        }
+                    ]
                 },
                 "title": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3,
-                    "example": "What's your favorite programming language?"
+                    "example": "Which month suits you?"
                 }
             }
         },
-        "poll.CreatePollOutput": {
+        "vote.CreateEstimateInput": {
             "type": "object",
+            "required": [
+                "estimate"
+            ],
             "properties": {
-                "id": {
+                "estimate": {
+                    "type": "number",
+                    "example": 8
+                }
+            }
+        },
+        "vote.CreateResponseInput": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "Faster onboarding"
+                }
+            }
+        },
+        "vote.SlotAnswer": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string",
+                    "example": "yes"
                 },
-                "qr_code_url": {
+                "option_id": {
                     "type": "string",
-                    "example": "http://localhost:8080/api/v1/polls/550e8400-e29b-41d4-a716-446655440000/qr"
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                }
+            }
+        },
+        "vote.SubmitAvailabilityInput": {
+            "type": "object",
+            "required": [
+                "availability",
+                "name"
+            ],
+            "properties": {
+                "availability": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/vote.SlotAnswer"
+                    }
                 },
-                "share_url": {
+                "name": {
                     "type": "string",
-                    "example": "http://localhost:8080/poll/550e8400-e29b-41d4-a716-446655440000"
+                    "example": "Alice"
+                }
+            }
+        },
+        "websocket.HubStats": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "integer"
+                },
+                "last_heartbeat": {
+                    "type": "string"
+                },
+                "rooms": {
+                    "type": "integer"
                 }
             }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Type \"Bearer\" followed by a space and JWT token.",
             "type": "apiKey",
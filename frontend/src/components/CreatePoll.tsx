@@ -1,7 +1,7 @@
 import React, { useState } from 'react';
 import { useTranslation } from 'react-i18next';
 import { pollService } from '../services/api';
-import { CreatePollRequest } from '../types/poll';
+import { ApiProblem, CreatePollRequest, FieldError } from '../types/poll';
 import Loader from './Loader';
 
 interface ValidationErrors {
@@ -12,6 +12,25 @@ interface ValidationErrors {
   general?: string;
 }
 
+// Associe les champs en erreur renvoyés par l'API (title, options[1]...) aux champs du formulaire
+const toValidationErrors = (fields: FieldError[]): ValidationErrors => {
+  const errors: ValidationErrors = {};
+  for (const { field, message } of fields) {
+    const option = field.match(/^options\[(\d+)\]$/);
+    if (option) {
+      errors.options = errors.options || [];
+      errors.options[Number(option[1])] = message;
+    } else if (field === 'title' || field === 'description') {
+      errors[field] = message;
+    } else if (field === 'expires_in') {
+      errors.expiresIn = message;
+    } else {
+      errors.general = message;
+    }
+  }
+  return errors;
+};
+
 interface CreatePollProps {
   onPollCreated: (pollId: string) => void;
 }
@@ -150,13 +169,11 @@ const CreatePoll: React.FC<CreatePollProps> = ({ onPollCreated }) => {
       onPollCreated(createdPoll.id);
     } catch (err: any) {
       // Handle backend validation errors
-      if (err.response?.data?.error) {
-        const backendError = err.response.data.error;
-        if (typeof backendError === 'object') {
-          setValidationErrors(backendError);
-        } else {
-          setError(backendError);
-        }
+      const problem: ApiProblem | undefined = err.response?.data;
+      if (problem?.errors?.length) {
+        setValidationErrors(toValidationErrors(problem.errors));
+      } else if (problem?.detail) {
+        setError(problem.detail);
       } else {
         setError(t('createPoll.form.error'));
       }
@@ -15,7 +15,8 @@ const getWebSocketBaseUrl = (): string => {
     if (apiUrl) {
       return apiUrl.replace(/^https?:/, apiUrl.startsWith('https:') ? 'wss:' : 'ws:');
     }
-    return '';
+    // Frontend servi par l'API (mode standalone) : même origine
+    return `${window.location.protocol === 'https:' ? 'wss:' : 'ws:'}//${window.location.host}`;
   }
   // En développement
   return process.env.REACT_APP_WS_BASE_URL || 'ws://localhost:8080';
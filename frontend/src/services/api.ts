@@ -1,6 +1,7 @@
 import axios from 'axios';
 import { Poll, CreatePollRequest, VoteRequest } from '../types/poll';
 import config from '../config/environment';
+import i18n from '../i18n';
 
 const API_BASE_URL = `${config.apiBaseUrl}/api/v1`;
 
@@ -11,6 +12,14 @@ const api = axios.create({
   },
 });
 
+// Les messages d'erreur de l'API suivent la langue choisie dans l'interface
+api.interceptors.request.use((request) => {
+  if (i18n.language) {
+    request.headers['Accept-Language'] = i18n.language;
+  }
+  return request;
+});
+
 export const pollService = {
   createPoll: async (pollData: CreatePollRequest): Promise<Poll> => {
     const response = await api.post('/polls', pollData);
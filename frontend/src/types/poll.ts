@@ -6,6 +6,13 @@ export interface PollOption {
   created_at: string;
   updated_at: string;
   vote_count: number;
+  pending?: boolean;
+  starts_at?: string;
+  ends_at?: string;
+  max_votes?: number;
+  remaining?: number;
+  waitlisted?: number;
+  correct?: boolean;
 }
 
 export interface Poll {
@@ -19,6 +26,76 @@ export interface Poll {
   created_at: string;
   updated_at: string;
   options: PollOption[];
+  language?: string;
+  locale?: string;
+  type?: 'choice' | 'text' | 'numeric' | 'schedule';
+  response_count?: number;
+  terms?: PollTerm[];
+  allow_write_in?: boolean;
+  numeric?: NumericSettings;
+  revealed_at?: string;
+  estimates?: EstimateResults;
+  time_zone?: string;
+  slots?: SlotResult[];
+  waitlist?: boolean;
+  quiz_id?: string;
+  position?: number;
+  time_limit?: number;
+}
+
+// Places d'une option limitée, poussées par le message WebSocket capacity_update
+export interface OptionCapacity {
+  option_id: string;
+  max_votes: number;
+  votes: number;
+  remaining: number;
+  waitlisted: number;
+}
+
+export type Availability = 'yes' | 'if_need_be' | 'no';
+
+// Créneau d'un sondage de planification, avec les noms des participants par réponse
+export interface SlotResult {
+  option_id: string;
+  text: string;
+  starts_at: string;
+  ends_at: string;
+  yes: string[];
+  if_need_be: string[];
+  no: string[];
+}
+
+export interface AvailabilityRequest {
+  name: string;
+  availability: { option_id: string; answer: Availability }[];
+}
+
+export interface NumericSettings {
+  min: number;
+  max: number;
+  step?: number;
+  buckets?: number;
+  hide_until_reveal?: boolean;
+}
+
+export interface EstimateResults {
+  count: number;
+  hidden?: boolean;
+  stats?: {
+    min: number;
+    max: number;
+    mean: number;
+    median: number;
+    q1: number;
+    q3: number;
+    std_dev: number;
+    histogram: { from: number; to: number; count: number }[];
+  };
+}
+
+export interface PollTerm {
+  term: string;
+  count: number;
 }
 
 export interface CreatePollRequest {
@@ -26,12 +103,96 @@ export interface CreatePollRequest {
   description: string;
   options: string[];
   expires_in?: number;
+  max_votes?: number[];
+  waitlist?: boolean;
 }
 
-export interface VoteRequest {
+// Quiz : questions minutées, lancées une à une par le créateur
+export interface Quiz {
+  id: string;
+  title: string;
+  description: string;
+  created_by: string;
+  current_question: number | null;
+  question_started_at?: string;
+  question_ends_at?: string;
+  question_closed_at?: string;
+  finished_at?: string;
+  created_at: string;
+  updated_at: string;
+  questions: Poll[];
+}
+
+export interface CreateQuizRequest {
+  title: string;
+  description?: string;
+  questions: {
+    title: string;
+    options: string[];
+    correct: number[];
+    time_limit?: number;
+  }[];
+}
+
+// L'id du joueur sert à répondre : à garder privé
+export interface QuizPlayer {
+  id: string;
+  quiz_id: string;
+  nickname: string;
+  created_at: string;
+}
+
+export interface QuizAnswerRequest {
+  player_id: string;
+  question_id: string;
   option_ids: string[];
 }
 
+// Messages WebSocket question_start, question_end et leaderboard
+export interface QuestionStart {
+  question: Poll;
+  time_limit: number;
+  started_at: string;
+  ends_at: string;
+}
+
+export interface QuestionResult {
+  question_id: string;
+  position: number;
+  correct: string[];
+  options: { option_id: string; answers: number }[];
+  answers: number;
+  correct_answers: number;
+}
+
+export interface Leaderboard {
+  final: boolean;
+  entries: { rank: number; nickname: string; score: number; correct: number }[];
+}
+
+export interface FieldError {
+  field: string;
+  rule: string;
+  param?: string;
+  message: string;
+}
+
+// Réponse d'erreur RFC 7807 (application/problem+json)
+export interface ApiProblem {
+  type: string;
+  title: string;
+  status: number;
+  detail?: string;
+  code: string;
+  request_id?: string;
+  errors?: FieldError[];
+}
+
+export interface VoteRequest {
+  option_ids?: string[];
+  write_in?: string;
+}
+
 export interface WebSocketMessage {
   type: string;
   poll_id: string;
@@ -39,6 +200,8 @@ export interface WebSocketMessage {
     option_id?: string;
     votes?: number;
     total_votes?: number;
+    text?: string;
+    order?: number;
   };
   timestamp: number;
 }
\ No newline at end of file
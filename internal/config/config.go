@@ -9,11 +9,15 @@ import (
 )
 
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	Server   ServerConfig
+	App       AppConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	JWT       JWTConfig
+	Server    ServerConfig
+	Tracing   TracingConfig
+	Retention RetentionConfig
+	Privacy   PrivacyConfig
+	Admin     AdminConfig
 }
 
 type AppConfig struct {
@@ -21,21 +25,53 @@ type AppConfig struct {
 	Version     string
 	Environment string
 	Debug       bool
+	LogLevel    string `mapstructure:"log_level"`
+	LogFormat   string `mapstructure:"log_format"`
+	// DefaultLanguage est la langue des messages d'erreur quand
+	// Accept-Language ne désigne aucune langue disponible (en, fr)
+	DefaultLanguage string `mapstructure:"default_language"`
+	// Standalone exécute toute l'application dans un seul binaire :
+	// SQLite, cache en mémoire et frontend servi par l'API
+	Standalone bool
 }
 
 type DatabaseConfig struct {
-	Type            string
-	Host            string
-	Port            int
-	User            string
-	Password        string
-	Name            string
-	MaxIdleConns    int
-	MaxOpenConns    int
-	ConnMaxLifetime time.Duration
+	Type     string
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Name     string
+	// Path est le fichier de la base SQLite
+	Path            string
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	// AutoMigrate applique les migrations en attente au démarrage
+	AutoMigrate bool `mapstructure:"auto_migrate"`
+	// DSN (ou URL) brut, prioritaire sur les champs individuels
+	DSN string
+	// SSLMode suit la nomenclature libpq : disable, prefer, require, verify-ca, verify-full
+	SSLMode          string `mapstructure:"ssl_mode"`
+	SSLRootCert      string `mapstructure:"ssl_root_cert"`
+	SSLCert          string `mapstructure:"ssl_cert"`
+	SSLKey           string `mapstructure:"ssl_key"`
+	Schema           string
+	TimeZone         string        `mapstructure:"time_zone"`
+	StatementTimeout time.Duration `mapstructure:"statement_timeout"`
+	ApplicationName  string        `mapstructure:"application_name"`
+	// ConnectRetries et ConnectBackoff règlent les tentatives de connexion au démarrage
+	ConnectRetries    int           `mapstructure:"connect_retries"`
+	ConnectBackoff    time.Duration `mapstructure:"connect_backoff"`
+	ConnectMaxBackoff time.Duration `mapstructure:"connect_max_backoff"`
+	// Replicas liste les réplicas en lecture ("host", "host:port" ou DSN complet),
+	// séparés par des virgules dans DATABASE_REPLICAS
+	Replicas             []string
+	ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval"`
 }
 
 type RedisConfig struct {
+	Enabled      bool
 	Host         string
 	Port         int
 	Password     string
@@ -45,6 +81,9 @@ type RedisConfig struct {
 	MaxRetries   int
 }
 
+// DefaultJWTSecret is the development secret; tokens signed with it are never trusted
+const DefaultJWTSecret = "quickpoll-secret-key"
+
 type JWTConfig struct {
 	Secret     string
 	Expiration time.Duration
@@ -57,6 +96,53 @@ type ServerConfig struct {
 	IdleTimeout  time.Duration
 	BaseURL      string
 	FrontendURL  string
+	// StaticDir sert le build du frontend depuis le disque au lieu des fichiers embarqués
+	StaticDir string `mapstructure:"static_dir"`
+	// ShutdownDelay leaves time for load balancers to observe /readyz failing before connections are drained
+	ShutdownDelay time.Duration `mapstructure:"shutdown_delay"`
+	// IdempotencyTTL is how long the response to a request carrying an Idempotency-Key is replayed
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
+}
+
+// TracingConfig configures OpenTelemetry span export.
+// Exporter is one of "otlp" (HTTP), "stdout" or "file".
+type TracingConfig struct {
+	Enabled     bool
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	FilePath    string  `mapstructure:"file_path"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// RetentionConfig defines how long personal and closed-poll data is kept.
+// A retention of 0 days disables the corresponding policy.
+type RetentionConfig struct {
+	Enabled  bool
+	Interval time.Duration
+	// DeletedPollDays hard-deletes soft-deleted polls and options after N days
+	DeletedPollDays int `mapstructure:"deleted_poll_days"`
+	// VoterDataDays clears or hashes the IP address and user agent of ballots after M days
+	VoterDataDays   int    `mapstructure:"voter_data_days"`
+	VoterDataAction string `mapstructure:"voter_data_action"`
+	// ArchiveClosedDays replaces the raw ballots of polls closed for N days with per-option totals
+	ArchiveClosedDays int `mapstructure:"archive_closed_days"`
+	BatchSize         int `mapstructure:"batch_size"`
+}
+
+// PrivacyConfig controls how client IP addresses are stored.
+// IPMode is "clear" or "hmac"; in hmac mode the first of IPHashKeys signs new
+// ballots and the others are still accepted for duplicate detection.
+type PrivacyConfig struct {
+	IPMode     string   `mapstructure:"ip_mode"`
+	IPHashKeys []string `mapstructure:"ip_hash_keys"`
+}
+
+// AdminConfig grants access to the admin API. APIKeys entries are written
+// "name:key"; JWTs must carry "admin" in RoleClaim.
+type AdminConfig struct {
+	APIKeys   []string `mapstructure:"api_keys"`
+	RoleClaim string   `mapstructure:"role_claim"`
 }
 
 func Load() (*Config, error) {
@@ -72,6 +158,10 @@ func Load() (*Config, error) {
 	viper.SetDefault("app.version", "1.0.0")
 	viper.SetDefault("app.environment", "development")
 	viper.SetDefault("app.debug", true)
+	viper.SetDefault("app.log_level", "info")
+	viper.SetDefault("app.log_format", "")
+	viper.SetDefault("app.default_language", "en")
+	viper.SetDefault("app.standalone", false)
 
 	viper.SetDefault("database.type", "mysql")
 	viper.SetDefault("database.host", "localhost")
@@ -79,10 +169,27 @@ func Load() (*Config, error) {
 	viper.SetDefault("database.user", "root")
 	viper.SetDefault("database.password", "root")
 	viper.SetDefault("database.name", "quickpoll")
+	viper.SetDefault("database.path", "quickpoll.db")
 	viper.SetDefault("database.max_idle_conns", 10)
 	viper.SetDefault("database.max_open_conns", 100)
 	viper.SetDefault("database.conn_max_lifetime", time.Hour)
+	viper.SetDefault("database.auto_migrate", true)
+	viper.SetDefault("database.dsn", "")
+	viper.SetDefault("database.ssl_mode", "")
+	viper.SetDefault("database.ssl_root_cert", "")
+	viper.SetDefault("database.ssl_cert", "")
+	viper.SetDefault("database.ssl_key", "")
+	viper.SetDefault("database.schema", "")
+	viper.SetDefault("database.time_zone", "")
+	viper.SetDefault("database.statement_timeout", 0)
+	viper.SetDefault("database.application_name", "quickpoll")
+	viper.SetDefault("database.connect_retries", 10)
+	viper.SetDefault("database.connect_backoff", time.Second)
+	viper.SetDefault("database.connect_max_backoff", 30*time.Second)
+	viper.SetDefault("database.replicas", []string{})
+	viper.SetDefault("database.replica_check_interval", 5*time.Second)
 
+	viper.SetDefault("redis.enabled", false)
 	viper.SetDefault("redis.host", "localhost")
 	viper.SetDefault("redis.port", 6379)
 	viper.SetDefault("redis.password", "")
@@ -91,7 +198,7 @@ func Load() (*Config, error) {
 	viper.SetDefault("redis.min_idle_conns", 5)
 	viper.SetDefault("redis.max_retries", 3)
 
-	viper.SetDefault("jwt.secret", "quickpoll-secret-key")
+	viper.SetDefault("jwt.secret", DefaultJWTSecret)
 	viper.SetDefault("jwt.expiration", 24*time.Hour)
 
 	viper.SetDefault("server.port", 8080)
@@ -100,6 +207,30 @@ func Load() (*Config, error) {
 	viper.SetDefault("server.idle_timeout", 60*time.Second)
 	viper.SetDefault("server.base_url", "http://localhost:8080")
 	viper.SetDefault("server.frontend_url", "http://localhost:3001")
+	viper.SetDefault("server.shutdown_delay", 5*time.Second)
+	viper.SetDefault("server.static_dir", "")
+	viper.SetDefault("server.idempotency_ttl", 24*time.Hour)
+
+	viper.SetDefault("tracing.enabled", false)
+	viper.SetDefault("tracing.exporter", "otlp")
+	viper.SetDefault("tracing.endpoint", "localhost:4318")
+	viper.SetDefault("tracing.insecure", true)
+	viper.SetDefault("tracing.file_path", "traces.json")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+
+	viper.SetDefault("retention.enabled", false)
+	viper.SetDefault("retention.interval", 24*time.Hour)
+	viper.SetDefault("retention.deleted_poll_days", 30)
+	viper.SetDefault("retention.voter_data_days", 90)
+	viper.SetDefault("retention.voter_data_action", "hash")
+	viper.SetDefault("retention.archive_closed_days", 0)
+	viper.SetDefault("retention.batch_size", 500)
+
+	viper.SetDefault("privacy.ip_mode", "clear")
+	viper.SetDefault("privacy.ip_hash_keys", []string{})
+
+	viper.SetDefault("admin.api_keys", []string{})
+	viper.SetDefault("admin.role_claim", "role")
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -112,5 +243,37 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
+	if config.App.Standalone {
+		config.applyStandalone()
+	}
+
+	if err := config.Privacy.validate(); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
-}
\ No newline at end of file
+}
+
+// applyStandalone remplace les dépendances externes par leurs équivalents embarqués
+func (c *Config) applyStandalone() {
+	c.Database.Type = "sqlite"
+	c.Redis.Enabled = false
+	// Le frontend est servi par l'API : les redirections QR restent sur la même origine
+	c.Server.FrontendURL = "/"
+}
+
+func (c PrivacyConfig) validate() error {
+	switch c.IPMode {
+	case "clear":
+		return nil
+	case "hmac":
+		for _, key := range c.IPHashKeys {
+			if strings.TrimSpace(key) != "" {
+				return nil
+			}
+		}
+		return fmt.Errorf("privacy.ip_hash_keys is required when privacy.ip_mode is hmac")
+	default:
+		return fmt.Errorf("unsupported privacy.ip_mode: %s", c.IPMode)
+	}
+}
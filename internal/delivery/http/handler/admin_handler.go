@@ -0,0 +1,324 @@
+package handler
+
+import (
+	"net/http"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/database"
+	"microservice-go-gin/internal/usecase/admin"
+	"microservice-go-gin/internal/usecase/poll"
+)
+
+// PoolStats describes the primary connection pool
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections" example:"100"`
+	OpenConnections    int   `json:"open_connections" example:"12"`
+	InUse              int   `json:"in_use" example:"3"`
+	Idle               int   `json:"idle" example:"9"`
+	WaitCount          int64 `json:"wait_count" example:"0"`
+	WaitDurationMS     int64 `json:"wait_duration_ms" example:"0"`
+}
+
+// SystemStats is the operator view of the running instance
+type SystemStats struct {
+	Version    string                   `json:"version" example:"1.0.0"`
+	StartedAt  time.Time                `json:"started_at"`
+	Goroutines int                      `json:"goroutines" example:"42"`
+	WebSocket  websocket.HubStats       `json:"websocket"`
+	Pool       PoolStats                `json:"db_pool"`
+	Replicas   []database.ReplicaStatus `json:"replicas,omitempty"`
+	Totals     *repository.Totals       `json:"totals"`
+}
+
+type AdminHandler struct {
+	pollAdminUC *admin.PollAdminUseCase
+	banUC       *admin.BanUseCase
+	historyUC   *poll.PollHistoryUseCase
+	reads       *database.Resolver
+	hub         *websocket.Hub
+	version     string
+	startedAt   time.Time
+}
+
+func NewAdminHandler(pollAdminUC *admin.PollAdminUseCase, banUC *admin.BanUseCase, historyUC *poll.PollHistoryUseCase, reads *database.Resolver, hub *websocket.Hub, version string) *AdminHandler {
+	return &AdminHandler{
+		pollAdminUC: pollAdminUC,
+		banUC:       banUC,
+		historyUC:   historyUC,
+		reads:       reads,
+		hub:         hub,
+		version:     version,
+		startedAt:   time.Now().UTC(),
+	}
+}
+
+// ListPolls godoc
+// @Summary List and search polls
+// @Description List every poll, optionally filtered by status and by a text matching the title, the description or the ID
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param q query string false "Search text"
+// @Param status query string false "open, closed, deleted or all" Enums(open, closed, deleted, all)
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Success 200 {object} admin.PollPage
+// @Failure 400 {object} problem.Problem
+// @Failure 401 {object} problem.Problem
+// @Failure 403 {object} problem.Problem
+// @Router /api/v1/admin/polls [get]
+func (h *AdminHandler) ListPolls(c *gin.Context) {
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.pollAdminUC.Search(c.Request.Context(), repository.PollFilter{
+		Query:  c.Query("q"),
+		Status: c.Query("status"),
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// ClosePoll godoc
+// @Summary Force-close a poll
+// @Description Close a poll immediately; ballots already cast are kept
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem "Poll already closed"
+// @Router /api/v1/admin/polls/{id}/close [post]
+func (h *AdminHandler) ClosePoll(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.pollAdminUC.Close(c.Request.Context(), pollID); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeletePoll godoc
+// @Summary Delete a poll
+// @Description Soft-delete a poll; it is purged by the retention job
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Router /api/v1/admin/polls/{id} [delete]
+func (h *AdminHandler) DeletePoll(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.pollAdminUC.Delete(c.Request.Context(), pollID); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PollStats godoc
+// @Summary Vote statistics of a poll
+// @Description Tallies per option, the number of responses of a text poll or of estimates of a numeric poll, unique voters and first/last ballot dates, deleted polls included
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 200 {object} repository.PollStats
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Router /api/v1/admin/polls/{id}/stats [get]
+func (h *AdminHandler) PollStats(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.pollAdminUC.Stats(c.Request.Context(), pollID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// PollHistory godoc
+// @Summary Audit history of a poll
+// @Description Every recorded action on the poll, oldest first, deleted polls included
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Page size" default(50)
+// @Success 200 {object} poll.HistoryPage
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Router /api/v1/admin/polls/{id}/history [get]
+func (h *AdminHandler) PollHistory(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.historyUC.Execute(c.Request.Context(), poll.HistoryQuery{
+		PollID: pollID,
+		Admin:  true,
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// ListBans godoc
+// @Summary List bans
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Success 200 {array} entity.Ban
+// @Router /api/v1/admin/bans [get]
+func (h *AdminHandler) ListBans(c *gin.Context) {
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	bans, err := h.banUC.List(c.Request.Context(), offset, limit)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bans)
+}
+
+// CreateBan godoc
+// @Summary Ban an IP address or a user
+// @Description Banned callers can no longer create polls or vote. IPv6 addresses are banned with their /64 network.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param ban body admin.CreateBanInput true "Ban"
+// @Success 201 {object} entity.Ban
+// @Failure 400 {object} problem.Problem
+// @Failure 409 {object} problem.Problem "Already banned"
+// @Router /api/v1/admin/bans [post]
+func (h *AdminHandler) CreateBan(c *gin.Context) {
+	var input admin.CreateBanInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	ban, err := h.banUC.Create(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ban)
+}
+
+// DeleteBan godoc
+// @Summary Lift a ban
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "Ban ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Router /api/v1/admin/bans/{id} [delete]
+func (h *AdminHandler) DeleteBan(c *gin.Context) {
+	banID, ok := parseID(c, errInvalidID)
+	if !ok {
+		return
+	}
+
+	if err := h.banUC.Delete(c.Request.Context(), banID); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SystemStats godoc
+// @Summary System statistics
+// @Description WebSocket hub rooms and clients, database pool usage, replicas and record counts
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Success 200 {object} SystemStats
+// @Router /api/v1/admin/system [get]
+func (h *AdminHandler) SystemStats(c *gin.Context) {
+	totals, err := h.pollAdminUC.Totals(c.Request.Context())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	stats := SystemStats{
+		Version:    h.version,
+		StartedAt:  h.startedAt,
+		Goroutines: runtime.NumGoroutine(),
+		WebSocket:  h.hub.Stats(),
+		Replicas:   h.reads.ReplicaStatuses(),
+		Totals:     totals,
+	}
+	if sqlDB, err := h.reads.Primary().DB(); err == nil {
+		pool := sqlDB.Stats()
+		stats.Pool = PoolStats{
+			MaxOpenConnections: pool.MaxOpenConnections,
+			OpenConnections:    pool.OpenConnections,
+			InUse:              pool.InUse,
+			Idle:               pool.Idle,
+			WaitCount:          pool.WaitCount,
+			WaitDurationMS:     pool.WaitDuration.Milliseconds(),
+		}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
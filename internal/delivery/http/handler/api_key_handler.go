@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/usecase/admin"
+)
+
+type APIKeyHandler struct {
+	apiKeyUC *admin.APIKeyUseCase
+}
+
+func NewAPIKeyHandler(apiKeyUC *admin.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyUC: apiKeyUC}
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description Keys are listed with their prefix only, revoked and expired keys included
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Success 200 {array} entity.APIKey
+// @Router /api/v1/admin/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	keys, err := h.apiKeyUC.List(c.Request.Context(), offset, limit)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description The key is returned once and only its hash is stored. Scopes: polls:write, votes:read, export.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param key body admin.CreateAPIKeyInput true "API key"
+// @Success 201 {object} admin.CreatedAPIKey
+// @Failure 400 {object} problem.Problem
+// @Router /api/v1/admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var input admin.CreateAPIKeyInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	key, err := h.apiKeyUC.Create(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, key)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Requests carrying the key are refused from now on
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param id path string true "API key ID" format(uuid)
+// @Success 204
+// @Failure 400 {object} problem.Problem
+// @Failure 404 {object} problem.Problem
+// @Failure 409 {object} problem.Problem "Already revoked"
+// @Router /api/v1/admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	keyID, ok := parseID(c, errInvalidID)
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyUC.Revoke(c.Request.Context(), keyID); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
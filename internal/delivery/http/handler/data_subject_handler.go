@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/usecase/datasubject"
+)
+
+type DataSubjectHandler struct {
+	dataSubjectUC *datasubject.DataSubjectUseCase
+}
+
+func NewDataSubjectHandler(dataSubjectUC *datasubject.DataSubjectUseCase) *DataSubjectHandler {
+	return &DataSubjectHandler{dataSubjectUC: dataSubjectUC}
+}
+
+// ExportData godoc
+// @Summary Export my data
+// @Description Export every ballot cast and every poll, survey and quiz created by the current voter. An API key or a JWT is required: an IP address alone may be shared by many people. Ballots are found by the IP address of the caller and exported without IP address or user agent; polls are found by principal and by IP address.
+// @Tags privacy
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} datasubject.Export "Data of the current voter"
+// @Failure 401 {object} problem.Problem "Missing or invalid credentials"
+// @Failure 500 {object} problem.Problem "Export failed"
+// @Router /api/v1/me/data [get]
+func (h *DataSubjectHandler) ExportData(c *gin.Context) {
+	export, err := h.dataSubjectUC.Export(c.Request.Context(), c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="quickpoll-data.json"`)
+	c.JSON(http.StatusOK, export)
+}
+
+// EraseData godoc
+// @Summary Erase my data
+// @Description Delete the ballots of the current voter in closed polls and finished quizzes and detach the polls, surveys and quizzes they created. Results of closed polls are preserved. Ballots of polls and quizzes still open are anonymized instead: they keep counting and still prevent a second vote. An API key or a JWT is required: an IP address alone may be shared by many people.
+// @Tags privacy
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} repository.ErasureResult "Records affected"
+// @Failure 401 {object} problem.Problem "Missing or invalid credentials"
+// @Failure 500 {object} problem.Problem "Erasure failed"
+// @Router /api/v1/me/data [delete]
+func (h *DataSubjectHandler) EraseData(c *gin.Context) {
+	result, err := h.dataSubjectUC.Erase(c.Request.Context(), c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/domain/apperror"
+)
+
+var (
+	errInvalidPollID     = apperror.Invalid("invalid_poll_id", "invalid poll ID")
+	errInvalidSurveyID   = apperror.Invalid("invalid_survey_id", "invalid survey ID")
+	errInvalidQuizID     = apperror.Invalid("invalid_quiz_id", "invalid quiz ID")
+	errInvalidOptionID   = apperror.Invalid("invalid_option_id", "invalid option ID")
+	errInvalidID         = apperror.Invalid("invalid_id", "invalid ID")
+	errInvalidResponseID = apperror.Invalid("invalid_response_id", "invalid response ID")
+	errInvalidBody       = apperror.Invalid("invalid_body", "request body is not valid JSON")
+)
+
+func init() {
+	// Les erreurs de validation désignent les champs par leur nom JSON
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// bindJSON decodes the request body into obj and responds with a
+// validation problem when it is malformed or breaks a binding rule
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		problem.Write(c, bindingError(err))
+		return false
+	}
+	return true
+}
+
+// bindingError converts a binding failure into a domain validation error
+// listing every rejected field
+func bindingError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		if errors.Is(err, io.EOF) {
+			return errInvalidBody.WithMessage("request body is empty")
+		}
+		return errInvalidBody.Wrap(err)
+	}
+
+	fields := make([]apperror.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fields = append(fields, apperror.FieldError{
+			Field:   fieldPath(fieldError),
+			Rule:    fieldError.Tag(),
+			Param:   fieldError.Param(),
+			Message: fieldMessage(fieldError),
+		})
+	}
+	return apperror.Validation(fields...)
+}
+
+// fieldPath is the path of the field without the name of the input struct,
+// e.g. "options[0]"
+func fieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldError.Field()
+}
+
+func fieldMessage(fieldError validator.FieldError) string {
+	field := fieldPath(fieldError)
+	isString := fieldError.Kind().String() == "string"
+	switch fieldError.Tag() {
+	case "required":
+		return field + " is required"
+	case "min":
+		if isString {
+			return field + " must be at least " + fieldError.Param() + " characters long"
+		}
+		return field + " must be at least " + fieldError.Param()
+	case "max":
+		if isString {
+			return field + " must be no more than " + fieldError.Param() + " characters long"
+		}
+		return field + " must be no more than " + fieldError.Param()
+	case "required_without":
+		return field + " is required"
+	case "unique":
+		return field + " must not contain duplicates"
+	case "oneof":
+		return field + " must be one of " + fieldError.Param()
+	default:
+		return field + " is invalid"
+	}
+}
+
+// parsePollID reads the poll ID of the route and responds with 400 when it is not a UUID
+func parsePollID(c *gin.Context) (uuid.UUID, bool) {
+	return parseID(c, errInvalidPollID)
+}
+
+// parseSurveyID reads the survey ID of the route and responds with 400 when it is not a UUID
+func parseSurveyID(c *gin.Context) (uuid.UUID, bool) {
+	return parseID(c, errInvalidSurveyID)
+}
+
+// parseQuizID reads the quiz ID of the route and responds with 400 when it is not a UUID
+func parseQuizID(c *gin.Context) (uuid.UUID, bool) {
+	return parseID(c, errInvalidQuizID)
+}
+
+func parseID(c *gin.Context, invalid error) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Write(c, invalid)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/usecase/poll"
+	"microservice-go-gin/internal/usecase/vote"
+)
+
+type EstimateHandler struct {
+	createEstimateUC *vote.CreateEstimateUseCase
+	revealUC         *poll.RevealUseCase
+	wsHub            *websocket.Hub
+}
+
+func NewEstimateHandler(createEstimateUC *vote.CreateEstimateUseCase, revealUC *poll.RevealUseCase, wsHub *websocket.Hub) *EstimateHandler {
+	return &EstimateHandler{
+		createEstimateUC: createEstimateUC,
+		revealUC:         revealUC,
+		wsHub:            wsHub,
+	}
+}
+
+// CreateEstimate godoc
+// @Summary Answer a numeric poll
+// @Description Submit a numeric estimate between the min and max of the poll, on one of its steps. The updated results are pushed on the websocket of the poll; while a planning poker poll hides its estimates, only their count is.
+// @Tags estimates
+// @Accept json
+// @Produce json
+// @Param id path string true "Poll ID" format(uuid)
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried"
+// @Param estimate body vote.CreateEstimateInput true "Estimate"
+// @Success 201 {object} entity.Estimate
+// @Failure 400 {object} problem.Problem "Estimate out of range, or not a numeric poll"
+// @Failure 401 {object} problem.Problem "Authentication required"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Failure 409 {object} problem.Problem "Already estimated, or Idempotency-Key reused with another request"
+// @Failure 410 {object} problem.Problem "Poll expired"
+// @Router /api/v1/polls/{id}/estimates [post]
+func (h *EstimateHandler) CreateEstimate(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	var input vote.CreateEstimateInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	input.PollID = pollID
+	input.VoterID = c.ClientIP()
+
+	output, err := h.createEstimateUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	h.wsHub.BroadcastEstimates(c.Request.Context(), pollID, output.Results)
+
+	c.JSON(http.StatusCreated, output.Estimate)
+}
+
+// RevealEstimates godoc
+// @Summary Reveal the estimates of a planning poker poll
+// @Description Publish the distribution of the estimates hidden until now, also pushed on the websocket of the poll. Reserved to the creator of the poll.
+// @Tags estimates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 200 {object} entity.EstimateResults
+// @Failure 400 {object} problem.Problem "Not a numeric poll"
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Failure 409 {object} problem.Problem "Estimates already revealed"
+// @Router /api/v1/polls/{id}/reveal [post]
+func (h *EstimateHandler) RevealEstimates(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.revealUC.Execute(c.Request.Context(), pollID, c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	h.wsHub.BroadcastEstimatesRevealed(c.Request.Context(), pollID, results)
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+const (
+	checkTimeout     = 2 * time.Second
+	hubMaxSilence    = 15 * time.Second
+	statusUp         = "up"
+	statusDown       = "down"
+	statusReady      = "ready"
+	statusNotReady   = "not_ready"
+	statusShutdown   = "shutting_down"
+	healthServiceTag = "QuickPoll API"
+)
+
+// Pinger is implemented by dependencies that can be probed for availability
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// CheckResult is the outcome of a single readiness check
+type CheckResult struct {
+	Status    string      `json:"status" example:"up"`
+	LatencyMS int64       `json:"latency_ms" example:"3"`
+	Error     string      `json:"error,omitempty" example:"context deadline exceeded"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// HealthResponse is returned by the liveness and readiness probes
+type HealthResponse struct {
+	Status  string                 `json:"status" example:"ready"`
+	Service string                 `json:"service" example:"QuickPoll API"`
+	Checks  map[string]CheckResult `json:"checks,omitempty"`
+}
+
+type HealthHandler struct {
+	db           *gorm.DB
+	reads        *database.Resolver
+	redis        Pinger
+	hub          *websocket.Hub
+	shuttingDown atomic.Bool
+}
+
+// NewHealthHandler creates the probe handler; redis may be nil when Redis is not configured
+func NewHealthHandler(reads *database.Resolver, redis Pinger, hub *websocket.Hub) *HealthHandler {
+	return &HealthHandler{
+		db:    reads.Primary(),
+		reads: reads,
+		redis: redis,
+		hub:   hub,
+	}
+}
+
+// MarkShuttingDown makes /readyz fail so that load balancers stop routing
+// traffic before the server drains its connections
+func (h *HealthHandler) MarkShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Reports that the process is running. Dependencies are not checked.
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /livez [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "alive", Service: healthServiceTag})
+}
+
+// Health godoc
+// @Summary Legacy health check
+// @Description Kept for backward compatibility, equivalent to /livez
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /health [get]
+func (h *HealthHandler) Health(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "healthy",
+		"service": healthServiceTag,
+	})
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Checks the SQL pool, Redis, the WebSocket hub and the schema version
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Failure 503 {object} HealthResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	ctx := c.Request.Context()
+	checks := map[string]CheckResult{
+		"database":  h.run(ctx, h.checkDatabase),
+		"migration": h.run(ctx, h.checkMigration),
+		"websocket": h.checkHub(),
+	}
+	if h.redis != nil {
+		checks["redis"] = h.run(ctx, h.checkRedis)
+	}
+
+	response := HealthResponse{Status: statusReady, Service: healthServiceTag, Checks: checks}
+	for _, check := range checks {
+		if check.Status != statusUp {
+			response.Status = statusNotReady
+		}
+	}
+	if h.shuttingDown.Load() {
+		response.Status = statusShutdown
+	}
+
+	status := http.StatusOK
+	if response.Status != statusReady {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, response)
+}
+
+// run executes check with a timeout and measures its latency
+func (h *HealthHandler) run(ctx context.Context, check func(context.Context) (interface{}, error)) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	details, err := check(ctx)
+	result := CheckResult{
+		Status:    statusUp,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Details:   details,
+	}
+	if err != nil {
+		result.Status = statusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (h *HealthHandler) checkDatabase(ctx context.Context) (interface{}, error) {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return nil, err
+	}
+	stats := sqlDB.Stats()
+	details := gin.H{
+		"open_connections": stats.OpenConnections,
+		"in_use":           stats.InUse,
+		"idle":             stats.Idle,
+	}
+	// Un réplica indisponible ne rend pas le service non-ready : les lectures basculent sur le primaire
+	if replicas := h.reads.ReplicaStatuses(); len(replicas) > 0 {
+		details["replicas"] = replicas
+	}
+	return details, nil
+}
+
+func (h *HealthHandler) checkRedis(ctx context.Context) (interface{}, error) {
+	return nil, h.redis.Ping(ctx)
+}
+
+func (h *HealthHandler) checkMigration(ctx context.Context) (interface{}, error) {
+	current, latest, err := database.SchemaVersion(ctx, h.db)
+	return gin.H{"version": current, "latest": latest}, err
+}
+
+func (h *HealthHandler) checkHub() CheckResult {
+	stats := h.hub.Stats()
+	result := CheckResult{Status: statusUp, Details: stats}
+	if !h.hub.Alive(hubMaxSilence) {
+		result.Status = statusDown
+		result.Error = "websocket hub loop is not running"
+	}
+	return result
+}
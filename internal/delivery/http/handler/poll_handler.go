@@ -1,41 +1,50 @@
 package handler
 
 import (
+	"encoding/csv"
 	"net/http"
-	"strings"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
-	"github.com/google/uuid"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/infrastructure/i18n"
 	"microservice-go-gin/internal/usecase/poll"
 )
 
 type PollHandler struct {
 	createPollUC *poll.CreatePollUseCase
 	getPollUC    *poll.GetPollUseCase
+	updatePollUC *poll.UpdatePollUseCase
+	historyUC    *poll.PollHistoryUseCase
+	ownerDataUC  *poll.PollOwnerDataUseCase
 }
 
-func NewPollHandler(createPollUC *poll.CreatePollUseCase, getPollUC *poll.GetPollUseCase) *PollHandler {
+func NewPollHandler(createPollUC *poll.CreatePollUseCase, getPollUC *poll.GetPollUseCase, updatePollUC *poll.UpdatePollUseCase, historyUC *poll.PollHistoryUseCase, ownerDataUC *poll.PollOwnerDataUseCase) *PollHandler {
 	return &PollHandler{
 		createPollUC: createPollUC,
 		getPollUC:    getPollUC,
+		updatePollUC: updatePollUC,
+		historyUC:    historyUC,
+		ownerDataUC:  ownerDataUC,
 	}
 }
 
 // CreatePoll godoc
 // @Summary Create a new poll
-// @Description Create a new poll with options
+// @Description Create a new poll with options. Services authenticate with an API key (Authorization: Bearer qp_...) holding the polls:write scope; the poll is then attributed to the key.
 // @Tags polls
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried"
 // @Param poll body poll.CreatePollInput true "Poll data"
 // @Success 201 {object} poll.CreatePollOutput
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} problem.Problem
+// @Failure 409 {object} problem.Problem "Idempotency-Key reused with another request"
 // @Router /api/v1/polls [post]
 func (h *PollHandler) CreatePoll(c *gin.Context) {
 	var input poll.CreatePollInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": h.formatValidationError(err)})
+	if !bindJSON(c, &input) {
 		return
 	}
 
@@ -43,7 +52,7 @@ func (h *PollHandler) CreatePoll(c *gin.Context) {
 
 	output, err := h.createPollUC.Execute(c.Request.Context(), input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -52,63 +61,174 @@ func (h *PollHandler) CreatePoll(c *gin.Context) {
 
 // GetPoll godoc
 // @Summary Get poll details
-// @Description Get poll details with current results and vote counts
+// @Description Get poll details with current results and vote counts. Multilingual polls are rendered in the locale requested by lang, else by Accept-Language, else in their own language; option IDs and counts are shared by every locale.
 // @Tags polls
 // @Accept json
 // @Produce json
 // @Param id path string true "Poll ID" format(uuid)
+// @Param lang query string false "Locale of the content, e.g. fr"
+// @Param Accept-Language header string false "Preferred locales"
 // @Success 200 {object} entity.Poll "Poll details with results"
-// @Failure 400 {object} map[string]string "Invalid poll ID"
-// @Failure 404 {object} map[string]string "Poll not found"
+// @Failure 400 {object} problem.Problem "Invalid poll ID"
+// @Failure 404 {object} problem.Problem "Poll not found"
 // @Router /api/v1/polls/{id} [get]
 func (h *PollHandler) GetPoll(c *gin.Context) {
-	idStr := c.Param("id")
-	pollID, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid poll ID"})
+	pollID, ok := parsePollID(c)
+	if !ok {
 		return
 	}
 
 	poll, err := h.getPollUC.Execute(c.Request.Context(), pollID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "poll not found"})
+		problem.Write(c, err)
 		return
 	}
 
+	// Sondage multilingue : ?lang= prime sur Accept-Language, la langue du sondage par défaut
+	if locales := poll.Locales(); len(locales) > 0 {
+		preference := c.Query("lang")
+		if preference == "" {
+			preference = c.GetHeader("Accept-Language")
+		}
+		poll.Localize(i18n.Negotiate(preference, locales))
+		c.Header("Content-Language", poll.Locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+	}
+
 	c.JSON(http.StatusOK, poll)
 }
 
-// formatValidationError formats validation errors into user-friendly messages
-func (h *PollHandler) formatValidationError(err error) map[string]string {
-	errors := make(map[string]string)
-	
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		for _, fieldError := range validationErrors {
-			fieldName := strings.ToLower(fieldError.Field())
-			switch fieldError.Tag() {
-			case "required":
-				errors[fieldName] = fieldName + " is required"
-			case "min":
-				if fieldError.Kind().String() == "string" {
-					errors[fieldName] = fieldName + " must be at least " + fieldError.Param() + " characters long"
-				} else {
-					errors[fieldName] = fieldName + " must be at least " + fieldError.Param()
-				}
-			case "max":
-				if fieldError.Kind().String() == "string" {
-					errors[fieldName] = fieldName + " must be no more than " + fieldError.Param() + " characters long"
-				} else {
-					errors[fieldName] = fieldName + " must be no more than " + fieldError.Param()
-				}
-			case "dive":
-				errors[fieldName] = "Each " + fieldName + " item is invalid"
-			default:
-				errors[fieldName] = fieldName + " is invalid"
-			}
-		}
-	} else {
-		errors["validation"] = err.Error()
+// UpdatePoll godoc
+// @Summary Edit a poll
+// @Description Change the title, description, expiry or options of an open poll. Reserved to its creator; options can only change before the first vote.
+// @Tags polls
+// @Accept json
+// @Produce json
+// @Param id path string true "Poll ID" format(uuid)
+// @Security BearerAuth
+// @Param poll body poll.UpdatePollInput true "Fields to change"
+// @Success 200 {object} entity.Poll
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Failure 409 {object} problem.Problem "Options locked by votes"
+// @Failure 410 {object} problem.Problem "Poll closed"
+// @Router /api/v1/polls/{id} [patch]
+func (h *PollHandler) UpdatePoll(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	var input poll.UpdatePollInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	input.PollID = pollID
+	input.Requester = c.ClientIP()
+
+	updated, err := h.updatePollUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// GetPollHistory godoc
+// @Summary Audit history of a poll
+// @Description Who created, edited, closed or deleted the poll and when, oldest first. Reserved to its creator.
+// @Tags polls
+// @Produce json
+// @Param id path string true "Poll ID" format(uuid)
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Page size" default(50)
+// @Success 200 {object} poll.HistoryPage
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Router /api/v1/polls/{id}/history [get]
+func (h *PollHandler) GetPollHistory(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
 	}
-	
-	return errors
-}
\ No newline at end of file
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.historyUC.Execute(c.Request.Context(), poll.HistoryQuery{
+		PollID:    pollID,
+		Requester: c.ClientIP(),
+		Offset:    offset,
+		Limit:     limit,
+	})
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// ListBallots godoc
+// @Summary Ballots of a poll
+// @Description Every ballot with its option and date, without voter identity. Reserved to the creator; API keys need the votes:read scope.
+// @Tags polls
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 200 {array} poll.Ballot
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Router /api/v1/polls/{id}/votes [get]
+func (h *PollHandler) ListBallots(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	ballots, err := h.ownerDataUC.Ballots(c.Request.Context(), pollID, c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ballots)
+}
+
+// ExportResults godoc
+// @Summary Export the results of a poll
+// @Description CSV with one line per option and its vote count. Reserved to the creator; API keys need the export scope.
+// @Tags polls
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Router /api/v1/polls/{id}/export [get]
+func (h *PollHandler) ExportResults(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.ownerDataUC.Results(c.Request.Context(), pollID, c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="poll-`+pollID.String()+`.csv"`)
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"option_id", "option", "votes"})
+	for _, option := range results.Options {
+		w.Write([]string{option.ID.String(), option.Text, strconv.Itoa(option.VoteCount)})
+	}
+	w.Flush()
+}
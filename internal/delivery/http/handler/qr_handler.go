@@ -4,8 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/skip2/go-qrcode"
+	"microservice-go-gin/internal/delivery/http/problem"
 )
 
 type QRHandler struct {
@@ -25,28 +25,66 @@ func NewQRHandler(baseURL string) *QRHandler {
 // @Produce png
 // @Param id path string true "Poll ID" format(uuid)
 // @Success 200 {file} png "QR code image"
-// @Failure 400 {object} map[string]string "Invalid poll ID"
-// @Failure 500 {object} map[string]string "Failed to generate QR code"
+// @Failure 400 {object} problem.Problem "Invalid poll ID"
+// @Failure 500 {object} problem.Problem "Failed to generate QR code"
 // @Router /api/v1/polls/{id}/qr [get]
 func (h *QRHandler) GenerateQRCode(c *gin.Context) {
-	pollIDStr := c.Param("id")
-	pollID, err := uuid.Parse(pollIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid poll ID"})
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	h.writeQRCode(c, h.baseURL+"/poll/"+pollID.String())
+}
+
+// GenerateSurveyQRCode godoc
+// @Summary Generate QR code for survey
+// @Description Generate QR code that links to the survey, all questions included
+// @Tags surveys
+// @Produce png
+// @Param id path string true "Survey ID" format(uuid)
+// @Success 200 {file} png "QR code image"
+// @Failure 400 {object} problem.Problem "Invalid survey ID"
+// @Failure 500 {object} problem.Problem "Failed to generate QR code"
+// @Router /api/v1/surveys/{id}/qr [get]
+func (h *QRHandler) GenerateSurveyQRCode(c *gin.Context) {
+	surveyID, ok := parseSurveyID(c)
+	if !ok {
 		return
 	}
 
-	pollURL := h.baseURL + "/poll/" + pollID.String()
+	h.writeQRCode(c, h.baseURL+"/survey/"+surveyID.String())
+}
+
+// GenerateQuizQRCode godoc
+// @Summary Generate QR code for quiz
+// @Description Generate QR code that links to the quiz, for the players to join from the room
+// @Tags quizzes
+// @Produce png
+// @Param id path string true "Quiz ID" format(uuid)
+// @Success 200 {file} png "QR code image"
+// @Failure 400 {object} problem.Problem "Invalid quiz ID"
+// @Failure 500 {object} problem.Problem "Failed to generate QR code"
+// @Router /api/v1/quizzes/{id}/qr [get]
+func (h *QRHandler) GenerateQuizQRCode(c *gin.Context) {
+	quizID, ok := parseQuizID(c)
+	if !ok {
+		return
+	}
+
+	h.writeQRCode(c, h.baseURL+"/quiz/"+quizID.String())
+}
 
-	qr, err := qrcode.New(pollURL, qrcode.Medium)
+func (h *QRHandler) writeQRCode(c *gin.Context, url string) {
+	qr, err := qrcode.New(url, qrcode.Medium)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate QR code"})
+		problem.Write(c, err)
 		return
 	}
 
 	png, err := qr.PNG(256)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate QR code image"})
+		problem.Write(c, err)
 		return
 	}
 
@@ -0,0 +1,314 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/usecase/quiz"
+)
+
+type QuizHandler struct {
+	createQuizUC *quiz.CreateQuizUseCase
+	getQuizUC    *quiz.GetQuizUseCase
+	joinQuizUC   *quiz.JoinQuizUseCase
+	answerUC     *quiz.AnswerQuestionUseCase
+	presentUC    *quiz.PresentQuizUseCase
+	wsHub        *websocket.Hub
+}
+
+func NewQuizHandler(createQuizUC *quiz.CreateQuizUseCase, getQuizUC *quiz.GetQuizUseCase, joinQuizUC *quiz.JoinQuizUseCase, answerUC *quiz.AnswerQuestionUseCase, presentUC *quiz.PresentQuizUseCase, wsHub *websocket.Hub) *QuizHandler {
+	return &QuizHandler{
+		createQuizUC: createQuizUC,
+		getQuizUC:    getQuizUC,
+		joinQuizUC:   joinQuizUC,
+		answerUC:     answerUC,
+		presentUC:    presentUC,
+		wsHub:        wsHub,
+	}
+}
+
+// CreateQuiz godoc
+// @Summary Create a quiz
+// @Description Create a quiz of timed questions, each with its correct options (by position) and a time limit of 5 to 300 seconds, 20 by default. A question with several correct options expects all of them.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried"
+// @Param quiz body quiz.CreateQuizInput true "Quiz data"
+// @Success 201 {object} quiz.CreateQuizOutput
+// @Failure 400 {object} problem.Problem
+// @Failure 409 {object} problem.Problem "Idempotency-Key reused with another request"
+// @Router /api/v1/quizzes [post]
+func (h *QuizHandler) CreateQuiz(c *gin.Context) {
+	var input quiz.CreateQuizInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	input.CreatedBy = c.ClientIP()
+
+	output, err := h.createQuizUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, output)
+}
+
+// GetQuiz godoc
+// @Summary Get quiz details
+// @Description Get the state of a quiz and the questions started so far. The correct options of the current question stay hidden until it is over.
+// @Tags quizzes
+// @Produce json
+// @Param id path string true "Quiz ID" format(uuid)
+// @Success 200 {object} entity.Quiz
+// @Failure 400 {object} problem.Problem "Invalid quiz ID"
+// @Failure 404 {object} problem.Problem "Quiz not found"
+// @Router /api/v1/quizzes/{id} [get]
+func (h *QuizHandler) GetQuiz(c *gin.Context) {
+	quizID, ok := parseQuizID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getQuizUC.Execute(c.Request.Context(), quizID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+	if result.Due(time.Now()) {
+		h.expireDue(c.Request.Context(), quizID)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Leaderboard godoc
+// @Summary Get the leaderboard of a quiz
+// @Description Rank the players by score; players with the same score share their rank. The answers to the current question only count once it is over.
+// @Tags quizzes
+// @Produce json
+// @Param id path string true "Quiz ID" format(uuid)
+// @Success 200 {object} entity.Leaderboard
+// @Failure 400 {object} problem.Problem "Invalid quiz ID"
+// @Failure 404 {object} problem.Problem "Quiz not found"
+// @Router /api/v1/quizzes/{id}/leaderboard [get]
+func (h *QuizHandler) Leaderboard(c *gin.Context) {
+	quizID, ok := parseQuizID(c)
+	if !ok {
+		return
+	}
+
+	board, err := h.getQuizUC.Leaderboard(c.Request.Context(), quizID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+	if board.QuestionDue {
+		h.expireDue(c.Request.Context(), quizID)
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+// JoinQuiz godoc
+// @Summary Join a quiz
+// @Description Join a quiz under a nickname, unique within the quiz whatever its case. The returned player ID is needed to answer: keep it private.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Param id path string true "Quiz ID" format(uuid)
+// @Param player body quiz.JoinQuizInput true "Nickname"
+// @Success 201 {object} entity.QuizPlayer
+// @Failure 400 {object} problem.Problem "Missing or too long nickname"
+// @Failure 404 {object} problem.Problem "Quiz not found"
+// @Failure 409 {object} problem.Problem "Nickname taken, or quiz over"
+// @Router /api/v1/quizzes/{id}/players [post]
+func (h *QuizHandler) JoinQuiz(c *gin.Context) {
+	quizID, ok := parseQuizID(c)
+	if !ok {
+		return
+	}
+
+	var input quiz.JoinQuizInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	input.QuizID = quizID
+	input.VoterID = c.ClientIP()
+	input.IPAddress = c.ClientIP()
+	input.UserAgent = c.Request.UserAgent()
+
+	player, err := h.joinQuizUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, player)
+}
+
+// AnswerQuestion godoc
+// @Summary Answer the current question of a quiz
+// @Description Answer the question being played, once per player. The faster a correct answer, the more points: 1000 at once down to 500 at the time limit. Answers after the time limit or once the question is closed are rejected.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Param id path string true "Quiz ID" format(uuid)
+// @Param answer body quiz.AnswerQuestionInput true "Player ID, question ID and chosen options"
+// @Success 201 {object} entity.QuizAnswer
+// @Failure 400 {object} problem.Problem "Invalid options"
+// @Failure 404 {object} problem.Problem "Quiz or player not found"
+// @Failure 409 {object} problem.Problem "Already answered, question not started, or quiz over"
+// @Failure 410 {object} problem.Problem "Time to answer is over"
+// @Router /api/v1/quizzes/{id}/answers [post]
+func (h *QuizHandler) AnswerQuestion(c *gin.Context) {
+	// Le temps de réponse s'arrête à la réception, avant toute lecture
+	receivedAt := time.Now()
+	quizID, ok := parseQuizID(c)
+	if !ok {
+		return
+	}
+
+	var input quiz.AnswerQuestionInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	input.QuizID = quizID
+	input.ReceivedAt = receivedAt
+
+	answer, err := h.answerUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		// Une réponse tardive révèle une question à clore
+		if errors.Is(err, entity.ErrQuestionClosed) {
+			h.expireDue(c.Request.Context(), quizID)
+		}
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, answer)
+}
+
+// NextQuestion godoc
+// @Summary Move a quiz to its next question
+// @Description Close the current question if still open, then start the next one, or finish the quiz after the last one. Every step is pushed on the websocket of the quiz. Reserved to the creator of the quiz.
+// @Tags quizzes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quiz ID" format(uuid)
+// @Success 200 {object} quiz.QuizStep
+// @Failure 400 {object} problem.Problem "Invalid quiz ID"
+// @Failure 403 {object} problem.Problem "Not the creator of the quiz"
+// @Failure 404 {object} problem.Problem "Quiz not found"
+// @Failure 409 {object} problem.Problem "Quiz over, or moved on by a concurrent request"
+// @Router /api/v1/quizzes/{id}/next [post]
+func (h *QuizHandler) NextQuestion(c *gin.Context) {
+	quizID, ok := parseQuizID(c)
+	if !ok {
+		return
+	}
+
+	step, err := h.presentUC.Next(c.Request.Context(), quizID, c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	h.broadcastStep(c.Request.Context(), quizID, step)
+	if step.Started != nil {
+		h.scheduleExpiry(c, quizID, step.Started.Question.Position, step.Started.EndsAt)
+	}
+
+	c.JSON(http.StatusOK, step)
+}
+
+// CloseQuestion godoc
+// @Summary Close the current question of a quiz
+// @Description End the current question before its time limit and publish its results and the leaderboard. Reserved to the creator of the quiz.
+// @Tags quizzes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Quiz ID" format(uuid)
+// @Success 200 {object} quiz.QuizStep
+// @Failure 400 {object} problem.Problem "Invalid quiz ID"
+// @Failure 403 {object} problem.Problem "Not the creator of the quiz"
+// @Failure 404 {object} problem.Problem "Quiz not found"
+// @Failure 409 {object} problem.Problem "No question started, or quiz over"
+// @Failure 410 {object} problem.Problem "Question already closed"
+// @Router /api/v1/quizzes/{id}/close [post]
+func (h *QuizHandler) CloseQuestion(c *gin.Context) {
+	quizID, ok := parseQuizID(c)
+	if !ok {
+		return
+	}
+
+	step, err := h.presentUC.Close(c.Request.Context(), quizID, c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	h.broadcastStep(c.Request.Context(), quizID, step)
+
+	c.JSON(http.StatusOK, step)
+}
+
+// scheduleExpiry closes the question at position once its time is up,
+// unless the presenter closed it or moved on before. The timer only lives
+// in this instance: after a restart, or when the question is read through
+// another instance, expireDue closes it on the next read or answer instead.
+func (h *QuizHandler) scheduleExpiry(c *gin.Context, quizID uuid.UUID, position int, endsAt time.Time) {
+	// Le minuteur survit à la requête : on ne garde que les valeurs de son contexte (request ID)
+	ctx := context.WithoutCancel(c.Request.Context())
+	time.AfterFunc(time.Until(endsAt), func() {
+		step, err := h.presentUC.Expire(ctx, quizID, position)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close expired quiz question", "quiz_id", quizID, "position", position, "error", err)
+			return
+		}
+		h.broadcastStep(ctx, quizID, step)
+	})
+}
+
+// expireDue closes the current question of the quiz if its time is up and
+// pushes the step. It costs a read of its own, so callers only use it once
+// the quiz they loaded shows the question past its deadline. Failures are
+// only logged: readers and players already see the question as over.
+func (h *QuizHandler) expireDue(ctx context.Context, quizID uuid.UUID) {
+	step, err := h.presentUC.ExpireDue(ctx, quizID)
+	if err != nil {
+		if !errors.Is(err, entity.ErrQuizNotFound) {
+			slog.ErrorContext(ctx, "failed to close expired quiz question", "quiz_id", quizID, "error", err)
+		}
+		return
+	}
+	h.broadcastStep(ctx, quizID, step)
+}
+
+// broadcastStep pushes the question that ended, the leaderboard and the
+// question that started to the websocket subscribers of the quiz, in that order
+func (h *QuizHandler) broadcastStep(ctx context.Context, quizID uuid.UUID, step *quiz.QuizStep) {
+	if step == nil {
+		return
+	}
+	if step.Ended != nil {
+		h.wsHub.BroadcastQuestionEnd(ctx, quizID, step.Ended)
+	}
+	if step.Leaderboard != nil {
+		h.wsHub.BroadcastLeaderboard(ctx, quizID, step.Leaderboard)
+	}
+	if step.Started != nil {
+		h.wsHub.BroadcastQuestionStart(ctx, quizID, step.Started)
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/usecase/poll"
+	"microservice-go-gin/internal/usecase/vote"
+)
+
+type ResponseHandler struct {
+	createResponseUC *vote.CreateResponseUseCase
+	responsesUC      *poll.TextResponsesUseCase
+	wsHub            *websocket.Hub
+}
+
+func NewResponseHandler(createResponseUC *vote.CreateResponseUseCase, responsesUC *poll.TextResponsesUseCase, wsHub *websocket.Hub) *ResponseHandler {
+	return &ResponseHandler{
+		createResponseUC: createResponseUC,
+		responsesUC:      responsesUC,
+		wsHub:            wsHub,
+	}
+}
+
+// CreateResponse godoc
+// @Summary Answer a text poll
+// @Description Submit a free-text response of at most 500 characters, stripped of markup and control characters. Responses to a moderated poll stay pending until its creator approves them.
+// @Tags responses
+// @Accept json
+// @Produce json
+// @Param id path string true "Poll ID" format(uuid)
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried"
+// @Param response body vote.CreateResponseInput true "Response text"
+// @Success 201 {object} entity.TextResponse
+// @Failure 400 {object} problem.Problem "Invalid text, or not a text poll"
+// @Failure 401 {object} problem.Problem "Authentication required"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Failure 409 {object} problem.Problem "Already answered, or Idempotency-Key reused with another request"
+// @Failure 410 {object} problem.Problem "Poll expired"
+// @Router /api/v1/polls/{id}/responses [post]
+func (h *ResponseHandler) CreateResponse(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	var input vote.CreateResponseInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	input.PollID = pollID
+	input.VoterID = c.ClientIP()
+
+	response, err := h.createResponseUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	h.broadcast(c, response)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListResponses godoc
+// @Summary Text responses of a poll
+// @Description Approved responses of a text poll, newest first. Pending and rejected responses are reserved to the creator of the poll.
+// @Tags responses
+// @Produce json
+// @Param id path string true "Poll ID" format(uuid)
+// @Param status query string false "Status of the responses" Enums(approved, pending, rejected) default(approved)
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Page size" default(50)
+// @Success 200 {object} poll.ResponsePage
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Router /api/v1/polls/{id}/responses [get]
+func (h *ResponseHandler) ListResponses(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.responsesUC.List(c.Request.Context(), poll.ResponseQuery{
+		PollID:    pollID,
+		Status:    c.Query("status"),
+		Requester: c.ClientIP(),
+		Offset:    offset,
+		Limit:     limit,
+	})
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// ModerateResponse godoc
+// @Summary Moderate a text response
+// @Description Approve or reject a response of a text poll. Reserved to its creator; approved responses are published on the websocket of the poll.
+// @Tags responses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Param response_id path string true "Response ID" format(uuid)
+// @Param moderation body poll.ModerateInput true "New status"
+// @Success 200 {object} entity.TextResponse
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll or response not found"
+// @Router /api/v1/polls/{id}/responses/{response_id} [patch]
+func (h *ResponseHandler) ModerateResponse(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+	responseID, err := uuid.Parse(c.Param("response_id"))
+	if err != nil {
+		problem.Write(c, errInvalidResponseID)
+		return
+	}
+
+	var input poll.ModerateInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	input.PollID = pollID
+	input.ResponseID = responseID
+	input.Requester = c.ClientIP()
+
+	response, err := h.responsesUC.Moderate(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+	h.broadcast(c, response)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// broadcast publishes an approved response to the websocket subscribers of its poll
+func (h *ResponseHandler) broadcast(c *gin.Context, response *entity.TextResponse) {
+	if response.Status != entity.ResponseApproved {
+		return
+	}
+	h.wsHub.BroadcastNewResponse(c.Request.Context(), response.PollID, map[string]interface{}{
+		"poll_id":     response.PollID.String(),
+		"response_id": response.ID.String(),
+		"text":        response.Text,
+		"created_at":  response.CreatedAt,
+	})
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/infrastructure/ics"
+	"microservice-go-gin/internal/usecase/poll"
+	"microservice-go-gin/internal/usecase/vote"
+)
+
+type ScheduleHandler struct {
+	submitAvailabilityUC *vote.SubmitAvailabilityUseCase
+	getPollUC            *poll.GetPollUseCase
+	wsHub                *websocket.Hub
+	baseURL              string
+}
+
+func NewScheduleHandler(submitAvailabilityUC *vote.SubmitAvailabilityUseCase, getPollUC *poll.GetPollUseCase, wsHub *websocket.Hub, baseURL string) *ScheduleHandler {
+	return &ScheduleHandler{
+		submitAvailabilityUC: submitAvailabilityUC,
+		getPollUC:            getPollUC,
+		wsHub:                wsHub,
+		baseURL:              baseURL,
+	}
+}
+
+// SubmitAvailability godoc
+// @Summary Answer a scheduling poll
+// @Description Answer yes, if_need_be or no to every time slot of the poll under a display name, which is published with the results. The slots ranked again are pushed on the websocket of the poll.
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Param id path string true "Poll ID" format(uuid)
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried"
+// @Param availability body vote.SubmitAvailabilityInput true "Availability"
+// @Success 201 {array} entity.Availability
+// @Failure 400 {object} problem.Problem "Missing or unknown time slot, or not a scheduling poll"
+// @Failure 401 {object} problem.Problem "Authentication required"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Failure 409 {object} problem.Problem "Already answered, or Idempotency-Key reused with another request"
+// @Failure 410 {object} problem.Problem "Poll expired"
+// @Router /api/v1/polls/{id}/availability [post]
+func (h *ScheduleHandler) SubmitAvailability(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	var input vote.SubmitAvailabilityInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	input.PollID = pollID
+	input.VoterID = c.ClientIP()
+
+	output, err := h.submitAvailabilityUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	h.wsHub.BroadcastAvailability(c.Request.Context(), pollID, output.Slots)
+
+	c.JSON(http.StatusCreated, output.Availabilities)
+}
+
+// ExportCalendar godoc
+// @Summary Export the winning slot of a scheduling poll
+// @Description iCalendar file with one event for the time slot the most participants are available for, if need be.
+// @Tags schedule
+// @Produce text/calendar
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 200 {string} string "iCalendar file"
+// @Failure 400 {object} problem.Problem "Not a scheduling poll"
+// @Failure 404 {object} problem.Problem "Poll not found, or nobody available yet"
+// @Router /api/v1/polls/{id}/calendar.ics [get]
+func (h *ScheduleHandler) ExportCalendar(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	scheduled, slot, err := h.getPollUC.WinningSlot(c.Request.Context(), pollID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	calendar := ics.Encode(ics.Event{
+		UID:         slot.OptionID.String() + "@quickpoll",
+		Summary:     scheduled.Title,
+		Description: scheduled.Description,
+		URL:         h.baseURL + "/poll/" + scheduled.ID.String(),
+		Start:       slot.StartsAt,
+		End:         slot.EndsAt,
+		Stamp:       time.Now(),
+	})
+	c.Header("Content-Disposition", `attachment; filename="poll-`+pollID.String()+`.ics"`)
+	c.Data(http.StatusOK, ics.ContentType, calendar)
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/infrastructure/database"
+	"microservice-go-gin/internal/usecase/survey"
+)
+
+// SurveyResponseRequest represents the request body for answering a survey
+type SurveyResponseRequest struct {
+	// Answers holds the chosen option IDs keyed by question ID
+	Answers map[uuid.UUID][]uuid.UUID `json:"answers" binding:"required"`
+}
+
+// SurveyResponseResponse represents the response after answering a survey
+type SurveyResponseResponse struct {
+	Message string `json:"message" example:"survey response submitted successfully"`
+}
+
+// HasRespondedResponse represents the response for checking if user has answered a survey
+type HasRespondedResponse struct {
+	HasResponded bool `json:"has_responded" example:"true"`
+}
+
+type SurveyHandler struct {
+	createSurveyUC *survey.CreateSurveyUseCase
+	getSurveyUC    *survey.GetSurveyUseCase
+	submitSurveyUC *survey.SubmitSurveyUseCase
+	ownerDataUC    *survey.SurveyOwnerDataUseCase
+	wsHub          *websocket.Hub
+}
+
+func NewSurveyHandler(createSurveyUC *survey.CreateSurveyUseCase, getSurveyUC *survey.GetSurveyUseCase, submitSurveyUC *survey.SubmitSurveyUseCase, ownerDataUC *survey.SurveyOwnerDataUseCase, wsHub *websocket.Hub) *SurveyHandler {
+	return &SurveyHandler{
+		createSurveyUC: createSurveyUC,
+		getSurveyUC:    getSurveyUC,
+		submitSurveyUC: submitSurveyUC,
+		ownerDataUC:    ownerDataUC,
+		wsHub:          wsHub,
+	}
+}
+
+// CreateSurvey godoc
+// @Summary Create a survey
+// @Description Create a survey of ordered questions, each with its own options and multi-choice setting. Questions may be optional or only asked when an earlier answer matches (show_if, by position).
+// @Tags surveys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried"
+// @Param survey body survey.CreateSurveyInput true "Survey data"
+// @Success 201 {object} survey.CreateSurveyOutput
+// @Failure 400 {object} problem.Problem
+// @Failure 409 {object} problem.Problem "Idempotency-Key reused with another request"
+// @Router /api/v1/surveys [post]
+func (h *SurveyHandler) CreateSurvey(c *gin.Context) {
+	var input survey.CreateSurveyInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	input.CreatedBy = c.ClientIP()
+
+	output, err := h.createSurveyUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, output)
+}
+
+// GetSurvey godoc
+// @Summary Get survey details
+// @Description Get the questions of a survey in order, with the results of each question
+// @Tags surveys
+// @Produce json
+// @Param id path string true "Survey ID" format(uuid)
+// @Success 200 {object} entity.Survey
+// @Failure 400 {object} problem.Problem "Invalid survey ID"
+// @Failure 404 {object} problem.Problem "Survey not found"
+// @Router /api/v1/surveys/{id} [get]
+func (h *SurveyHandler) GetSurvey(c *gin.Context) {
+	surveyID, ok := parseSurveyID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getSurveyUC.Execute(c.Request.Context(), surveyID)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SubmitResponse godoc
+// @Summary Answer a survey
+// @Description Submit the answers to every question at once: when one answer is rejected, none is recorded. Required questions must be answered unless hidden by their condition.
+// @Tags surveys
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey ID" format(uuid)
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried"
+// @Param response body SurveyResponseRequest true "Option IDs keyed by question ID"
+// @Success 200 {object} SurveyResponseResponse
+// @Failure 400 {object} problem.Problem "Missing, hidden or invalid answers"
+// @Failure 401 {object} problem.Problem "Authentication required"
+// @Failure 404 {object} problem.Problem "Survey not found"
+// @Failure 409 {object} problem.Problem "Already answered, or Idempotency-Key reused with another request"
+// @Failure 410 {object} problem.Problem "Survey expired"
+// @Router /api/v1/surveys/{id}/responses [post]
+func (h *SurveyHandler) SubmitResponse(c *gin.Context) {
+	surveyID, ok := parseSurveyID(c)
+	if !ok {
+		return
+	}
+
+	var request SurveyResponseRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	input := survey.SubmitSurveyInput{
+		SurveyID:  surveyID,
+		Answers:   request.Answers,
+		VoterID:   c.ClientIP(),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	if err := h.submitSurveyUC.Execute(c.Request.Context(), input); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	h.broadcast(c, surveyID, request.Answers)
+
+	c.JSON(http.StatusOK, SurveyResponseResponse{Message: "survey response submitted successfully"})
+}
+
+// broadcast sends the new counts of the answered options to the websocket
+// subscribers of each question, read from the primary to include this response
+func (h *SurveyHandler) broadcast(c *gin.Context, surveyID uuid.UUID, answers map[uuid.UUID][]uuid.UUID) {
+	result, err := h.getSurveyUC.Execute(database.WithPrimary(c.Request.Context()), surveyID)
+	if err != nil {
+		return
+	}
+	for _, question := range result.Questions {
+		chosen := answers[question.ID]
+		if len(chosen) == 0 {
+			continue
+		}
+		optionVotes := make(map[uuid.UUID]int, len(question.Options))
+		totalVotes := 0
+		for _, option := range question.Options {
+			optionVotes[option.ID] = option.VoteCount
+			totalVotes += option.VoteCount
+		}
+		for _, optionID := range chosen {
+			h.wsHub.BroadcastVoteUpdate(c.Request.Context(), question.ID, map[string]interface{}{
+				"option_id":   optionID.String(),
+				"poll_id":     question.ID.String(),
+				"votes":       optionVotes[optionID],
+				"total_votes": totalVotes,
+			})
+		}
+	}
+}
+
+// HasResponded godoc
+// @Summary Check if user has answered a survey
+// @Description Check if the current user (by IP) has already answered this survey
+// @Tags surveys
+// @Produce json
+// @Param id path string true "Survey ID" format(uuid)
+// @Success 200 {object} HasRespondedResponse
+// @Failure 400 {object} problem.Problem "Invalid survey ID"
+// @Failure 404 {object} problem.Problem "Survey not found"
+// @Router /api/v1/surveys/{id}/has-responded [get]
+func (h *SurveyHandler) HasResponded(c *gin.Context) {
+	surveyID, ok := parseSurveyID(c)
+	if !ok {
+		return
+	}
+
+	responded, err := h.submitSurveyUC.HasResponded(c.Request.Context(), surveyID, c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, HasRespondedResponse{HasResponded: responded})
+}
+
+// ExportResults godoc
+// @Summary Export the results of a survey
+// @Description CSV with one line per option of every question and its vote count. Reserved to the creator; API keys need the export scope.
+// @Tags surveys
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path string true "Survey ID" format(uuid)
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the survey"
+// @Failure 404 {object} problem.Problem "Survey not found"
+// @Router /api/v1/surveys/{id}/export [get]
+func (h *SurveyHandler) ExportResults(c *gin.Context) {
+	surveyID, ok := parseSurveyID(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.ownerDataUC.Results(c.Request.Context(), surveyID, c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="survey-`+surveyID.String()+`.csv"`)
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"question", "question_id", "title", "option_id", "option", "votes"})
+	for _, question := range results.Questions {
+		for _, option := range question.Options {
+			w.Write([]string{
+				strconv.Itoa(question.Position + 1),
+				question.ID.String(),
+				question.Title,
+				option.ID.String(),
+				option.Text,
+				strconv.Itoa(option.VoteCount),
+			})
+		}
+	}
+	w.Flush()
+}
@@ -1,65 +1,83 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"microservice-go-gin/internal/delivery/http/problem"
 	"microservice-go-gin/internal/delivery/websocket"
-	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/database"
 	"microservice-go-gin/internal/usecase/poll"
 	"microservice-go-gin/internal/usecase/vote"
 )
 
 // VoteRequest represents the request body for voting
 type VoteRequest struct {
-	OptionIDs []string `json:"option_ids" binding:"required,min=1" example:"550e8400-e29b-41d4-a716-446655440001"`
+	OptionIDs []string `json:"option_ids" binding:"required_without=WriteIn,unique" example:"550e8400-e29b-41d4-a716-446655440001"`
+	// WriteIn adds an option of the voter's own when the poll allows it
+	WriteIn string `json:"write_in" example:"Zig"`
 }
 
 // VoteResponse represents the response after voting
 type VoteResponse struct {
 	Message string `json:"message" example:"vote submitted successfully"`
+	// WriteIn is the option written in by the voter, pending when the poll is moderated
+	WriteIn *entity.Option `json:"write_in,omitempty"`
+	// Waitlisted are the places of the voter in the waitlists of the full
+	// options of a sign-up sheet
+	Waitlisted []*entity.WaitlistEntry `json:"waitlisted,omitempty"`
+}
+
+// WithdrawVoteResponse represents the response after withdrawing a vote
+type WithdrawVoteResponse struct {
+	Message string `json:"message" example:"vote withdrawn successfully"`
+	// Promoted is the number of waitlisted voters given the freed places
+	Promoted int `json:"promoted" example:"1"`
 }
 
 type VoteHandler struct {
-	createVoteUC *vote.CreateVoteUseCase
-	getPollUC    *poll.GetPollUseCase
-	wsHub        *websocket.Hub
-	voteRepo     repository.VoteRepository
+	createVoteUC   *vote.CreateVoteUseCase
+	withdrawVoteUC *vote.WithdrawVoteUseCase
+	getPollUC      *poll.GetPollUseCase
+	wsHub          *websocket.Hub
 }
 
-func NewVoteHandler(createVoteUC *vote.CreateVoteUseCase, getPollUC *poll.GetPollUseCase, wsHub *websocket.Hub, voteRepo repository.VoteRepository) *VoteHandler {
+func NewVoteHandler(createVoteUC *vote.CreateVoteUseCase, withdrawVoteUC *vote.WithdrawVoteUseCase, getPollUC *poll.GetPollUseCase, wsHub *websocket.Hub) *VoteHandler {
 	return &VoteHandler{
-		createVoteUC: createVoteUC,
-		getPollUC:    getPollUC,
-		wsHub:        wsHub,
-		voteRepo:     voteRepo,
+		createVoteUC:   createVoteUC,
+		withdrawVoteUC: withdrawVoteUC,
+		getPollUC:      getPollUC,
+		wsHub:          wsHub,
 	}
 }
 
 // CreateVote godoc
 // @Summary Submit a vote
-// @Description Submit a vote for one or more options in a poll
+// @Description Submit a vote for one or more options in a poll. Polls with allow_write_in also accept a write_in text: it votes for the option with the same text regardless of case, or adds it, pending the approval of the creator when the poll is moderated. On a sign-up sheet, a vote for a full option is rejected, or put on the waitlist of the option when the poll has one.
 // @Tags votes
 // @Accept json
 // @Produce json
 // @Param id path string true "Poll ID" format(uuid)
+// @Param Idempotency-Key header string false "Replays the first response when the request is retried"
 // @Param vote body VoteRequest true "Vote data with option IDs"
 // @Success 200 {object} VoteResponse "Vote submitted successfully"
-// @Failure 400 {object} map[string]string "Invalid request or voting error"
+// @Failure 400 {object} problem.Problem "Invalid request or option"
+// @Failure 401 {object} problem.Problem "Authentication required"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Failure 409 {object} problem.Problem "Already voted, option limit reached, write-in awaiting moderation, option full, or Idempotency-Key reused with another request"
+// @Failure 410 {object} problem.Problem "Poll expired"
 // @Router /api/v1/polls/{id}/vote [post]
 func (h *VoteHandler) CreateVote(c *gin.Context) {
-	pollIDStr := c.Param("id")
-	pollID, err := uuid.Parse(pollIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid poll ID"})
+	pollID, ok := parsePollID(c)
+	if !ok {
 		return
 	}
 
 	var requestBody VoteRequest
-
-	if err := c.ShouldBindJSON(&requestBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &requestBody) {
 		return
 	}
 
@@ -67,7 +85,7 @@ func (h *VoteHandler) CreateVote(c *gin.Context) {
 	for _, idStr := range requestBody.OptionIDs {
 		optionID, err := uuid.Parse(idStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid option ID"})
+			problem.Write(c, errInvalidOptionID)
 			return
 		}
 		optionIDs = append(optionIDs, optionID)
@@ -76,40 +94,58 @@ func (h *VoteHandler) CreateVote(c *gin.Context) {
 	input := vote.CreateVoteInput{
 		PollID:    pollID,
 		OptionIDs: optionIDs,
+		WriteIn:   requestBody.WriteIn,
 		VoterID:   c.ClientIP(),
 		IPAddress: c.ClientIP(),
 		UserAgent: c.Request.UserAgent(),
 	}
 
-	if err := h.createVoteUC.Execute(c.Request.Context(), input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	output, err := h.createVoteUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
 		return
 	}
 
-	// Get updated poll data and broadcast via WebSocket
-	updatedPoll, err := h.getPollUC.Execute(c.Request.Context(), pollID)
-	if err == nil {
-		// Create a map of option votes for quick lookup
-		optionVotes := make(map[string]int)
-		totalVotes := 0
-		for _, option := range updatedPoll.Options {
-			optionVotes[option.ID.String()] = option.VoteCount
-			totalVotes += option.VoteCount
-		}
+	// Nouvelle option publiée : les clients l'ajoutent avant de recevoir ses votes
+	if output.WriteInCreated && !output.WriteIn.Pending {
+		h.wsHub.BroadcastOptionAdded(c.Request.Context(), pollID, output.WriteIn)
+	}
 
-		// Broadcast updates for each voted option
-		for _, optionID := range optionIDs {
-			voteData := map[string]interface{}{
-				"option_id":   optionID.String(),
-				"poll_id":     pollID.String(),
-				"votes":       optionVotes[optionID.String()],
-				"total_votes": totalVotes,
-			}
-			h.wsHub.BroadcastVoteUpdate(pollID, voteData)
-		}
+	h.broadcastResults(c.Request.Context(), pollID, output.OptionIDs)
+
+	c.JSON(http.StatusOK, VoteResponse{Message: "vote submitted successfully", WriteIn: output.WriteIn, Waitlisted: output.Waitlisted})
+}
+
+// WithdrawVote godoc
+// @Summary Withdraw a vote
+// @Description Withdraw the vote of the current user (by IP) from a sign-up sheet, and their places in waitlists. The freed places go to the first waitlisted voters.
+// @Tags votes
+// @Produce json
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 200 {object} WithdrawVoteResponse "Vote withdrawn successfully"
+// @Failure 400 {object} problem.Problem "Invalid poll ID or survey question"
+// @Failure 404 {object} problem.Problem "Poll or vote not found"
+// @Failure 409 {object} problem.Problem "Not a sign-up sheet"
+// @Failure 410 {object} problem.Problem "Poll expired"
+// @Router /api/v1/polls/{id}/vote [delete]
+func (h *VoteHandler) WithdrawVote(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
 	}
 
-	c.JSON(http.StatusOK, VoteResponse{Message: "vote submitted successfully"})
+	withdrawal, err := h.withdrawVoteUC.Execute(c.Request.Context(), vote.WithdrawVoteInput{
+		PollID:  pollID,
+		VoterID: c.ClientIP(),
+	})
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	h.broadcastResults(c.Request.Context(), pollID, withdrawal.OptionIDs)
+
+	c.JSON(http.StatusOK, WithdrawVoteResponse{Message: "vote withdrawn successfully", Promoted: len(withdrawal.Promoted)})
 }
 
 // HasVotedResponse represents the response for checking if user has voted
@@ -124,22 +160,56 @@ type HasVotedResponse struct {
 // @Produce json
 // @Param id path string true "Poll ID" format(uuid)
 // @Success 200 {object} HasVotedResponse "Voting status"
-// @Failure 400 {object} map[string]string "Invalid poll ID"
+// @Failure 400 {object} problem.Problem "Invalid poll ID"
 // @Router /api/v1/polls/{id}/has-voted [get]
 func (h *VoteHandler) HasVoted(c *gin.Context) {
-	pollIDStr := c.Param("id")
-	pollID, err := uuid.Parse(pollIDStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid poll ID"})
+	pollID, ok := parsePollID(c)
+	if !ok {
 		return
 	}
 
 	voterID := c.ClientIP()
-	hasVoted, err := h.voteRepo.HasVoted(c.Request.Context(), pollID, voterID)
+	hasVoted, err := h.createVoteUC.HasVoted(c.Request.Context(), pollID, voterID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check voting status"})
+		problem.Write(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, HasVotedResponse{HasVoted: hasVoted})
-}
\ No newline at end of file
+}
+
+// broadcastResults publishes the vote counts of optionIDs and, on a sign-up
+// sheet, the remaining capacity of its options
+func (h *VoteHandler) broadcastResults(ctx context.Context, pollID uuid.UUID, optionIDs []uuid.UUID) {
+	// Read from the primary so that the broadcast includes this vote.
+	updatedPoll, err := h.getPollUC.Execute(database.WithPrimary(ctx), pollID)
+	if err != nil {
+		return
+	}
+
+	// Create a map of option votes for quick lookup
+	optionVotes := make(map[string]int)
+	totalVotes := 0
+	for _, option := range updatedPoll.Options {
+		optionVotes[option.ID.String()] = option.VoteCount
+		totalVotes += option.VoteCount
+	}
+
+	// Broadcast updates for each option, except write-ins awaiting approval
+	for _, optionID := range optionIDs {
+		if _, published := optionVotes[optionID.String()]; !published {
+			continue
+		}
+		voteData := map[string]interface{}{
+			"option_id":   optionID.String(),
+			"poll_id":     pollID.String(),
+			"votes":       optionVotes[optionID.String()],
+			"total_votes": totalVotes,
+		}
+		h.wsHub.BroadcastVoteUpdate(ctx, pollID, voteData)
+	}
+
+	if capacities := updatedPoll.Capacities(); len(capacities) > 0 {
+		h.wsHub.BroadcastCapacity(ctx, pollID, capacities)
+	}
+}
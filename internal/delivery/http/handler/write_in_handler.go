@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/usecase/poll"
+)
+
+type WriteInHandler struct {
+	writeInsUC *poll.WriteInsUseCase
+	wsHub      *websocket.Hub
+}
+
+func NewWriteInHandler(writeInsUC *poll.WriteInsUseCase, wsHub *websocket.Hub) *WriteInHandler {
+	return &WriteInHandler{
+		writeInsUC: writeInsUC,
+		wsHub:      wsHub,
+	}
+}
+
+// ListPendingWriteIns godoc
+// @Summary Write-in options awaiting approval
+// @Description Options written in by voters on a moderated poll, with their votes so far. Reserved to the creator of the poll.
+// @Tags polls
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Success 200 {array} entity.Option
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll not found"
+// @Router /api/v1/polls/{id}/write-ins [get]
+func (h *WriteInHandler) ListPendingWriteIns(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+
+	options, err := h.writeInsUC.Pending(c.Request.Context(), pollID, c.ClientIP())
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// ModerateWriteIn godoc
+// @Summary Moderate a write-in option
+// @Description Approve a pending write-in option, which is then published on the websocket of the poll, or reject it: the option and its ballots are deleted. Reserved to the creator of the poll.
+// @Tags polls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Poll ID" format(uuid)
+// @Param option_id path string true "Option ID" format(uuid)
+// @Param moderation body poll.ModerateOptionInput true "New status"
+// @Success 200 {object} entity.Option
+// @Failure 400 {object} problem.Problem
+// @Failure 403 {object} problem.Problem "Not the creator of the poll"
+// @Failure 404 {object} problem.Problem "Poll or pending option not found"
+// @Router /api/v1/polls/{id}/options/{option_id} [patch]
+func (h *WriteInHandler) ModerateWriteIn(c *gin.Context) {
+	pollID, ok := parsePollID(c)
+	if !ok {
+		return
+	}
+	optionID, err := uuid.Parse(c.Param("option_id"))
+	if err != nil {
+		problem.Write(c, errInvalidOptionID)
+		return
+	}
+
+	var input poll.ModerateOptionInput
+	if !bindJSON(c, &input) {
+		return
+	}
+	input.PollID = pollID
+	input.OptionID = optionID
+	input.Requester = c.ClientIP()
+
+	option, err := h.writeInsUC.Moderate(c.Request.Context(), input)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+	if !option.Pending {
+		h.wsHub.BroadcastOptionAdded(c.Request.Context(), pollID, option)
+	}
+
+	c.JSON(http.StatusOK, option)
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/infrastructure/auth"
+)
+
+var errBanned = apperror.Forbidden("banned", "you are banned from this service")
+
+// BanChecker reports whether a client IP address or a user is banned
+type BanChecker interface {
+	IsBanned(ctx context.Context, ip, user string) (bool, error)
+}
+
+// RejectBanned refuses the request with 403 when the caller is banned.
+// A failing check lets the request through: a ban store outage must not stop voting.
+func RejectBanned(checker BanChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := ""
+		if principal := auth.PrincipalFromContext(c.Request.Context()); principal != nil {
+			user = principal.Actor
+		}
+
+		banned, err := checker.IsBanned(c.Request.Context(), c.ClientIP(), user)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "ban check failed", "error", err)
+		}
+		if banned {
+			problem.Abort(c, errBanned)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/cache"
+	"microservice-go-gin/internal/infrastructure/privacy"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients retry a POST without repeating its effect
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed from a previous attempt
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTTL bounds how long a request that never completes
+	// (crashed instance) blocks retries with the same key
+	idempotencyLockTTL = time.Minute
+	// maxIdempotentBody caps the request bodies read to compute the fingerprint
+	maxIdempotentBody = 1 << 20
+)
+
+var (
+	errIdempotencyKeyTooLong = apperror.Invalid("idempotency_key_too_long", "Idempotency-Key must be at most 255 characters")
+	errUnreadableBody        = apperror.Invalid("invalid_body", "failed to read request body")
+	errBodyTooLarge          = apperror.New(apperror.KindTooLarge, "request_too_large", "request body too large")
+	errIdempotencyKeyReused  = apperror.Conflict("idempotency_key_reused", "Idempotency-Key was already used with a different request")
+	errIdempotencyInProgress = apperror.Conflict("idempotency_in_progress", "a request with this Idempotency-Key is in progress, retry later")
+)
+
+// idempotentResponse is what is stored under an idempotency key: a pending
+// marker while the first request runs, then its response
+type idempotentResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	Pending     bool   `json:"pending,omitempty"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// capturingWriter keeps a copy of the response body
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response when a request is retried with
+// the same Idempotency-Key and body, and answers 409 when the key is reused
+// for a different request or while the first attempt is still running.
+// Server errors are not stored so that they can be retried. A failing store
+// lets requests through without protection. Keys are scoped to the
+// authenticated caller, or to the client IP address hashed with ipHasher.
+func Idempotency(store cache.Cache, ttl time.Duration, ipHasher *privacy.IPHasher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			problem.Abort(c, errIdempotencyKeyTooLong)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxIdempotentBody+1))
+		if err != nil {
+			problem.Abort(c, errUnreadableBody.Wrap(err))
+			return
+		}
+		if len(body) > maxIdempotentBody {
+			problem.Abort(c, errBodyTooLarge)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		storeKey := idempotencyStoreKey(idempotencyScope(c, ipHasher), key)
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, body)
+
+		pending, _ := json.Marshal(idempotentResponse{Fingerprint: fingerprint, Pending: true})
+		claimed, err := store.SetNX(ctx, storeKey, pending, idempotencyLockTTL)
+		if err != nil {
+			slog.ErrorContext(ctx, "idempotency store unavailable", "error", err)
+			c.Next()
+			return
+		}
+		if !claimed {
+			replayIdempotent(c, store, storeKey, fingerprint)
+			return
+		}
+
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// Un contexte détaché : la réponse doit être enregistrée même si le client s'est déconnecté
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if writer.Status() >= http.StatusInternalServerError {
+			if err := store.Delete(saveCtx, storeKey); err != nil {
+				slog.ErrorContext(ctx, "failed to release idempotency key", "error", err)
+			}
+			return
+		}
+		record, _ := json.Marshal(idempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		if err := store.Set(saveCtx, storeKey, record, ttl); err != nil {
+			slog.ErrorContext(ctx, "failed to store idempotent response", "error", err)
+		}
+	}
+}
+
+// replayIdempotent answers a retry from the record stored under storeKey
+func replayIdempotent(c *gin.Context, store cache.Cache, storeKey, fingerprint string) {
+	raw, err := store.Get(c.Request.Context(), storeKey)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			// Le premier essai vient d'échouer et a libéré la clé
+			problem.Abort(c, errIdempotencyInProgress)
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "idempotency store unavailable", "error", err)
+		c.Next()
+		return
+	}
+
+	var record idempotentResponse
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		problem.Abort(c, fmt.Errorf("corrupted idempotency record: %w", err))
+		return
+	}
+	switch {
+	case record.Fingerprint != fingerprint:
+		problem.Abort(c, errIdempotencyKeyReused)
+	case record.Pending:
+		problem.Abort(c, errIdempotencyInProgress)
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(record.Status, record.ContentType, record.Body)
+		c.Abort()
+	}
+}
+
+// idempotencyScope identifies the caller a key belongs to: the authenticated
+// principal, else the client IP address, so that anonymous clients cannot
+// replay the responses of one another
+func idempotencyScope(c *gin.Context, ipHasher *privacy.IPHasher) string {
+	if principal := auth.PrincipalFromContext(c.Request.Context()); principal != nil {
+		return "actor:" + principal.Actor
+	}
+	return "ip:" + ipHasher.Pseudonym(privacy.TruncateIP(c.ClientIP()))
+}
+
+// idempotencyStoreKey scopes key to the caller identified by scope
+func idempotencyStoreKey(scope, key string) string {
+	sum := sha256.Sum256([]byte(scope + "\x00" + key))
+	return "idempotency:" + hex.EncodeToString(sum[:])
+}
+
+// requestFingerprint identifies the target and body of a request
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\x00"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,126 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/delivery/http/middleware"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/cache"
+)
+
+// idempotentServer compte les sondages créés derrière le middleware ;
+// hold, s'il est fourni, est appelé par le handler avant de répondre
+func idempotentServer(t *testing.T, hold func()) (*gin.Engine, *atomic.Int64) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	store := cache.NewMemoryCache()
+	t.Cleanup(func() { store.Close() })
+
+	var created atomic.Int64
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if actor := c.GetHeader("X-Test-Actor"); actor != "" {
+			c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), &auth.Principal{Actor: actor}))
+		}
+		c.Next()
+	})
+	router.Use(middleware.Idempotency(store, time.Hour, nil))
+	router.POST("/api/v1/polls", func(c *gin.Context) {
+		if hold != nil {
+			hold()
+		}
+		c.JSON(http.StatusCreated, gin.H{"poll": created.Add(1)})
+	})
+	return router, &created
+}
+
+func postPoll(router *gin.Engine, ip, actor, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/polls", strings.NewReader(body))
+	req.RemoteAddr = ip + ":40000"
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	if actor != "" {
+		req.Header.Set("X-Test-Actor", actor)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func problemCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var p problem.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	return p.Code
+}
+
+func TestIdempotency_ReplaysRetry(t *testing.T) {
+	router, created := idempotentServer(t, nil)
+
+	first := postPoll(router, "203.0.113.7", "", "key-1", `{"title":"Lunch"}`)
+	retry := postPoll(router, "203.0.113.7", "", "key-1", `{"title":"Lunch"}`)
+
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, int64(1), created.Load())
+}
+
+func TestIdempotency_ScopesKeysToTheCaller(t *testing.T) {
+	router, created := idempotentServer(t, nil)
+
+	postPoll(router, "203.0.113.7", "", "key-1", `{"title":"Lunch"}`)
+
+	// Un autre client anonyme qui devine la clé ne rejoue pas la réponse du premier
+	other := postPoll(router, "198.51.100.4", "", "key-1", `{"title":"Lunch"}`)
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.Empty(t, other.Header().Get(middleware.IdempotentReplayedHeader))
+
+	// Un appelant authentifié a sa propre portée, même derrière la même adresse
+	authenticated := postPoll(router, "203.0.113.7", "api-key:ci", "key-1", `{"title":"Lunch"}`)
+	assert.Equal(t, http.StatusCreated, authenticated.Code)
+	assert.Empty(t, authenticated.Header().Get(middleware.IdempotentReplayedHeader))
+
+	assert.Equal(t, int64(3), created.Load())
+}
+
+func TestIdempotency_RejectsKeyReusedWithAnotherBody(t *testing.T) {
+	router, created := idempotentServer(t, nil)
+
+	postPoll(router, "203.0.113.7", "", "key-1", `{"title":"Lunch"}`)
+	reused := postPoll(router, "203.0.113.7", "", "key-1", `{"title":"Dinner"}`)
+
+	assert.Equal(t, http.StatusConflict, reused.Code)
+	assert.Equal(t, "idempotency_key_reused", problemCode(t, reused))
+	assert.Equal(t, int64(1), created.Load())
+}
+
+func TestIdempotency_RejectsRetryWhileFirstAttemptRuns(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	router, created := idempotentServer(t, func() {
+		close(started)
+		<-release
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- postPoll(router, "203.0.113.7", "", "key-1", `{"title":"Lunch"}`) }()
+	<-started
+
+	// Le premier essai garde la clé réservée tant qu'il n'a pas répondu
+	retry := postPoll(router, "203.0.113.7", "", "key-1", `{"title":"Lunch"}`)
+	assert.Equal(t, http.StatusConflict, retry.Code)
+	assert.Equal(t, "idempotency_in_progress", problemCode(t, retry))
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+	assert.Equal(t, int64(1), created.Load())
+}
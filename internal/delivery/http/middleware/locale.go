@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/infrastructure/i18n"
+)
+
+// Localize picks the language of the error messages from the
+// Accept-Language header, the default language of bundle otherwise
+func Localize(bundle *i18n.Bundle) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		localizer := bundle.Localizer(bundle.Match(c.GetHeader("Accept-Language")))
+		c.Request = c.Request.WithContext(i18n.WithLocalizer(c.Request.Context(), localizer))
+		c.Next()
+	}
+}
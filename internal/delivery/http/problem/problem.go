@@ -0,0 +1,107 @@
+package problem
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/infrastructure/i18n"
+	"microservice-go-gin/internal/infrastructure/logger"
+)
+
+// ContentType is the media type of RFC 7807 responses
+const ContentType = "application/problem+json"
+
+// TypeBase prefixes the code of an error to form its problem type URI
+const TypeBase = "https://quickpoll.example.com/problems/"
+
+const codeInternal = "internal_error"
+
+// Problem is an RFC 7807 problem details document, extended with the stable
+// error code, the request ID and the fields that failed validation
+type Problem struct {
+	Type      string                `json:"type" example:"https://quickpoll.example.com/problems/poll_not_found"`
+	Title     string                `json:"title" example:"Not Found"`
+	Status    int                   `json:"status" example:"404"`
+	Detail    string                `json:"detail,omitempty" example:"poll not found"`
+	Instance  string                `json:"instance,omitempty" example:"/api/v1/polls/550e8400-e29b-41d4-a716-446655440000"`
+	Code      string                `json:"code" example:"poll_not_found"`
+	RequestID string                `json:"request_id,omitempty"`
+	Errors    []apperror.FieldError `json:"errors,omitempty"`
+}
+
+// Status returns the HTTP status of errors of kind
+func Status(kind apperror.Kind) int {
+	switch kind {
+	case apperror.KindValidation:
+		return http.StatusBadRequest
+	case apperror.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperror.KindForbidden:
+		return http.StatusForbidden
+	case apperror.KindNotFound:
+		return http.StatusNotFound
+	case apperror.KindConflict, apperror.KindAlreadyVoted:
+		return http.StatusConflict
+	case apperror.KindExpired:
+		return http.StatusGone
+	case apperror.KindTooLarge:
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// New builds the problem describing err, with messages in the language
+// chosen for the request. Errors that are not domain errors are reported as
+// internal errors without their message, which may leak implementation details.
+func New(c *gin.Context, err error) Problem {
+	localizer := i18n.FromContext(c.Request.Context())
+	p := Problem{
+		Instance:  c.Request.URL.Path,
+		RequestID: logger.RequestIDFromContext(c.Request.Context()),
+	}
+	if e, ok := apperror.As(err); ok {
+		p.Status = Status(e.Kind)
+		p.Code = e.Code
+		p.Detail = localizer.Error(e.Code, e.Message)
+		if len(e.Fields) > 0 {
+			p.Errors = make([]apperror.FieldError, len(e.Fields))
+			for i, field := range e.Fields {
+				field.Message = localizer.Field(field)
+				p.Errors[i] = field
+			}
+			if len(p.Errors) == 1 {
+				p.Detail = p.Errors[0].Message
+			}
+		}
+	} else {
+		p.Status = http.StatusInternalServerError
+		p.Code = codeInternal
+		p.Detail = localizer.Error(codeInternal, "internal server error")
+	}
+	p.Type = TypeBase + p.Code
+	p.Title = http.StatusText(p.Status)
+	return p
+}
+
+// Write responds with the problem describing err
+func Write(c *gin.Context, err error) {
+	p := New(c, err)
+	if p.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(c.Request.Context(), "request failed", "path", p.Instance, "error", err)
+	}
+	c.Header("Content-Type", ContentType)
+	if lang := i18n.FromContext(c.Request.Context()).Language(); lang != "" {
+		c.Header("Content-Language", lang)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+	}
+	c.JSON(p.Status, p)
+}
+
+// Abort responds with the problem describing err and stops the handler chain
+func Abort(c *gin.Context, err error) {
+	Write(c, err)
+	c.Abort()
+}
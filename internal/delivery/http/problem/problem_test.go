@@ -0,0 +1,71 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+func write(t *testing.T, err error) (*httptest.ResponseRecorder, problem.Problem) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/polls/42/vote", nil)
+
+	problem.Write(c, err)
+
+	var p problem.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	return w, p
+}
+
+func TestWrite_DomainError(t *testing.T) {
+	w, p := write(t, fmt.Errorf("vote: %w", entity.ErrAlreadyVoted))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, http.StatusConflict, p.Status)
+	assert.Equal(t, "already_voted", p.Code)
+	assert.Equal(t, problem.TypeBase+"already_voted", p.Type)
+	assert.Equal(t, "Conflict", p.Title)
+	assert.Equal(t, "you have already voted in this poll", p.Detail)
+	assert.Equal(t, "/api/v1/polls/42/vote", p.Instance)
+}
+
+func TestWrite_ValidationFields(t *testing.T) {
+	w, p := write(t, apperror.Validation(
+		apperror.FieldError{Field: "title", Rule: "min", Param: "3", Message: "title is too short"},
+		apperror.FieldError{Field: "options[1]", Rule: "required", Message: "option 2 cannot be empty"},
+	))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, apperror.CodeValidation, p.Code)
+	require.Len(t, p.Errors, 2)
+	assert.Equal(t, "options[1]", p.Errors[1].Field)
+}
+
+func TestWrite_HidesInternalErrors(t *testing.T) {
+	w, p := write(t, errors.New("dial tcp 10.0.0.3:5432: connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "internal_error", p.Code)
+	assert.NotContains(t, w.Body.String(), "10.0.0.3")
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, problem.Status(apperror.KindOf(entity.ErrPollNotFound)))
+	assert.Equal(t, http.StatusGone, problem.Status(apperror.KindOf(entity.ErrPollExpired)))
+	assert.Equal(t, http.StatusUnauthorized, problem.Status(apperror.KindOf(entity.ErrAuthRequired)))
+	assert.Equal(t, http.StatusBadRequest, problem.Status(apperror.KindOf(entity.ErrInvalidOption)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, problem.Status(apperror.KindTooLarge))
+}
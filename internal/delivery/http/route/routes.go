@@ -1,49 +1,205 @@
 package route
 
 import (
+	"log/slog"
+
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 	"microservice-go-gin/internal/config"
 	"microservice-go-gin/internal/delivery/http/handler"
+	"microservice-go-gin/internal/delivery/http/middleware"
 	"microservice-go-gin/internal/delivery/websocket"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/cache"
 	"microservice-go-gin/internal/infrastructure/database"
+	"microservice-go-gin/internal/infrastructure/i18n"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/admin"
+	"microservice-go-gin/internal/usecase/audit"
+	"microservice-go-gin/internal/usecase/datasubject"
 	"microservice-go-gin/internal/usecase/poll"
+	"microservice-go-gin/internal/usecase/quiz"
+	"microservice-go-gin/internal/usecase/survey"
 	"microservice-go-gin/internal/usecase/vote"
 )
 
-func SetupRoutes(router *gin.Engine, db *gorm.DB, baseURL string, cfg *config.Config) {
+// Services exposes the long-lived components created by SetupRoutes so that
+// main can drive their lifecycle
+type Services struct {
+	Hub    *websocket.Hub
+	Health *handler.HealthHandler
+}
+
+// SetupRoutes wires repositories, use cases and handlers on router.
+// store is optional and may be nil.
+func SetupRoutes(router *gin.Engine, reads *database.Resolver, baseURL string, cfg *config.Config, store cache.Cache) *Services {
+	db := reads.Primary()
+
+	// Adresses IP hachées par sondage en mode privacy, en clair sinon (nil)
+	ipHasher := privacy.NewIPHasher(cfg.Privacy)
+
 	// Initialize repositories
-	pollRepo := database.NewPollRepository(db)
+	pollRepo := database.NewPollRepository(reads)
 	voteRepo := database.NewVoteRepository(db)
+	auditRepo := database.NewAuditRepository(db)
+	dataSubjectRepo := database.NewDataSubjectRepository(db, ipHasher)
+	adminRepo := database.NewAdminRepository(reads)
+	banRepo := database.NewBanRepository(db)
+	apiKeyRepo := database.NewAPIKeyRepository(db)
+	surveyRepo := database.NewSurveyRepository(reads)
+	responseRepo := database.NewTextResponseRepository(reads)
+	estimateRepo := database.NewEstimateRepository(reads)
+	availabilityRepo := database.NewAvailabilityRepository(reads)
+	quizRepo := database.NewQuizRepository(db)
+
+	// Journal d'audit des actions sur les sondages et de l'administration
+	recorder := audit.NewRecorder(auditRepo)
 
 	// Initialize use cases
-	createPollUC := poll.NewCreatePollUseCase(pollRepo, baseURL)
-	getPollUC := poll.NewGetPollUseCase(pollRepo, voteRepo)
-	createVoteUC := vote.NewCreateVoteUseCase(pollRepo, voteRepo)
+	createPollUC := poll.NewCreatePollUseCase(pollRepo, baseURL, ipHasher, recorder)
+	getPollUC := poll.NewGetPollUseCase(pollRepo, voteRepo, responseRepo, estimateRepo, availabilityRepo)
+	updatePollUC := poll.NewUpdatePollUseCase(pollRepo, voteRepo, recorder, ipHasher)
+	pollHistoryUC := poll.NewPollHistoryUseCase(pollRepo, auditRepo, ipHasher)
+	pollOwnerDataUC := poll.NewPollOwnerDataUseCase(pollRepo, voteRepo, ipHasher)
+	createVoteUC := vote.NewCreateVoteUseCase(pollRepo, voteRepo, ipHasher)
+	withdrawVoteUC := vote.NewWithdrawVoteUseCase(pollRepo, voteRepo, ipHasher)
+	createResponseUC := vote.NewCreateResponseUseCase(pollRepo, responseRepo, ipHasher)
+	textResponsesUC := poll.NewTextResponsesUseCase(pollRepo, responseRepo, recorder, ipHasher)
+	writeInsUC := poll.NewWriteInsUseCase(pollRepo, recorder, ipHasher)
+	createEstimateUC := vote.NewCreateEstimateUseCase(pollRepo, estimateRepo, ipHasher)
+	revealUC := poll.NewRevealUseCase(pollRepo, estimateRepo, recorder, ipHasher)
+	submitAvailabilityUC := vote.NewSubmitAvailabilityUseCase(pollRepo, availabilityRepo, ipHasher)
+	dataSubjectUC := datasubject.NewDataSubjectUseCase(dataSubjectRepo, auditRepo, ipHasher)
+	pollAdminUC := admin.NewPollAdminUseCase(pollRepo, adminRepo, recorder)
+	banUC := admin.NewBanUseCase(banRepo, recorder, ipHasher)
+	apiKeyUC := admin.NewAPIKeyUseCase(apiKeyRepo, recorder)
+	createSurveyUC := survey.NewCreateSurveyUseCase(surveyRepo, baseURL, ipHasher)
+	getSurveyUC := survey.NewGetSurveyUseCase(surveyRepo)
+	submitSurveyUC := survey.NewSubmitSurveyUseCase(surveyRepo, voteRepo, ipHasher)
+	surveyOwnerDataUC := survey.NewSurveyOwnerDataUseCase(surveyRepo, ipHasher)
+	createQuizUC := quiz.NewCreateQuizUseCase(quizRepo, baseURL, ipHasher)
+	getQuizUC := quiz.NewGetQuizUseCase(quizRepo)
+	joinQuizUC := quiz.NewJoinQuizUseCase(quizRepo, ipHasher)
+	answerQuestionUC := quiz.NewAnswerQuestionUseCase(quizRepo)
+	presentQuizUC := quiz.NewPresentQuizUseCase(quizRepo, ipHasher)
 
 	// Initialize WebSocket hub
 	wsHub := websocket.NewHub()
 
 	// Initialize handlers
-	pollHandler := handler.NewPollHandler(createPollUC, getPollUC)
-	voteHandler := handler.NewVoteHandler(createVoteUC, getPollUC, wsHub, voteRepo)
+	pollHandler := handler.NewPollHandler(createPollUC, getPollUC, updatePollUC, pollHistoryUC, pollOwnerDataUC)
+	voteHandler := handler.NewVoteHandler(createVoteUC, withdrawVoteUC, getPollUC, wsHub)
+	responseHandler := handler.NewResponseHandler(createResponseUC, textResponsesUC, wsHub)
+	writeInHandler := handler.NewWriteInHandler(writeInsUC, wsHub)
+	estimateHandler := handler.NewEstimateHandler(createEstimateUC, revealUC, wsHub)
+	scheduleHandler := handler.NewScheduleHandler(submitAvailabilityUC, getPollUC, wsHub, baseURL)
+	surveyHandler := handler.NewSurveyHandler(createSurveyUC, getSurveyUC, submitSurveyUC, surveyOwnerDataUC, wsHub)
+	quizHandler := handler.NewQuizHandler(createQuizUC, getQuizUC, joinQuizUC, answerQuestionUC, presentQuizUC, wsHub)
 	qrHandler := handler.NewQRHandler(baseURL)
+	dataSubjectHandler := handler.NewDataSubjectHandler(dataSubjectUC)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUC)
+	adminHandler := handler.NewAdminHandler(pollAdminUC, banUC, pollHistoryUC, reads, wsHub, cfg.App.Version)
+
+	authenticator := auth.NewAuthenticator(cfg.JWT, cfg.Admin)
+	if len(cfg.Admin.APIKeys) == 0 && !authenticator.JWTEnabled() {
+		slog.Warn("admin API has no credentials configured, set ADMIN_API_KEYS or JWT_SECRET")
+	}
+	rejectBanned := middleware.RejectBanned(banUC)
+	// Clés d'API (Bearer qp_...) et JWT facultatifs sur les routes publiques
+	authenticate := middleware.Authenticate(authenticator, apiKeyUC)
+
+	// Rejeu des réponses aux POST renvoyés avec le même Idempotency-Key (désactivé sans cache)
+	idempotent := func(c *gin.Context) { c.Next() }
+	if store != nil {
+		idempotent = middleware.Idempotency(store, cfg.Server.IdempotencyTTL, ipHasher)
+	}
+
+	// Seul Redis est une dépendance externe à vérifier par /readyz
+	var redisPinger handler.Pinger
+	if redisClient, ok := store.(*cache.RedisClient); ok && redisClient != nil {
+		redisPinger = redisClient
+	}
+	healthHandler := handler.NewHealthHandler(reads, redisPinger, wsHub)
 
 	// Start WebSocket hub
 	go wsHub.Run()
 
+	// Messages d'erreur dans la langue demandée par Accept-Language
+	router.Use(middleware.Localize(i18n.NewBundle(cfg.App.DefaultLanguage)))
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
 		// Poll routes
-		polls := v1.Group("/polls")
+		polls := v1.Group("/polls", authenticate)
 		{
-			polls.POST("", pollHandler.CreatePoll)
+			polls.POST("", middleware.RequireScope(auth.ScopePollsWrite), rejectBanned, idempotent, pollHandler.CreatePoll)
 			polls.GET("/:id", pollHandler.GetPoll)
-			polls.POST("/:id/vote", voteHandler.CreateVote)
+			polls.PATCH("/:id", middleware.RequireScope(auth.ScopePollsWrite), rejectBanned, pollHandler.UpdatePoll)
+			polls.GET("/:id/history", pollHandler.GetPollHistory)
+			polls.GET("/:id/votes", middleware.RequireScope(auth.ScopeVotesRead), pollHandler.ListBallots)
+			polls.GET("/:id/export", middleware.RequireScope(auth.ScopeExport), pollHandler.ExportResults)
+			polls.POST("/:id/vote", rejectBanned, idempotent, voteHandler.CreateVote)
+			polls.DELETE("/:id/vote", voteHandler.WithdrawVote)
 			polls.GET("/:id/has-voted", voteHandler.HasVoted)
+			polls.POST("/:id/responses", rejectBanned, idempotent, responseHandler.CreateResponse)
+			polls.GET("/:id/responses", responseHandler.ListResponses)
+			polls.PATCH("/:id/responses/:response_id", middleware.RequireScope(auth.ScopePollsWrite), responseHandler.ModerateResponse)
+			polls.GET("/:id/write-ins", writeInHandler.ListPendingWriteIns)
+			polls.PATCH("/:id/options/:option_id", middleware.RequireScope(auth.ScopePollsWrite), writeInHandler.ModerateWriteIn)
+			polls.POST("/:id/estimates", rejectBanned, idempotent, estimateHandler.CreateEstimate)
+			polls.POST("/:id/reveal", middleware.RequireScope(auth.ScopePollsWrite), estimateHandler.RevealEstimates)
+			polls.POST("/:id/availability", rejectBanned, idempotent, scheduleHandler.SubmitAvailability)
+			polls.GET("/:id/calendar.ics", scheduleHandler.ExportCalendar)
 			polls.GET("/:id/qr", qrHandler.GenerateQRCode)
 		}
+
+		// Questionnaires : questions ordonnées, réponses soumises en une fois
+		surveys := v1.Group("/surveys", authenticate)
+		{
+			surveys.POST("", middleware.RequireScope(auth.ScopePollsWrite), rejectBanned, idempotent, surveyHandler.CreateSurvey)
+			surveys.GET("/:id", surveyHandler.GetSurvey)
+			surveys.POST("/:id/responses", rejectBanned, idempotent, surveyHandler.SubmitResponse)
+			surveys.GET("/:id/has-responded", surveyHandler.HasResponded)
+			surveys.GET("/:id/export", middleware.RequireScope(auth.ScopeExport), surveyHandler.ExportResults)
+			surveys.GET("/:id/qr", qrHandler.GenerateSurveyQRCode)
+		}
+
+		// Quiz : questions minutées lancées par le présentateur, classement en direct
+		quizzes := v1.Group("/quizzes", authenticate)
+		{
+			quizzes.POST("", middleware.RequireScope(auth.ScopePollsWrite), rejectBanned, idempotent, quizHandler.CreateQuiz)
+			quizzes.GET("/:id", quizHandler.GetQuiz)
+			quizzes.GET("/:id/leaderboard", quizHandler.Leaderboard)
+			quizzes.POST("/:id/players", rejectBanned, quizHandler.JoinQuiz)
+			quizzes.POST("/:id/answers", rejectBanned, quizHandler.AnswerQuestion)
+			quizzes.POST("/:id/next", middleware.RequireScope(auth.ScopePollsWrite), quizHandler.NextQuestion)
+			quizzes.POST("/:id/close", middleware.RequireScope(auth.ScopePollsWrite), quizHandler.CloseQuestion)
+			quizzes.GET("/:id/qr", qrHandler.GenerateQuizQRCode)
+		}
+
+		// Droits d'accès et d'effacement du votant courant
+		me := v1.Group("/me", authenticate)
+		{
+			me.GET("/data", dataSubjectHandler.ExportData)
+			me.DELETE("/data", dataSubjectHandler.EraseData)
+		}
+
+		// Administration : réservée au rôle admin (JWT ou clé d'API statique)
+		adminGroup := v1.Group("/admin", middleware.RequireRole(authenticator, auth.RoleAdmin))
+		{
+			adminGroup.GET("/polls", adminHandler.ListPolls)
+			adminGroup.POST("/polls/:id/close", adminHandler.ClosePoll)
+			adminGroup.DELETE("/polls/:id", adminHandler.DeletePoll)
+			adminGroup.GET("/polls/:id/stats", adminHandler.PollStats)
+			adminGroup.GET("/polls/:id/history", adminHandler.PollHistory)
+			adminGroup.GET("/bans", adminHandler.ListBans)
+			adminGroup.POST("/bans", adminHandler.CreateBan)
+			adminGroup.DELETE("/bans/:id", adminHandler.DeleteBan)
+			adminGroup.GET("/system", adminHandler.SystemStats)
+			adminGroup.GET("/api-keys", apiKeyHandler.ListAPIKeys)
+			adminGroup.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+			adminGroup.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+		}
 	}
 
 	// WebSocket route
@@ -51,6 +207,11 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, baseURL string, cfg *config.Co
 		websocket.HandleWebSocket(c, wsHub)
 	})
 
+	// Les joueurs d'un quiz suivent les questions et le classement sur la salle du quiz
+	router.GET("/ws/quizzes/:id", func(c *gin.Context) {
+		websocket.HandleWebSocket(c, wsHub)
+	})
+
 	// Poll redirect route for QR codes
 	router.GET("/poll/:id", func(c *gin.Context) {
 		pollID := c.Param("id")
@@ -59,11 +220,23 @@ func SetupRoutes(router *gin.Engine, db *gorm.DB, baseURL string, cfg *config.Co
 		c.Redirect(302, redirectURL)
 	})
 
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "healthy",
-			"service": "QuickPoll API",
-		})
+	// Survey redirect route for QR codes
+	router.GET("/survey/:id", func(c *gin.Context) {
+		c.Redirect(302, cfg.Server.FrontendURL+"?survey="+c.Param("id"))
+	})
+
+	// Quiz redirect route for QR codes
+	router.GET("/quiz/:id", func(c *gin.Context) {
+		c.Redirect(302, cfg.Server.FrontendURL+"?quiz="+c.Param("id"))
 	})
-}
\ No newline at end of file
+
+	// Health checks
+	router.GET("/health", healthHandler.Health)
+	router.GET("/livez", healthHandler.Live)
+	router.GET("/readyz", healthHandler.Ready)
+
+	return &Services{
+		Hub:    wsHub,
+		Health: healthHandler,
+	}
+}
@@ -0,0 +1,22 @@
+//go:build embedfrontend
+
+package static
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// dist is populated by `make build-standalone` from frontend/build
+//
+//go:embed all:dist
+var dist embed.FS
+
+// Embedded returns the frontend compiled into the binary
+func Embedded() fs.FS {
+	assets, err := fs.Sub(dist, "dist")
+	if err != nil {
+		return nil
+	}
+	return assets
+}
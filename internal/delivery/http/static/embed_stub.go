@@ -0,0 +1,10 @@
+//go:build !embedfrontend
+
+package static
+
+import "io/fs"
+
+// Embedded returns nil: the binary was built without the embedfrontend tag
+func Embedded() fs.FS {
+	return nil
+}
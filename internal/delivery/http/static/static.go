@@ -0,0 +1,58 @@
+package static
+
+import (
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/domain/apperror"
+)
+
+var errRouteNotFound = apperror.NotFound("route_not_found", "route not found")
+
+// apiPrefixes are never answered with the frontend so that unknown API
+// routes keep returning a JSON 404
+var apiPrefixes = []string{"/api/", "/ws/", "/swagger/", "/metrics"}
+
+// Register serves the single-page frontend from assets for every route not
+// handled by the API. Unknown paths fall back to index.html so that the
+// client-side router can resolve them.
+func Register(router *gin.Engine, assets fs.FS) {
+	fileServer := http.FileServer(http.FS(assets))
+
+	router.NoRoute(func(c *gin.Context) {
+		if (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) || isAPIPath(c.Request.URL.Path) {
+			problem.Write(c, errRouteNotFound)
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean(c.Request.URL.Path), "/")
+		if name == "" || !exists(assets, name) {
+			c.FileFromFS("/", http.FS(assets))
+			return
+		}
+
+		// Les fichiers du build CRA sont suffixés par leur hash
+		if strings.HasPrefix(name, "static/") {
+			c.Header("Cache-Control", "public, max-age=31536000, immutable")
+		}
+		fileServer.ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+func isAPIPath(p string) bool {
+	for _, prefix := range apiPrefixes {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func exists(assets fs.FS, name string) bool {
+	info, err := fs.Stat(assets, name)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,54 @@
+package static_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"microservice-go-gin/internal/delivery/http/static"
+)
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	static.Register(router, fstest.MapFS{
+		"index.html":           {Data: []byte("<html>QuickPoll</html>")},
+		"static/js/main.1a.js": {Data: []byte("console.log('quickpoll')")},
+	})
+	return router
+}
+
+func get(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestRegister_ServesAssets(t *testing.T) {
+	w := get(newRouter(), "/static/js/main.1a.js")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "quickpoll")
+	assert.Contains(t, w.Header().Get("Cache-Control"), "immutable")
+}
+
+func TestRegister_FallsBackToIndex(t *testing.T) {
+	router := newRouter()
+	for _, path := range []string{"/", "/poll/123"} {
+		w := get(router, path)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Contains(t, w.Body.String(), "QuickPoll", path)
+	}
+}
+
+func TestRegister_KeepsAPINotFound(t *testing.T) {
+	router := newRouter()
+	assert.Equal(t, "pong", get(router, "/api/v1/ping").Body.String())
+
+	w := get(router, "/api/v1/unknown")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "route_not_found")
+}
@@ -1,7 +1,8 @@
 package websocket
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
@@ -10,6 +11,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"microservice-go-gin/internal/delivery/http/problem"
+	"microservice-go-gin/internal/domain/apperror"
 )
 
 const (
@@ -19,6 +22,8 @@ const (
 	maxMessageSize = 512
 )
 
+var errInvalidPollID = apperror.Invalid("invalid_poll_id", "invalid poll ID")
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
@@ -34,7 +39,7 @@ var upgrader = websocket.Upgrader{
 			os.Getenv("FRONTEND_URL"),
 			"https://localhost:3000",
 			"http://localhost:3000",
-			"https://localhost:3001", 
+			"https://localhost:3001",
 			"http://localhost:3001",
 		}
 
@@ -44,7 +49,7 @@ var upgrader = websocket.Upgrader{
 			}
 		}
 
-		log.Printf("WebSocket origin denied: %s", origin)
+		slog.WarnContext(r.Context(), "websocket origin denied", "origin", origin)
 		return false
 	},
 }
@@ -53,13 +58,13 @@ func HandleWebSocket(c *gin.Context, hub *Hub) {
 	pollIDStr := c.Param("id")
 	pollID, err := uuid.Parse(pollIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid poll ID"})
+		problem.Write(c, errInvalidPollID)
 		return
 	}
 
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		log.Printf("WebSocket upgrade error: %v", err)
+		slog.WarnContext(c.Request.Context(), "websocket upgrade failed", "error", err)
 		return
 	}
 
@@ -68,6 +73,8 @@ func HandleWebSocket(c *gin.Context, hub *Hub) {
 		conn:   conn,
 		send:   make(chan []byte, 256),
 		pollID: pollID.String(),
+		// Le contexte de la requête est annulé après l'upgrade : on ne garde que ses valeurs (request ID)
+		ctx: context.WithoutCancel(c.Request.Context()),
 	}
 
 	client.hub.register <- client
@@ -94,7 +101,7 @@ func (c *Client) readPump() {
 		_, _, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("WebSocket error: %v", err)
+				slog.WarnContext(c.ctx, "websocket read error", "poll_id", c.pollID, "error", err)
 			}
 			break
 		}
@@ -141,4 +148,4 @@ func (c *Client) writePump() {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
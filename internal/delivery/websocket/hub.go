@@ -1,12 +1,19 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/tracing"
 )
 
 type Hub struct {
@@ -16,6 +23,17 @@ type Hub struct {
 	unregister chan *Client
 	rooms      map[string]map[*Client]bool
 	mu         sync.RWMutex
+	heartbeat  atomic.Int64
+}
+
+// heartbeatInterval is how often an idle Run loop records that it is alive
+const heartbeatInterval = 5 * time.Second
+
+// HubStats is a snapshot of the hub state used by health checks
+type HubStats struct {
+	Clients       int       `json:"clients"`
+	Rooms         int       `json:"rooms"`
+	LastHeartbeat time.Time `json:"last_heartbeat"`
 }
 
 type Client struct {
@@ -23,6 +41,7 @@ type Client struct {
 	conn   interface{}
 	send   chan []byte
 	pollID string
+	ctx    context.Context
 }
 
 type Message struct {
@@ -43,8 +62,15 @@ func NewHub() *Hub {
 }
 
 func (h *Hub) Run() {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
 	for {
+		h.heartbeat.Store(time.Now().UnixNano())
+
 		select {
+		case <-ticker.C:
+
 		case client := <-h.register:
 			h.mu.Lock()
 			h.clients[client] = true
@@ -52,8 +78,9 @@ func (h *Hub) Run() {
 				h.rooms[client.pollID] = make(map[*Client]bool)
 			}
 			h.rooms[client.pollID][client] = true
+			h.updateGauges()
 			h.mu.Unlock()
-			log.Printf("Client connected to poll: %s", client.pollID)
+			slog.InfoContext(client.ctx, "websocket client connected", "poll_id", client.pollID)
 
 		case client := <-h.unregister:
 			h.mu.Lock()
@@ -65,13 +92,14 @@ func (h *Hub) Run() {
 					delete(h.rooms, client.pollID)
 				}
 			}
+			h.updateGauges()
 			h.mu.Unlock()
-			log.Printf("Client disconnected from poll: %s", client.pollID)
+			slog.InfoContext(client.ctx, "websocket client disconnected", "poll_id", client.pollID)
 
 		case message := <-h.broadcast:
 			var msg Message
 			if err := json.Unmarshal(message, &msg); err != nil {
-				log.Printf("Error unmarshaling message: %v", err)
+				slog.Error("failed to unmarshal websocket message", "error", err)
 				continue
 			}
 
@@ -87,31 +115,130 @@ func (h *Hub) Run() {
 					delete(h.clients, client)
 					delete(h.rooms[msg.PollID], client)
 					close(client.send)
+					if len(h.rooms[msg.PollID]) == 0 {
+						delete(h.rooms, msg.PollID)
+					}
+					h.updateGauges()
 					h.mu.Unlock()
+					metrics.WebSocketDroppedClients.Inc()
+					slog.WarnContext(client.ctx, "dropped slow websocket client", "poll_id", msg.PollID)
 				}
 			}
 		}
 	}
 }
 
-func (h *Hub) BroadcastVoteUpdate(pollID uuid.UUID, data interface{}) {
+func (h *Hub) BroadcastVoteUpdate(ctx context.Context, pollID uuid.UUID, data interface{}) {
+	h.publish(ctx, "vote_update", "poll.id", pollID, data)
+}
+
+// BroadcastNewResponse publishes an approved free-text response of a text poll
+func (h *Hub) BroadcastNewResponse(ctx context.Context, pollID uuid.UUID, data interface{}) {
+	h.publish(ctx, "new_response", "poll.id", pollID, data)
+}
+
+// BroadcastOptionAdded publishes an option added to a poll after its creation:
+// a write-in of a voter, or one approved by the creator
+func (h *Hub) BroadcastOptionAdded(ctx context.Context, pollID uuid.UUID, option *entity.Option) {
+	h.publish(ctx, "option_added", "poll.id", pollID, map[string]interface{}{
+		"poll_id":   pollID.String(),
+		"option_id": option.ID.String(),
+		"text":      option.Text,
+		"order":     option.Order,
+	})
+}
+
+// BroadcastEstimates publishes the results of a numeric poll after a new
+// estimate: the count alone while the estimates are hidden
+func (h *Hub) BroadcastEstimates(ctx context.Context, pollID uuid.UUID, results *entity.EstimateResults) {
+	h.publish(ctx, "estimate_update", "poll.id", pollID, results)
+}
+
+// BroadcastEstimatesRevealed publishes the distribution of the estimates of
+// a planning poker poll once its creator reveals them
+func (h *Hub) BroadcastEstimatesRevealed(ctx context.Context, pollID uuid.UUID, results *entity.EstimateResults) {
+	h.publish(ctx, "estimates_revealed", "poll.id", pollID, results)
+}
+
+// BroadcastAvailability publishes the time slots of a scheduling poll,
+// ranked again after a participant answered
+func (h *Hub) BroadcastAvailability(ctx context.Context, pollID uuid.UUID, slots []entity.SlotResult) {
+	h.publish(ctx, "availability_update", "poll.id", pollID, slots)
+}
+
+// BroadcastCapacity publishes the places left and the waitlist length of
+// the options of a sign-up sheet after a vote or a withdrawal
+func (h *Hub) BroadcastCapacity(ctx context.Context, pollID uuid.UUID, capacities []entity.OptionCapacity) {
+	h.publish(ctx, "capacity_update", "poll.id", pollID, capacities)
+}
+
+// BroadcastQuestionStart publishes the question a quiz presenter started,
+// without its correct options, to the room of the quiz
+func (h *Hub) BroadcastQuestionStart(ctx context.Context, quizID uuid.UUID, start *entity.QuestionStart) {
+	h.publish(ctx, "question_start", "quiz.id", quizID, start)
+}
+
+// BroadcastQuestionEnd publishes the correct options and the answer counts
+// of a quiz question once it is over
+func (h *Hub) BroadcastQuestionEnd(ctx context.Context, quizID uuid.UUID, result *entity.QuestionResult) {
+	h.publish(ctx, "question_end", "quiz.id", quizID, result)
+}
+
+// BroadcastLeaderboard publishes the ranking of the players of a quiz
+// after a question, final once the quiz is over
+func (h *Hub) BroadcastLeaderboard(ctx context.Context, quizID uuid.UUID, board *entity.Leaderboard) {
+	h.publish(ctx, "leaderboard", "quiz.id", quizID, board)
+}
+
+// publish sends a message of the given type to the room of a poll or quiz,
+// traced under one span whose idKey attribute names the room
+func (h *Hub) publish(ctx context.Context, msgType, idKey string, roomID uuid.UUID, data interface{}) {
+	ctx, span := tracing.Start(ctx, "Hub.Broadcast", trace.WithAttributes(
+		attribute.String(idKey, roomID.String()),
+		attribute.String("ws.message_type", msgType),
+	))
+	defer span.End()
+
 	msg := Message{
-		Type:      "vote_update",
-		PollID:    pollID.String(),
+		Type:      msgType,
+		PollID:    roomID.String(),
 		Data:      data,
 		Timestamp: nowUnix(),
 	}
 
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
-		log.Printf("Error marshaling vote update: %v", err)
+		slog.ErrorContext(ctx, "failed to marshal websocket message", "poll_id", roomID, "type", msgType, "error", err)
 		return
 	}
 
 	h.broadcast <- msgBytes
 }
 
+// Stats returns the number of connected clients and rooms and the last time the Run loop was seen alive
+func (h *Hub) Stats() HubStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	stats := HubStats{Clients: len(h.clients), Rooms: len(h.rooms)}
+	if beat := h.heartbeat.Load(); beat > 0 {
+		stats.LastHeartbeat = time.Unix(0, beat)
+	}
+	return stats
+}
+
+// Alive reports whether the Run loop has made progress within maxSilence
+func (h *Hub) Alive(maxSilence time.Duration) bool {
+	beat := h.heartbeat.Load()
+	return beat > 0 && time.Since(time.Unix(0, beat)) <= maxSilence
+}
+
+// updateGauges publishes the current client and room counts; callers must hold h.mu
+func (h *Hub) updateGauges() {
+	metrics.WebSocketClients.Set(float64(len(h.clients)))
+	metrics.WebSocketRooms.Set(float64(len(h.rooms)))
+}
 
 func nowUnix() int64 {
 	return time.Now().Unix()
-}
\ No newline at end of file
+}
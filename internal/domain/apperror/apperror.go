@@ -0,0 +1,114 @@
+package apperror
+
+import "errors"
+
+// Kind classifies domain errors; the delivery layer derives the response status from it
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindExpired
+	KindAlreadyVoted
+	KindTooLarge
+)
+
+// CodeValidation is the code of every validation error, the failing fields
+// being listed in Fields
+const CodeValidation = "validation_failed"
+
+// FieldError describes why one field of the input was rejected
+type FieldError struct {
+	Field string `json:"field" example:"title"`
+	// Rule is the failed rule, e.g. "required", "min", "duplicate"
+	Rule string `json:"rule" example:"min"`
+	// Param is the parameter of the rule, e.g. the minimum length
+	Param   string `json:"param,omitempty" example:"3"`
+	Message string `json:"message" example:"title must be at least 3 characters long"`
+}
+
+// Error is a domain error identified by a stable, machine-readable code.
+// Two errors with the same code match with errors.Is, so sentinels can be
+// returned wrapped or enriched with fields.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NotFound(code, message string) *Error     { return New(KindNotFound, code, message) }
+func Conflict(code, message string) *Error     { return New(KindConflict, code, message) }
+func Forbidden(code, message string) *Error    { return New(KindForbidden, code, message) }
+func Unauthorized(code, message string) *Error { return New(KindUnauthorized, code, message) }
+func Expired(code, message string) *Error      { return New(KindExpired, code, message) }
+
+// Invalid is a validation error with its own code, for rules that are not tied to a single field
+func Invalid(code, message string) *Error { return New(KindValidation, code, message) }
+
+// Validation reports the fields that failed validation
+func Validation(fields ...FieldError) *Error {
+	e := New(KindValidation, CodeValidation, "validation failed")
+	e.Fields = fields
+	if len(fields) == 1 {
+		e.Message = fields[0].Message
+	}
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches any *Error with the same code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of e caused by err
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// WithMessage returns a copy of e with a more specific message
+func (e *Error) WithMessage(message string) *Error {
+	copied := *e
+	copied.Message = message
+	return &copied
+}
+
+// As returns the domain error in the chain of err, if any
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// KindOf returns the kind of err, KindInternal for errors that are not domain errors
+func KindOf(err error) Kind {
+	if e, ok := As(err); ok {
+		return e.Kind
+	}
+	return KindInternal
+}
@@ -0,0 +1,35 @@
+package apperror_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"microservice-go-gin/internal/domain/apperror"
+)
+
+var errPollNotFound = apperror.NotFound("poll_not_found", "poll not found")
+
+func TestError_IsMatchesCode(t *testing.T) {
+	cause := errors.New("record not found")
+	err := fmt.Errorf("loading poll: %w", errPollNotFound.Wrap(cause))
+
+	assert.ErrorIs(t, err, errPollNotFound)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, apperror.NotFound("option_not_found", "option not found"))
+	assert.Equal(t, apperror.KindNotFound, apperror.KindOf(err))
+	assert.Equal(t, apperror.KindInternal, apperror.KindOf(cause))
+}
+
+func TestValidation(t *testing.T) {
+	err := apperror.Validation(
+		apperror.FieldError{Field: "title", Rule: "required", Message: "title is required"},
+		apperror.FieldError{Field: "options", Rule: "min", Param: "2", Message: "poll must have at least 2 options"},
+	)
+
+	assert.Equal(t, apperror.CodeValidation, err.Code)
+	assert.Len(t, err.Fields, 2)
+	assert.Equal(t, "validation failed", err.Error())
+	assert.Equal(t, "title is required", apperror.Validation(err.Fields[0]).Error())
+}
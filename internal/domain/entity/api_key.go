@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKey lets another service call the API on its own behalf. Only the
+// SHA-256 of the key is stored; the key itself is shown once at creation.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name       string     `json:"name" gorm:"type:varchar(100);not null" example:"sprint-retro-bot"`
+	Prefix     string     `json:"prefix" gorm:"type:varchar(16);not null" example:"qp_Zk3v9QaB"`
+	KeyHash    string     `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	Scopes     []string   `json:"scopes" gorm:"type:varchar(255);serializer:json;not null" example:"polls:write,votes:read"`
+	CreatedBy  string     `json:"created_by" gorm:"type:varchar(100)" example:"api-key:ops"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" example:"2025-01-15T10:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2024-01-15T10:00:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" example:"2024-01-15T10:00:00Z"`
+}
+
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	k.ID = uuid.New()
+	return nil
+}
+
+// IsActive reports whether the key can still be used at now
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// Actor identifies the key in CreatedBy columns and the audit log
+func (k *APIKey) Actor() string {
+	return "apikey:" + k.ID.String()
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Audit actions
+const (
+	AuditDataExported = "data_subject.exported"
+	AuditDataErased   = "data_subject.erased"
+	AuditPollCreated  = "poll.created"
+	AuditPollUpdated  = "poll.updated"
+	AuditPollClosed   = "poll.closed"
+	AuditPollDeleted  = "poll.deleted"
+	AuditBanCreated   = "ban.created"
+	AuditBanDeleted   = "ban.deleted"
+	AuditKeyCreated   = "api_key.created"
+	AuditKeyRevoked   = "api_key.revoked"
+
+	AuditResponseModerated = "response.moderated"
+	AuditOptionModerated   = "option.moderated"
+	AuditPollRevealed      = "poll.revealed"
+)
+
+// AuditEvent is an append-only record of who did what and when.
+// Changes holds a JSON document describing the change (e.g. before/after).
+type AuditEvent struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:char(36);primary_key"`
+	Actor     string     `json:"actor" gorm:"type:varchar(100);not null;index" example:"voter:sha256:4f1c..."`
+	Action    string     `json:"action" gorm:"type:varchar(50);not null" example:"data_subject.erased"`
+	Target    string     `json:"target,omitempty" gorm:"type:varchar(100)"`
+	PollID    *uuid.UUID `json:"poll_id,omitempty" gorm:"type:char(36);index"`
+	Changes   string     `json:"changes,omitempty" gorm:"type:text"`
+	RequestID string     `json:"request_id,omitempty" gorm:"type:varchar(128)"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index"`
+}
+
+func (e *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	e.ID = uuid.New()
+	return nil
+}
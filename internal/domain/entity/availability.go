@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxNameLength bounds the display name of a participant to a scheduling
+// poll, in characters once sanitized
+const MaxNameLength = 50
+
+// Answers of a participant to a time slot of a scheduling poll
+const (
+	AvailabilityYes      = "yes"
+	AvailabilityIfNeedBe = "if_need_be"
+	AvailabilityNo       = "no"
+)
+
+// Availability is the answer of a participant to one time slot of a
+// scheduling poll. The display name is public, the voter ID is only kept
+// for duplicate checks.
+type Availability struct {
+	ID        uuid.UUID `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440005"`
+	PollID    uuid.UUID `json:"poll_id" gorm:"type:char(36);not null;index" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OptionID  uuid.UUID `json:"option_id" gorm:"type:char(36);not null;index" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Name      string    `json:"name" gorm:"type:varchar(50);not null" example:"Alice"`
+	Answer    string    `json:"answer" gorm:"type:varchar(10);not null" example:"yes"`
+	VoterID   string    `json:"-" gorm:"type:varchar(100);index"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:00:00Z"`
+	Poll      *Poll     `json:"-" gorm:"foreignKey:PollID"`
+	Option    *Option   `json:"-" gorm:"foreignKey:OptionID"`
+}
+
+func (a *Availability) BeforeCreate(tx *gorm.DB) error {
+	a.ID = uuid.New()
+	return nil
+}
+
+// SlotResult is a time slot of a scheduling poll with the names of the
+// participants for each answer
+type SlotResult struct {
+	OptionID uuid.UUID `json:"option_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Text     string    `json:"text" example:"Mon 15 Jan 2024 10:00–11:00 CET"`
+	StartsAt time.Time `json:"starts_at" example:"2024-01-15T10:00:00+01:00"`
+	EndsAt   time.Time `json:"ends_at" example:"2024-01-15T11:00:00+01:00"`
+	Yes      []string  `json:"yes"`
+	IfNeedBe []string  `json:"if_need_be"`
+	No       []string  `json:"no"`
+}
+
+// Available counts the participants who can attend the slot, if need be
+func (s *SlotResult) Available() int {
+	return len(s.Yes) + len(s.IfNeedBe)
+}
+
+// RankSlots sorts the time slots of a scheduling poll by the number of
+// participants available, then by the number of unreserved yes, then by
+// start time. The slots are shown in the time zone of the poll.
+func RankSlots(poll *Poll, answers []*Availability) []SlotResult {
+	location := poll.Location()
+	slots := make([]SlotResult, 0, len(poll.Options))
+	index := make(map[uuid.UUID]int, len(poll.Options))
+	for _, option := range poll.Options {
+		if option.StartsAt == nil || option.EndsAt == nil {
+			continue
+		}
+		index[option.ID] = len(slots)
+		slots = append(slots, SlotResult{
+			OptionID: option.ID,
+			Text:     option.Text,
+			StartsAt: option.StartsAt.In(location),
+			EndsAt:   option.EndsAt.In(location),
+			Yes:      []string{},
+			IfNeedBe: []string{},
+			No:       []string{},
+		})
+	}
+
+	for _, answer := range answers {
+		i, ok := index[answer.OptionID]
+		if !ok {
+			continue
+		}
+		switch answer.Answer {
+		case AvailabilityYes:
+			slots[i].Yes = append(slots[i].Yes, answer.Name)
+		case AvailabilityIfNeedBe:
+			slots[i].IfNeedBe = append(slots[i].IfNeedBe, answer.Name)
+		default:
+			slots[i].No = append(slots[i].No, answer.Name)
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		if slots[i].Available() != slots[j].Available() {
+			return slots[i].Available() > slots[j].Available()
+		}
+		if len(slots[i].Yes) != len(slots[j].Yes) {
+			return len(slots[i].Yes) > len(slots[j].Yes)
+		}
+		return slots[i].StartsAt.Before(slots[j].StartsAt)
+	})
+	return slots
+}
+
+// SlotLabel renders a time slot in location, e.g.
+// "Mon 15 Jan 2024 10:00–11:00 CET"
+func SlotLabel(startsAt, endsAt time.Time, location *time.Location) string {
+	start, end := startsAt.In(location), endsAt.In(location)
+	if start.Format("20060102") == end.Format("20060102") {
+		return start.Format("Mon 2 Jan 2006 15:04") + "–" + end.Format("15:04 MST")
+	}
+	return start.Format("Mon 2 Jan 2006 15:04") + " – " + end.Format("Mon 2 Jan 2006 15:04 MST")
+}
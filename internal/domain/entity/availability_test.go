@@ -0,0 +1,91 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+func slot(start time.Time, hours int) entity.Option {
+	end := start.Add(time.Duration(hours) * time.Hour)
+	return entity.Option{ID: uuid.New(), Text: start.Format(time.RFC3339), StartsAt: &start, EndsAt: &end}
+}
+
+func TestRankSlots(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	early, late, maybe := slot(monday, 1), slot(monday.Add(24*time.Hour), 1), slot(monday.Add(48*time.Hour), 1)
+	poll := &entity.Poll{Type: entity.PollTypeSchedule, TimeZone: "UTC", Options: []entity.Option{early, late, maybe}}
+
+	answer := func(option entity.Option, name, value string) *entity.Availability {
+		return &entity.Availability{OptionID: option.ID, Name: name, Answer: value}
+	}
+	slots := entity.RankSlots(poll, []*entity.Availability{
+		answer(early, "Alice", entity.AvailabilityYes),
+		answer(late, "Alice", entity.AvailabilityYes),
+		answer(maybe, "Alice", entity.AvailabilityIfNeedBe),
+		answer(early, "Bob", entity.AvailabilityNo),
+		answer(late, "Bob", entity.AvailabilityYes),
+		answer(maybe, "Bob", entity.AvailabilityYes),
+	})
+
+	require.Len(t, slots, 3)
+	// Deux disponibles sans réserve passent devant deux disponibles dont un « si besoin »
+	assert.Equal(t, late.ID, slots[0].OptionID)
+	assert.Equal(t, []string{"Alice", "Bob"}, slots[0].Yes)
+	assert.Equal(t, maybe.ID, slots[1].OptionID)
+	assert.Equal(t, []string{"Alice"}, slots[1].IfNeedBe)
+	assert.Equal(t, early.ID, slots[2].OptionID)
+	assert.Equal(t, []string{"Bob"}, slots[2].No)
+
+	poll.Slots = slots
+	assert.Equal(t, late.ID, poll.WinningSlot().OptionID)
+}
+
+func TestRankSlots_TiesBreakOnStartTime(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	later, sooner := slot(monday.Add(time.Hour), 1), slot(monday, 1)
+	poll := &entity.Poll{Type: entity.PollTypeSchedule, Options: []entity.Option{later, sooner}}
+
+	slots := entity.RankSlots(poll, nil)
+
+	require.Len(t, slots, 2)
+	assert.Equal(t, sooner.ID, slots[0].OptionID)
+	assert.Empty(t, slots[0].Yes)
+	poll.Slots = slots
+	assert.Nil(t, poll.WinningSlot(), "no winner before anyone is available")
+}
+
+func TestRankSlots_InTimeZoneOfPoll(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	start := time.Date(2024, 7, 1, 8, 0, 0, 0, time.UTC)
+	poll := &entity.Poll{Type: entity.PollTypeSchedule, TimeZone: "Europe/Paris", Options: []entity.Option{slot(start, 1)}}
+
+	slots := entity.RankSlots(poll, nil)
+
+	assert.Equal(t, paris.String(), slots[0].StartsAt.Location().String())
+	assert.Equal(t, 10, slots[0].StartsAt.Hour())
+	assert.True(t, slots[0].StartsAt.Equal(start))
+}
+
+func TestSlotLabel(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "Mon 15 Jan 2024 10:00–11:30 CET", entity.SlotLabel(start, start.Add(90*time.Minute), paris))
+	assert.Equal(t, "Mon 15 Jan 2024 10:00 – Tue 16 Jan 2024 10:00 CET", entity.SlotLabel(start, start.Add(24*time.Hour), paris))
+}
+
+func TestPoll_Location(t *testing.T) {
+	assert.Equal(t, time.UTC, (&entity.Poll{}).Location())
+	assert.Equal(t, time.UTC, (&entity.Poll{TimeZone: "Not/AZone"}).Location())
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ban kinds
+const (
+	BanIP   = "ip"
+	BanUser = "user"
+)
+
+// Ban blocks an IP address or a user from creating polls and voting.
+// IP values are stored as hashed by the IP privacy mode.
+type Ban struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Kind      string     `json:"kind" gorm:"type:varchar(10);not null;uniqueIndex:idx_bans_kind_value" example:"ip"`
+	Value     string     `json:"value" gorm:"type:varchar(100);not null;uniqueIndex:idx_bans_kind_value" example:"203.0.113.7"`
+	Reason    string     `json:"reason,omitempty" gorm:"type:varchar(255)" example:"vote flooding"`
+	CreatedBy string     `json:"created_by" gorm:"type:varchar(100)" example:"api-key:ops"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2024-01-16T10:00:00Z"`
+	CreatedAt time.Time  `json:"created_at" example:"2024-01-15T10:00:00Z"`
+}
+
+func (b *Ban) BeforeCreate(tx *gorm.DB) error {
+	b.ID = uuid.New()
+	return nil
+}
+
+// IsActive reports whether the ban still applies at now
+func (b *Ban) IsActive(now time.Time) bool {
+	return b.ExpiresAt == nil || now.Before(*b.ExpiresAt)
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "microservice-go-gin/internal/domain/apperror"
+
+// Erreurs du domaine partagées par les repositories et les cas d'usage
+var (
+	ErrPollNotFound  = apperror.NotFound("poll_not_found", "poll not found")
+	ErrPollExpired   = apperror.Expired("poll_expired", "poll has expired")
+	ErrAlreadyVoted  = apperror.New(apperror.KindAlreadyVoted, "already_voted", "you have already voted in this poll")
+	ErrAuthRequired  = apperror.Unauthorized("auth_required", "authentication required to vote")
+	ErrSingleChoice  = apperror.Invalid("single_choice_only", "only one option can be selected")
+	ErrInvalidOption = apperror.Invalid("invalid_option", "invalid option selected")
+
+	ErrSurveyNotFound      = apperror.NotFound("survey_not_found", "survey not found")
+	ErrSurveyExpired       = apperror.Expired("survey_expired", "survey has expired")
+	ErrAlreadyResponded    = apperror.New(apperror.KindAlreadyVoted, "already_responded", "you have already answered this survey")
+	ErrAnswerThroughSurvey = apperror.Invalid("survey_question", "this question can only be answered through its survey")
+
+	ErrTextPoll         = apperror.Invalid("text_poll", "this poll expects a text response")
+	ErrChoicePoll       = apperror.Invalid("choice_poll", "this poll expects option IDs, not a text response")
+	ErrResponseNotFound = apperror.NotFound("response_not_found", "response not found")
+
+	ErrWriteInDisabled = apperror.Invalid("write_in_disabled", "this poll does not accept write-in options")
+	ErrOptionLimit     = apperror.Conflict("option_limit", "this poll already has the maximum number of options")
+	ErrOptionPending   = apperror.Conflict("option_pending", "this option is awaiting moderation")
+	ErrOptionNotFound  = apperror.NotFound("option_not_found", "option not found")
+
+	ErrNumericPoll     = apperror.Invalid("numeric_poll", "this poll expects a numeric estimate")
+	ErrNotNumericPoll  = apperror.Invalid("not_numeric_poll", "this poll does not take numeric estimates")
+	ErrAlreadyRevealed = apperror.Conflict("already_revealed", "the estimates of this poll are already revealed")
+
+	ErrSchedulePoll    = apperror.Invalid("schedule_poll", "this poll expects the availability of the participant for each time slot")
+	ErrNotSchedulePoll = apperror.Invalid("not_schedule_poll", "this poll has no time slots")
+	ErrNoAvailableSlot = apperror.NotFound("no_available_slot", "no participant is available for any time slot yet")
+
+	ErrOptionFull   = apperror.Conflict("option_full", "this option has no places left")
+	ErrVoteNotFound = apperror.NotFound("vote_not_found", "you have no vote to withdraw in this poll")
+	ErrNotSignUp    = apperror.Conflict("not_sign_up", "votes can only be withdrawn from a sign-up sheet")
+
+	ErrQuizNotFound       = apperror.NotFound("quiz_not_found", "quiz not found")
+	ErrPlayerNotFound     = apperror.NotFound("player_not_found", "this player has not joined the quiz")
+	ErrNicknameTaken      = apperror.Conflict("nickname_taken", "another player of this quiz already uses this nickname")
+	ErrQuizFinished       = apperror.Conflict("quiz_finished", "the quiz is over")
+	ErrQuizMovedOn        = apperror.Conflict("quiz_moved_on", "the quiz has moved on to another question in the meantime")
+	ErrQuestionNotStarted = apperror.Conflict("question_not_started", "this question has not started yet")
+	ErrQuestionClosed     = apperror.Expired("question_closed", "the time to answer this question is over")
+	ErrAlreadyAnswered    = apperror.New(apperror.KindAlreadyVoted, "already_answered", "you have already answered this question")
+	ErrAnswerThroughQuiz  = apperror.Invalid("quiz_question", "this question can only be answered through its quiz")
+)
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Bounds of the histogram of a numeric poll
+const (
+	DefaultBuckets = 10
+	MaxBuckets     = 50
+)
+
+// NumericSettings are the bounds of the estimates of a numeric poll.
+// A zero Step accepts any value between Min and Max.
+type NumericSettings struct {
+	Min     float64 `json:"min" example:"0"`
+	Max     float64 `json:"max" example:"100"`
+	Step    float64 `json:"step,omitempty" example:"1"`
+	Buckets int     `json:"buckets,omitempty" example:"10"`
+	// HideUntilReveal keeps the estimates secret until the creator reveals
+	// them, as in planning poker
+	HideUntilReveal bool `json:"hide_until_reveal,omitempty" example:"false"`
+}
+
+// Accepts reports whether value lies within the bounds and on a step
+func (s *NumericSettings) Accepts(value float64) bool {
+	if math.IsNaN(value) || math.IsInf(value, 0) || value < s.Min || value > s.Max {
+		return false
+	}
+	if s.Step <= 0 {
+		return true
+	}
+	steps := (value - s.Min) / s.Step
+	return math.Abs(steps-math.Round(steps)) < 1e-9
+}
+
+// Estimate is the numeric answer of a voter to a numeric poll. Like text
+// responses, only the voter ID is kept, for duplicate checks.
+type Estimate struct {
+	ID        uuid.UUID `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440004"`
+	PollID    uuid.UUID `json:"poll_id" gorm:"type:char(36);not null;index" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Value     float64   `json:"value" gorm:"not null" example:"8"`
+	VoterID   string    `json:"-" gorm:"type:varchar(100);index"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:00:00Z"`
+	Poll      *Poll     `json:"-" gorm:"foreignKey:PollID"`
+}
+
+func (e *Estimate) BeforeCreate(tx *gorm.DB) error {
+	e.ID = uuid.New()
+	return nil
+}
+
+// EstimateResults are the results of a numeric poll. Stats is left out
+// while the estimates are hidden or when there are none.
+type EstimateResults struct {
+	Count  int           `json:"count" example:"7"`
+	Hidden bool          `json:"hidden,omitempty" example:"false"`
+	Stats  *Distribution `json:"stats,omitempty"`
+}
+
+// Distribution summarizes the estimates of a numeric poll
+type Distribution struct {
+	Min       float64  `json:"min" example:"1"`
+	Max       float64  `json:"max" example:"13"`
+	Mean      float64  `json:"mean" example:"5.4"`
+	Median    float64  `json:"median" example:"5"`
+	Q1        float64  `json:"q1" example:"3"`
+	Q3        float64  `json:"q3" example:"8"`
+	StdDev    float64  `json:"std_dev" example:"3.2"`
+	Histogram []Bucket `json:"histogram"`
+}
+
+// Bucket counts the estimates in [From, To), the last bucket including To
+type Bucket struct {
+	From  float64 `json:"from" example:"0"`
+	To    float64 `json:"to" example:"10"`
+	Count int     `json:"count" example:"3"`
+}
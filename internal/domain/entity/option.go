@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +19,65 @@ type Option struct {
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 	Votes     []Vote         `json:"-" gorm:"foreignKey:OptionID;constraint:OnDelete:CASCADE"`
 	VoteCount int            `json:"vote_count" gorm:"-" validate:"min=0" example:"5"`
+
+	// ArchivedVotes holds the ballots aggregated by the retention job once the poll is closed
+	ArchivedVotes int `json:"-" gorm:"not null;default:0"`
+
+	// Translations holds the text of the option keyed by locale
+	Translations map[string]string `json:"translations,omitempty" gorm:"serializer:json" example:"fr:Go"`
+
+	// Pending write-in options wait for the approval of the creator of the poll
+	Pending bool `json:"pending,omitempty" gorm:"not null;default:false" example:"false"`
+
+	// StartsAt and EndsAt bound the time slot of a scheduling poll
+	StartsAt *time.Time `json:"starts_at,omitempty" example:"2024-01-15T10:00:00+01:00"`
+	EndsAt   *time.Time `json:"ends_at,omitempty" example:"2024-01-15T11:00:00+01:00"`
+
+	// MaxVotes caps the votes of an option of a sign-up sheet, unlimited when nil
+	MaxVotes *int `json:"max_votes,omitempty" example:"20"`
+	// Remaining and Waitlisted are the places left and the voters waiting
+	// for one, for options with MaxVotes
+	Remaining  *int `json:"remaining,omitempty" gorm:"-" example:"3"`
+	Waitlisted int  `json:"waitlisted,omitempty" gorm:"-" example:"0"`
+
+	// Correct marks the right answers of a quiz question
+	Correct bool `json:"correct,omitempty" gorm:"not null;default:false" example:"false"`
+}
+
+// MaxOptions bounds the options of a poll, write-ins included
+const MaxOptions = 10
+
+// NormalizeOption trims the text of an option and collapses its whitespace
+func NormalizeOption(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// OptionKey is the case-insensitive form under which two options are duplicates
+func OptionKey(text string) string {
+	return strings.TrimSpace(strings.ToLower(text))
+}
+
+// WriteIn is the outcome of a ballot with an option of the voter's own: the
+// option written in, or the existing one it duplicates, and the places the
+// ballot took in the waitlists of full options
+type WriteIn struct {
+	Option     *Option
+	Created    bool
+	Waitlisted []*WaitlistEntry
+}
+
+// SetCapacity fills Remaining from VoteCount and records the waitlist
+// length; it does nothing for options without a capacity
+func (o *Option) SetCapacity(waitlisted int) {
+	if o.MaxVotes == nil {
+		return
+	}
+	remaining := *o.MaxVotes - o.VoteCount
+	if remaining < 0 {
+		remaining = 0
+	}
+	o.Remaining = &remaining
+	o.Waitlisted = waitlisted
 }
 
 func (o *Option) BeforeCreate(tx *gorm.DB) error {
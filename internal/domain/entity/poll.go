@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,15 +22,107 @@ type Poll struct {
 	CreatedAt   time.Time      `json:"created_at" example:"2024-01-15T10:00:00Z"`
 	UpdatedAt   time.Time      `json:"updated_at" example:"2024-01-15T10:00:00Z"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ArchivedAt  *time.Time     `json:"archived_at,omitempty" gorm:"index" example:"2024-02-15T10:00:00Z"`
+	IPSalt      string         `json:"-" gorm:"type:varchar(64);not null;default:''"`
 	Options     []Option       `json:"options" gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE" validate:"required,min=2,max=10,dive"`
 	Votes       []Vote         `json:"-" gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE"`
+
+	// Language is the locale of the title, description and option texts,
+	// empty for polls without translations
+	Language string `json:"language,omitempty" gorm:"type:varchar(35);not null;default:''" example:"en"`
+	// Translations holds the title and description in other locales
+	Translations map[string]PollTranslation `json:"translations,omitempty" gorm:"serializer:json"`
+	// Locale is the locale the poll is rendered in after Localize
+	Locale string `json:"locale,omitempty" gorm:"-" example:"fr"`
+
+	// SurveyID is set on the questions of a survey, which are only
+	// answered through it
+	SurveyID *uuid.UUID `json:"survey_id,omitempty" gorm:"type:char(36);index" example:"550e8400-e29b-41d4-a716-446655440002"`
+	// Position orders the questions of a survey or a quiz
+	Position int `json:"position,omitempty" gorm:"not null;default:0" example:"0"`
+	// Optional questions of a survey may be left unanswered
+	Optional bool `json:"optional,omitempty" gorm:"not null;default:false" example:"false"`
+	// ShowIf asks the question only for some answers to an earlier question
+	ShowIf *Condition `json:"show_if,omitempty" gorm:"serializer:json"`
+
+	// Type is PollTypeChoice (options), PollTypeText (free-text responses),
+	// PollTypeNumeric (numeric estimates) or PollTypeSchedule (time slots)
+	Type string `json:"type" gorm:"type:varchar(10);not null;default:'choice'" example:"choice"`
+	// Moderated polls only publish the text responses and the write-in
+	// options approved by their creator
+	Moderated bool `json:"moderated,omitempty" gorm:"not null;default:false" example:"false"`
+	// ResponseCount and Terms are the results of a text poll: published
+	// responses and their most frequent terms, for word clouds
+	ResponseCount int    `json:"response_count,omitempty" gorm:"-" example:"12"`
+	Terms         []Term `json:"terms,omitempty" gorm:"-"`
+
+	// AllowWriteIn lets voters add an option of their own when they vote
+	AllowWriteIn bool `json:"allow_write_in,omitempty" gorm:"not null;default:false" example:"false"`
+
+	// Numeric holds the bounds of a numeric poll
+	Numeric *NumericSettings `json:"numeric,omitempty" gorm:"column:numeric_settings;serializer:json"`
+	// RevealedAt is when the creator revealed hidden estimates
+	RevealedAt *time.Time `json:"revealed_at,omitempty" example:"2024-01-15T11:00:00Z"`
+	// Estimates are the results of a numeric poll
+	Estimates *EstimateResults `json:"estimates,omitempty" gorm:"-"`
+
+	// TimeZone is the IANA time zone the slots of a scheduling poll are
+	// shown in, UTC when empty
+	TimeZone string `json:"time_zone,omitempty" gorm:"type:varchar(64);not null;default:''" example:"Europe/Paris"`
+	// Slots are the time slots of a scheduling poll, ranked by availability
+	Slots []SlotResult `json:"slots,omitempty" gorm:"-"`
+
+	// Waitlist queues the voters of full options instead of turning them
+	// away, for polls whose options have a capacity
+	Waitlist bool `json:"waitlist,omitempty" gorm:"not null;default:false" example:"false"`
+
+	// QuizID is set on the questions of a quiz, which are only answered
+	// through it while the presenter shows them
+	QuizID *uuid.UUID `json:"quiz_id,omitempty" gorm:"type:char(36);index" example:"550e8400-e29b-41d4-a716-446655440003"`
+	// TimeLimit is the number of seconds the players have to answer a
+	// question of a quiz
+	TimeLimit int `json:"time_limit,omitempty" gorm:"not null;default:0" example:"20"`
+}
+
+// Poll types
+const (
+	PollTypeChoice  = "choice"
+	PollTypeText    = "text"
+	PollTypeNumeric = "numeric"
+
+	PollTypeSchedule = "schedule"
+)
+
+// Term is a normalized word of the responses to a text poll with its frequency
+type Term struct {
+	Term  string `json:"term" example:"onboarding"`
+	Count int    `json:"count" example:"7"`
+}
+
+// PollTranslation is the title and description of a poll in one locale
+type PollTranslation struct {
+	Title       string `json:"title" example:"Quel est votre langage de programmation préféré ?"`
+	Description string `json:"description,omitempty" example:"Choisissez votre langage préféré"`
 }
 
 func (p *Poll) BeforeCreate(tx *gorm.DB) error {
 	p.ID = uuid.New()
+	if p.IPSalt == "" {
+		p.IPSalt = NewIPSalt()
+	}
 	return nil
 }
 
+// NewIPSalt returns a random salt so that the hashed IP addresses of a voter
+// cannot be linked from one poll to another
+func NewIPSalt() string {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(salt)
+}
+
 func (p *Poll) IsExpired() bool {
 	if p.ExpiresAt == nil {
 		return false
@@ -35,6 +130,148 @@ func (p *Poll) IsExpired() bool {
 	return time.Now().After(*p.ExpiresAt)
 }
 
+// HidePendingOptions drops the write-in options awaiting approval, which
+// only the creator of the poll may see
+func (p *Poll) HidePendingOptions() {
+	published := p.Options[:0]
+	for _, option := range p.Options {
+		if !option.Pending {
+			published = append(published, option)
+		}
+	}
+	p.Options = published
+}
+
+// IsText reports whether the poll collects free-text responses instead of votes
+func (p *Poll) IsText() bool {
+	return p.Type == PollTypeText
+}
+
+// IsNumeric reports whether the poll collects numeric estimates instead of votes
+func (p *Poll) IsNumeric() bool {
+	return p.Type == PollTypeNumeric
+}
+
+// EstimatesHidden reports whether the estimates of a planning poker poll
+// are still secret
+func (p *Poll) EstimatesHidden() bool {
+	return p.IsNumeric() && p.Numeric != nil && p.Numeric.HideUntilReveal && p.RevealedAt == nil
+}
+
+// IsSchedule reports whether the poll collects the availability of its
+// participants for time slots instead of votes
+func (p *Poll) IsSchedule() bool {
+	return p.Type == PollTypeSchedule
+}
+
+// Location is the time zone of the poll, UTC when unset or unknown
+func (p *Poll) Location() *time.Location {
+	location, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// WinningSlot is the slot the most participants are available for, nil
+// before anyone is
+func (p *Poll) WinningSlot() *SlotResult {
+	if len(p.Slots) == 0 || p.Slots[0].Available() == 0 {
+		return nil
+	}
+	return &p.Slots[0]
+}
+
+// HideCorrect clears the correct flag of the options, which the players
+// of a quiz only learn once the question is over
+func (p *Poll) HideCorrect() {
+	for i := range p.Options {
+		p.Options[i].Correct = false
+	}
+}
+
+// CorrectOptions returns the IDs of the correct options of a quiz question
+func (p *Poll) CorrectOptions() []uuid.UUID {
+	var correct []uuid.UUID
+	for _, option := range p.Options {
+		if option.Correct {
+			correct = append(correct, option.ID)
+		}
+	}
+	return correct
+}
+
+// HasCapacity reports whether the poll is a sign-up sheet, with at least
+// one option limited to MaxVotes votes
+func (p *Poll) HasCapacity() bool {
+	for _, option := range p.Options {
+		if option.MaxVotes != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Capacities returns the remaining capacity of the options that have one,
+// as filled by Option.SetCapacity
+func (p *Poll) Capacities() []OptionCapacity {
+	var capacities []OptionCapacity
+	for _, option := range p.Options {
+		if option.MaxVotes == nil || option.Remaining == nil {
+			continue
+		}
+		capacities = append(capacities, OptionCapacity{
+			OptionID:   option.ID,
+			MaxVotes:   *option.MaxVotes,
+			Votes:      option.VoteCount,
+			Remaining:  *option.Remaining,
+			Waitlisted: option.Waitlisted,
+		})
+	}
+	return capacities
+}
+
 func (p *Poll) IsActive() bool {
 	return !p.IsExpired() && p.DeletedAt.Time.IsZero()
-}
\ No newline at end of file
+}
+
+// Locales lists the locales the poll content is available in, its own
+// language first. It is empty for polls without translations.
+func (p *Poll) Locales() []string {
+	if p.Language == "" {
+		return nil
+	}
+	locales := make([]string, 0, len(p.Translations)+1)
+	locales = append(locales, p.Language)
+	for locale := range p.Translations {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// Localize renders the title, description and option texts in locale.
+// Options without a translation keep their text. It reports whether the
+// poll is available in locale; the content is left untouched otherwise.
+func (p *Poll) Localize(locale string) bool {
+	if locale == "" {
+		return false
+	}
+	if locale == p.Language {
+		p.Locale = locale
+		return true
+	}
+	translation, ok := p.Translations[locale]
+	if !ok {
+		return false
+	}
+	p.Title = translation.Title
+	p.Description = translation.Description
+	for i := range p.Options {
+		if text, ok := p.Options[i].Translations[locale]; ok {
+			p.Options[i].Text = text
+		}
+	}
+	p.Locale = locale
+	return true
+}
@@ -108,4 +108,45 @@ func TestPoll_IsActive(t *testing.T) {
 
 func timePtr(t time.Time) *time.Time {
 	return &t
-}
\ No newline at end of file
+}
+
+func TestPoll_Localize(t *testing.T) {
+	newPoll := func() *entity.Poll {
+		return &entity.Poll{
+			Title:    "Favorite language?",
+			Language: "en",
+			Translations: map[string]entity.PollTranslation{
+				"fr": {Title: "Langage préféré ?", Description: "Un seul choix"},
+				"de": {Title: "Lieblingssprache?"},
+			},
+			Options: []entity.Option{
+				{Text: "Go", Translations: map[string]string{"fr": "Go", "de": "Go"}},
+				{Text: "Other", Translations: map[string]string{"fr": "Autre"}},
+			},
+		}
+	}
+
+	assert.Equal(t, []string{"en", "de", "fr"}, newPoll().Locales())
+	assert.Empty(t, (&entity.Poll{Title: "Untranslated"}).Locales())
+
+	poll := newPoll()
+	assert.True(t, poll.Localize("fr"))
+	assert.Equal(t, "fr", poll.Locale)
+	assert.Equal(t, "Langage préféré ?", poll.Title)
+	assert.Equal(t, "Un seul choix", poll.Description)
+	assert.Equal(t, "Autre", poll.Options[1].Text)
+
+	// Option sans traduction : le texte d'origine est conservé
+	poll = newPoll()
+	assert.True(t, poll.Localize("de"))
+	assert.Equal(t, "Other", poll.Options[1].Text)
+
+	poll = newPoll()
+	assert.True(t, poll.Localize("en"))
+	assert.Equal(t, "Favorite language?", poll.Title)
+
+	poll = newPoll()
+	assert.False(t, poll.Localize("es"))
+	assert.Equal(t, "Favorite language?", poll.Title)
+	assert.Empty(t, poll.Locale)
+}
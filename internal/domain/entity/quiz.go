@@ -0,0 +1,292 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxNicknameLength bounds the nickname of a quiz player, in characters
+// once sanitized
+const MaxNicknameLength = 30
+
+// MaxQuizPoints is the score of a correct answer given at once; it goes
+// down to half at the end of the time limit of the question
+const MaxQuizPoints = 1000
+
+// Quiz is a sequence of timed questions with correct options. The
+// presenter starts the questions one after the other; each question is a
+// poll of the quiz.
+type Quiz struct {
+	ID          uuid.UUID `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Title       string    `json:"title" gorm:"type:varchar(255);not null" example:"Onboarding quiz"`
+	Description string    `json:"description" gorm:"type:text" example:"How well do you know our tooling?"`
+	CreatedBy   string    `json:"created_by" gorm:"type:varchar(100);index" example:"user123"`
+	IPSalt      string    `json:"-" gorm:"type:varchar(64);not null;default:''"`
+	// CurrentQuestion is the position of the last question started, nil
+	// before the first one
+	CurrentQuestion *int `json:"current_question" example:"0"`
+	// QuestionStartedAt and QuestionEndsAt bound the time given to answer
+	// the current question; QuestionClosedAt is set once it is over
+	QuestionStartedAt *time.Time     `json:"question_started_at,omitempty" example:"2024-01-15T10:00:00Z"`
+	QuestionEndsAt    *time.Time     `json:"question_ends_at,omitempty" example:"2024-01-15T10:00:20Z"`
+	QuestionClosedAt  *time.Time     `json:"question_closed_at,omitempty" example:"2024-01-15T10:00:20Z"`
+	FinishedAt        *time.Time     `json:"finished_at,omitempty" example:"2024-01-15T10:15:00Z"`
+	CreatedAt         time.Time      `json:"created_at" example:"2024-01-15T10:00:00Z"`
+	UpdatedAt         time.Time      `json:"updated_at" example:"2024-01-15T10:00:00Z"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
+	Questions         []Poll         `json:"questions" gorm:"foreignKey:QuizID"`
+}
+
+func (q *Quiz) BeforeCreate(tx *gorm.DB) error {
+	q.ID = uuid.New()
+	if q.IPSalt == "" {
+		q.IPSalt = NewIPSalt()
+	}
+	return nil
+}
+
+// Question returns the question at position, nil if there is none
+func (q *Quiz) Question(position int) *Poll {
+	for i := range q.Questions {
+		if q.Questions[i].Position == position {
+			return &q.Questions[i]
+		}
+	}
+	return nil
+}
+
+// Current returns the question started last, nil before the first one
+func (q *Quiz) Current() *Poll {
+	if q.CurrentQuestion == nil {
+		return nil
+	}
+	return q.Question(*q.CurrentQuestion)
+}
+
+// Next returns the question the presenter starts next, nil after the last one
+func (q *Quiz) Next() *Poll {
+	if q.CurrentQuestion == nil {
+		return q.Question(0)
+	}
+	return q.Question(*q.CurrentQuestion + 1)
+}
+
+// IsFinished reports whether the presenter went past the last question
+func (q *Quiz) IsFinished() bool {
+	return q.FinishedAt != nil
+}
+
+// Open reports whether the current question still takes answers at the
+// given time: neither closed by the presenter nor past its time limit
+func (q *Quiz) Open(at time.Time) bool {
+	return q.Current() != nil && q.QuestionClosedAt == nil &&
+		q.QuestionEndsAt != nil && !at.After(*q.QuestionEndsAt)
+}
+
+// Due reports whether the current question is past its time limit at the
+// given time but nobody closed it yet
+func (q *Quiz) Due(at time.Time) bool {
+	return !q.IsFinished() && q.Current() != nil && q.QuestionClosedAt == nil &&
+		q.QuestionEndsAt != nil && at.After(*q.QuestionEndsAt)
+}
+
+// HideUpcoming keeps the questions started so far and hides the correct
+// options of the current question while it is open at the given time
+func (q *Quiz) HideUpcoming(at time.Time) {
+	asked := q.Questions[:0]
+	for _, question := range q.Questions {
+		if q.CurrentQuestion == nil || question.Position > *q.CurrentQuestion {
+			continue
+		}
+		if question.Position == *q.CurrentQuestion && q.Open(at) {
+			question.HideCorrect()
+		}
+		asked = append(asked, question)
+	}
+	q.Questions = asked
+}
+
+// QuizPlayer joins a quiz under a nickname shown on the leaderboard. The
+// player ID, only returned to the player, identifies their answers.
+type QuizPlayer struct {
+	ID        uuid.UUID    `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440006"`
+	QuizID    uuid.UUID    `json:"quiz_id" gorm:"type:char(36);not null;uniqueIndex:idx_quiz_players_quiz_nickname" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Nickname  string       `json:"nickname" gorm:"type:varchar(30);not null;uniqueIndex:idx_quiz_players_quiz_nickname" example:"Ada"`
+	VoterID   string       `json:"-" gorm:"type:varchar(100);index"`
+	IPAddress string       `json:"-" gorm:"type:varchar(45)"`
+	UserAgent string       `json:"-" gorm:"type:varchar(255)"`
+	CreatedAt time.Time    `json:"created_at" example:"2024-01-15T10:00:00Z"`
+	Quiz      *Quiz        `json:"-" gorm:"foreignKey:QuizID"`
+	Answers   []QuizAnswer `json:"-" gorm:"foreignKey:PlayerID;constraint:OnDelete:CASCADE"`
+}
+
+func (p *QuizPlayer) BeforeCreate(tx *gorm.DB) error {
+	p.ID = uuid.New()
+	return nil
+}
+
+// QuizAnswer is the answer of a player to a question of a quiz, scored
+// when recorded. Correctness and points stay private until the question
+// is over.
+type QuizAnswer struct {
+	ID         uuid.UUID   `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440007"`
+	QuizID     uuid.UUID   `json:"quiz_id" gorm:"type:char(36);not null;index" example:"550e8400-e29b-41d4-a716-446655440003"`
+	QuestionID uuid.UUID   `json:"question_id" gorm:"type:char(36);not null;uniqueIndex:idx_quiz_answers_question_player" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PlayerID   uuid.UUID   `json:"-" gorm:"type:char(36);not null;uniqueIndex:idx_quiz_answers_question_player;index"`
+	OptionIDs  []uuid.UUID `json:"option_ids" gorm:"serializer:json"`
+	Correct    bool        `json:"-" gorm:"not null;default:false"`
+	Points     int         `json:"-" gorm:"not null;default:0"`
+	// ResponseTime is the number of milliseconds between the start of the
+	// question and the answer
+	ResponseTime int64       `json:"response_time_ms" gorm:"not null;default:0" example:"4200"`
+	CreatedAt    time.Time   `json:"created_at" example:"2024-01-15T10:00:04Z"`
+	Player       *QuizPlayer `json:"-" gorm:"foreignKey:PlayerID"`
+	Question     *Poll       `json:"-" gorm:"foreignKey:QuestionID"`
+}
+
+func (a *QuizAnswer) BeforeCreate(tx *gorm.DB) error {
+	a.ID = uuid.New()
+	return nil
+}
+
+// Score reports whether chosen is exactly the set of correct options of
+// question and the points it earns after elapsed: from MaxQuizPoints for
+// an instant answer down to half at the end of the time limit, none when
+// wrong
+func Score(question *Poll, chosen []uuid.UUID, elapsed time.Duration) (bool, int) {
+	correct := question.CorrectOptions()
+	if len(correct) == 0 {
+		return false, 0
+	}
+	picked := make(map[uuid.UUID]bool, len(chosen))
+	for _, optionID := range chosen {
+		picked[optionID] = true
+	}
+	if len(picked) != len(correct) {
+		return false, 0
+	}
+	for _, optionID := range correct {
+		if !picked[optionID] {
+			return false, 0
+		}
+	}
+
+	limit := time.Duration(question.TimeLimit) * time.Second
+	if limit <= 0 || elapsed <= 0 {
+		return true, MaxQuizPoints
+	}
+	if elapsed > limit {
+		elapsed = limit
+	}
+	return true, MaxQuizPoints - int(int64(MaxQuizPoints/2)*int64(elapsed)/int64(limit))
+}
+
+// QuestionResult is what the players learn when a question is over: the
+// correct options and how many players chose each option
+type QuestionResult struct {
+	QuestionID uuid.UUID       `json:"question_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Position   int             `json:"position" example:"0"`
+	Correct    []uuid.UUID     `json:"correct"`
+	Options    []OptionAnswers `json:"options"`
+	// Answers and CorrectAnswers count the players who answered, and
+	// answered right
+	Answers        int `json:"answers" example:"24"`
+	CorrectAnswers int `json:"correct_answers" example:"17"`
+}
+
+// OptionAnswers counts the players who chose an option of a quiz question
+type OptionAnswers struct {
+	OptionID uuid.UUID `json:"option_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Answers  int       `json:"answers" example:"17"`
+}
+
+// NewQuestionResult tallies the answers to a question of a quiz
+func NewQuestionResult(question *Poll, answers []*QuizAnswer) *QuestionResult {
+	result := &QuestionResult{
+		QuestionID: question.ID,
+		Position:   question.Position,
+		Correct:    question.CorrectOptions(),
+		Options:    make([]OptionAnswers, 0, len(question.Options)),
+		Answers:    len(answers),
+	}
+	if result.Correct == nil {
+		result.Correct = []uuid.UUID{}
+	}
+	chosen := make(map[uuid.UUID]int, len(question.Options))
+	for _, answer := range answers {
+		for _, optionID := range answer.OptionIDs {
+			chosen[optionID]++
+		}
+		if answer.Correct {
+			result.CorrectAnswers++
+		}
+	}
+	for _, option := range question.Options {
+		result.Options = append(result.Options, OptionAnswers{OptionID: option.ID, Answers: chosen[option.ID]})
+	}
+	return result
+}
+
+// QuestionStart is what the players receive when the presenter starts a
+// question: the question without its correct options and its deadline
+type QuestionStart struct {
+	Question  *Poll     `json:"question"`
+	TimeLimit int       `json:"time_limit" example:"20"`
+	StartedAt time.Time `json:"started_at" example:"2024-01-15T10:00:00Z"`
+	EndsAt    time.Time `json:"ends_at" example:"2024-01-15T10:00:20Z"`
+}
+
+// PlayerScore sums the points and correct answers of a player
+type PlayerScore struct {
+	PlayerID uuid.UUID
+	Nickname string
+	Score    int
+	Correct  int
+}
+
+// Leaderboard ranks the players of a quiz; Final is set once the quiz is over
+type Leaderboard struct {
+	Final   bool               `json:"final" example:"false"`
+	Entries []LeaderboardEntry `json:"entries"`
+	// QuestionDue tells that the current question is past its time limit
+	// but nobody closed it yet
+	QuestionDue bool `json:"-"`
+}
+
+// LeaderboardEntry is the rank of a player, shared by players with the
+// same score
+type LeaderboardEntry struct {
+	Rank     int    `json:"rank" example:"1"`
+	Nickname string `json:"nickname" example:"Ada"`
+	Score    int    `json:"score" example:"2750"`
+	Correct  int    `json:"correct" example:"3"`
+}
+
+// RankPlayers sorts the players by score, then by nickname, and ranks them
+func RankPlayers(scores []PlayerScore) []LeaderboardEntry {
+	sorted := append([]PlayerScore(nil), scores...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Score != sorted[j].Score {
+			return sorted[i].Score > sorted[j].Score
+		}
+		return sorted[i].Nickname < sorted[j].Nickname
+	})
+
+	entries := make([]LeaderboardEntry, 0, len(sorted))
+	for i, score := range sorted {
+		rank := i + 1
+		if i > 0 && score.Score == sorted[i-1].Score {
+			rank = entries[i-1].Rank
+		}
+		entries = append(entries, LeaderboardEntry{
+			Rank:     rank,
+			Nickname: score.Nickname,
+			Score:    score.Score,
+			Correct:  score.Correct,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,117 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+func TestScore(t *testing.T) {
+	right, also, wrong := uuid.New(), uuid.New(), uuid.New()
+	single := &entity.Poll{TimeLimit: 20, Options: []entity.Option{{ID: right, Correct: true}, {ID: wrong}}}
+	multi := &entity.Poll{TimeLimit: 20, MultiChoice: true, Options: []entity.Option{
+		{ID: right, Correct: true}, {ID: also, Correct: true}, {ID: wrong},
+	}}
+
+	tests := []struct {
+		name     string
+		question *entity.Poll
+		chosen   []uuid.UUID
+		elapsed  time.Duration
+		correct  bool
+		points   int
+	}{
+		{"instant", single, []uuid.UUID{right}, 0, true, 1000},
+		{"halfway", single, []uuid.UUID{right}, 10 * time.Second, true, 750},
+		{"at the deadline", single, []uuid.UUID{right}, 20 * time.Second, true, 500},
+		{"past the deadline", single, []uuid.UUID{right}, time.Minute, true, 500},
+		{"wrong", single, []uuid.UUID{wrong}, 0, false, 0},
+		{"all correct options", multi, []uuid.UUID{also, right}, 5 * time.Second, true, 875},
+		{"some correct options", multi, []uuid.UUID{right}, 0, false, 0},
+		{"correct and wrong options", multi, []uuid.UUID{right, also, wrong}, 0, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			correct, points := entity.Score(tt.question, tt.chosen, tt.elapsed)
+			assert.Equal(t, tt.correct, correct)
+			assert.Equal(t, tt.points, points)
+		})
+	}
+}
+
+func TestRankPlayers(t *testing.T) {
+	entries := entity.RankPlayers([]entity.PlayerScore{
+		{Nickname: "Linus", Score: 900, Correct: 1},
+		{Nickname: "Ada", Score: 1800, Correct: 2},
+		{Nickname: "Grace", Score: 900, Correct: 1},
+		{Nickname: "Ken"},
+	})
+
+	// Les ex aequo partagent leur rang, le suivant reprend à sa place
+	assert.Equal(t, []entity.LeaderboardEntry{
+		{Rank: 1, Nickname: "Ada", Score: 1800, Correct: 2},
+		{Rank: 2, Nickname: "Grace", Score: 900, Correct: 1},
+		{Rank: 2, Nickname: "Linus", Score: 900, Correct: 1},
+		{Rank: 4, Nickname: "Ken"},
+	}, entries)
+}
+
+func TestNewQuestionResult(t *testing.T) {
+	right, wrong := uuid.New(), uuid.New()
+	question := &entity.Poll{ID: uuid.New(), Position: 2, Options: []entity.Option{{ID: right, Correct: true}, {ID: wrong}}}
+
+	result := entity.NewQuestionResult(question, []*entity.QuizAnswer{
+		{OptionIDs: []uuid.UUID{right}, Correct: true},
+		{OptionIDs: []uuid.UUID{wrong}},
+		{OptionIDs: []uuid.UUID{right}, Correct: true},
+	})
+
+	assert.Equal(t, &entity.QuestionResult{
+		QuestionID:     question.ID,
+		Position:       2,
+		Correct:        []uuid.UUID{right},
+		Options:        []entity.OptionAnswers{{OptionID: right, Answers: 2}, {OptionID: wrong, Answers: 1}},
+		Answers:        3,
+		CorrectAnswers: 2,
+	}, result)
+}
+
+func TestQuiz_HideUpcoming(t *testing.T) {
+	now := time.Now()
+	started, endsAt := now.Add(-5*time.Second), now.Add(15*time.Second)
+	newQuiz := func() *entity.Quiz {
+		return &entity.Quiz{Questions: []entity.Poll{
+			{Position: 0, Options: []entity.Option{{Correct: true}, {}}},
+			{Position: 1, Options: []entity.Option{{Correct: true}, {}}},
+			{Position: 2, Options: []entity.Option{{Correct: true}, {}}},
+		}}
+	}
+
+	quiz := newQuiz()
+	quiz.HideUpcoming(now)
+	assert.Empty(t, quiz.Questions)
+
+	current := 1
+	quiz = newQuiz()
+	quiz.CurrentQuestion, quiz.QuestionStartedAt, quiz.QuestionEndsAt = &current, &started, &endsAt
+	require.True(t, quiz.Open(now))
+	quiz.HideUpcoming(now)
+	require.Len(t, quiz.Questions, 2)
+	assert.True(t, quiz.Questions[0].Options[0].Correct)
+	assert.False(t, quiz.Questions[1].Options[0].Correct)
+
+	// Passé le chrono, la bonne réponse de la question en cours est publiée
+	quiz = newQuiz()
+	quiz.CurrentQuestion, quiz.QuestionStartedAt, quiz.QuestionEndsAt = &current, &started, &endsAt
+	later := endsAt.Add(time.Second)
+	assert.False(t, quiz.Open(later))
+	assert.True(t, quiz.Due(later))
+	assert.False(t, quiz.Due(now))
+	quiz.HideUpcoming(later)
+	require.Len(t, quiz.Questions, 2)
+	assert.True(t, quiz.Questions[1].Options[0].Correct)
+}
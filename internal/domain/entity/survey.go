@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Survey groups ordered questions answered together through one link.
+// Each question is a poll with its own options, multi-choice and results.
+type Survey struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Title       string         `json:"title" gorm:"type:varchar(255);not null" example:"Team offsite"`
+	Description string         `json:"description" gorm:"type:text" example:"Help us plan the next offsite"`
+	CreatedBy   string         `json:"created_by" gorm:"type:varchar(100);index" example:"user123"`
+	RequireAuth bool           `json:"require_auth" gorm:"default:false" example:"false"`
+	IPSalt      string         `json:"-" gorm:"type:varchar(64);not null;default:''"`
+	ExpiresAt   *time.Time     `json:"expires_at" example:"2024-01-16T10:00:00Z"`
+	CreatedAt   time.Time      `json:"created_at" example:"2024-01-15T10:00:00Z"`
+	UpdatedAt   time.Time      `json:"updated_at" example:"2024-01-15T10:00:00Z"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	Questions   []Poll         `json:"questions" gorm:"foreignKey:SurveyID"`
+}
+
+// Condition asks a survey question only when the answer to the question at
+// position Question includes one of the options at positions Options
+type Condition struct {
+	Question int   `json:"question" example:"0"`
+	Options  []int `json:"options" example:"1"`
+}
+
+func (s *Survey) BeforeCreate(tx *gorm.DB) error {
+	s.ID = uuid.New()
+	if s.IPSalt == "" {
+		s.IPSalt = NewIPSalt()
+	}
+	return nil
+}
+
+func (s *Survey) IsExpired() bool {
+	if s.ExpiresAt == nil {
+		return false
+	}
+	return time.Now().After(*s.ExpiresAt)
+}
+
+// Question returns the question at position, nil if there is none
+func (s *Survey) Question(position int) *Poll {
+	for i := range s.Questions {
+		if s.Questions[i].Position == position {
+			return &s.Questions[i]
+		}
+	}
+	return nil
+}
+
+// Visible reports whether question is asked given the options chosen so far,
+// keyed by question ID. A question whose condition depends on a hidden
+// question is hidden too.
+func (s *Survey) Visible(question *Poll, answers map[uuid.UUID][]uuid.UUID) bool {
+	if question.ShowIf == nil {
+		return true
+	}
+	previous := s.Question(question.ShowIf.Question)
+	// Une condition ne peut viser qu'une question précédente
+	if previous == nil || previous.Position >= question.Position || !s.Visible(previous, answers) {
+		return false
+	}
+	for _, chosen := range answers[previous.ID] {
+		for _, option := range previous.Options {
+			if option.ID != chosen {
+				continue
+			}
+			for _, order := range question.ShowIf.Options {
+				if option.Order == order {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
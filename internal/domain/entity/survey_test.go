@@ -0,0 +1,35 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+func TestSurvey_Visible(t *testing.T) {
+	yes, no := uuid.New(), uuid.New()
+	remote, onsite := uuid.New(), uuid.New()
+	survey := &entity.Survey{Questions: []entity.Poll{
+		{ID: uuid.New(), Position: 0, Options: []entity.Option{{ID: yes, Order: 0}, {ID: no, Order: 1}}},
+		{ID: uuid.New(), Position: 1, ShowIf: &entity.Condition{Question: 0, Options: []int{0}},
+			Options: []entity.Option{{ID: remote, Order: 0}, {ID: onsite, Order: 1}}},
+		{ID: uuid.New(), Position: 2, ShowIf: &entity.Condition{Question: 1, Options: []int{1}}},
+		{ID: uuid.New(), Position: 3, ShowIf: &entity.Condition{Question: 3, Options: []int{0}}},
+	}}
+	first, second, third, selfReferencing := &survey.Questions[0], &survey.Questions[1], &survey.Questions[2], &survey.Questions[3]
+
+	assert.True(t, survey.Visible(first, nil))
+	assert.False(t, survey.Visible(second, nil))
+	assert.False(t, survey.Visible(selfReferencing, nil))
+
+	answers := map[uuid.UUID][]uuid.UUID{first.ID: {yes}, second.ID: {onsite}}
+	assert.True(t, survey.Visible(second, answers))
+	assert.True(t, survey.Visible(third, answers))
+
+	// La troisième question dépend de la deuxième, masquée dès que la première vaut "non"
+	answers[first.ID] = []uuid.UUID{no}
+	assert.False(t, survey.Visible(second, answers))
+	assert.False(t, survey.Visible(third, answers))
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxResponseLength bounds a text response, in characters once sanitized
+const MaxResponseLength = 500
+
+// Statuses of a text response. Responses to unmoderated polls are approved
+// as soon as they are submitted.
+const (
+	ResponsePending  = "pending"
+	ResponseApproved = "approved"
+	ResponseRejected = "rejected"
+)
+
+// TextResponse is a free-text answer to a text poll. Only the voter ID is
+// kept, for duplicate checks: no IP address nor user agent.
+type TextResponse struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440003"`
+	PollID      uuid.UUID  `json:"poll_id" gorm:"type:char(36);not null;index:idx_text_responses_poll_status" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Text        string     `json:"text" gorm:"type:text;not null" example:"Faster onboarding"`
+	Status      string     `json:"status" gorm:"type:varchar(10);not null;index:idx_text_responses_poll_status" example:"approved"`
+	VoterID     string     `json:"-" gorm:"type:varchar(100);index"`
+	CreatedAt   time.Time  `json:"created_at" example:"2024-01-15T10:00:00Z"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty" example:"2024-01-15T11:00:00Z"`
+	Poll        *Poll      `json:"-" gorm:"foreignKey:PollID"`
+}
+
+func (r *TextResponse) BeforeCreate(tx *gorm.DB) error {
+	r.ID = uuid.New()
+	return nil
+}
+
+var markupPattern = regexp.MustCompile(`<[^>]*>`)
+
+// SanitizeResponse strips markup and control characters from a text
+// response and collapses its whitespace
+func SanitizeResponse(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = markupPattern.ReplaceAllString(text, " ")
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return ' '
+		}
+		return r
+	}, text)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,29 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+func TestSanitizeResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain text", text: "Faster builds", want: "Faster builds"},
+		{name: "markup", text: "<script>alert(1)</script>Faster <b>builds</b>", want: "alert(1) Faster builds"},
+		{name: "control characters", text: "Faster\x00builds\r\n\tplease", want: "Faster builds please"},
+		{name: "invisible format characters", text: "Faster\u200bbuilds\u202e", want: "Faster builds"},
+		{name: "invalid UTF-8", text: "caf\xc3\xa9 \xff\xfe", want: "café"},
+		{name: "only whitespace", text: " \n\t ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, entity.SanitizeResponse(tt.text))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WaitlistEntry queues a voter for a full option of a sign-up sheet. The
+// first entry of an option becomes a vote when a place frees up.
+type WaitlistEntry struct {
+	ID       uuid.UUID `json:"id" gorm:"type:char(36);primary_key" example:"550e8400-e29b-41d4-a716-446655440004"`
+	PollID   uuid.UUID `json:"poll_id" gorm:"type:char(36);not null;index" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OptionID uuid.UUID `json:"option_id" gorm:"type:char(36);not null;uniqueIndex:idx_waitlist_entries_option_position" example:"550e8400-e29b-41d4-a716-446655440001"`
+	// Position orders the entries of an option, first come first served
+	Position int64 `json:"-" gorm:"not null;uniqueIndex:idx_waitlist_entries_option_position"`
+	// Rank is the place of the entry in the waitlist of its option, from 1
+	Rank      int       `json:"rank" gorm:"-" example:"2"`
+	VoterID   string    `json:"-" gorm:"type:varchar(100);index"`
+	IPAddress string    `json:"-" gorm:"type:varchar(45)"`
+	UserAgent string    `json:"-" gorm:"type:varchar(255)"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:00:00Z"`
+	Poll      *Poll     `json:"-" gorm:"foreignKey:PollID"`
+	Option    *Option   `json:"-" gorm:"foreignKey:OptionID"`
+}
+
+func (w *WaitlistEntry) BeforeCreate(tx *gorm.DB) error {
+	w.ID = uuid.New()
+	return nil
+}
+
+// Vote is the ballot the entry becomes once promoted
+func (w *WaitlistEntry) Vote() *Vote {
+	return &Vote{
+		PollID:    w.PollID,
+		OptionID:  w.OptionID,
+		VoterID:   w.VoterID,
+		IPAddress: w.IPAddress,
+		UserAgent: w.UserAgent,
+	}
+}
+
+// OptionCapacity is the state of an option of a sign-up sheet: its votes,
+// the places left and the length of its waitlist
+type OptionCapacity struct {
+	OptionID   uuid.UUID `json:"option_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	MaxVotes   int       `json:"max_votes" example:"20"`
+	Votes      int       `json:"votes" example:"17"`
+	Remaining  int       `json:"remaining" example:"3"`
+	Waitlisted int       `json:"waitlisted" example:"0"`
+}
+
+// Withdrawal is the outcome of a voter withdrawing from a poll: the options
+// they left, whether voted for or waited on, and the waitlisted voters
+// promoted to the places they freed
+type Withdrawal struct {
+	OptionIDs []uuid.UUID
+	Promoted  []*Vote
+}
@@ -0,0 +1,38 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+func TestPoll_Capacities(t *testing.T) {
+	capacity := 3
+	full := entity.Option{ID: uuid.New(), MaxVotes: &capacity, VoteCount: 4}
+	open := entity.Option{ID: uuid.New(), MaxVotes: &capacity, VoteCount: 1}
+	unlimited := entity.Option{ID: uuid.New(), VoteCount: 10}
+	full.SetCapacity(2)
+	open.SetCapacity(0)
+	unlimited.SetCapacity(0)
+	poll := &entity.Poll{Options: []entity.Option{full, unlimited, open}}
+
+	assert.True(t, poll.HasCapacity())
+	assert.Nil(t, unlimited.Remaining)
+	// Des bulletins archivés peuvent dépasser la capacité : il ne reste alors aucune place
+	require.Equal(t, []entity.OptionCapacity{
+		{OptionID: full.ID, MaxVotes: 3, Votes: 4, Remaining: 0, Waitlisted: 2},
+		{OptionID: open.ID, MaxVotes: 3, Votes: 1, Remaining: 2},
+	}, poll.Capacities())
+	assert.False(t, (&entity.Poll{Options: []entity.Option{unlimited}}).HasCapacity())
+}
+
+func TestWaitlistEntry_Vote(t *testing.T) {
+	entry := &entity.WaitlistEntry{PollID: uuid.New(), OptionID: uuid.New(), VoterID: "voter", IPAddress: "203.0.113.7", UserAgent: "curl"}
+
+	assert.Equal(t, &entity.Vote{
+		PollID: entry.PollID, OptionID: entry.OptionID, VoterID: "voter", IPAddress: "203.0.113.7", UserAgent: "curl",
+	}, entry.Vote())
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+// Poll statuses accepted by PollFilter
+const (
+	PollStatusOpen    = "open"
+	PollStatusClosed  = "closed"
+	PollStatusDeleted = "deleted"
+	PollStatusAll     = "all"
+)
+
+// PollFilter selects polls in the admin listing. An empty Status lists the
+// polls that are not deleted.
+type PollFilter struct {
+	Query  string
+	Status string
+	Offset int
+	Limit  int
+}
+
+// OptionStats is the tally of one option
+type OptionStats struct {
+	OptionID uuid.UUID `json:"option_id"`
+	Text     string    `json:"text"`
+	Votes    int64     `json:"votes"`
+}
+
+// PollStats summarizes the ballots of a poll: the votes per option, the
+// responses of a text poll or the estimates of a numeric poll. UniqueVoters and the dates cover whichever the
+// poll collects.
+type PollStats struct {
+	PollID        uuid.UUID     `json:"poll_id"`
+	Type          string        `json:"type"`
+	Responses     int64         `json:"responses,omitempty"`
+	Estimates     int64         `json:"estimates,omitempty"`
+	TotalVotes    int64         `json:"total_votes"`
+	ArchivedVotes int64         `json:"archived_votes"`
+	UniqueVoters  int64         `json:"unique_voters"`
+	FirstVoteAt   *time.Time    `json:"first_vote_at,omitempty"`
+	LastVoteAt    *time.Time    `json:"last_vote_at,omitempty"`
+	Options       []OptionStats `json:"options"`
+}
+
+// Totals counts the main records of the database
+type Totals struct {
+	Polls        int64 `json:"polls"`
+	OpenPolls    int64 `json:"open_polls"`
+	DeletedPolls int64 `json:"deleted_polls"`
+	Votes        int64 `json:"votes"`
+	ActiveBans   int64 `json:"active_bans"`
+}
+
+// AdminRepository serves the operator queries that span every poll
+type AdminRepository interface {
+	SearchPolls(ctx context.Context, filter PollFilter, now time.Time) ([]*entity.Poll, int64, error)
+	ClosePoll(ctx context.Context, id uuid.UUID, at time.Time) error
+	// PollStats returns entity.ErrPollNotFound when no poll has id, deleted or not
+	PollStats(ctx context.Context, id uuid.UUID) (*PollStats, error)
+	Totals(ctx context.Context, now time.Time) (*Totals, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.APIKey, error)
+	// FindByHash returns the key whose hash is hash, revoked or not, or nil
+	FindByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+	List(ctx context.Context, offset, limit int) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+// AuditRepository stores audit events; events are never updated or deleted
+type AuditRepository interface {
+	Append(ctx context.Context, event *entity.AuditEvent) error
+	// ListByPoll returns the events of a poll, oldest first, and their total count
+	ListByPoll(ctx context.Context, pollID uuid.UUID, offset, limit int) ([]*entity.AuditEvent, int64, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type AvailabilityRepository interface {
+	// CreateAll records the answers of a participant to every time slot at once
+	CreateAll(ctx context.Context, answers []*entity.Availability) error
+	HasAnswered(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error)
+	// ListByPoll returns the answers to the time slots of a poll, oldest first
+	ListByPoll(ctx context.Context, pollID uuid.UUID) ([]*entity.Availability, error)
+}
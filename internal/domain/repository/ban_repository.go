@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type BanRepository interface {
+	Create(ctx context.Context, ban *entity.Ban) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Ban, error)
+	// FindByValue returns the ban of kind on value, expired or not, or nil
+	FindByValue(ctx context.Context, kind, value string) (*entity.Ban, error)
+	List(ctx context.Context, offset, limit int) ([]*entity.Ban, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// IsBanned reports whether one of values is under an active ban of kind
+	IsBanned(ctx context.Context, kind string, values []string, now time.Time) (bool, error)
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"microservice-go-gin/internal/domain/entity"
+)
+
+// ErasureResult counts the records affected by a data subject erasure
+type ErasureResult struct {
+	// AnonymizedBallots were cast in polls and quizzes still open: they keep
+	// counting and block a second ballot, but lose their IP address, user agent
+	// and voter ID in clear
+	AnonymizedBallots int64 `json:"anonymized_ballots"`
+	// ArchivedVotes were cast in closed polls: folded into the option totals, then deleted
+	ArchivedVotes int64 `json:"archived_votes"`
+	// AnonymizedPolls were created by the subject and no longer reference them
+	AnonymizedPolls int64 `json:"anonymized_polls"`
+	// AnonymizedSurveys were created by the subject and no longer reference them
+	AnonymizedSurveys int64 `json:"anonymized_surveys"`
+	// AnonymizedQuizzes were created by the subject and no longer reference them
+	AnonymizedQuizzes int64 `json:"anonymized_quizzes"`
+	// DeletedResponses are the free-text responses of the subject in closed polls, whatever their status
+	DeletedResponses int64 `json:"deleted_responses"`
+	// DeletedEstimates are the numeric estimates of the subject in closed polls
+	DeletedEstimates int64 `json:"deleted_estimates"`
+	// DeletedAvailabilities are the answers of the subject to time slots of closed polls
+	DeletedAvailabilities int64 `json:"deleted_availabilities"`
+	// DeletedWaitlistEntries are the places of the subject in the waitlists of closed sign-up sheets
+	DeletedWaitlistEntries int64 `json:"deleted_waitlist_entries"`
+	// DeletedQuizPlayers are the players of finished quizzes the subject joined as, with their answers
+	DeletedQuizPlayers int64 `json:"deleted_quiz_players"`
+}
+
+// DataSubjectRepository finds and erases everything stored about one voter.
+// identities lists every value the voter may be stored as: the raw voter ID,
+// or one hash per poll salt in IP privacy mode.
+type DataSubjectRepository interface {
+	// ListIPSalts returns the distinct salts of all polls, surveys and quizzes, deleted ones included
+	ListIPSalts(ctx context.Context) ([]string, error)
+	// FindVotes returns the ballots of the voter with their poll and option loaded
+	FindVotes(ctx context.Context, identities []string) ([]*entity.Vote, error)
+	FindPolls(ctx context.Context, identities []string) ([]*entity.Poll, error)
+	// FindSurveys returns the surveys created by the voter; their questions are among its polls
+	FindSurveys(ctx context.Context, identities []string) ([]*entity.Survey, error)
+	// FindQuizzes returns the quizzes created by the voter; their questions are among its polls
+	FindQuizzes(ctx context.Context, identities []string) ([]*entity.Quiz, error)
+	// FindTextResponses returns the free-text responses of the voter with their poll loaded
+	FindTextResponses(ctx context.Context, identities []string) ([]*entity.TextResponse, error)
+	// FindEstimates returns the numeric estimates of the voter with their poll loaded
+	FindEstimates(ctx context.Context, identities []string) ([]*entity.Estimate, error)
+	// FindAvailabilities returns the answers of the voter to time slots with their poll and option loaded
+	FindAvailabilities(ctx context.Context, identities []string) ([]*entity.Availability, error)
+	// FindWaitlistEntries returns the places of the voter in waitlists with their poll and option loaded
+	FindWaitlistEntries(ctx context.Context, identities []string) ([]*entity.WaitlistEntry, error)
+	// FindQuizPlayers returns the quiz players of the voter with their quiz and answers loaded
+	FindQuizPlayers(ctx context.Context, identities []string) ([]*entity.QuizPlayer, error)
+	// Erase removes the ballots, text responses, estimates, availabilities,
+	// waitlist entries and quiz players of the voter in the polls closed and
+	// quizzes finished at now, anonymizes them elsewhere and detaches the
+	// polls, surveys and quizzes it created. Ballots of closed polls keep counting in the results.
+	Erase(ctx context.Context, identities []string, now time.Time) (*ErasureResult, error)
+}
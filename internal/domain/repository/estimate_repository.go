@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type EstimateRepository interface {
+	Create(ctx context.Context, estimate *entity.Estimate) error
+	HasEstimated(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error)
+	// ListValues returns the value of every estimate of a poll
+	ListValues(ctx context.Context, pollID uuid.UUID) ([]float64, error)
+}
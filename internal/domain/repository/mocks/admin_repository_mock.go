@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type MockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminRepository) SearchPolls(ctx context.Context, filter repository.PollFilter, now time.Time) ([]*entity.Poll, int64, error) {
+	args := m.Called(ctx, filter, now)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Poll), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAdminRepository) ClosePoll(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockAdminRepository) PollStats(ctx context.Context, id uuid.UUID) (*repository.PollStats, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.PollStats), args.Error(1)
+}
+
+func (m *MockAdminRepository) Totals(ctx context.Context, now time.Time) (*repository.Totals, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.Totals), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Append(ctx context.Context, event *entity.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) ListByPoll(ctx context.Context, pollID uuid.UUID, offset, limit int) ([]*entity.AuditEvent, int64, error) {
+	args := m.Called(ctx, pollID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.AuditEvent), args.Get(1).(int64), args.Error(2)
+}
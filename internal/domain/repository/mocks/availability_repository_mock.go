@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type MockAvailabilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityRepository) CreateAll(ctx context.Context, answers []*entity.Availability) error {
+	args := m.Called(ctx, answers)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) HasAnswered(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error) {
+	args := m.Called(ctx, pollID, voterID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) ListByPoll(ctx context.Context, pollID uuid.UUID) ([]*entity.Availability, error) {
+	args := m.Called(ctx, pollID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Availability), args.Error(1)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type MockBanRepository struct {
+	mock.Mock
+}
+
+func (m *MockBanRepository) Create(ctx context.Context, ban *entity.Ban) error {
+	args := m.Called(ctx, ban)
+	return args.Error(0)
+}
+
+func (m *MockBanRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Ban, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Ban), args.Error(1)
+}
+
+func (m *MockBanRepository) FindByValue(ctx context.Context, kind, value string) (*entity.Ban, error) {
+	args := m.Called(ctx, kind, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Ban), args.Error(1)
+}
+
+func (m *MockBanRepository) List(ctx context.Context, offset, limit int) ([]*entity.Ban, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Ban), args.Error(1)
+}
+
+func (m *MockBanRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBanRepository) IsBanned(ctx context.Context, kind string, values []string, now time.Time) (bool, error) {
+	args := m.Called(ctx, kind, values, now)
+	return args.Bool(0), args.Error(1)
+}
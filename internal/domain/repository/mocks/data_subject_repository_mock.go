@@ -0,0 +1,102 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type MockDataSubjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockDataSubjectRepository) ListIPSalts(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindVotes(ctx context.Context, identities []string) ([]*entity.Vote, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Vote), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindPolls(ctx context.Context, identities []string) ([]*entity.Poll, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Poll), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindSurveys(ctx context.Context, identities []string) ([]*entity.Survey, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Survey), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindQuizzes(ctx context.Context, identities []string) ([]*entity.Quiz, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Quiz), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindTextResponses(ctx context.Context, identities []string) ([]*entity.TextResponse, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.TextResponse), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindEstimates(ctx context.Context, identities []string) ([]*entity.Estimate, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Estimate), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindAvailabilities(ctx context.Context, identities []string) ([]*entity.Availability, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Availability), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindWaitlistEntries(ctx context.Context, identities []string) ([]*entity.WaitlistEntry, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) FindQuizPlayers(ctx context.Context, identities []string) ([]*entity.QuizPlayer, error) {
+	args := m.Called(ctx, identities)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.QuizPlayer), args.Error(1)
+}
+
+func (m *MockDataSubjectRepository) Erase(ctx context.Context, identities []string, now time.Time) (*repository.ErasureResult, error) {
+	args := m.Called(ctx, identities, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.ErasureResult), args.Error(1)
+}
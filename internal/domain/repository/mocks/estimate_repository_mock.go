@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type MockEstimateRepository struct {
+	mock.Mock
+}
+
+func (m *MockEstimateRepository) Create(ctx context.Context, estimate *entity.Estimate) error {
+	args := m.Called(ctx, estimate)
+	return args.Error(0)
+}
+
+func (m *MockEstimateRepository) HasEstimated(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error) {
+	args := m.Called(ctx, pollID, voterID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockEstimateRepository) ListValues(ctx context.Context, pollID uuid.UUID) ([]float64, error) {
+	args := m.Called(ctx, pollID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]float64), args.Error(1)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -38,6 +39,26 @@ func (m *MockPollRepository) Update(ctx context.Context, poll *entity.Poll) erro
 	return args.Error(0)
 }
 
+func (m *MockPollRepository) UpdateDetails(ctx context.Context, poll *entity.Poll, options []entity.Option) error {
+	args := m.Called(ctx, poll, options)
+	return args.Error(0)
+}
+
+func (m *MockPollRepository) Reveal(ctx context.Context, pollID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, pollID, at)
+	return args.Error(0)
+}
+
+func (m *MockPollRepository) ApproveOption(ctx context.Context, optionID uuid.UUID) error {
+	args := m.Called(ctx, optionID)
+	return args.Error(0)
+}
+
+func (m *MockPollRepository) DeleteOption(ctx context.Context, optionID uuid.UUID) error {
+	args := m.Called(ctx, optionID)
+	return args.Error(0)
+}
+
 func (m *MockPollRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
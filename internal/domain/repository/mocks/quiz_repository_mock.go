@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type MockQuizRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuizRepository) Create(ctx context.Context, quiz *entity.Quiz) error {
+	args := m.Called(ctx, quiz)
+	return args.Error(0)
+}
+
+func (m *MockQuizRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Quiz, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Quiz), args.Error(1)
+}
+
+func (m *MockQuizRepository) StartQuestion(ctx context.Context, quizID uuid.UUID, position int, startedAt, endsAt time.Time) (bool, error) {
+	args := m.Called(ctx, quizID, position, startedAt, endsAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuizRepository) CloseQuestion(ctx context.Context, quizID uuid.UUID, position int, closedAt time.Time) (bool, error) {
+	args := m.Called(ctx, quizID, position, closedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuizRepository) Finish(ctx context.Context, quizID uuid.UUID, finishedAt time.Time) error {
+	args := m.Called(ctx, quizID, finishedAt)
+	return args.Error(0)
+}
+
+func (m *MockQuizRepository) CreatePlayer(ctx context.Context, player *entity.QuizPlayer) error {
+	args := m.Called(ctx, player)
+	return args.Error(0)
+}
+
+func (m *MockQuizRepository) GetPlayer(ctx context.Context, quizID, playerID uuid.UUID) (*entity.QuizPlayer, error) {
+	args := m.Called(ctx, quizID, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.QuizPlayer), args.Error(1)
+}
+
+func (m *MockQuizRepository) CreateAnswer(ctx context.Context, answer *entity.QuizAnswer) error {
+	args := m.Called(ctx, answer)
+	return args.Error(0)
+}
+
+func (m *MockQuizRepository) ListAnswers(ctx context.Context, questionID uuid.UUID) ([]*entity.QuizAnswer, error) {
+	args := m.Called(ctx, questionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.QuizAnswer), args.Error(1)
+}
+
+func (m *MockQuizRepository) ListScores(ctx context.Context, quizID uuid.UUID) ([]entity.PlayerScore, error) {
+	args := m.Called(ctx, quizID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.PlayerScore), args.Error(1)
+}
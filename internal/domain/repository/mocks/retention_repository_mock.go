@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type MockRetentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRetentionRepository) CountDeletedPolls(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeDeletedPolls(ctx context.Context, before time.Time, batchSize int) (int64, error) {
+	args := m.Called(ctx, before, batchSize)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionRepository) CountVoterData(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionRepository) AnonymizeVoterData(ctx context.Context, before time.Time, action repository.VoterDataAction, batchSize int) (int64, error) {
+	args := m.Called(ctx, before, action, batchSize)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionRepository) CountArchivableBallots(ctx context.Context, closedBefore time.Time) (int64, int64, error) {
+	args := m.Called(ctx, closedBefore)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRetentionRepository) ArchiveClosedPolls(ctx context.Context, closedBefore time.Time, batchSize int) (int64, int64, error) {
+	args := m.Called(ctx, closedBefore, batchSize)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
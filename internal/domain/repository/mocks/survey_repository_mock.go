@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type MockSurveyRepository struct {
+	mock.Mock
+}
+
+func (m *MockSurveyRepository) Create(ctx context.Context, survey *entity.Survey) error {
+	args := m.Called(ctx, survey)
+	return args.Error(0)
+}
+
+func (m *MockSurveyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Survey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Survey), args.Error(1)
+}
+
+func (m *MockSurveyRepository) GetByIDWithResults(ctx context.Context, id uuid.UUID) (*entity.Survey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Survey), args.Error(1)
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type MockTextResponseRepository struct {
+	mock.Mock
+}
+
+func (m *MockTextResponseRepository) Create(ctx context.Context, response *entity.TextResponse) error {
+	args := m.Called(ctx, response)
+	return args.Error(0)
+}
+
+func (m *MockTextResponseRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.TextResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.TextResponse), args.Error(1)
+}
+
+func (m *MockTextResponseRepository) HasResponded(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error) {
+	args := m.Called(ctx, pollID, voterID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTextResponseRepository) ListByPoll(ctx context.Context, pollID uuid.UUID, status string, offset, limit int) ([]*entity.TextResponse, int64, error) {
+	args := m.Called(ctx, pollID, status, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.TextResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTextResponseRepository) ListTexts(ctx context.Context, pollID uuid.UUID, status string) ([]string, error) {
+	args := m.Called(ctx, pollID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTextResponseRepository) UpdateStatus(ctx context.Context, response *entity.TextResponse) error {
+	args := m.Called(ctx, response)
+	return args.Error(0)
+}
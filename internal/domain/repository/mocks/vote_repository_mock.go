@@ -17,6 +17,11 @@ func (m *MockVoteRepository) Create(ctx context.Context, vote *entity.Vote) erro
 	return args.Error(0)
 }
 
+func (m *MockVoteRepository) CreateBatch(ctx context.Context, votes []*entity.Vote) error {
+	args := m.Called(ctx, votes)
+	return args.Error(0)
+}
+
 func (m *MockVoteRepository) GetByPollAndVoter(ctx context.Context, pollID uuid.UUID, voterID string) ([]*entity.Vote, error) {
 	args := m.Called(ctx, pollID, voterID)
 	if args.Get(0) == nil {
@@ -46,4 +51,28 @@ func (m *MockVoteRepository) GetVotesByPoll(ctx context.Context, pollID uuid.UUI
 func (m *MockVoteRepository) HasVoted(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error) {
 	args := m.Called(ctx, pollID, voterID)
 	return args.Bool(0), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockVoteRepository) CreateWithinCapacity(ctx context.Context, votes []*entity.Vote, waitlist bool) ([]*entity.WaitlistEntry, error) {
+	args := m.Called(ctx, votes, waitlist)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockVoteRepository) CreateWithWriteIn(ctx context.Context, option *entity.Option, votes []*entity.Vote, waitlist bool) (*entity.WriteIn, error) {
+	args := m.Called(ctx, option, votes, waitlist)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WriteIn), args.Error(1)
+}
+
+func (m *MockVoteRepository) Withdraw(ctx context.Context, pollID uuid.UUID, voterIDs []string) (*entity.Withdrawal, error) {
+	args := m.Called(ctx, pollID, voterIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Withdrawal), args.Error(1)
+}
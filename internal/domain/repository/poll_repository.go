@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"microservice-go-gin/internal/domain/entity"
@@ -12,7 +13,17 @@ type PollRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Poll, error)
 	GetByIDWithResults(ctx context.Context, id uuid.UUID) (*entity.Poll, error)
 	Update(ctx context.Context, poll *entity.Poll) error
+	// UpdateDetails saves the title, description and expiry of poll and,
+	// when options is not nil, replaces its options in the same transaction
+	UpdateDetails(ctx context.Context, poll *entity.Poll, options []entity.Option) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// ApproveOption publishes a pending write-in option
+	ApproveOption(ctx context.Context, optionID uuid.UUID) error
+	// DeleteOption removes a write-in option and its ballots for good
+	DeleteOption(ctx context.Context, optionID uuid.UUID) error
+	// Reveal publishes the hidden estimates of a numeric poll at the given
+	// time. It fails with ErrAlreadyRevealed when they already are.
+	Reveal(ctx context.Context, pollID uuid.UUID, at time.Time) error
 	List(ctx context.Context, offset, limit int) ([]*entity.Poll, error)
 	GetActivePolls(ctx context.Context, offset, limit int) ([]*entity.Poll, error)
 	GetPollsByCreator(ctx context.Context, creatorID string, offset, limit int) ([]*entity.Poll, error)
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type QuizRepository interface {
+	// Create saves the quiz with its questions and their options in one transaction
+	Create(ctx context.Context, quiz *entity.Quiz) error
+	// GetByID returns the quiz with its questions and options in display order
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Quiz, error)
+	// StartQuestion moves the quiz from the question before position, none
+	// for the first one, to the question at position. It reports false when
+	// the quiz has moved on in the meantime.
+	StartQuestion(ctx context.Context, quizID uuid.UUID, position int, startedAt, endsAt time.Time) (bool, error)
+	// CloseQuestion ends the question at position if it is still the
+	// current one and open. It reports whether this call closed it.
+	CloseQuestion(ctx context.Context, quizID uuid.UUID, position int, closedAt time.Time) (bool, error)
+	// Finish marks the quiz as over once past its last question
+	Finish(ctx context.Context, quizID uuid.UUID, finishedAt time.Time) error
+	// CreatePlayer adds a player to a quiz, ErrNicknameTaken when another
+	// player uses the same nickname, whatever its case
+	CreatePlayer(ctx context.Context, player *entity.QuizPlayer) error
+	// GetPlayer returns a player of the quiz, ErrPlayerNotFound otherwise
+	GetPlayer(ctx context.Context, quizID, playerID uuid.UUID) (*entity.QuizPlayer, error)
+	// CreateAnswer records the answer of a player, ErrAlreadyAnswered when
+	// they have already answered the question
+	CreateAnswer(ctx context.Context, answer *entity.QuizAnswer) error
+	// ListAnswers returns the answers to a question of a quiz
+	ListAnswers(ctx context.Context, questionID uuid.UUID) ([]*entity.QuizAnswer, error)
+	// ListScores sums the points of every player of the quiz, players
+	// without an answer included
+	ListScores(ctx context.Context, quizID uuid.UUID) ([]entity.PlayerScore, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// VoterDataAction tells how the IP address and user agent of old ballots are
+// anonymized. Voter IDs are always replaced by a form duplicate checks still recognize.
+type VoterDataAction string
+
+const (
+	VoterDataClear VoterDataAction = "clear"
+	VoterDataHash  VoterDataAction = "hash"
+)
+
+// RetentionRepository purges or anonymizes data older than a cutoff.
+// Count methods report what the matching purge would affect, for dry runs.
+type RetentionRepository interface {
+	CountDeletedPolls(ctx context.Context, before time.Time) (int64, error)
+	PurgeDeletedPolls(ctx context.Context, before time.Time, batchSize int) (int64, error)
+	CountVoterData(ctx context.Context, before time.Time) (int64, error)
+	AnonymizeVoterData(ctx context.Context, before time.Time, action VoterDataAction, batchSize int) (int64, error)
+	CountArchivableBallots(ctx context.Context, closedBefore time.Time) (polls int64, ballots int64, err error)
+	ArchiveClosedPolls(ctx context.Context, closedBefore time.Time, batchSize int) (polls int64, ballots int64, err error)
+}
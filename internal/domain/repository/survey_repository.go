@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type SurveyRepository interface {
+	// Create saves the survey with its questions and their options in one transaction
+	Create(ctx context.Context, survey *entity.Survey) error
+	// GetByID returns the survey with its questions and options in display order
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Survey, error)
+	// GetByIDWithResults also fills the vote count of every option
+	GetByIDWithResults(ctx context.Context, id uuid.UUID) (*entity.Survey, error)
+}
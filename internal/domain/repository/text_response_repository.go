@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+type TextResponseRepository interface {
+	Create(ctx context.Context, response *entity.TextResponse) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.TextResponse, error)
+	HasResponded(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error)
+	// ListByPoll returns the responses of a poll with status, newest first,
+	// and their total count
+	ListByPoll(ctx context.Context, pollID uuid.UUID, status string, offset, limit int) ([]*entity.TextResponse, int64, error)
+	// ListTexts returns the text of every response of a poll with status
+	ListTexts(ctx context.Context, pollID uuid.UUID, status string) ([]string, error)
+	// UpdateStatus saves the status and moderation date of response
+	UpdateStatus(ctx context.Context, response *entity.TextResponse) error
+}
@@ -9,9 +9,32 @@ import (
 
 type VoteRepository interface {
 	Create(ctx context.Context, vote *entity.Vote) error
+	// CreateBatch saves votes in one transaction: all of them or none
+	CreateBatch(ctx context.Context, votes []*entity.Vote) error
 	GetByPollAndVoter(ctx context.Context, pollID uuid.UUID, voterID string) ([]*entity.Vote, error)
 	CountByOption(ctx context.Context, optionID uuid.UUID) (int64, error)
 	CountByPoll(ctx context.Context, pollID uuid.UUID) (int64, error)
 	GetVotesByPoll(ctx context.Context, pollID uuid.UUID) ([]*entity.Vote, error)
+	// HasVoted also reports the voters waiting for a place on a sign-up sheet
 	HasVoted(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error)
+
+	// CreateWithinCapacity saves the votes of a ballot in one transaction,
+	// locking the options that have a capacity so that concurrent ballots
+	// cannot exceed it. A vote for a full option joins the end of its
+	// waitlist when waitlist is set; otherwise the whole ballot fails with
+	// ErrOptionFull. It returns the waitlist entries created.
+	CreateWithinCapacity(ctx context.Context, votes []*entity.Vote, waitlist bool) ([]*entity.WaitlistEntry, error)
+	// CreateWithWriteIn saves a ballot and the option its voter wrote in, in
+	// one transaction: a refused ballot leaves no option behind. An option
+	// of the poll that duplicates option takes its place; a pending one
+	// fails with ErrOptionPending, since only its author votes for it. A new
+	// option goes after the others, within entity.MaxOptions
+	// (ErrOptionLimit). The vote of votes without an OptionID goes to the
+	// write-in, then votes are saved like CreateWithinCapacity.
+	CreateWithWriteIn(ctx context.Context, option *entity.Option, votes []*entity.Vote, waitlist bool) (*entity.WriteIn, error)
+	// Withdraw deletes the votes and waitlist entries of the voter, under any
+	// of voterIDs, and promotes in order the first waitlisted voters of the
+	// options it frees. It fails with ErrVoteNotFound when there is nothing
+	// to withdraw.
+	Withdraw(ctx context.Context, pollID uuid.UUID, voterIDs []string) (*entity.Withdrawal, error)
 }
\ No newline at end of file
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// APIKeyPrefix starts every API key so that it can be told apart from a JWT
+	APIKeyPrefix = "qp_"
+	// apiKeyDisplayLength is the number of leading characters kept in clear
+	// to recognize a key in listings
+	apiKeyDisplayLength = len(APIKeyPrefix) + 8
+)
+
+// KeyAuthenticator resolves an API key to its principal
+type KeyAuthenticator interface {
+	AuthenticateKey(ctx context.Context, token string) (*Principal, error)
+}
+
+// GenerateAPIKey returns a new API key, its displayable prefix and the hash to store
+func GenerateAPIKey() (token, prefix, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+	token = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return token, token[:apiKeyDisplayLength], HashAPIKey(token), nil
+}
+
+// HashAPIKey returns the stored form of token. Keys carry 256 bits of
+// entropy, so a plain SHA-256 is enough to make a leaked table useless.
+func HashAPIKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKey reports whether token looks like an API key rather than a JWT
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/domain/apperror"
+)
+
+// RoleAdmin grants access to the /api/v1/admin routes
+const RoleAdmin = "admin"
+
+// Scopes granted to API keys
+const (
+	ScopePollsWrite = "polls:write"
+	ScopeVotesRead  = "votes:read"
+	ScopeExport     = "export"
+)
+
+// Scopes lists every scope an API key can hold
+var Scopes = []string{ScopePollsWrite, ScopeVotesRead, ScopeExport}
+
+var (
+	ErrMissingCredentials = apperror.Unauthorized("missing_credentials", "missing credentials")
+	ErrInvalidCredentials = apperror.Unauthorized("invalid_credentials", "invalid credentials")
+	ErrForbidden          = apperror.Forbidden("insufficient_role", "insufficient role")
+	ErrInsufficientScope  = apperror.Forbidden("insufficient_scope", "insufficient scope")
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	// Actor identifies the caller in the audit log, e.g. "jwt:alice" or "api-key:ops"
+	Actor string
+	Roles []string
+	// Scopes restricts what an API key may do; nil means unrestricted
+	Scopes []string
+}
+
+// HasRole reports whether the principal holds role
+func (p *Principal) HasRole(role string) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the principal holds scope
+func (p *Principal) HasScope(scope string) bool {
+	if p == nil {
+		return false
+	}
+	if p.Scopes == nil {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated caller
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller carried by ctx, if any
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// ActorFromContext returns the audit identity of the caller, "anonymous" if none
+func ActorFromContext(ctx context.Context) string {
+	if principal := PrincipalFromContext(ctx); principal != nil {
+		return principal.Actor
+	}
+	return "anonymous"
+}
+
+type staticKey struct {
+	name string
+	key  []byte
+}
+
+// Authenticator validates admin credentials: HS256 JWTs signed with the
+// configured secret, or static API keys from the configuration
+type Authenticator struct {
+	secret     []byte
+	roleClaim  string
+	staticKeys []staticKey
+}
+
+// NewAuthenticator builds the authenticator from cfg. Static keys are
+// written "name:key"; a key without a name is named after its position.
+// JWTs are refused while the secret is left to its insecure default.
+func NewAuthenticator(jwtCfg config.JWTConfig, adminCfg config.AdminConfig) *Authenticator {
+	a := &Authenticator{roleClaim: adminCfg.RoleClaim}
+	if a.roleClaim == "" {
+		a.roleClaim = "role"
+	}
+	if jwtCfg.Secret != "" && jwtCfg.Secret != config.DefaultJWTSecret {
+		a.secret = []byte(jwtCfg.Secret)
+	}
+	for i, entry := range adminCfg.APIKeys {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, key, found := strings.Cut(entry, ":")
+		if !found {
+			name, key = fmt.Sprintf("key-%d", i+1), entry
+		}
+		a.staticKeys = append(a.staticKeys, staticKey{name: name, key: []byte(key)})
+	}
+	return a
+}
+
+// JWTEnabled reports whether bearer tokens can be accepted
+func (a *Authenticator) JWTEnabled() bool {
+	return len(a.secret) > 0
+}
+
+// AuthenticateAPIKey matches key against the static admin keys in constant time
+func (a *Authenticator) AuthenticateAPIKey(key string) (*Principal, error) {
+	if key == "" {
+		return nil, ErrMissingCredentials
+	}
+	for _, static := range a.staticKeys {
+		if subtle.ConstantTimeCompare(static.key, []byte(key)) == 1 {
+			return &Principal{Actor: "api-key:" + static.name, Roles: []string{RoleAdmin}}, nil
+		}
+	}
+	return nil, ErrInvalidCredentials
+}
+
+// AuthenticateToken verifies an HS256 JWT and reads its roles from the
+// configured claim, either a string or a list of strings. The subject
+// becomes the actor that owns polls, so a token without one is refused.
+func (a *Authenticator) AuthenticateToken(token string) (*Principal, error) {
+	if token == "" {
+		return nil, ErrMissingCredentials
+	}
+	if !a.JWTEnabled() {
+		return nil, ErrInvalidCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return a.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, ErrInvalidCredentials
+	}
+	principal := &Principal{Actor: "jwt:" + subject}
+	switch roles := claims[a.roleClaim].(type) {
+	case string:
+		principal.Roles = []string{roles}
+	case []interface{}:
+		for _, role := range roles {
+			if r, ok := role.(string); ok {
+				principal.Roles = append(principal.Roles, r)
+			}
+		}
+	}
+	return principal, nil
+}
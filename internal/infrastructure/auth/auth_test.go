@@ -0,0 +1,133 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/auth"
+)
+
+func sign(t *testing.T, secret string, method jwt.SigningMethod, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthenticator_APIKey(t *testing.T) {
+	authenticator := auth.NewAuthenticator(config.JWTConfig{}, config.AdminConfig{APIKeys: []string{"ops:s3cret", "unnamed"}})
+
+	principal, err := authenticator.AuthenticateAPIKey("s3cret")
+	require.NoError(t, err)
+	assert.Equal(t, "api-key:ops", principal.Actor)
+	assert.True(t, principal.HasRole(auth.RoleAdmin))
+
+	principal, err = authenticator.AuthenticateAPIKey("unnamed")
+	require.NoError(t, err)
+	assert.Equal(t, "api-key:key-2", principal.Actor)
+
+	_, err = authenticator.AuthenticateAPIKey("wrong")
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+}
+
+func TestAuthenticator_Token(t *testing.T) {
+	secret := "a-real-secret"
+	authenticator := auth.NewAuthenticator(config.JWTConfig{Secret: secret}, config.AdminConfig{RoleClaim: "role"})
+	expires := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name      string
+		token     string
+		wantErr   bool
+		wantAdmin bool
+	}{
+		{
+			name:      "admin role",
+			token:     sign(t, secret, jwt.SigningMethodHS256, jwt.MapClaims{"sub": "alice", "role": "admin", "exp": expires}),
+			wantAdmin: true,
+		},
+		{
+			name:      "admin in role list",
+			token:     sign(t, secret, jwt.SigningMethodHS256, jwt.MapClaims{"sub": "alice", "role": []string{"viewer", "admin"}, "exp": expires}),
+			wantAdmin: true,
+		},
+		{
+			name:  "other role",
+			token: sign(t, secret, jwt.SigningMethodHS256, jwt.MapClaims{"sub": "bob", "role": "viewer", "exp": expires}),
+		},
+		{
+			name:    "no subject",
+			token:   sign(t, secret, jwt.SigningMethodHS256, jwt.MapClaims{"role": "admin", "exp": expires}),
+			wantErr: true,
+		},
+		{
+			name:    "wrong secret",
+			token:   sign(t, "another-secret", jwt.SigningMethodHS256, jwt.MapClaims{"role": "admin", "exp": expires}),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   sign(t, secret, jwt.SigningMethodHS256, jwt.MapClaims{"role": "admin", "exp": time.Now().Add(-time.Minute).Unix()}),
+			wantErr: true,
+		},
+		{
+			name:    "no expiration",
+			token:   sign(t, secret, jwt.SigningMethodHS256, jwt.MapClaims{"role": "admin"}),
+			wantErr: true,
+		},
+		{
+			name:    "unexpected algorithm",
+			token:   sign(t, secret, jwt.SigningMethodHS512, jwt.MapClaims{"role": "admin", "exp": expires}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := authenticator.AuthenticateToken(tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAdmin, principal.HasRole(auth.RoleAdmin))
+		})
+	}
+}
+
+func TestAuthenticator_DefaultSecretRejected(t *testing.T) {
+	authenticator := auth.NewAuthenticator(config.JWTConfig{Secret: config.DefaultJWTSecret}, config.AdminConfig{})
+	token := sign(t, config.DefaultJWTSecret, jwt.SigningMethodHS256, jwt.MapClaims{"role": "admin", "exp": time.Now().Add(time.Hour).Unix()})
+
+	assert.False(t, authenticator.JWTEnabled())
+	_, err := authenticator.AuthenticateToken(token)
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+}
+
+func TestPrincipal_HasScope(t *testing.T) {
+	key := &auth.Principal{Actor: "apikey:1", Scopes: []string{auth.ScopePollsWrite}}
+	assert.True(t, key.HasScope(auth.ScopePollsWrite))
+	assert.False(t, key.HasScope(auth.ScopeExport))
+
+	// Une clé sans scope n'a aucun droit, un JWT n'est pas restreint
+	assert.False(t, (&auth.Principal{Scopes: []string{}}).HasScope(auth.ScopePollsWrite))
+	assert.True(t, (&auth.Principal{Actor: "jwt:alice"}).HasScope(auth.ScopeExport))
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	token, prefix, hash, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+
+	assert.True(t, auth.IsAPIKey(token))
+	assert.Equal(t, token[:len(prefix)], prefix)
+	assert.Equal(t, auth.HashAPIKey(token), hash)
+	assert.NotContains(t, hash, token)
+
+	other, _, _, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCacheMiss is returned by Get when the key does not exist or has expired
+var ErrCacheMiss = errors.New("cache: key not found")
+
+// Cache is the key/value store shared by the application.
+// RedisClient backs it in multi-instance deployments and MemoryCache in standalone mode.
+type Cache interface {
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	// SetNX sets key only if it does not exist yet and reports whether it did
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	Get(ctx context.Context, key string) (string, error)
+	Delete(ctx context.Context, keys ...string) error
+	Ping(ctx context.Context) error
+	Close() error
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const memoryCleanupInterval = time.Minute
+
+type memoryEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// MemoryCache is an in-process Cache for single-instance deployments.
+// Values are stored as strings, like Redis does.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewMemoryCache creates the cache and starts the expired entries cleanup
+func NewMemoryCache() *MemoryCache {
+	c := &MemoryCache{
+		entries: make(map[string]memoryEntry),
+		done:    make(chan struct{}),
+	}
+	go c.cleanup()
+	return c
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	entry := memoryEntry{value: stringify(value)}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *MemoryCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	entry := memoryEntry{value: stringify(value)}
+	now := time.Now()
+	if expiration > 0 {
+		entry.expiresAt = now.Add(expiration)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.entries[key]; ok && !existing.expired(now) {
+		return false, nil
+	}
+	c.entries[key] = entry
+	return true, nil
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || entry.expired(time.Now()) {
+		return "", ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *MemoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (c *MemoryCache) Close() error {
+	c.once.Do(func() { close(c.done) })
+	return nil
+}
+
+func (c *MemoryCache) cleanup() {
+	ticker := time.NewTicker(memoryCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case now := <-ticker.C:
+			c.mu.Lock()
+			for key, entry := range c.entries {
+				if entry.expired(now) {
+					delete(c.entries, key)
+				}
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+// stringify mirrors how go-redis encodes scalar values
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,63 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/infrastructure/cache"
+)
+
+func TestMemoryCache_SetGetDelete(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache()
+	defer c.Close()
+
+	require.NoError(t, c.Set(ctx, "poll:1", 42, 0))
+	value, err := c.Get(ctx, "poll:1")
+	require.NoError(t, err)
+	assert.Equal(t, "42", value)
+
+	require.NoError(t, c.Delete(ctx, "poll:1"))
+	_, err = c.Get(ctx, "poll:1")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+}
+
+func TestMemoryCache_Expiration(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache()
+	defer c.Close()
+
+	require.NoError(t, c.Set(ctx, "session", "abc", 10*time.Millisecond))
+	value, err := c.Get(ctx, "session")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", value)
+
+	time.Sleep(20 * time.Millisecond)
+	_, err = c.Get(ctx, "session")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+}
+
+func TestMemoryCache_SetNX(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache()
+	defer c.Close()
+
+	ok, err := c.SetNX(ctx, "lock", "first", 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = c.SetNX(ctx, "lock", "second", 0)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	value, _ := c.Get(ctx, "lock")
+	assert.Equal(t, "first", value)
+
+	// Une entrée expirée peut être reprise
+	time.Sleep(20 * time.Millisecond)
+	ok, err = c.SetNX(ctx, "lock", "third", 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+// likeEscaper escapes the wildcards of a LIKE pattern; '!' is used as escape
+// character because it has no special meaning in any supported dialect
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+type adminRepository struct {
+	db    *gorm.DB
+	reads *Resolver
+}
+
+// NewAdminRepository writes to the primary of reads and serves listings and
+// statistics from its replicas
+func NewAdminRepository(reads *Resolver) repository.AdminRepository {
+	return &adminRepository{db: reads.Primary(), reads: reads}
+}
+
+func filterPolls(db *gorm.DB, filter repository.PollFilter, now time.Time) *gorm.DB {
+	query := db.Model(&entity.Poll{})
+	switch filter.Status {
+	case repository.PollStatusOpen:
+		query = query.Where("expires_at IS NULL OR expires_at > ?", now)
+	case repository.PollStatusClosed:
+		query = query.Where("expires_at IS NOT NULL AND expires_at <= ?", now)
+	case repository.PollStatusDeleted:
+		query = query.Unscoped().Where("deleted_at IS NOT NULL")
+	case repository.PollStatusAll:
+		query = query.Unscoped()
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(q)) + "%"
+		query = query.Where("LOWER(title) LIKE ? ESCAPE '!' OR LOWER(description) LIKE ? ESCAPE '!' OR id = ?", pattern, pattern, q)
+	}
+	return query
+}
+
+func (r *adminRepository) SearchPolls(ctx context.Context, filter repository.PollFilter, now time.Time) ([]*entity.Poll, int64, error) {
+	var (
+		polls []*entity.Poll
+		total int64
+	)
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		if err := filterPolls(db, filter, now).Count(&total).Error; err != nil {
+			return err
+		}
+		return filterPolls(db, filter, now).
+			Preload("Options").
+			Offset(filter.Offset).
+			Limit(filter.Limit).
+			Order("created_at DESC").
+			Find(&polls).Error
+	})
+	return polls, total, err
+}
+
+func (r *adminRepository) ClosePoll(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.Poll{}).Where("id = ?", id).Update("expires_at", at).Error
+}
+
+func (r *adminRepository) PollStats(ctx context.Context, id uuid.UUID) (*repository.PollStats, error) {
+	stats := &repository.PollStats{PollID: id}
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		var poll entity.Poll
+		if err := db.Unscoped().Select("id", "type").First(&poll, "id = ?", id).Error; err != nil {
+			return err
+		}
+		stats.Type = poll.Type
+
+		var options []entity.Option
+		if err := db.Unscoped().Where("poll_id = ?", id).Order(clause.OrderByColumn{Column: clause.Column{Name: "order"}}).Find(&options).Error; err != nil {
+			return err
+		}
+
+		var counts []struct {
+			OptionID uuid.UUID
+			Count    int64
+		}
+		err := db.Model(&entity.Vote{}).
+			Select("option_id, COUNT(*) AS count").
+			Where("poll_id = ?", id).
+			Group("option_id").
+			Scan(&counts).Error
+		if err != nil {
+			return err
+		}
+		byOption := make(map[uuid.UUID]int64, len(counts))
+		for _, c := range counts {
+			byOption[c.OptionID] = c.Count
+		}
+
+		stats.Options = make([]repository.OptionStats, 0, len(options))
+		for _, option := range options {
+			votes := byOption[option.ID] + int64(option.ArchivedVotes)
+			stats.Options = append(stats.Options, repository.OptionStats{OptionID: option.ID, Text: option.Text, Votes: votes})
+			stats.TotalVotes += votes
+			stats.ArchivedVotes += int64(option.ArchivedVotes)
+		}
+
+		// Les sondages texte et numériques n'ont pas d'options : leurs bulletins
+		// sont les réponses libres ou les estimations
+		var ballots interface{} = &entity.Vote{}
+		switch poll.Type {
+		case entity.PollTypeText:
+			ballots = &entity.TextResponse{}
+			if err := db.Model(ballots).Where("poll_id = ?", id).Count(&stats.Responses).Error; err != nil {
+				return err
+			}
+		case entity.PollTypeNumeric:
+			ballots = &entity.Estimate{}
+			if err := db.Model(ballots).Where("poll_id = ?", id).Count(&stats.Estimates).Error; err != nil {
+				return err
+			}
+		}
+
+		err = db.Model(ballots).Where("poll_id = ?", id).Distinct("voter_id").Count(&stats.UniqueVoters).Error
+		if err != nil {
+			return err
+		}
+
+		// MIN/MAX sur une date ne se scanne pas de façon portable (SQLite renvoie du texte)
+		var first, last []struct{ CreatedAt time.Time }
+		if err := db.Model(ballots).Select("created_at").Where("poll_id = ?", id).Order("created_at").Limit(1).Find(&first).Error; err != nil {
+			return err
+		}
+		if err := db.Model(ballots).Select("created_at").Where("poll_id = ?", id).Order("created_at DESC").Limit(1).Find(&last).Error; err != nil {
+			return err
+		}
+		if len(first) > 0 {
+			stats.FirstVoteAt, stats.LastVoteAt = &first[0].CreatedAt, &last[0].CreatedAt
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrPollNotFound
+		}
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *adminRepository) Totals(ctx context.Context, now time.Time) (*repository.Totals, error) {
+	totals := &repository.Totals{}
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		counts := []struct {
+			target *int64
+			query  *gorm.DB
+		}{
+			{&totals.Polls, db.Model(&entity.Poll{})},
+			{&totals.OpenPolls, db.Model(&entity.Poll{}).Where("expires_at IS NULL OR expires_at > ?", now)},
+			{&totals.DeletedPolls, db.Model(&entity.Poll{}).Unscoped().Where("deleted_at IS NOT NULL")},
+			{&totals.Votes, db.Model(&entity.Vote{})},
+			{&totals.ActiveBans, db.Model(&entity.Ban{}).Where("expires_at IS NULL OR expires_at > ?", now)},
+		}
+		for _, c := range counts {
+			if err := c.query.Count(c.target).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
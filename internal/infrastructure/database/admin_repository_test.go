@@ -0,0 +1,168 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+func TestAdminRepository_SearchPolls(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewAdminRepository(database.NewResolver(db))
+
+	closedAt := time.Now().Add(-time.Hour)
+	open := createPoll(t, db, nil)
+	closed := createPoll(t, db, &closedAt)
+	deleted := createPoll(t, db, nil)
+	require.NoError(t, db.Model(&entity.Poll{}).Where("id = ?", open.ID).Update("title", "Best 100% pizza").Error)
+	require.NoError(t, db.Delete(deleted).Error)
+
+	tests := []struct {
+		name   string
+		filter repository.PollFilter
+		want   []*entity.Poll
+	}{
+		{name: "not deleted by default", filter: repository.PollFilter{}, want: []*entity.Poll{open, closed}},
+		{name: "open", filter: repository.PollFilter{Status: repository.PollStatusOpen}, want: []*entity.Poll{open}},
+		{name: "closed", filter: repository.PollFilter{Status: repository.PollStatusClosed}, want: []*entity.Poll{closed}},
+		{name: "deleted", filter: repository.PollFilter{Status: repository.PollStatusDeleted}, want: []*entity.Poll{deleted}},
+		{name: "all", filter: repository.PollFilter{Status: repository.PollStatusAll}, want: []*entity.Poll{open, closed, deleted}},
+		{name: "title search escapes wildcards", filter: repository.PollFilter{Query: "100%"}, want: []*entity.Poll{open}},
+		{name: "search by id", filter: repository.PollFilter{Query: closed.ID.String()}, want: []*entity.Poll{closed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Limit = 10
+			polls, total, err := repo.SearchPolls(ctx, tt.filter, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.want)), total)
+
+			var got, want []string
+			for _, p := range polls {
+				got = append(got, p.ID.String())
+			}
+			for _, p := range tt.want {
+				want = append(want, p.ID.String())
+			}
+			assert.ElementsMatch(t, want, got)
+		})
+	}
+}
+
+func TestAdminRepository_PollStatsAndTotals(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewAdminRepository(database.NewResolver(db))
+	poll := createPoll(t, db, nil)
+	require.NoError(t, db.Model(&entity.Option{}).Where("id = ?", poll.Options[0].ID).Update("archived_votes", 3).Error)
+
+	stats, err := repo.PollStats(ctx, poll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stats.TotalVotes)
+	assert.Equal(t, int64(3), stats.ArchivedVotes)
+	assert.Equal(t, int64(1), stats.UniqueVoters)
+	require.Len(t, stats.Options, 2)
+	assert.Equal(t, int64(4), stats.Options[0].Votes)
+	assert.NotNil(t, stats.LastVoteAt)
+
+	require.NoError(t, repo.ClosePoll(ctx, poll.ID, time.Now().Add(-time.Minute)))
+	totals, err := repo.Totals(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), totals.Polls)
+	assert.Zero(t, totals.OpenPolls)
+	assert.Equal(t, int64(2), totals.Votes)
+}
+
+func TestAdminRepository_PollStatsTextPoll(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewAdminRepository(database.NewResolver(db))
+
+	// Un sondage texte n'a aucune option : il existe quand même
+	poll := &entity.Poll{Title: "What should we improve?", Type: entity.PollTypeText}
+	require.NoError(t, db.Create(poll).Error)
+	for _, voter := range []string{"alice", "bob"} {
+		require.NoError(t, db.Create(&entity.TextResponse{
+			PollID: poll.ID, Text: "Faster builds", Status: entity.ResponseApproved, VoterID: voter,
+		}).Error)
+	}
+
+	stats, err := repo.PollStats(ctx, poll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.PollTypeText, stats.Type)
+	assert.Equal(t, int64(2), stats.Responses)
+	assert.Equal(t, int64(2), stats.UniqueVoters)
+	assert.Empty(t, stats.Options)
+	assert.NotNil(t, stats.FirstVoteAt)
+
+	_, err = repo.PollStats(ctx, uuid.New())
+	assert.ErrorIs(t, err, entity.ErrPollNotFound)
+}
+
+func TestAdminRepository_PollStatsNumericPoll(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewAdminRepository(database.NewResolver(db))
+
+	poll := &entity.Poll{Title: "How many story points?", Type: entity.PollTypeNumeric}
+	require.NoError(t, db.Create(poll).Error)
+	for _, estimate := range []*entity.Estimate{
+		{PollID: poll.ID, Value: 3, VoterID: "alice"},
+		{PollID: poll.ID, Value: 5, VoterID: "bob"},
+		{PollID: poll.ID, Value: 8, VoterID: "bob"},
+	} {
+		require.NoError(t, db.Create(estimate).Error)
+	}
+
+	stats, err := repo.PollStats(ctx, poll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.PollTypeNumeric, stats.Type)
+	assert.Equal(t, int64(3), stats.Estimates)
+	assert.Equal(t, int64(2), stats.UniqueVoters)
+	assert.Empty(t, stats.Options)
+	assert.NotNil(t, stats.LastVoteAt)
+}
+
+func TestBanRepository_IsBanned(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewBanRepository(db)
+
+	expired := time.Now().Add(-time.Hour)
+	require.NoError(t, repo.Create(ctx, &entity.Ban{Kind: entity.BanIP, Value: "203.0.113.7"}))
+	require.NoError(t, repo.Create(ctx, &entity.Ban{Kind: entity.BanIP, Value: "198.51.100.1", ExpiresAt: &expired}))
+
+	banned, err := repo.IsBanned(ctx, entity.BanIP, []string{"203.0.113.7"}, time.Now())
+	require.NoError(t, err)
+	assert.True(t, banned)
+
+	banned, err = repo.IsBanned(ctx, entity.BanIP, []string{"198.51.100.1"}, time.Now())
+	require.NoError(t, err)
+	assert.False(t, banned, "expired ban")
+
+	banned, err = repo.IsBanned(ctx, entity.BanUser, []string{"203.0.113.7"}, time.Now())
+	require.NoError(t, err)
+	assert.False(t, banned, "other kind")
+
+	existing, err := repo.FindByValue(ctx, entity.BanIP, "198.51.100.1")
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	require.NoError(t, repo.Delete(ctx, existing.ID))
+	existing, err = repo.FindByValue(ctx, entity.BanIP, "198.51.100.1")
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+}
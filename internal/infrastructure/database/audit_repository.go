@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) repository.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Append(ctx context.Context, event *entity.AuditEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+
+func (r *auditRepository) ListByPoll(ctx context.Context, pollID uuid.UUID, offset, limit int) ([]*entity.AuditEvent, int64, error) {
+	var total int64
+	query := r.db.WithContext(ctx).Model(&entity.AuditEvent{}).Where("poll_id = ?", pollID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []*entity.AuditEvent
+	err := r.db.WithContext(ctx).
+		Where("poll_id = ?", pollID).
+		Order("created_at ASC").
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&events).Error
+	return events, total, err
+}
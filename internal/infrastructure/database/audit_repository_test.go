@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+func TestAuditRepository_ListByPoll(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewAuditRepository(db)
+
+	pollID := uuid.New()
+	other := uuid.New()
+	start := time.Now().UTC().Add(-time.Hour)
+	for i, action := range []string{entity.AuditPollCreated, entity.AuditPollUpdated, entity.AuditPollClosed} {
+		require.NoError(t, repo.Append(ctx, &entity.AuditEvent{
+			Actor:     "creator:sha256:abc",
+			Action:    action,
+			PollID:    &pollID,
+			CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+	require.NoError(t, repo.Append(ctx, &entity.AuditEvent{Actor: "x", Action: entity.AuditPollCreated, PollID: &other, CreatedAt: start}))
+
+	events, total, err := repo.ListByPoll(ctx, pollID, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, events, 2)
+	assert.Equal(t, entity.AuditPollCreated, events[0].Action)
+	assert.Equal(t, entity.AuditPollUpdated, events[1].Action)
+
+	events, _, err = repo.ListByPoll(ctx, pollID, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, entity.AuditPollClosed, events[0].Action)
+}
+
+func TestPollRepository_UpdateDetails(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	reads := database.NewResolver(db)
+	defer reads.Close()
+	repo := database.NewPollRepository(reads)
+
+	poll := &entity.Poll{Title: "Before", Options: []entity.Option{{Text: "A"}, {Text: "B"}}}
+	require.NoError(t, repo.Create(ctx, poll))
+
+	poll.Title = "After"
+	require.NoError(t, repo.UpdateDetails(ctx, poll, []entity.Option{{Text: "C", Order: 0}, {Text: "D", Order: 1}, {Text: "E", Order: 2}}))
+
+	stored, err := repo.GetByID(ctx, poll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "After", stored.Title)
+	require.Len(t, stored.Options, 3)
+	texts := []string{}
+	for _, option := range stored.Options {
+		texts = append(texts, option.Text)
+	}
+	assert.ElementsMatch(t, []string{"C", "D", "E"}, texts)
+
+	// Sans options, seules les métadonnées changent
+	stored.Description = "Details"
+	require.NoError(t, repo.UpdateDetails(ctx, stored, nil))
+	stored, err = repo.GetByID(ctx, poll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Details", stored.Description)
+	assert.Len(t, stored.Options, 3)
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type availabilityRepository struct {
+	db    *gorm.DB
+	reads *Resolver
+}
+
+// NewAvailabilityRepository checks duplicates on the primary of reads and
+// ranks the time slots from its replicas
+func NewAvailabilityRepository(reads *Resolver) repository.AvailabilityRepository {
+	return &availabilityRepository{db: reads.Primary(), reads: reads}
+}
+
+func (r *availabilityRepository) CreateAll(ctx context.Context, answers []*entity.Availability) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, answer := range answers {
+			if err := tx.Create(answer).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *availabilityRepository) HasAnswered(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.Availability{}).
+		Where("poll_id = ? AND voter_id = ?", pollID, voterID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *availabilityRepository) ListByPoll(ctx context.Context, pollID uuid.UUID) ([]*entity.Availability, error) {
+	var answers []*entity.Availability
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		answers = nil
+		return db.Where("poll_id = ?", pollID).
+			Order("created_at ASC").
+			Find(&answers).Error
+	})
+	return answers, err
+}
@@ -0,0 +1,56 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+func TestAvailabilityRepository(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	reads := database.NewResolver(db)
+	repo := database.NewAvailabilityRepository(reads)
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	poll := &entity.Poll{
+		Title:    "Sprint review",
+		Type:     entity.PollTypeSchedule,
+		TimeZone: "Europe/Paris",
+		Options:  []entity.Option{{Text: "Mon 15 Jan 2024 10:00–11:00 CET", StartsAt: &start, EndsAt: &end}},
+	}
+	require.NoError(t, db.Create(poll).Error)
+
+	answered, err := repo.HasAnswered(ctx, poll.ID, "voter")
+	require.NoError(t, err)
+	assert.False(t, answered)
+
+	require.NoError(t, repo.CreateAll(ctx, []*entity.Availability{{
+		PollID: poll.ID, OptionID: poll.Options[0].ID, Name: "Alice", Answer: entity.AvailabilityIfNeedBe, VoterID: "voter",
+	}}))
+
+	answered, err = repo.HasAnswered(ctx, poll.ID, "voter")
+	require.NoError(t, err)
+	assert.True(t, answered)
+
+	answers, err := repo.ListByPoll(ctx, poll.ID)
+	require.NoError(t, err)
+	require.Len(t, answers, 1)
+	assert.Equal(t, "Alice", answers[0].Name)
+	assert.Equal(t, entity.AvailabilityIfNeedBe, answers[0].Answer)
+
+	// Les créneaux relus gardent leur instant, quel que soit le fuseau de stockage
+	reloaded, err := database.NewPollRepository(reads).GetByIDWithResults(ctx, poll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Paris", reloaded.TimeZone)
+	require.NotNil(t, reloaded.Options[0].StartsAt)
+	assert.True(t, reloaded.Options[0].StartsAt.Equal(start))
+	assert.True(t, reloaded.Options[0].EndsAt.Equal(end))
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type banRepository struct {
+	db *gorm.DB
+}
+
+// NewBanRepository keeps every query on the primary so that a new ban
+// applies immediately
+func NewBanRepository(db *gorm.DB) repository.BanRepository {
+	return &banRepository{db: db}
+}
+
+func (r *banRepository) Create(ctx context.Context, ban *entity.Ban) error {
+	return r.db.WithContext(ctx).Create(ban).Error
+}
+
+func (r *banRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Ban, error) {
+	var ban entity.Ban
+	if err := r.db.WithContext(ctx).First(&ban, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("ban not found")
+		}
+		return nil, err
+	}
+	return &ban, nil
+}
+
+func (r *banRepository) FindByValue(ctx context.Context, kind, value string) (*entity.Ban, error) {
+	var bans []*entity.Ban
+	err := r.db.WithContext(ctx).Where("kind = ? AND value = ?", kind, value).Limit(1).Find(&bans).Error
+	if err != nil || len(bans) == 0 {
+		return nil, err
+	}
+	return bans[0], nil
+}
+
+func (r *banRepository) List(ctx context.Context, offset, limit int) ([]*entity.Ban, error) {
+	var bans []*entity.Ban
+	err := r.db.WithContext(ctx).
+		Offset(offset).
+		Limit(limit).
+		Order("created_at DESC").
+		Find(&bans).Error
+	return bans, err
+}
+
+func (r *banRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&entity.Ban{}, "id = ?", id).Error
+}
+
+func (r *banRepository) IsBanned(ctx context.Context, kind string, values []string, now time.Time) (bool, error) {
+	if len(values) == 0 {
+		return false, nil
+	}
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.Ban{}).
+		Where("kind = ? AND value IN ?", kind, values).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Count(&count).Error
+	return count > 0, err
+}
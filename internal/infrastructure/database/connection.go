@@ -1,52 +1,75 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/plugin/opentelemetry/tracing"
 	"microservice-go-gin/internal/config"
-	"microservice-go-gin/internal/domain/entity"
 )
 
 func NewConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := open(cfg, false)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	slog.Info("database connection established", "type", cfg.Type, "host", cfg.Host, "name", cfg.Name, "path", cfg.Path)
+
+	return db, nil
+}
+
+// open configures the pool without checking that the database is reachable.
+// lazy also skips the MySQL version probe so that an unreachable replica does
+// not fail the startup.
+func open(cfg *config.DatabaseConfig, lazy bool) (*gorm.DB, error) {
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:               NewGormLogger(),
+		DisableAutomaticPing: true,
 	}
 
-	var db *gorm.DB
-	var err error
+	dsn, err := BuildDSN(cfg)
+	if err != nil {
+		return nil, err
+	}
 
+	var dialector gorm.Dialector
 	switch cfg.Type {
 	case "postgres":
-		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=require TimeZone=UTC",
-			cfg.Host,
-			cfg.User,
-			cfg.Password,
-			cfg.Name,
-			cfg.Port,
-		)
-		db, err = gorm.Open(postgres.Open(dsn), gormConfig)
+		dialector = postgres.Open(dsn)
 	case "mysql":
-		dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-			cfg.User,
-			cfg.Password,
-			cfg.Host,
-			cfg.Port,
-			cfg.Name,
-		)
-		db, err = gorm.Open(mysql.Open(dsn), gormConfig)
+		dialector = mysql.New(mysql.Config{DSN: dsn, SkipInitializeWithVersion: lazy})
+	case "sqlite":
+		dialector = sqlite.Open(dsn)
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", cfg.Type)
 	}
 
+	db, err := gorm.Open(dialector, gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Les valeurs liées ne sont jamais exportées dans les spans (IP, user agent)
+	if err := db.Use(tracing.NewPlugin(tracing.WithoutMetrics(), tracing.WithoutQueryVariables(), tracing.WithDBName(cfg.Name))); err != nil {
+		return nil, fmt.Errorf("failed to enable database tracing: %w", err)
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database instance: %w", err)
@@ -56,19 +79,77 @@ func NewConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
-	if err := sqlDB.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
+	return db, nil
+}
+
+// sqliteDSN enables WAL so that readers do not block the writer, waits on
+// locks instead of failing with SQLITE_BUSY and enforces foreign keys.
+// Write transactions take the lock up front to avoid deadlocked upgrades.
+func sqliteDSN(path string) string {
+	pragmas := "_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=5000&_foreign_keys=on&_txlock=immediate&_cache_size=-16000"
+	return fmt.Sprintf("file:%s?%s", path, pragmas)
+}
 
-	log.Println("Database connection established")
+// Connect opens the database, retrying with exponential backoff so that the
+// service survives starting before its database
+func Connect(ctx context.Context, cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	backoff := cfg.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		db, err := NewConnection(cfg)
+		if err == nil || attempt > cfg.ConnectRetries {
+			return db, err
+		}
 
-	return db, nil
+		slog.Warn("database not reachable, retrying", "attempt", attempt, "max_attempts", cfg.ConnectRetries+1, "retry_in", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if cfg.ConnectMaxBackoff > 0 && backoff > cfg.ConnectMaxBackoff {
+			backoff = cfg.ConnectMaxBackoff
+		}
+	}
 }
 
+// Migrate applies the pending embedded SQL migrations for the dialect of db
 func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&entity.Poll{},
-		&entity.Option{},
-		&entity.Vote{},
-	)
-}
\ No newline at end of file
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up(context.Background())
+	return err
+}
+
+// CheckSchema fails with ErrSchemaTooNew when db was migrated by a newer binary
+func CheckSchema(ctx context.Context, db *gorm.DB) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	return migrator.CheckCompatible(ctx)
+}
+
+// SchemaVersion reports the schema version applied to db and the latest version
+// known by this binary. An error is returned when they differ.
+func SchemaVersion(ctx context.Context, db *gorm.DB) (current int, latest int, err error) {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return 0, 0, err
+	}
+	current, err = migrator.Version(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	latest = migrator.Latest()
+	switch {
+	case current > latest:
+		return current, latest, ErrSchemaTooNew
+	case current < latest:
+		return current, latest, fmt.Errorf("%d pending migration(s)", latest-current)
+	}
+	return current, latest, nil
+}
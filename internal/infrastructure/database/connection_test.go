@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+func TestNewConnection_SQLite(t *testing.T) {
+	db, err := database.NewConnection(&config.DatabaseConfig{
+		Type:         "sqlite",
+		Path:         filepath.Join(t.TempDir(), "quickpoll.db"),
+		MaxIdleConns: 2,
+		MaxOpenConns: 4,
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.Migrate(db))
+
+	var journalMode string
+	require.NoError(t, db.Raw("PRAGMA journal_mode").Scan(&journalMode).Error)
+	assert.Equal(t, "wal", journalMode)
+
+	var foreignKeys int
+	require.NoError(t, db.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error)
+	assert.Equal(t, 1, foreignKeys)
+}
+
+func TestNewConnection_UnsupportedType(t *testing.T) {
+	_, err := database.NewConnection(&config.DatabaseConfig{Type: "oracle"})
+	assert.Error(t, err)
+}
+
+func TestConnect_RetriesThenFails(t *testing.T) {
+	start := time.Now()
+	_, err := database.Connect(context.Background(), &config.DatabaseConfig{
+		Type:           "sqlite",
+		Path:           filepath.Join(t.TempDir(), "missing", "quickpoll.db"),
+		ConnectRetries: 2,
+		ConnectBackoff: 10 * time.Millisecond,
+	})
+	assert.Error(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
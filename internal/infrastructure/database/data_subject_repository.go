@@ -0,0 +1,354 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/privacy"
+)
+
+// identityBatchSize bounds the number of bind parameters of a lookup
+const identityBatchSize = 500
+
+type dataSubjectRepository struct {
+	db       *gorm.DB
+	ipHasher *privacy.IPHasher
+}
+
+// NewDataSubjectRepository works on the primary only: an export must include
+// the latest ballots and an erasure must not be checked against a lagging
+// replica. ipHasher must be the one used to record ballots, nil when voter IP
+// addresses are stored in clear.
+func NewDataSubjectRepository(db *gorm.DB, ipHasher *privacy.IPHasher) repository.DataSubjectRepository {
+	return &dataSubjectRepository{db: db, ipHasher: ipHasher}
+}
+
+// inBatches calls fn with consecutive slices of at most identityBatchSize identities
+func inBatches(identities []string, fn func(batch []string) error) error {
+	for start := 0; start < len(identities); start += identityBatchSize {
+		end := start + identityBatchSize
+		if end > len(identities) {
+			end = len(identities)
+		}
+		if err := fn(identities[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListIPSalts also reads the salts of the surveys and quizzes, which hashed
+// the identity of their creator and players even when no question is left
+func (r *dataSubjectRepository) ListIPSalts(ctx context.Context) ([]string, error) {
+	var salts []string
+	seen := make(map[string]bool)
+	for _, model := range []interface{}{&entity.Poll{}, &entity.Survey{}, &entity.Quiz{}} {
+		var found []string
+		err := r.db.WithContext(ctx).Unscoped().Model(model).
+			Distinct("ip_salt").
+			Pluck("ip_salt", &found).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, salt := range found {
+			if !seen[salt] {
+				seen[salt] = true
+				salts = append(salts, salt)
+			}
+		}
+	}
+	return salts, nil
+}
+
+func (r *dataSubjectRepository) FindVotes(ctx context.Context, identities []string) ([]*entity.Vote, error) {
+	var votes []*entity.Vote
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.Vote
+		err := r.db.WithContext(ctx).
+			Preload("Poll", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Preload("Option", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Where("voter_id IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		votes = append(votes, found...)
+		return err
+	})
+	return votes, err
+}
+
+func (r *dataSubjectRepository) FindPolls(ctx context.Context, identities []string) ([]*entity.Poll, error) {
+	var polls []*entity.Poll
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.Poll
+		err := r.db.WithContext(ctx).
+			Preload("Options").
+			Where("created_by IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		polls = append(polls, found...)
+		return err
+	})
+	return polls, err
+}
+
+func (r *dataSubjectRepository) FindSurveys(ctx context.Context, identities []string) ([]*entity.Survey, error) {
+	var surveys []*entity.Survey
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.Survey
+		err := r.db.WithContext(ctx).
+			Where("created_by IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		surveys = append(surveys, found...)
+		return err
+	})
+	return surveys, err
+}
+
+func (r *dataSubjectRepository) FindQuizzes(ctx context.Context, identities []string) ([]*entity.Quiz, error) {
+	var quizzes []*entity.Quiz
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.Quiz
+		err := r.db.WithContext(ctx).
+			Where("created_by IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		quizzes = append(quizzes, found...)
+		return err
+	})
+	return quizzes, err
+}
+
+func (r *dataSubjectRepository) FindTextResponses(ctx context.Context, identities []string) ([]*entity.TextResponse, error) {
+	var responses []*entity.TextResponse
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.TextResponse
+		err := r.db.WithContext(ctx).
+			Preload("Poll", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Where("voter_id IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		responses = append(responses, found...)
+		return err
+	})
+	return responses, err
+}
+
+func (r *dataSubjectRepository) FindEstimates(ctx context.Context, identities []string) ([]*entity.Estimate, error) {
+	var estimates []*entity.Estimate
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.Estimate
+		err := r.db.WithContext(ctx).
+			Preload("Poll", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Where("voter_id IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		estimates = append(estimates, found...)
+		return err
+	})
+	return estimates, err
+}
+
+func (r *dataSubjectRepository) FindAvailabilities(ctx context.Context, identities []string) ([]*entity.Availability, error) {
+	var availabilities []*entity.Availability
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.Availability
+		err := r.db.WithContext(ctx).
+			Preload("Poll", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Preload("Option", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Where("voter_id IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		availabilities = append(availabilities, found...)
+		return err
+	})
+	return availabilities, err
+}
+
+func (r *dataSubjectRepository) FindWaitlistEntries(ctx context.Context, identities []string) ([]*entity.WaitlistEntry, error) {
+	var entries []*entity.WaitlistEntry
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.WaitlistEntry
+		err := r.db.WithContext(ctx).
+			Preload("Poll", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Preload("Option", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Where("voter_id IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		entries = append(entries, found...)
+		return err
+	})
+	return entries, err
+}
+
+func (r *dataSubjectRepository) FindQuizPlayers(ctx context.Context, identities []string) ([]*entity.QuizPlayer, error) {
+	var players []*entity.QuizPlayer
+	err := inBatches(identities, func(batch []string) error {
+		var found []*entity.QuizPlayer
+		err := r.db.WithContext(ctx).
+			Preload("Quiz", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Preload("Answers").
+			Where("voter_id IN ?", batch).
+			Order("created_at").
+			Find(&found).Error
+		players = append(players, found...)
+		return err
+	})
+	return players, err
+}
+
+// Erase detaches the polls, surveys and quizzes created by the voter and erases its ballots in a
+// single transaction. Ballots of closed or archived polls are folded into
+// Option.ArchivedVotes and deleted, so that the published results do not
+// change; the other records of closed polls and finished quizzes are deleted.
+// Ballots of polls and quizzes still open are only anonymized: they keep
+// counting and still block a second ballot from the same voter.
+func (r *dataSubjectRepository) Erase(ctx context.Context, identities []string, now time.Time) (*repository.ErasureResult, error) {
+	result := &repository.ErasureResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return inBatches(identities, func(batch []string) error {
+			if err := eraseClosed(tx, batch, now, result); err != nil {
+				return err
+			}
+
+			// Il ne reste que les bulletins des sondages et quiz ouverts
+			for _, table := range voterTables {
+				anonymized, err := r.anonymize(tx, table, batch)
+				if err != nil {
+					return err
+				}
+				result.AnonymizedBallots += anonymized
+			}
+
+			detached := tx.Model(&entity.Poll{}).Unscoped().
+				Where("created_by IN ?", batch).
+				Update("created_by", "")
+			if detached.Error != nil {
+				return detached.Error
+			}
+			result.AnonymizedPolls += detached.RowsAffected
+
+			surveys := tx.Model(&entity.Survey{}).Unscoped().
+				Where("created_by IN ?", batch).
+				Update("created_by", "")
+			if surveys.Error != nil {
+				return surveys.Error
+			}
+			result.AnonymizedSurveys += surveys.RowsAffected
+
+			quizzes := tx.Model(&entity.Quiz{}).Unscoped().
+				Where("created_by IN ?", batch).
+				Update("created_by", "")
+			if quizzes.Error != nil {
+				return quizzes.Error
+			}
+			result.AnonymizedQuizzes += quizzes.RowsAffected
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// eraseClosed folds the ballots of batch cast in polls closed at now into
+// the option totals, then deletes them with the other records of batch in
+// closed polls and finished quizzes
+func eraseClosed(tx *gorm.DB, batch []string, now time.Time, result *repository.ErasureResult) error {
+	closedPolls := func() *gorm.DB {
+		return tx.Model(&entity.Poll{}).Unscoped().Select("id").
+			Where("archived_at IS NOT NULL OR (expires_at IS NOT NULL AND expires_at < ?)", now)
+	}
+
+	var closed []struct {
+		OptionID uuid.UUID
+		Count    int64
+	}
+	err := tx.Model(&entity.Vote{}).
+		Select("option_id, COUNT(*) AS count").
+		Where("voter_id IN ? AND poll_id IN (?)", batch, closedPolls()).
+		Group("option_id").
+		Scan(&closed).Error
+	if err != nil {
+		return err
+	}
+	for _, total := range closed {
+		err := tx.Model(&entity.Option{}).Unscoped().
+			Where("id = ?", total.OptionID).
+			Update("archived_votes", gorm.Expr("archived_votes + ?", total.Count)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	votes := tx.Where("voter_id IN ? AND poll_id IN (?)", batch, closedPolls()).Delete(&entity.Vote{})
+	if votes.Error != nil {
+		return votes.Error
+	}
+	result.ArchivedVotes += votes.RowsAffected
+
+	for _, records := range []struct {
+		model   interface{}
+		deleted *int64
+	}{
+		{model: &entity.TextResponse{}, deleted: &result.DeletedResponses},
+		{model: &entity.Estimate{}, deleted: &result.DeletedEstimates},
+		{model: &entity.Availability{}, deleted: &result.DeletedAvailabilities},
+		{model: &entity.WaitlistEntry{}, deleted: &result.DeletedWaitlistEntries},
+	} {
+		deleted := tx.Where("voter_id IN ? AND poll_id IN (?)", batch, closedPolls()).Delete(records.model)
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		*records.deleted += deleted.RowsAffected
+	}
+
+	finished := tx.Model(&entity.Quiz{}).Unscoped().Select("id").Where("finished_at IS NOT NULL")
+	players := tx.Model(&entity.QuizPlayer{}).Select("id").Where("voter_id IN ? AND quiz_id IN (?)", batch, finished)
+	if err := tx.Where("player_id IN (?)", players).Delete(&entity.QuizAnswer{}).Error; err != nil {
+		return err
+	}
+	finished = tx.Model(&entity.Quiz{}).Unscoped().Select("id").Where("finished_at IS NOT NULL")
+	deletedPlayers := tx.Where("voter_id IN ? AND quiz_id IN (?)", batch, finished).Delete(&entity.QuizPlayer{})
+	if deletedPlayers.Error != nil {
+		return deletedPlayers.Error
+	}
+	result.DeletedQuizPlayers += deletedPlayers.RowsAffected
+	return nil
+}
+
+// anonymize clears the IP address and user agent of the rows of table
+// stored under batch and replaces their voter ID with the tombstone that
+// duplicate checks still recognize. It returns the number of rows.
+func (r *dataSubjectRepository) anonymize(tx *gorm.DB, table voterTable, batch []string) (int64, error) {
+	var count int64
+	if err := tx.Model(table.model).Where("voter_id IN ?", batch).Count(&count).Error; err != nil || count == 0 {
+		return 0, err
+	}
+
+	if table.clientData {
+		err := tx.Model(table.model).Where("voter_id IN ?", batch).
+			Updates(map[string]interface{}{"ip_address": "", "user_agent": ""}).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, identity := range batch {
+		// Une identité hachée par sel est déjà une empreinte à clé, qu'Anonymize garde telle quelle :
+		// seules les adresses en clair changent, et leur empreinte ne dépend pas du sel
+		tombstone := r.ipHasher.Anonymize("", identity)
+		if tombstone == identity {
+			continue
+		}
+		err := tx.Model(table.model).Where("voter_id = ?", identity).Update("voter_id", tombstone).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,224 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/database"
+	"microservice-go-gin/internal/infrastructure/privacy"
+)
+
+func TestDataSubjectRepository_FindVotes(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewDataSubjectRepository(db, nil)
+	poll := createPoll(t, db, nil)
+
+	votes, err := repo.FindVotes(ctx, []string{"voter"})
+	require.NoError(t, err)
+	require.Len(t, votes, 2)
+	require.NotNil(t, votes[0].Poll)
+	require.NotNil(t, votes[0].Option)
+	assert.Equal(t, poll.Title, votes[0].Poll.Title)
+
+	votes, err = repo.FindVotes(ctx, []string{"someone-else"})
+	require.NoError(t, err)
+	assert.Empty(t, votes)
+}
+
+func TestDataSubjectRepository_Erase(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewDataSubjectRepository(db, nil)
+
+	closedAt := time.Now().Add(-time.Hour)
+	open := createPoll(t, db, nil)
+	closed := createPoll(t, db, &closedAt)
+	require.NoError(t, db.Model(&entity.Poll{}).Where("id = ?", open.ID).Update("created_by", "voter").Error)
+	for _, poll := range []*entity.Poll{open, closed} {
+		require.NoError(t, db.Create(&entity.TextResponse{
+			PollID: poll.ID, Text: "More coffee", Status: entity.ResponsePending, VoterID: "voter",
+		}).Error)
+	}
+	require.NoError(t, db.Create(&entity.Estimate{PollID: open.ID, Value: 5, VoterID: "voter"}).Error)
+	require.NoError(t, db.Create(&entity.Availability{
+		PollID: open.ID, OptionID: open.Options[0].ID, Name: "Alice", Answer: entity.AvailabilityYes, VoterID: "voter",
+	}).Error)
+	require.NoError(t, db.Create(&entity.WaitlistEntry{
+		PollID: open.ID, OptionID: open.Options[1].ID, Position: 1, VoterID: "voter", IPAddress: "203.0.113.7",
+	}).Error)
+	running, runningPlayer := createQuizPlayer(t, db, nil)
+	_, finishedPlayer := createQuizPlayer(t, db, &closedAt)
+
+	result, err := repo.Erase(ctx, []string{"voter", "other-identity"}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.ArchivedVotes)
+	assert.Equal(t, int64(7), result.AnonymizedBallots)
+	assert.Equal(t, int64(1), result.AnonymizedPolls)
+	assert.Equal(t, int64(1), result.DeletedResponses)
+	assert.Zero(t, result.DeletedEstimates)
+	assert.Zero(t, result.DeletedAvailabilities)
+	assert.Zero(t, result.DeletedWaitlistEntries)
+	assert.Equal(t, int64(1), result.DeletedQuizPlayers)
+
+	// Les bulletins du sondage ouvert restent comptés, sans IP ni user agent
+	var votes []entity.Vote
+	require.NoError(t, db.Where("poll_id = ?", open.ID).Find(&votes).Error)
+	require.Len(t, votes, 2)
+	for _, vote := range votes {
+		assert.Equal(t, privacy.Pseudonymize("voter"), vote.VoterID)
+		assert.Empty(t, vote.IPAddress)
+		assert.Empty(t, vote.UserAgent)
+	}
+	// Effacer ses données ne permet pas de voter une seconde fois
+	var hasher *privacy.IPHasher
+	voted, err := hasher.Recorded(ctx, "", open.ID, "voter", database.NewVoteRepository(db).HasVoted)
+	require.NoError(t, err)
+	assert.True(t, voted)
+
+	var entry entity.WaitlistEntry
+	require.NoError(t, db.Where("poll_id = ?", open.ID).First(&entry).Error)
+	assert.Equal(t, privacy.Pseudonymize("voter"), entry.VoterID)
+	assert.Empty(t, entry.IPAddress)
+
+	var answers int64
+	db.Model(&entity.QuizAnswer{}).Where("player_id = ?", runningPlayer.ID).Count(&answers)
+	assert.Equal(t, int64(1), answers, "the answers of a running quiz keep counting")
+	db.Model(&entity.QuizAnswer{}).Where("player_id = ?", finishedPlayer.ID).Count(&answers)
+	assert.Zero(t, answers)
+	var player entity.QuizPlayer
+	require.NoError(t, db.Where("quiz_id = ?", running.ID).First(&player).Error)
+	assert.Equal(t, privacy.Pseudonymize("voter"), player.VoterID)
+
+	// Les résultats publiés du sondage clos ne changent pas
+	var options []entity.Option
+	require.NoError(t, db.Where("poll_id = ?", closed.ID).Find(&options).Error)
+	for _, option := range options {
+		assert.Equal(t, 1, option.ArchivedVotes)
+	}
+	require.NoError(t, db.Where("poll_id = ?", open.ID).Find(&options).Error)
+	for _, option := range options {
+		assert.Zero(t, option.ArchivedVotes)
+	}
+	var remaining int64
+	db.Model(&entity.Vote{}).Where("poll_id = ?", closed.ID).Count(&remaining)
+	assert.Zero(t, remaining)
+
+	var reloaded entity.Poll
+	require.NoError(t, db.First(&reloaded, "id = ?", open.ID).Error)
+	assert.Empty(t, reloaded.CreatedBy)
+}
+
+func TestDataSubjectRepository_EraseSurveyCreator(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewDataSubjectRepository(db, nil)
+
+	survey := &entity.Survey{Title: "Team offsite", CreatedBy: "voter", Questions: []entity.Poll{{
+		Title: "Where?", CreatedBy: "voter", Options: []entity.Option{{Text: "Lyon"}, {Text: "Nantes", Order: 1}},
+	}}}
+	require.NoError(t, db.Create(survey).Error)
+	// Le sel d'un questionnaire compte même quand il ne lui reste aucune question
+	empty := &entity.Survey{Title: "Empty survey", CreatedBy: "someone-else"}
+	require.NoError(t, db.Create(empty).Error)
+
+	salts, err := repo.ListIPSalts(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, salts, empty.IPSalt)
+
+	surveys, err := repo.FindSurveys(ctx, []string{"voter"})
+	require.NoError(t, err)
+	require.Len(t, surveys, 1)
+	assert.Equal(t, survey.ID, surveys[0].ID)
+
+	result, err := repo.Erase(ctx, []string{"voter"}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.AnonymizedSurveys)
+	assert.Equal(t, int64(1), result.AnonymizedPolls)
+
+	var reloaded entity.Survey
+	require.NoError(t, db.First(&reloaded, "id = ?", survey.ID).Error)
+	assert.Empty(t, reloaded.CreatedBy)
+	var question entity.Poll
+	require.NoError(t, db.First(&question, "survey_id = ?", survey.ID).Error)
+	assert.Empty(t, question.CreatedBy)
+	var untouched entity.Survey
+	require.NoError(t, db.First(&untouched, "id = ?", empty.ID).Error)
+	assert.Equal(t, "someone-else", untouched.CreatedBy)
+}
+
+func TestDataSubjectRepository_EraseQuizCreator(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewDataSubjectRepository(db, nil)
+
+	quiz := &entity.Quiz{Title: "Onboarding quiz", CreatedBy: "voter", Questions: []entity.Poll{{
+		Title: "Which command formats Go code?", CreatedBy: "voter", Options: []entity.Option{{Text: "gofmt", Correct: true}, {Text: "go vet", Order: 1}},
+	}}}
+	require.NoError(t, db.Create(quiz).Error)
+	// Les joueurs sont hachés avec le sel du quiz, même sans question restante
+	empty := &entity.Quiz{Title: "Empty quiz", CreatedBy: "someone-else"}
+	require.NoError(t, db.Create(empty).Error)
+
+	salts, err := repo.ListIPSalts(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, salts, empty.IPSalt)
+
+	quizzes, err := repo.FindQuizzes(ctx, []string{"voter"})
+	require.NoError(t, err)
+	require.Len(t, quizzes, 1)
+	assert.Equal(t, quiz.ID, quizzes[0].ID)
+
+	result, err := repo.Erase(ctx, []string{"voter"}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.AnonymizedQuizzes)
+	assert.Equal(t, int64(1), result.AnonymizedPolls)
+
+	var reloaded entity.Quiz
+	require.NoError(t, db.First(&reloaded, "id = ?", quiz.ID).Error)
+	assert.Empty(t, reloaded.CreatedBy)
+	var question entity.Poll
+	require.NoError(t, db.First(&question, "quiz_id = ?", quiz.ID).Error)
+	assert.Empty(t, question.CreatedBy)
+	var untouched entity.Quiz
+	require.NoError(t, db.First(&untouched, "id = ?", empty.ID).Error)
+	assert.Equal(t, "someone-else", untouched.CreatedBy)
+}
+
+// createQuizPlayer creates a one-question quiz, finished at finishedAt when
+// set, and a player of "voter" with one answer
+func createQuizPlayer(t *testing.T, db *gorm.DB, finishedAt *time.Time) (*entity.Quiz, *entity.QuizPlayer) {
+	t.Helper()
+	quiz := &entity.Quiz{Title: "Onboarding quiz", FinishedAt: finishedAt, Questions: []entity.Poll{{
+		Title: "Which command formats Go code?", Options: []entity.Option{{Text: "gofmt", Correct: true}, {Text: "go vet", Order: 1}},
+	}}}
+	require.NoError(t, db.Create(quiz).Error)
+	player := &entity.QuizPlayer{QuizID: quiz.ID, Nickname: "Ada", VoterID: "voter", IPAddress: "203.0.113.7"}
+	require.NoError(t, db.Create(player).Error)
+	require.NoError(t, db.Create(&entity.QuizAnswer{
+		QuizID: quiz.ID, QuestionID: quiz.Questions[0].ID, PlayerID: player.ID, Correct: true, Points: 900,
+	}).Error)
+	return quiz, player
+}
+
+func TestDataSubjectRepository_ListIPSalts(t *testing.T) {
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewDataSubjectRepository(db, nil)
+	first := createPoll(t, db, nil)
+	second := createPoll(t, db, nil)
+	require.NoError(t, db.Delete(second).Error)
+
+	salts, err := repo.ListIPSalts(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{first.IPSalt, second.IPSalt}, salts)
+}
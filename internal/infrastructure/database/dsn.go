@@ -0,0 +1,189 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"microservice-go-gin/internal/config"
+)
+
+// mysqlTLSConfigName is the name under which the custom TLS config is registered with the MySQL driver
+const mysqlTLSConfigName = "quickpoll"
+
+// BuildDSN returns the connection string for cfg. A raw DSN or URL takes
+// precedence over the individual fields.
+func BuildDSN(cfg *config.DatabaseConfig) (string, error) {
+	if cfg.DSN != "" {
+		return cfg.DSN, nil
+	}
+
+	switch cfg.Type {
+	case "postgres":
+		return postgresDSN(cfg), nil
+	case "mysql":
+		return mysqlDSN(cfg)
+	case "sqlite":
+		return sqliteDSN(cfg.Path), nil
+	default:
+		return "", fmt.Errorf("unsupported database type: %s", cfg.Type)
+	}
+}
+
+// postgresDSN builds a libpq key/value string. Unknown keys such as
+// statement_timeout and search_path are sent by pgx as runtime parameters.
+func postgresDSN(cfg *config.DatabaseConfig) string {
+	sslMode := cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "require"
+	}
+
+	params := map[string]string{
+		"host":     cfg.Host,
+		"port":     strconv.Itoa(cfg.Port),
+		"user":     cfg.User,
+		"password": cfg.Password,
+		"dbname":   cfg.Name,
+		"sslmode":  sslMode,
+		"TimeZone": postgresTimeZone(cfg),
+	}
+	optional := map[string]string{
+		"sslrootcert":      cfg.SSLRootCert,
+		"sslcert":          cfg.SSLCert,
+		"sslkey":           cfg.SSLKey,
+		"search_path":      cfg.Schema,
+		"application_name": cfg.ApplicationName,
+	}
+	for key, value := range optional {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	if cfg.StatementTimeout > 0 {
+		params["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+quotePostgresValue(params[key]))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// quotePostgresValue quotes values that would otherwise break the key/value syntax
+func quotePostgresValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " '\\") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+func mysqlDSN(cfg *config.DatabaseConfig) (string, error) {
+	loc, err := mysqlLocation(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	dsn := mysqldriver.NewConfig()
+	dsn.User = cfg.User
+	dsn.Passwd = cfg.Password
+	dsn.Net = "tcp"
+	dsn.Addr = fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	dsn.DBName = cfg.Name
+	dsn.ParseTime = true
+	dsn.Loc = loc
+	dsn.Params = map[string]string{"charset": "utf8mb4"}
+	if cfg.StatementTimeout > 0 {
+		// Limite les SELECT côté serveur, équivalent MySQL de statement_timeout
+		dsn.Params["max_execution_time"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	tlsConfig, err := mysqlTLS(cfg)
+	if err != nil {
+		return "", err
+	}
+	dsn.TLSConfig = tlsConfig
+	return dsn.FormatDSN(), nil
+}
+
+// mysqlTLS maps the libpq-style SSL modes to the MySQL driver tls parameter.
+// verify-ca is verified like verify-full since the driver has no CA-only mode.
+func mysqlTLS(cfg *config.DatabaseConfig) (string, error) {
+	switch cfg.SSLMode {
+	case "", "disable":
+		return "", nil
+	case "prefer":
+		return "preferred", nil
+	case "require":
+		if cfg.SSLRootCert == "" && cfg.SSLCert == "" {
+			return "skip-verify", nil
+		}
+	case "verify-ca", "verify-full":
+	default:
+		return "", fmt.Errorf("unsupported ssl mode: %s", cfg.SSLMode)
+	}
+
+	tlsConfig := &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12}
+	if cfg.SSLMode == "require" {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	if cfg.SSLRootCert != "" {
+		pem, err := os.ReadFile(cfg.SSLRootCert)
+		if err != nil {
+			return "", fmt.Errorf("failed to read database CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return "", errors.New("no certificate found in database CA file")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.SSLCert != "" || cfg.SSLKey != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.SSLCert, cfg.SSLKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to load database client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if err := mysqldriver.RegisterTLSConfig(mysqlTLSConfigName, tlsConfig); err != nil {
+		return "", fmt.Errorf("failed to register database TLS config: %w", err)
+	}
+	return mysqlTLSConfigName, nil
+}
+
+// postgresTimeZone returns the session time zone, UTC unless configured
+func postgresTimeZone(cfg *config.DatabaseConfig) string {
+	if cfg.TimeZone == "" {
+		return "UTC"
+	}
+	return cfg.TimeZone
+}
+
+// mysqlLocation returns the zone in which the driver reads and writes
+// DATETIME values, the local one of the server unless configured (loc=Local)
+func mysqlLocation(cfg *config.DatabaseConfig) (*time.Location, error) {
+	if cfg.TimeZone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database time zone: %w", err)
+	}
+	return loc, nil
+}
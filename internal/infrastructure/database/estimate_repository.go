@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type estimateRepository struct {
+	db    *gorm.DB
+	reads *Resolver
+}
+
+// NewEstimateRepository checks duplicates on the primary of reads and
+// computes the statistics from its replicas
+func NewEstimateRepository(reads *Resolver) repository.EstimateRepository {
+	return &estimateRepository{db: reads.Primary(), reads: reads}
+}
+
+func (r *estimateRepository) Create(ctx context.Context, estimate *entity.Estimate) error {
+	return r.db.WithContext(ctx).Create(estimate).Error
+}
+
+func (r *estimateRepository) HasEstimated(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.Estimate{}).
+		Where("poll_id = ? AND voter_id = ?", pollID, voterID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *estimateRepository) ListValues(ctx context.Context, pollID uuid.UUID) ([]float64, error) {
+	var values []float64
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		values = nil
+		return db.Model(&entity.Estimate{}).
+			Where("poll_id = ?", pollID).
+			Pluck("value", &values).Error
+	})
+	return values, err
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold is the duration above which a query is logged as a warning
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger forwards GORM logs to slog so they share the request context.
+// SQL statements are emitted at debug level and are therefore hidden unless
+// app.log_level is "debug". Bound parameters are never logged since they may
+// carry voter IP addresses or user agents.
+type gormLogger struct {
+	level logger.LogLevel
+}
+
+// NewGormLogger returns a GORM logger backed by the default slog logger
+func NewGormLogger() logger.Interface {
+	return &gormLogger{level: logger.Info}
+}
+
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &gormLogger{level: level}
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(msg, args...), "component", "gorm")
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(msg, args...), "component", "gorm")
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(msg, args...), "component", "gorm")
+	}
+}
+
+// ParamsFilter keeps placeholders in logged statements instead of interpolated values
+func (l *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	attrs := []any{
+		"component", "gorm",
+		"duration", elapsed,
+		"rows", rows,
+		"sql", sql,
+	}
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		slog.ErrorContext(ctx, "query failed", append(attrs, "error", err)...)
+	case elapsed > slowQueryThreshold && l.level >= logger.Warn:
+		slog.WarnContext(ctx, "slow query", attrs...)
+	case l.level >= logger.Info:
+		slog.DebugContext(ctx, "query", attrs...)
+	}
+}
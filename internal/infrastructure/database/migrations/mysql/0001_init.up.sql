@@ -0,0 +1,47 @@
+-- Create polls table
+CREATE TABLE IF NOT EXISTS polls (
+    id CHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_by VARCHAR(100),
+    multi_choice BOOLEAN DEFAULT FALSE,
+    require_auth BOOLEAN DEFAULT FALSE,
+    expires_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    deleted_at DATETIME(3) NULL,
+    INDEX idx_polls_created_by (created_by),
+    INDEX idx_polls_expires_at (expires_at),
+    INDEX idx_polls_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create options table
+CREATE TABLE IF NOT EXISTS options (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    text VARCHAR(255) NOT NULL,
+    `order` INT DEFAULT 0,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    deleted_at DATETIME(3) NULL,
+    INDEX idx_options_poll_id (poll_id),
+    INDEX idx_options_deleted_at (deleted_at),
+    CONSTRAINT fk_polls_options FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create votes table
+CREATE TABLE IF NOT EXISTS votes (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    option_id CHAR(36) NOT NULL,
+    voter_id VARCHAR(100),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    created_at DATETIME(3) NULL,
+    INDEX idx_votes_poll_id (poll_id),
+    INDEX idx_votes_option_id (option_id),
+    INDEX idx_votes_voter_id (voter_id),
+    INDEX idx_votes_poll_voter (poll_id, voter_id),
+    CONSTRAINT fk_polls_votes FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_options_votes FOREIGN KEY (option_id) REFERENCES options(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+DROP INDEX idx_votes_created_at ON votes;
+DROP INDEX idx_polls_archived_at ON polls;
+ALTER TABLE polls DROP COLUMN archived_at;
+ALTER TABLE options DROP COLUMN archived_votes;
@@ -0,0 +1,14 @@
+-- Create append-only audit_events table
+CREATE TABLE IF NOT EXISTS audit_events (
+    id CHAR(36) PRIMARY KEY,
+    actor VARCHAR(100) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target VARCHAR(100),
+    poll_id CHAR(36) NULL,
+    changes TEXT,
+    request_id VARCHAR(128),
+    created_at DATETIME(3) NULL,
+    INDEX idx_audit_events_poll_id (poll_id),
+    INDEX idx_audit_events_actor (actor),
+    INDEX idx_audit_events_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
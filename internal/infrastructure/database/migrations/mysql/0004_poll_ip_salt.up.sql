@@ -0,0 +1,2 @@
+-- Add the per-poll salt used to hash voter IP addresses
+ALTER TABLE polls ADD COLUMN ip_salt VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+-- Create bans table for blocked IP addresses and users
+CREATE TABLE IF NOT EXISTS bans (
+    id CHAR(36) PRIMARY KEY,
+    kind VARCHAR(10) NOT NULL,
+    value VARCHAR(100) NOT NULL,
+    reason VARCHAR(255),
+    created_by VARCHAR(100),
+    expires_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    UNIQUE INDEX idx_bans_kind_value (kind, value)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+ALTER TABLE options DROP COLUMN translations;
+ALTER TABLE polls DROP COLUMN translations;
+ALTER TABLE polls DROP COLUMN language;
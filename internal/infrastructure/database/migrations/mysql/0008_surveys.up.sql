@@ -0,0 +1,20 @@
+-- Create surveys table and attach ordered, optionally conditional questions (polls) to them
+CREATE TABLE IF NOT EXISTS surveys (
+    id CHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_by VARCHAR(100),
+    require_auth BOOLEAN DEFAULT FALSE,
+    ip_salt VARCHAR(64) NOT NULL DEFAULT '',
+    expires_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    deleted_at DATETIME(3) NULL,
+    INDEX idx_surveys_created_by (created_by),
+    INDEX idx_surveys_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+ALTER TABLE polls ADD COLUMN survey_id CHAR(36) NULL;
+ALTER TABLE polls ADD COLUMN position INT NOT NULL DEFAULT 0;
+ALTER TABLE polls ADD COLUMN optional BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE polls ADD COLUMN show_if TEXT;
+CREATE INDEX idx_polls_survey_id ON polls (survey_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS text_responses;
+ALTER TABLE polls DROP COLUMN moderated;
+ALTER TABLE polls DROP COLUMN type;
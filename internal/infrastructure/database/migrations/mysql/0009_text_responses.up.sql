@@ -0,0 +1,15 @@
+-- Add open-text polls and their moderated text responses
+ALTER TABLE polls ADD COLUMN type VARCHAR(10) NOT NULL DEFAULT 'choice';
+ALTER TABLE polls ADD COLUMN moderated BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE TABLE IF NOT EXISTS text_responses (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    text TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    voter_id VARCHAR(100),
+    created_at DATETIME(3) NULL,
+    moderated_at DATETIME(3) NULL,
+    INDEX idx_text_responses_poll_status (poll_id, status),
+    INDEX idx_text_responses_voter_id (voter_id),
+    CONSTRAINT fk_polls_text_responses FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE options DROP COLUMN pending;
+ALTER TABLE polls DROP COLUMN allow_write_in;
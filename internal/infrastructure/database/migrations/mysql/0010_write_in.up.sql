@@ -0,0 +1,3 @@
+-- Let voters add their own option to polls that allow write-ins
+ALTER TABLE polls ADD COLUMN allow_write_in BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE options ADD COLUMN pending BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,13 @@
+-- Add numeric estimation polls and their estimates
+ALTER TABLE polls ADD COLUMN numeric_settings TEXT;
+ALTER TABLE polls ADD COLUMN revealed_at DATETIME(3) NULL;
+CREATE TABLE IF NOT EXISTS estimates (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    value DOUBLE NOT NULL,
+    voter_id VARCHAR(100),
+    created_at DATETIME(3) NULL,
+    INDEX idx_estimates_poll_id (poll_id),
+    INDEX idx_estimates_voter_id (voter_id),
+    CONSTRAINT fk_polls_estimates FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
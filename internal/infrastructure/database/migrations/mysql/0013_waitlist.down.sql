@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS waitlist_entries;
+ALTER TABLE options DROP COLUMN max_votes;
+ALTER TABLE polls DROP COLUMN waitlist;
@@ -0,0 +1,18 @@
+-- Add sign-up sheets: the capacity of options and the waitlists of full options
+ALTER TABLE polls ADD COLUMN waitlist BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE options ADD COLUMN max_votes INTEGER NULL;
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    option_id CHAR(36) NOT NULL,
+    position BIGINT NOT NULL,
+    voter_id VARCHAR(100),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    created_at DATETIME(3) NULL,
+    INDEX idx_waitlist_entries_poll_id (poll_id),
+    UNIQUE INDEX idx_waitlist_entries_option_position (option_id, position),
+    INDEX idx_waitlist_entries_voter_id (voter_id),
+    CONSTRAINT fk_polls_waitlist_entries FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_options_waitlist_entries FOREIGN KEY (option_id) REFERENCES options(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
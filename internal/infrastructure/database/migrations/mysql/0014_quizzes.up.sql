@@ -0,0 +1,51 @@
+-- Add quizzes: timed questions (polls) with correct options, their players and scored answers
+CREATE TABLE IF NOT EXISTS quizzes (
+    id CHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_by VARCHAR(100),
+    ip_salt VARCHAR(64) NOT NULL DEFAULT '',
+    current_question INT NULL,
+    question_started_at DATETIME(3) NULL,
+    question_ends_at DATETIME(3) NULL,
+    question_closed_at DATETIME(3) NULL,
+    finished_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    deleted_at DATETIME(3) NULL,
+    INDEX idx_quizzes_created_by (created_by),
+    INDEX idx_quizzes_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+ALTER TABLE polls ADD COLUMN quiz_id CHAR(36) NULL;
+ALTER TABLE polls ADD COLUMN time_limit INT NOT NULL DEFAULT 0;
+ALTER TABLE options ADD COLUMN correct BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX idx_polls_quiz_id ON polls (quiz_id);
+CREATE TABLE IF NOT EXISTS quiz_players (
+    id CHAR(36) PRIMARY KEY,
+    quiz_id CHAR(36) NOT NULL,
+    nickname VARCHAR(30) NOT NULL,
+    voter_id VARCHAR(100),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    created_at DATETIME(3) NULL,
+    UNIQUE INDEX idx_quiz_players_quiz_nickname (quiz_id, nickname),
+    INDEX idx_quiz_players_voter_id (voter_id),
+    CONSTRAINT fk_quizzes_quiz_players FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+CREATE TABLE IF NOT EXISTS quiz_answers (
+    id CHAR(36) PRIMARY KEY,
+    quiz_id CHAR(36) NOT NULL,
+    question_id CHAR(36) NOT NULL,
+    player_id CHAR(36) NOT NULL,
+    option_ids TEXT,
+    correct BOOLEAN NOT NULL DEFAULT FALSE,
+    points INT NOT NULL DEFAULT 0,
+    response_time BIGINT NOT NULL DEFAULT 0,
+    created_at DATETIME(3) NULL,
+    INDEX idx_quiz_answers_quiz_id (quiz_id),
+    UNIQUE INDEX idx_quiz_answers_question_player (question_id, player_id),
+    INDEX idx_quiz_answers_player_id (player_id),
+    CONSTRAINT fk_quizzes_quiz_answers FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_polls_quiz_answers FOREIGN KEY (question_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_quiz_players_quiz_answers FOREIGN KEY (player_id) REFERENCES quiz_players(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,47 @@
+-- Create polls table
+CREATE TABLE IF NOT EXISTS polls (
+    id CHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_by VARCHAR(100),
+    multi_choice BOOLEAN DEFAULT FALSE,
+    require_auth BOOLEAN DEFAULT FALSE,
+    expires_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NULL,
+    updated_at TIMESTAMPTZ NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_polls_created_by ON polls (created_by);
+CREATE INDEX IF NOT EXISTS idx_polls_expires_at ON polls (expires_at);
+CREATE INDEX IF NOT EXISTS idx_polls_deleted_at ON polls (deleted_at);
+
+-- Create options table
+CREATE TABLE IF NOT EXISTS options (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    text VARCHAR(255) NOT NULL,
+    "order" INTEGER DEFAULT 0,
+    created_at TIMESTAMPTZ NULL,
+    updated_at TIMESTAMPTZ NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    CONSTRAINT fk_polls_options FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_options_poll_id ON options (poll_id);
+CREATE INDEX IF NOT EXISTS idx_options_deleted_at ON options (deleted_at);
+
+-- Create votes table
+CREATE TABLE IF NOT EXISTS votes (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    option_id CHAR(36) NOT NULL,
+    voter_id VARCHAR(100),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    created_at TIMESTAMPTZ NULL,
+    CONSTRAINT fk_polls_votes FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_options_votes FOREIGN KEY (option_id) REFERENCES options(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_votes_poll_id ON votes (poll_id);
+CREATE INDEX IF NOT EXISTS idx_votes_option_id ON votes (option_id);
+CREATE INDEX IF NOT EXISTS idx_votes_voter_id ON votes (voter_id);
+CREATE INDEX IF NOT EXISTS idx_votes_poll_voter ON votes (poll_id, voter_id);
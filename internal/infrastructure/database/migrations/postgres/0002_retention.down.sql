@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_votes_created_at;
+DROP INDEX IF EXISTS idx_polls_archived_at;
+ALTER TABLE polls DROP COLUMN archived_at;
+ALTER TABLE options DROP COLUMN archived_votes;
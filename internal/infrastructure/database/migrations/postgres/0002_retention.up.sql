@@ -0,0 +1,5 @@
+-- Add archived ballot aggregates and poll archive date
+ALTER TABLE options ADD COLUMN archived_votes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE polls ADD COLUMN archived_at TIMESTAMPTZ NULL;
+CREATE INDEX IF NOT EXISTS idx_polls_archived_at ON polls (archived_at);
+CREATE INDEX IF NOT EXISTS idx_votes_created_at ON votes (created_at);
@@ -0,0 +1,14 @@
+-- Create append-only audit_events table
+CREATE TABLE IF NOT EXISTS audit_events (
+    id CHAR(36) PRIMARY KEY,
+    actor VARCHAR(100) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target VARCHAR(100),
+    poll_id CHAR(36) NULL,
+    changes TEXT,
+    request_id VARCHAR(128),
+    created_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_events_poll_id ON audit_events (poll_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
@@ -0,0 +1,11 @@
+-- Create bans table for blocked IP addresses and users
+CREATE TABLE IF NOT EXISTS bans (
+    id CHAR(36) PRIMARY KEY,
+    kind VARCHAR(10) NOT NULL,
+    value VARCHAR(100) NOT NULL,
+    reason VARCHAR(255),
+    created_by VARCHAR(100),
+    expires_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bans_kind_value ON bans (kind, value);
@@ -0,0 +1,4 @@
+-- Store the locale of the poll content and its translations keyed by locale
+ALTER TABLE polls ADD COLUMN language VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE polls ADD COLUMN translations TEXT;
+ALTER TABLE options ADD COLUMN translations TEXT;
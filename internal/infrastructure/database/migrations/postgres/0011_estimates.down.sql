@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS estimates;
+ALTER TABLE polls DROP COLUMN revealed_at;
+ALTER TABLE polls DROP COLUMN numeric_settings;
@@ -0,0 +1,18 @@
+-- Add scheduling polls, their time slots and the availability of their participants
+ALTER TABLE polls ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE options ADD COLUMN starts_at TIMESTAMPTZ NULL;
+ALTER TABLE options ADD COLUMN ends_at TIMESTAMPTZ NULL;
+CREATE TABLE IF NOT EXISTS availabilities (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    option_id CHAR(36) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    answer VARCHAR(10) NOT NULL,
+    voter_id VARCHAR(100),
+    created_at TIMESTAMPTZ NULL,
+    CONSTRAINT fk_polls_availabilities FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_options_availabilities FOREIGN KEY (option_id) REFERENCES options(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_availabilities_poll_id ON availabilities (poll_id);
+CREATE INDEX IF NOT EXISTS idx_availabilities_option_id ON availabilities (option_id);
+CREATE INDEX IF NOT EXISTS idx_availabilities_voter_id ON availabilities (voter_id);
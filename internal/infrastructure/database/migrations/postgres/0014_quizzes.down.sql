@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS quiz_answers;
+DROP TABLE IF EXISTS quiz_players;
+DROP INDEX IF EXISTS idx_polls_quiz_id;
+ALTER TABLE options DROP COLUMN correct;
+ALTER TABLE polls DROP COLUMN time_limit;
+ALTER TABLE polls DROP COLUMN quiz_id;
+DROP TABLE IF EXISTS quizzes;
@@ -0,0 +1,51 @@
+-- Add quizzes: timed questions (polls) with correct options, their players and scored answers
+CREATE TABLE IF NOT EXISTS quizzes (
+    id CHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_by VARCHAR(100),
+    ip_salt VARCHAR(64) NOT NULL DEFAULT '',
+    current_question INTEGER NULL,
+    question_started_at TIMESTAMPTZ NULL,
+    question_ends_at TIMESTAMPTZ NULL,
+    question_closed_at TIMESTAMPTZ NULL,
+    finished_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NULL,
+    updated_at TIMESTAMPTZ NULL,
+    deleted_at TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS idx_quizzes_created_by ON quizzes (created_by);
+CREATE INDEX IF NOT EXISTS idx_quizzes_deleted_at ON quizzes (deleted_at);
+ALTER TABLE polls ADD COLUMN quiz_id CHAR(36) NULL;
+ALTER TABLE polls ADD COLUMN time_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE options ADD COLUMN correct BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_polls_quiz_id ON polls (quiz_id);
+CREATE TABLE IF NOT EXISTS quiz_players (
+    id CHAR(36) PRIMARY KEY,
+    quiz_id CHAR(36) NOT NULL,
+    nickname VARCHAR(30) NOT NULL,
+    voter_id VARCHAR(100),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    created_at TIMESTAMPTZ NULL,
+    CONSTRAINT fk_quizzes_quiz_players FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quiz_players_quiz_nickname ON quiz_players (quiz_id, nickname);
+CREATE INDEX IF NOT EXISTS idx_quiz_players_voter_id ON quiz_players (voter_id);
+CREATE TABLE IF NOT EXISTS quiz_answers (
+    id CHAR(36) PRIMARY KEY,
+    quiz_id CHAR(36) NOT NULL,
+    question_id CHAR(36) NOT NULL,
+    player_id CHAR(36) NOT NULL,
+    option_ids TEXT,
+    correct BOOLEAN NOT NULL DEFAULT FALSE,
+    points INTEGER NOT NULL DEFAULT 0,
+    response_time BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NULL,
+    CONSTRAINT fk_quizzes_quiz_answers FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_polls_quiz_answers FOREIGN KEY (question_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_quiz_players_quiz_answers FOREIGN KEY (player_id) REFERENCES quiz_players(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_quiz_answers_quiz_id ON quiz_answers (quiz_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quiz_answers_question_player ON quiz_answers (question_id, player_id);
+CREATE INDEX IF NOT EXISTS idx_quiz_answers_player_id ON quiz_answers (player_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_polls_survey_id;
+ALTER TABLE polls DROP COLUMN show_if;
+ALTER TABLE polls DROP COLUMN optional;
+ALTER TABLE polls DROP COLUMN position;
+ALTER TABLE polls DROP COLUMN survey_id;
+DROP TABLE IF EXISTS surveys;
@@ -0,0 +1,20 @@
+-- Create surveys table and attach ordered, optionally conditional questions (polls) to them
+CREATE TABLE IF NOT EXISTS surveys (
+    id CHAR(36) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_by VARCHAR(100),
+    require_auth NUMERIC DEFAULT false,
+    ip_salt VARCHAR(64) NOT NULL DEFAULT '',
+    expires_at DATETIME NULL,
+    created_at DATETIME NULL,
+    updated_at DATETIME NULL,
+    deleted_at DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS idx_surveys_created_by ON surveys (created_by);
+CREATE INDEX IF NOT EXISTS idx_surveys_deleted_at ON surveys (deleted_at);
+ALTER TABLE polls ADD COLUMN survey_id CHAR(36) NULL;
+ALTER TABLE polls ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE polls ADD COLUMN optional NUMERIC NOT NULL DEFAULT false;
+ALTER TABLE polls ADD COLUMN show_if TEXT;
+CREATE INDEX IF NOT EXISTS idx_polls_survey_id ON polls (survey_id);
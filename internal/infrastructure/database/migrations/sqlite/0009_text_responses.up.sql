@@ -0,0 +1,15 @@
+-- Add open-text polls and their moderated text responses
+ALTER TABLE polls ADD COLUMN type VARCHAR(10) NOT NULL DEFAULT 'choice';
+ALTER TABLE polls ADD COLUMN moderated NUMERIC NOT NULL DEFAULT false;
+CREATE TABLE IF NOT EXISTS text_responses (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    text TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    voter_id VARCHAR(100),
+    created_at DATETIME NULL,
+    moderated_at DATETIME NULL,
+    CONSTRAINT fk_polls_text_responses FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_text_responses_poll_status ON text_responses (poll_id, status);
+CREATE INDEX IF NOT EXISTS idx_text_responses_voter_id ON text_responses (voter_id);
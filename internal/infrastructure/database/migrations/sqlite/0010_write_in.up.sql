@@ -0,0 +1,3 @@
+-- Let voters add their own option to polls that allow write-ins
+ALTER TABLE polls ADD COLUMN allow_write_in NUMERIC NOT NULL DEFAULT false;
+ALTER TABLE options ADD COLUMN pending NUMERIC NOT NULL DEFAULT false;
@@ -0,0 +1,13 @@
+-- Add numeric estimation polls and their estimates
+ALTER TABLE polls ADD COLUMN numeric_settings TEXT;
+ALTER TABLE polls ADD COLUMN revealed_at DATETIME NULL;
+CREATE TABLE IF NOT EXISTS estimates (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    value REAL NOT NULL,
+    voter_id VARCHAR(100),
+    created_at DATETIME NULL,
+    CONSTRAINT fk_polls_estimates FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_estimates_poll_id ON estimates (poll_id);
+CREATE INDEX IF NOT EXISTS idx_estimates_voter_id ON estimates (voter_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS availabilities;
+ALTER TABLE options DROP COLUMN ends_at;
+ALTER TABLE options DROP COLUMN starts_at;
+ALTER TABLE polls DROP COLUMN time_zone;
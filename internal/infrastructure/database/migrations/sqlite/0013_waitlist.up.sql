@@ -0,0 +1,18 @@
+-- Add sign-up sheets: the capacity of options and the waitlists of full options
+ALTER TABLE polls ADD COLUMN waitlist NUMERIC NOT NULL DEFAULT false;
+ALTER TABLE options ADD COLUMN max_votes INTEGER NULL;
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id CHAR(36) PRIMARY KEY,
+    poll_id CHAR(36) NOT NULL,
+    option_id CHAR(36) NOT NULL,
+    position BIGINT NOT NULL,
+    voter_id VARCHAR(100),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    created_at DATETIME NULL,
+    CONSTRAINT fk_polls_waitlist_entries FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    CONSTRAINT fk_options_waitlist_entries FOREIGN KEY (option_id) REFERENCES options(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_poll_id ON waitlist_entries (poll_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_entries_option_position ON waitlist_entries (option_id, position);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_voter_id ON waitlist_entries (voter_id);
@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "quickpoll.db")), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	migrator, err := database.NewMigrator(db)
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, migrator.Latest())
+	assert.True(t, db.Migrator().HasTable("polls"))
+	assert.True(t, db.Migrator().HasTable("votes"))
+
+	// Une seconde exécution ne rejoue rien
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	version, err := migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), version)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, "migration %04d_%s", status.Version, status.Name)
+	}
+
+	reverted, err := migrator.Down(ctx, migrator.Latest())
+	require.NoError(t, err)
+	assert.Len(t, reverted, migrator.Latest())
+	assert.False(t, db.Migrator().HasTable("polls"))
+
+	version, err = migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, version)
+}
+
+func TestMigrator_RefusesNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+
+	migrator, err := database.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, migrator.CheckCompatible(ctx))
+
+	future := migrator.Latest() + 1
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, CURRENT_TIMESTAMP)", future, "future").Error)
+
+	assert.ErrorIs(t, migrator.CheckCompatible(ctx), database.ErrSchemaTooNew)
+	_, err = migrator.Up(ctx)
+	assert.ErrorIs(t, err, database.ErrSchemaTooNew)
+
+	_, _, err = database.SchemaVersion(ctx, db)
+	assert.ErrorIs(t, err, database.ErrSchemaTooNew)
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -11,11 +12,14 @@ import (
 )
 
 type pollRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	reads *Resolver
 }
 
-func NewPollRepository(db *gorm.DB) repository.PollRepository {
-	return &pollRepository{db: db}
+// NewPollRepository writes to the primary of reads and serves lookups,
+// results and listings from its replicas
+func NewPollRepository(reads *Resolver) repository.PollRepository {
+	return &pollRepository{db: reads.Primary(), reads: reads}
 }
 
 func (r *pollRepository) Create(ctx context.Context, poll *entity.Poll) error {
@@ -24,10 +28,12 @@ func (r *pollRepository) Create(ctx context.Context, poll *entity.Poll) error {
 
 func (r *pollRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Poll, error) {
 	var poll entity.Poll
-	err := r.db.WithContext(ctx).Preload("Options").First(&poll, "id = ?", id).Error
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		return db.Preload("Options").First(&poll, "id = ?", id).Error
+	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("poll not found")
+			return nil, entity.ErrPollNotFound
 		}
 		return nil, err
 	}
@@ -36,17 +42,36 @@ func (r *pollRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Pol
 
 func (r *pollRepository) GetByIDWithResults(ctx context.Context, id uuid.UUID) (*entity.Poll, error) {
 	var poll entity.Poll
-	err := r.db.WithContext(ctx).Preload("Options").First(&poll, "id = ?", id).Error
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		poll = entity.Poll{}
+		if err := db.Preload("Options").First(&poll, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		for i := range poll.Options {
+			var count int64
+			if err := db.Model(&entity.Vote{}).Where("option_id = ?", poll.Options[i].ID).Count(&count).Error; err != nil {
+				return err
+			}
+			poll.Options[i].VoteCount = int(count) + poll.Options[i].ArchivedVotes
+
+			if poll.Options[i].MaxVotes != nil {
+				var waitlisted int64
+				if err := db.Model(&entity.WaitlistEntry{}).Where("option_id = ?", poll.Options[i].ID).Count(&waitlisted).Error; err != nil {
+					return err
+				}
+				poll.Options[i].SetCapacity(int(waitlisted))
+			}
+		}
+		return nil
+	})
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrPollNotFound
+		}
 		return nil, err
 	}
 
-	for i := range poll.Options {
-		var count int64
-		r.db.Model(&entity.Vote{}).Where("option_id = ?", poll.Options[i].ID).Count(&count)
-		poll.Options[i].VoteCount = int(count)
-	}
-
 	return &poll, nil
 }
 
@@ -54,38 +79,104 @@ func (r *pollRepository) Update(ctx context.Context, poll *entity.Poll) error {
 	return r.db.WithContext(ctx).Save(poll).Error
 }
 
+func (r *pollRepository) UpdateDetails(ctx context.Context, poll *entity.Poll, options []entity.Option) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(poll).
+			Select("title", "description", "expires_at", "language", "translations", "updated_at").
+			Updates(poll).Error
+		if err != nil {
+			return err
+		}
+		if options == nil {
+			// Les options sont conservées (et leurs votes) : seules leurs traductions changent
+			for i := range poll.Options {
+				err := tx.Model(&poll.Options[i]).Select("translations").Updates(&poll.Options[i]).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if err := tx.Where("poll_id = ?", poll.ID).Delete(&entity.Option{}).Error; err != nil {
+			return err
+		}
+		for i := range options {
+			options[i].PollID = poll.ID
+		}
+		if err := tx.Create(&options).Error; err != nil {
+			return err
+		}
+		poll.Options = options
+		return nil
+	})
+}
+
+func (r *pollRepository) ApproveOption(ctx context.Context, optionID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entity.Option{}).Where("id = ?", optionID).Update("pending", false).Error
+}
+
+func (r *pollRepository) DeleteOption(ctx context.Context, optionID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("option_id = ?", optionID).Delete(&entity.Vote{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", optionID).Delete(&entity.Option{}).Error
+	})
+}
+
+func (r *pollRepository) Reveal(ctx context.Context, pollID uuid.UUID, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.Poll{}).
+		Where("id = ? AND revealed_at IS NULL", pollID).
+		Update("revealed_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrAlreadyRevealed
+	}
+	return nil
+}
+
 func (r *pollRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&entity.Poll{}, "id = ?", id).Error
 }
 
 func (r *pollRepository) List(ctx context.Context, offset, limit int) ([]*entity.Poll, error) {
 	var polls []*entity.Poll
-	err := r.db.WithContext(ctx).
-		Offset(offset).
-		Limit(limit).
-		Order("created_at DESC").
-		Find(&polls).Error
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		return db.
+			Offset(offset).
+			Limit(limit).
+			Order("created_at DESC").
+			Find(&polls).Error
+	})
 	return polls, err
 }
 
 func (r *pollRepository) GetActivePolls(ctx context.Context, offset, limit int) ([]*entity.Poll, error) {
 	var polls []*entity.Poll
-	err := r.db.WithContext(ctx).
-		Where("expires_at IS NULL OR expires_at > ?", gorm.Expr("NOW()")).
-		Offset(offset).
-		Limit(limit).
-		Order("created_at DESC").
-		Find(&polls).Error
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		return db.
+			Where("expires_at IS NULL OR expires_at > ?", gorm.Expr("NOW()")).
+			Offset(offset).
+			Limit(limit).
+			Order("created_at DESC").
+			Find(&polls).Error
+	})
 	return polls, err
 }
 
 func (r *pollRepository) GetPollsByCreator(ctx context.Context, creatorID string, offset, limit int) ([]*entity.Poll, error) {
 	var polls []*entity.Poll
-	err := r.db.WithContext(ctx).
-		Where("created_by = ?", creatorID).
-		Offset(offset).
-		Limit(limit).
-		Order("created_at DESC").
-		Find(&polls).Error
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		return db.
+			Where("created_by = ?", creatorID).
+			Offset(offset).
+			Limit(limit).
+			Order("created_at DESC").
+			Find(&polls).Error
+	})
 	return polls, err
-}
\ No newline at end of file
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type quizRepository struct {
+	db *gorm.DB
+}
+
+// NewQuizRepository works on the primary only: the players of a live quiz
+// answer against the current question, which a lagging replica would miss
+func NewQuizRepository(db *gorm.DB) repository.QuizRepository {
+	return &quizRepository{db: db}
+}
+
+func (r *quizRepository) Create(ctx context.Context, quiz *entity.Quiz) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(quiz).Error
+	})
+}
+
+func (r *quizRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Quiz, error) {
+	var quiz entity.Quiz
+	err := r.db.WithContext(ctx).Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Questions.Options").First(&quiz, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrQuizNotFound
+		}
+		return nil, err
+	}
+	for i := range quiz.Questions {
+		options := quiz.Questions[i].Options
+		sort.SliceStable(options, func(a, b int) bool {
+			return options[a].Order < options[b].Order
+		})
+	}
+	return &quiz, nil
+}
+
+func (r *quizRepository) StartQuestion(ctx context.Context, quizID uuid.UUID, position int, startedAt, endsAt time.Time) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&entity.Quiz{}).
+		Where("id = ? AND finished_at IS NULL", quizID)
+	// Mise à jour conditionnelle : deux « suivant » simultanés ne sautent pas de question
+	if position == 0 {
+		query = query.Where("current_question IS NULL")
+	} else {
+		query = query.Where("current_question = ?", position-1)
+	}
+	result := query.Updates(map[string]interface{}{
+		"current_question":    position,
+		"question_started_at": startedAt,
+		"question_ends_at":    endsAt,
+		"question_closed_at":  nil,
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *quizRepository) CloseQuestion(ctx context.Context, quizID uuid.UUID, position int, closedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.Quiz{}).
+		Where("id = ? AND current_question = ? AND question_closed_at IS NULL", quizID, position).
+		Update("question_closed_at", closedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *quizRepository) Finish(ctx context.Context, quizID uuid.UUID, finishedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&entity.Quiz{}).
+		Where("id = ? AND finished_at IS NULL", quizID).
+		Update("finished_at", finishedAt).Error
+}
+
+// CreatePlayer locks the quiz so that two players cannot take the same
+// nickname at once
+func (r *quizRepository) CreatePlayer(ctx context.Context, player *entity.QuizPlayer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var quiz entity.Quiz
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&quiz, "id = ?", player.QuizID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.ErrQuizNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var taken int64
+		err = tx.Model(&entity.QuizPlayer{}).
+			Where("quiz_id = ? AND LOWER(nickname) = LOWER(?)", player.QuizID, player.Nickname).
+			Count(&taken).Error
+		if err != nil {
+			return err
+		}
+		if taken > 0 {
+			return entity.ErrNicknameTaken
+		}
+		return tx.Create(player).Error
+	})
+}
+
+func (r *quizRepository) GetPlayer(ctx context.Context, quizID, playerID uuid.UUID) (*entity.QuizPlayer, error) {
+	var player entity.QuizPlayer
+	err := r.db.WithContext(ctx).First(&player, "id = ? AND quiz_id = ?", playerID, quizID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, entity.ErrPlayerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &player, nil
+}
+
+// CreateAnswer locks the player so that the same answer sent twice at
+// once is only recorded once
+func (r *quizRepository) CreateAnswer(ctx context.Context, answer *entity.QuizAnswer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var player entity.QuizPlayer
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&player, "id = ?", answer.PlayerID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.ErrPlayerNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var answered int64
+		err = tx.Model(&entity.QuizAnswer{}).
+			Where("question_id = ? AND player_id = ?", answer.QuestionID, answer.PlayerID).
+			Count(&answered).Error
+		if err != nil {
+			return err
+		}
+		if answered > 0 {
+			return entity.ErrAlreadyAnswered
+		}
+		return tx.Create(answer).Error
+	})
+}
+
+func (r *quizRepository) ListAnswers(ctx context.Context, questionID uuid.UUID) ([]*entity.QuizAnswer, error) {
+	var answers []*entity.QuizAnswer
+	err := r.db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Order("created_at ASC").
+		Find(&answers).Error
+	return answers, err
+}
+
+func (r *quizRepository) ListScores(ctx context.Context, quizID uuid.UUID) ([]entity.PlayerScore, error) {
+	var scores []entity.PlayerScore
+	err := r.db.WithContext(ctx).Model(&entity.QuizPlayer{}).
+		Select("quiz_players.id AS player_id, quiz_players.nickname, "+
+			"COALESCE(SUM(quiz_answers.points), 0) AS score, "+
+			"COALESCE(SUM(CASE WHEN quiz_answers.correct THEN 1 ELSE 0 END), 0) AS correct").
+		Joins("LEFT JOIN quiz_answers ON quiz_answers.player_id = quiz_players.id").
+		Where("quiz_players.quiz_id = ?", quizID).
+		Group("quiz_players.id, quiz_players.nickname").
+		Scan(&scores).Error
+	return scores, err
+}
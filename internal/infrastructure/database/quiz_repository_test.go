@@ -0,0 +1,103 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+func TestQuizRepository(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewQuizRepository(db)
+
+	quiz := &entity.Quiz{Title: "Onboarding quiz", CreatedBy: "creator", Questions: []entity.Poll{
+		{Title: "Which command formats Go code?", Position: 0, TimeLimit: 20, Options: []entity.Option{
+			{Text: "go vet", Order: 0}, {Text: "gofmt", Order: 1, Correct: true},
+		}},
+		{Title: "Which are Go keywords?", Position: 1, TimeLimit: 20, MultiChoice: true, Options: []entity.Option{
+			{Text: "defer", Order: 0, Correct: true}, {Text: "lambda", Order: 1},
+		}},
+	}}
+	require.NoError(t, repo.Create(ctx, quiz))
+
+	loaded, err := repo.GetByID(ctx, quiz.ID)
+	require.NoError(t, err)
+	require.Len(t, loaded.Questions, 2)
+	assert.Equal(t, quiz.ID, *loaded.Questions[1].QuizID)
+	assert.True(t, loaded.Questions[0].Options[1].Correct)
+	assert.Nil(t, loaded.CurrentQuestion)
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, entity.ErrQuizNotFound)
+
+	now := time.Now()
+	// Seule la question suivant la question en cours peut démarrer
+	started, err := repo.StartQuestion(ctx, quiz.ID, 1, now, now.Add(20*time.Second))
+	require.NoError(t, err)
+	assert.False(t, started)
+	started, err = repo.StartQuestion(ctx, quiz.ID, 0, now, now.Add(20*time.Second))
+	require.NoError(t, err)
+	assert.True(t, started)
+	started, err = repo.StartQuestion(ctx, quiz.ID, 0, now, now.Add(20*time.Second))
+	require.NoError(t, err)
+	assert.False(t, started)
+
+	ada := &entity.QuizPlayer{QuizID: quiz.ID, Nickname: "Ada"}
+	grace := &entity.QuizPlayer{QuizID: quiz.ID, Nickname: "Grace"}
+	require.NoError(t, repo.CreatePlayer(ctx, ada))
+	require.NoError(t, repo.CreatePlayer(ctx, grace))
+	assert.ErrorIs(t, repo.CreatePlayer(ctx, &entity.QuizPlayer{QuizID: quiz.ID, Nickname: "ADA"}), entity.ErrNicknameTaken)
+
+	player, err := repo.GetPlayer(ctx, quiz.ID, ada.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", player.Nickname)
+	_, err = repo.GetPlayer(ctx, uuid.New(), ada.ID)
+	assert.ErrorIs(t, err, entity.ErrPlayerNotFound)
+
+	first := loaded.Questions[0]
+	require.NoError(t, repo.CreateAnswer(ctx, &entity.QuizAnswer{
+		QuizID: quiz.ID, QuestionID: first.ID, PlayerID: ada.ID, OptionIDs: []uuid.UUID{first.Options[1].ID}, Correct: true, Points: 900,
+	}))
+	assert.ErrorIs(t, repo.CreateAnswer(ctx, &entity.QuizAnswer{
+		QuizID: quiz.ID, QuestionID: first.ID, PlayerID: ada.ID, OptionIDs: []uuid.UUID{first.Options[0].ID},
+	}), entity.ErrAlreadyAnswered)
+	require.NoError(t, repo.CreateAnswer(ctx, &entity.QuizAnswer{
+		QuizID: quiz.ID, QuestionID: first.ID, PlayerID: grace.ID, OptionIDs: []uuid.UUID{first.Options[0].ID},
+	}))
+
+	answers, err := repo.ListAnswers(ctx, first.ID)
+	require.NoError(t, err)
+	require.Len(t, answers, 2)
+	assert.Equal(t, []uuid.UUID{first.Options[1].ID}, answers[0].OptionIDs)
+
+	closed, err := repo.CloseQuestion(ctx, quiz.ID, 0, now.Add(10*time.Second))
+	require.NoError(t, err)
+	assert.True(t, closed)
+	closed, err = repo.CloseQuestion(ctx, quiz.ID, 0, now.Add(20*time.Second))
+	require.NoError(t, err)
+	assert.False(t, closed)
+
+	scores, err := repo.ListScores(ctx, quiz.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []entity.PlayerScore{
+		{PlayerID: ada.ID, Nickname: "Ada", Score: 900, Correct: 1},
+		{PlayerID: grace.ID, Nickname: "Grace"},
+	}, scores)
+
+	require.NoError(t, repo.Finish(ctx, quiz.ID, now.Add(time.Minute)))
+	loaded, err = repo.GetByID(ctx, quiz.ID)
+	require.NoError(t, err)
+	assert.True(t, loaded.IsFinished())
+	require.NotNil(t, loaded.QuestionClosedAt)
+	started, err = repo.StartQuestion(ctx, quiz.ID, 1, now, now.Add(20*time.Second))
+	require.NoError(t, err)
+	assert.False(t, started)
+}
@@ -0,0 +1,258 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/metrics"
+)
+
+const (
+	replicaPingTimeout          = 2 * time.Second
+	defaultReplicaCheckInterval = 5 * time.Second
+)
+
+type primaryKey struct{}
+
+// WithPrimary forces the reads made with ctx onto the primary, for callers
+// that must observe their own writes (e.g. the results broadcast after a vote)
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func primaryRequested(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// ReplicaStatus is the health of one read replica as reported by /readyz
+type ReplicaStatus struct {
+	Name      string    `json:"name"`
+	Healthy   bool      `json:"healthy"`
+	LastError string    `json:"last_error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Replica is a named read-only connection pool
+type Replica struct {
+	Name string
+	DB   *gorm.DB
+}
+
+type replica struct {
+	name      string
+	db        *gorm.DB
+	healthy   atomic.Bool
+	lastError atomic.Value // string
+	checkedAt atomic.Int64
+}
+
+func (r *replica) setHealthy(healthy bool, err error) {
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	r.lastError.Store(message)
+	r.checkedAt.Store(time.Now().UnixNano())
+
+	if r.healthy.Swap(healthy) != healthy {
+		if healthy {
+			slog.Info("database replica is back", "replica", r.name)
+		} else {
+			slog.Warn("database replica marked unhealthy, reads fall back to the primary", "replica", r.name, "error", err)
+		}
+	}
+	up := 0.0
+	if healthy {
+		up = 1
+	}
+	metrics.DBReplicaUp.WithLabelValues(r.name).Set(up)
+}
+
+// Resolver routes read-only queries to healthy read replicas in round-robin
+// and everything else to the primary. Without replicas every query hits the primary.
+type Resolver struct {
+	primary  *gorm.DB
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+// NewResolver creates a resolver over primary and the named replicas.
+// Replicas start unhealthy until their first successful health check.
+func NewResolver(primary *gorm.DB, replicas ...Replica) *Resolver {
+	r := &Resolver{primary: primary}
+	for _, named := range replicas {
+		rep := &replica{name: named.Name, db: named.DB}
+		rep.setHealthy(false, errors.New("not checked yet"))
+		r.replicas = append(r.replicas, rep)
+	}
+	return r
+}
+
+// OpenReplicas opens one lazy pool per entry of cfg.Replicas, reusing the
+// credentials and options of the primary
+func OpenReplicas(cfg *config.DatabaseConfig) ([]Replica, error) {
+	replicas := make([]Replica, 0, len(cfg.Replicas))
+	for i, entry := range cfg.Replicas {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		replicaCfg, name, err := replicaConfig(cfg, entry, i+1)
+		if err != nil {
+			return nil, err
+		}
+		db, err := open(replicaCfg, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open replica %s: %w", name, err)
+		}
+		replicas = append(replicas, Replica{Name: name, DB: db})
+	}
+	return replicas, nil
+}
+
+// replicaConfig derives the configuration of a replica from the primary one.
+// entry is either a raw DSN/URL, named by its position so that credentials
+// never reach logs and metrics, or a host with an optional port.
+func replicaConfig(primary *config.DatabaseConfig, entry string, position int) (*config.DatabaseConfig, string, error) {
+	cfg := *primary
+	cfg.Replicas = nil
+
+	if strings.Contains(entry, "://") || strings.Contains(entry, "=") || strings.Contains(entry, "@") {
+		cfg.DSN = entry
+		return &cfg, fmt.Sprintf("replica-%d", position), nil
+	}
+
+	cfg.DSN = ""
+	cfg.Host = entry
+	if host, port, err := net.SplitHostPort(entry); err == nil {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid replica port in %q", entry)
+		}
+		cfg.Host, cfg.Port = host, p
+	}
+	return &cfg, fmt.Sprintf("%s:%d", cfg.Host, cfg.Port), nil
+}
+
+// Primary returns the connection used for writes
+func (r *Resolver) Primary() *gorm.DB {
+	return r.primary
+}
+
+// Reader returns a healthy replica for ctx, or the primary when none is
+// available or WithPrimary was used
+func (r *Resolver) Reader(ctx context.Context) *gorm.DB {
+	if rep := r.pick(ctx); rep != nil {
+		return rep.db.WithContext(ctx)
+	}
+	return r.primary.WithContext(ctx)
+}
+
+func (r *Resolver) pick(ctx context.Context) *replica {
+	if len(r.replicas) == 0 || primaryRequested(ctx) {
+		return nil
+	}
+	start := r.next.Add(1)
+	for i := range r.replicas {
+		rep := r.replicas[(start+uint64(i))%uint64(len(r.replicas))]
+		if rep.healthy.Load() {
+			return rep
+		}
+	}
+	return nil
+}
+
+// Read runs query on a replica and retries it on the primary when the replica
+// fails, or misses a record that may not be replicated yet
+func (r *Resolver) Read(ctx context.Context, query func(db *gorm.DB) error) error {
+	rep := r.pick(ctx)
+	if rep == nil {
+		return query(r.primary.WithContext(ctx))
+	}
+
+	err := query(rep.db.WithContext(ctx))
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		rep.setHealthy(false, err)
+		metrics.DBReplicaFallbacks.WithLabelValues(rep.name).Inc()
+	}
+	return query(r.primary.WithContext(ctx))
+}
+
+// Run checks the replicas every interval until ctx is cancelled
+func (r *Resolver) Run(ctx context.Context, interval time.Duration) {
+	if len(r.replicas) == 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = defaultReplicaCheckInterval
+	}
+	r.CheckReplicas(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.CheckReplicas(ctx)
+		}
+	}
+}
+
+// CheckReplicas pings every replica and updates its health
+func (r *Resolver) CheckReplicas(ctx context.Context) {
+	for _, rep := range r.replicas {
+		err := ping(ctx, rep.db)
+		rep.setHealthy(err == nil, err)
+	}
+}
+
+func ping(ctx context.Context, db *gorm.DB) error {
+	ctx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+	defer cancel()
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// ReplicaStatuses reports the health of every replica
+func (r *Resolver) ReplicaStatuses() []ReplicaStatus {
+	statuses := make([]ReplicaStatus, 0, len(r.replicas))
+	for _, rep := range r.replicas {
+		lastError, _ := rep.lastError.Load().(string)
+		statuses = append(statuses, ReplicaStatus{
+			Name:      rep.name,
+			Healthy:   rep.healthy.Load(),
+			LastError: lastError,
+			CheckedAt: time.Unix(0, rep.checkedAt.Load()).UTC(),
+		})
+	}
+	return statuses
+}
+
+// Close closes the replica pools; the primary is owned by the caller
+func (r *Resolver) Close() {
+	for _, rep := range r.replicas {
+		if sqlDB, err := rep.db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+// seedPoll migrates db and stores a poll titled title, bypassing the ID generation hook
+func seedPoll(t *testing.T, db *gorm.DB, id uuid.UUID, title string) {
+	t.Helper()
+	require.NoError(t, database.Migrate(db))
+	require.NoError(t, db.Exec("INSERT INTO polls (id, title, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)", id.String(), title).Error)
+}
+
+func TestResolver_RoutesReadsToHealthyReplica(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	primary, replica := openSQLite(t), openSQLite(t)
+	seedPoll(t, primary, id, "primary")
+	seedPoll(t, replica, id, "replica")
+
+	reads := database.NewResolver(primary, database.Replica{Name: "replica-1", DB: replica})
+	repo := database.NewPollRepository(reads)
+
+	// Tant que le réplica n'a pas été vérifié, le primaire est utilisé
+	poll, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", poll.Title)
+
+	reads.CheckReplicas(ctx)
+	poll, err = repo.GetByIDWithResults(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "replica", poll.Title)
+
+	poll, err = repo.GetByID(database.WithPrimary(ctx), id)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", poll.Title)
+}
+
+func TestResolver_FallsBackToPrimary(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	primary, replica := openSQLite(t), openSQLite(t)
+	seedPoll(t, primary, id, "primary")
+	require.NoError(t, database.Migrate(replica))
+
+	reads := database.NewResolver(primary, database.Replica{Name: "replica-1", DB: replica})
+	reads.CheckReplicas(ctx)
+	require.True(t, reads.ReplicaStatuses()[0].Healthy)
+	repo := database.NewPollRepository(reads)
+
+	// Le sondage n'est pas encore répliqué : relu sur le primaire
+	poll, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", poll.Title)
+	assert.True(t, reads.ReplicaStatuses()[0].Healthy)
+
+	// Un réplica en erreur est écarté jusqu'au prochain check réussi
+	sqlDB, err := replica.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	polls, err := repo.List(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, polls, 1)
+	assert.False(t, reads.ReplicaStatuses()[0].Healthy)
+
+	reads.CheckReplicas(ctx)
+	assert.False(t, reads.ReplicaStatuses()[0].Healthy)
+	assert.NotEmpty(t, reads.ReplicaStatuses()[0].LastError)
+}
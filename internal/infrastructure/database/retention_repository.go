@@ -0,0 +1,358 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/privacy"
+)
+
+const (
+	// rawVoterID matches rows whose voter ID is still stored in clear
+	rawVoterID = "voter_id <> '' AND voter_id NOT LIKE 'sha256:%' AND voter_id NOT LIKE 'hmac:%'"
+	// rawClientData matches rows still holding a clear IP address or user agent
+	rawClientData = "(ip_address <> '' AND ip_address NOT LIKE 'sha256:%' AND ip_address NOT LIKE 'hmac:%') OR (user_agent <> '' AND user_agent NOT LIKE 'sha256:%')"
+)
+
+// voterTable is a table keeping the identity of the voters of a poll or a quiz
+type voterTable struct {
+	model interface{}
+	// owner is the column referencing the poll or the quiz whose salt hashed the voter ID
+	owner string
+	// clientData is set when the table also keeps the IP address and user agent
+	clientData bool
+}
+
+var voterTables = []voterTable{
+	{model: &entity.Vote{}, owner: "poll_id", clientData: true},
+	{model: &entity.TextResponse{}, owner: "poll_id"},
+	{model: &entity.Estimate{}, owner: "poll_id"},
+	{model: &entity.Availability{}, owner: "poll_id"},
+	{model: &entity.WaitlistEntry{}, owner: "poll_id", clientData: true},
+	{model: &entity.QuizPlayer{}, owner: "quiz_id", clientData: true},
+}
+
+// voterRow is the part of a voterTable row that retention anonymizes
+type voterRow struct {
+	ID        uuid.UUID
+	OwnerID   uuid.UUID
+	VoterID   string
+	IPAddress string
+	UserAgent string
+}
+
+type retentionRepository struct {
+	db       *gorm.DB
+	ipHasher *privacy.IPHasher
+}
+
+// NewRetentionRepository creates the repository; ipHasher must be the one used
+// to record ballots, nil when voter IP addresses are stored in clear
+func NewRetentionRepository(db *gorm.DB, ipHasher *privacy.IPHasher) repository.RetentionRepository {
+	return &retentionRepository{db: db, ipHasher: ipHasher}
+}
+
+func (r *retentionRepository) deletedPolls(ctx context.Context, before time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Unscoped().Model(&entity.Poll{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+}
+
+func (r *retentionRepository) deletedQuizzes(ctx context.Context, before time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Unscoped().Model(&entity.Quiz{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+}
+
+func (r *retentionRepository) CountDeletedPolls(ctx context.Context, before time.Time) (int64, error) {
+	var polls, quizzes int64
+	if err := r.deletedPolls(ctx, before).Count(&polls).Error; err != nil {
+		return 0, err
+	}
+	err := r.deletedQuizzes(ctx, before).Count(&quizzes).Error
+	return polls + quizzes, err
+}
+
+// PurgeDeletedPolls hard-deletes the polls soft-deleted before the cutoff with
+// their options, ballots and text responses, then the soft-deleted quizzes with
+// their questions and players. Children are removed explicitly so that the
+// purge does not depend on foreign key enforcement.
+func (r *retentionRepository) PurgeDeletedPolls(ctx context.Context, before time.Time, batchSize int) (int64, error) {
+	polls, err := r.purgePolls(ctx, before, batchSize)
+	if err != nil {
+		return polls, err
+	}
+	quizzes, err := r.purgeQuizzes(ctx, before, batchSize)
+	return polls + quizzes, err
+}
+
+func (r *retentionRepository) purgePolls(ctx context.Context, before time.Time, batchSize int) (int64, error) {
+	var total int64
+	for {
+		var ids []uuid.UUID
+		if err := r.deletedPolls(ctx, before).Limit(batchSize).Pluck("id", &ids).Error; err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("poll_id IN ?", ids).Delete(&entity.Vote{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("poll_id IN ?", ids).Delete(&entity.TextResponse{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("poll_id IN ?", ids).Delete(&entity.Estimate{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("poll_id IN ?", ids).Delete(&entity.Availability{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("poll_id IN ?", ids).Delete(&entity.WaitlistEntry{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("question_id IN ?", ids).Delete(&entity.QuizAnswer{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("poll_id IN ?", ids).Delete(&entity.Option{}).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("id IN ?", ids).Delete(&entity.Poll{}).Error
+		})
+		if err != nil {
+			return total, err
+		}
+		total += int64(len(ids))
+	}
+}
+
+func (r *retentionRepository) purgeQuizzes(ctx context.Context, before time.Time, batchSize int) (int64, error) {
+	var total int64
+	for {
+		var ids []uuid.UUID
+		if err := r.deletedQuizzes(ctx, before).Limit(batchSize).Pluck("id", &ids).Error; err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("quiz_id IN ?", ids).Delete(&entity.QuizAnswer{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("quiz_id IN ?", ids).Delete(&entity.QuizPlayer{}).Error; err != nil {
+				return err
+			}
+			questions := tx.Unscoped().Model(&entity.Poll{}).Select("id").Where("quiz_id IN ?", ids)
+			if err := tx.Unscoped().Where("poll_id IN (?)", questions).Delete(&entity.Option{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("quiz_id IN ?", ids).Delete(&entity.Poll{}).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("id IN ?", ids).Delete(&entity.Quiz{}).Error
+		})
+		if err != nil {
+			return total, err
+		}
+		total += int64(len(ids))
+	}
+}
+
+func (r *retentionRepository) voterData(ctx context.Context, table voterTable, before time.Time) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(table.model).Where("created_at < ?", before)
+	if table.clientData {
+		return query.Where(rawVoterID + " OR " + rawClientData)
+	}
+	return query.Where(rawVoterID)
+}
+
+func (r *retentionRepository) CountVoterData(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for _, table := range voterTables {
+		var count int64
+		if err := r.voterData(ctx, table, before).Count(&count).Error; err != nil {
+			return total, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// AnonymizeVoterData replaces the voter IDs stored in clear before the cutoff
+// with their anonymized form, which duplicate checks still recognize, and
+// clears or hashes the IP address and user agent of the ballots
+func (r *retentionRepository) AnonymizeVoterData(ctx context.Context, before time.Time, action repository.VoterDataAction, batchSize int) (int64, error) {
+	if action != repository.VoterDataClear && action != repository.VoterDataHash {
+		return 0, fmt.Errorf("unsupported voter data action: %s", action)
+	}
+
+	var total int64
+	for _, table := range voterTables {
+		anonymized, err := r.anonymizeTable(ctx, table, before, action, batchSize)
+		total += anonymized
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (r *retentionRepository) anonymizeTable(ctx context.Context, table voterTable, before time.Time, action repository.VoterDataAction, batchSize int) (int64, error) {
+	columns := []string{"id", table.owner + " AS owner_id", "voter_id"}
+	if table.clientData {
+		columns = append(columns, "ip_address", "user_agent")
+	}
+
+	var total int64
+	for {
+		var rows []voterRow
+		if err := r.voterData(ctx, table, before).Select(columns).Limit(batchSize).Scan(&rows).Error; err != nil {
+			return total, err
+		}
+		if len(rows) == 0 {
+			return total, nil
+		}
+
+		salts, err := r.salts(ctx, table, rows)
+		if err != nil {
+			return total, err
+		}
+
+		err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, row := range rows {
+				updates := map[string]interface{}{"voter_id": r.ipHasher.Anonymize(salts[row.OwnerID], row.VoterID)}
+				if table.clientData {
+					updates["ip_address"], updates["user_agent"] = "", ""
+					if action == repository.VoterDataHash {
+						updates["ip_address"] = privacy.Pseudonymize(row.IPAddress)
+						updates["user_agent"] = privacy.Pseudonymize(row.UserAgent)
+					}
+				}
+				if err := tx.Model(table.model).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		total += int64(len(rows))
+	}
+}
+
+// salts returns the salt that hashed the voter IDs of each poll or quiz of
+// rows: the one of the survey for the questions of a survey
+func (r *retentionRepository) salts(ctx context.Context, table voterTable, rows []voterRow) (map[uuid.UUID]string, error) {
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.OwnerID)
+	}
+
+	query := r.db.WithContext(ctx).Table("quizzes").Select("id, ip_salt AS salt").Where("id IN ?", ids)
+	if table.owner == "poll_id" {
+		query = r.db.WithContext(ctx).Table("polls").
+			Select("polls.id, COALESCE(surveys.ip_salt, polls.ip_salt) AS salt").
+			Joins("LEFT JOIN surveys ON surveys.id = polls.survey_id").
+			Where("polls.id IN ?", ids)
+	}
+
+	var owners []struct {
+		ID   uuid.UUID
+		Salt string
+	}
+	if err := query.Scan(&owners).Error; err != nil {
+		return nil, err
+	}
+
+	salts := make(map[uuid.UUID]string, len(owners))
+	for _, owner := range owners {
+		salts[owner.ID] = owner.Salt
+	}
+	return salts, nil
+}
+
+func (r *retentionRepository) archivablePolls(ctx context.Context, closedBefore time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&entity.Poll{}).
+		Where("archived_at IS NULL AND expires_at IS NOT NULL AND expires_at < ?", closedBefore)
+}
+
+func (r *retentionRepository) CountArchivableBallots(ctx context.Context, closedBefore time.Time) (int64, int64, error) {
+	var polls, ballots int64
+	if err := r.archivablePolls(ctx, closedBefore).Count(&polls).Error; err != nil {
+		return 0, 0, err
+	}
+	err := r.db.WithContext(ctx).Model(&entity.Vote{}).
+		Where("poll_id IN (?)", r.archivablePolls(ctx, closedBefore).Select("id")).
+		Count(&ballots).Error
+	return polls, ballots, err
+}
+
+// ArchiveClosedPolls folds the ballots of polls closed before the cutoff into
+// Option.ArchivedVotes and deletes them, one transaction per poll
+func (r *retentionRepository) ArchiveClosedPolls(ctx context.Context, closedBefore time.Time, batchSize int) (int64, int64, error) {
+	var polls, ballots int64
+	for {
+		var ids []uuid.UUID
+		if err := r.archivablePolls(ctx, closedBefore).Limit(batchSize).Pluck("id", &ids).Error; err != nil {
+			return polls, ballots, err
+		}
+		if len(ids) == 0 {
+			return polls, ballots, nil
+		}
+
+		for _, id := range ids {
+			archived, err := r.archivePoll(ctx, id)
+			if err != nil {
+				return polls, ballots, fmt.Errorf("failed to archive poll %s: %w", id, err)
+			}
+			polls++
+			ballots += archived
+		}
+	}
+}
+
+func (r *retentionRepository) archivePoll(ctx context.Context, pollID uuid.UUID) (int64, error) {
+	var archived int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var totals []struct {
+			OptionID uuid.UUID
+			Count    int64
+		}
+		err := tx.Model(&entity.Vote{}).
+			Select("option_id, COUNT(*) AS count").
+			Where("poll_id = ?", pollID).
+			Group("option_id").
+			Scan(&totals).Error
+		if err != nil {
+			return err
+		}
+
+		for _, total := range totals {
+			err := tx.Model(&entity.Option{}).Unscoped().
+				Where("id = ?", total.OptionID).
+				Update("archived_votes", gorm.Expr("archived_votes + ?", total.Count)).Error
+			if err != nil {
+				return err
+			}
+			archived += total.Count
+		}
+
+		if err := tx.Where("poll_id = ?", pollID).Delete(&entity.Vote{}).Error; err != nil {
+			return err
+		}
+		// Les listes d'attente n'ont plus d'objet une fois le sondage clos
+		if err := tx.Where("poll_id = ?", pollID).Delete(&entity.WaitlistEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.Poll{}).Where("id = ?", pollID).Update("archived_at", time.Now().UTC()).Error
+	})
+	return archived, err
+}
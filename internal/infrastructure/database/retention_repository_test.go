@@ -0,0 +1,298 @@
+package database_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/database"
+	"microservice-go-gin/internal/infrastructure/privacy"
+)
+
+// createPoll stores a poll with two options and one ballot per option
+func createPoll(t *testing.T, db *gorm.DB, expiresAt *time.Time) *entity.Poll {
+	t.Helper()
+	poll := &entity.Poll{
+		Title:     "Retention",
+		ExpiresAt: expiresAt,
+		Options:   []entity.Option{{Text: "A"}, {Text: "B"}},
+	}
+	require.NoError(t, db.Create(poll).Error)
+	for _, option := range poll.Options {
+		require.NoError(t, db.Create(&entity.Vote{
+			PollID:    poll.ID,
+			OptionID:  option.ID,
+			VoterID:   "voter",
+			IPAddress: "203.0.113.7",
+			UserAgent: "Mozilla/5.0",
+		}).Error)
+	}
+	return poll
+}
+
+func TestRetentionRepository_PurgeDeletedPolls(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewRetentionRepository(db, nil)
+
+	deleted := createPoll(t, db, nil)
+	kept := createPoll(t, db, nil)
+	require.NoError(t, db.Create(&entity.TextResponse{
+		PollID: deleted.ID, Text: "Gone", Status: entity.ResponseApproved, VoterID: "voter",
+	}).Error)
+	require.NoError(t, db.Create(&entity.Availability{
+		PollID: deleted.ID, OptionID: deleted.Options[0].ID, Name: "Alice", Answer: entity.AvailabilityYes, VoterID: "voter",
+	}).Error)
+	require.NoError(t, db.Create(&entity.WaitlistEntry{
+		PollID: deleted.ID, OptionID: deleted.Options[0].ID, Position: 1, VoterID: "voter", IPAddress: "203.0.113.7",
+	}).Error)
+	quiz := &entity.Quiz{Title: "Onboarding quiz"}
+	require.NoError(t, db.Create(quiz).Error)
+	player := &entity.QuizPlayer{QuizID: quiz.ID, Nickname: "Ada", VoterID: "voter"}
+	require.NoError(t, db.Create(player).Error)
+	require.NoError(t, db.Create(&entity.QuizAnswer{QuizID: quiz.ID, QuestionID: deleted.ID, PlayerID: player.ID}).Error)
+	require.NoError(t, db.Delete(deleted).Error)
+
+	deletedQuiz := &entity.Quiz{Title: "Old quiz", Questions: []entity.Poll{
+		{Title: "Which command formats Go code?", Options: []entity.Option{{Text: "gofmt", Correct: true}}},
+	}}
+	require.NoError(t, db.Create(deletedQuiz).Error)
+	gone := &entity.QuizPlayer{QuizID: deletedQuiz.ID, Nickname: "Grace", VoterID: "voter", IPAddress: "203.0.113.7"}
+	require.NoError(t, db.Create(gone).Error)
+	require.NoError(t, db.Create(&entity.QuizAnswer{QuizID: deletedQuiz.ID, QuestionID: deletedQuiz.Questions[0].ID, PlayerID: gone.ID}).Error)
+	require.NoError(t, db.Delete(deletedQuiz).Error)
+
+	count, err := repo.CountDeletedPolls(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	purged, err := repo.PurgeDeletedPolls(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	var votes int64
+	db.Model(&entity.Vote{}).Where("poll_id = ?", deleted.ID).Count(&votes)
+	assert.Zero(t, votes)
+	db.Model(&entity.Vote{}).Where("poll_id = ?", kept.ID).Count(&votes)
+	assert.Equal(t, int64(2), votes)
+
+	var responses int64
+	db.Model(&entity.TextResponse{}).Where("poll_id = ?", deleted.ID).Count(&responses)
+	assert.Zero(t, responses)
+
+	var availabilities int64
+	db.Model(&entity.Availability{}).Where("poll_id = ?", deleted.ID).Count(&availabilities)
+	assert.Zero(t, availabilities)
+
+	var waiting int64
+	db.Model(&entity.WaitlistEntry{}).Where("poll_id = ?", deleted.ID).Count(&waiting)
+	assert.Zero(t, waiting)
+
+	var answers int64
+	db.Model(&entity.QuizAnswer{}).Where("question_id = ?", deleted.ID).Count(&answers)
+	assert.Zero(t, answers)
+
+	// Un quiz supprimé disparaît avec ses questions, ses joueurs et leurs réponses
+	var players, questions int64
+	db.Model(&entity.QuizPlayer{}).Where("quiz_id = ?", deletedQuiz.ID).Count(&players)
+	assert.Zero(t, players)
+	db.Model(&entity.QuizAnswer{}).Where("quiz_id = ?", deletedQuiz.ID).Count(&answers)
+	assert.Zero(t, answers)
+	db.Unscoped().Model(&entity.Poll{}).Where("quiz_id = ?", deletedQuiz.ID).Count(&questions)
+	assert.Zero(t, questions)
+	db.Model(&entity.QuizPlayer{}).Where("quiz_id = ?", quiz.ID).Count(&players)
+	assert.Equal(t, int64(1), players)
+}
+
+// createAnswers stores a text response, an estimate, an availability, a
+// waitlist entry of voterID in poll and a quiz player of voterID
+func createAnswers(t *testing.T, db *gorm.DB, poll *entity.Poll, voterID string) *entity.Quiz {
+	t.Helper()
+	quiz := &entity.Quiz{Title: "Onboarding quiz"}
+	require.NoError(t, db.Create(quiz).Error)
+	require.NoError(t, db.Create(&entity.QuizPlayer{
+		QuizID: quiz.ID, Nickname: "Ada", VoterID: voterID, IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0",
+	}).Error)
+	require.NoError(t, db.Create(&entity.WaitlistEntry{
+		PollID: poll.ID, OptionID: poll.Options[1].ID, Position: 1, VoterID: voterID, IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0",
+	}).Error)
+	require.NoError(t, db.Create(&entity.TextResponse{
+		PollID: poll.ID, Text: "Faster onboarding", Status: entity.ResponseApproved, VoterID: voterID,
+	}).Error)
+	require.NoError(t, db.Create(&entity.Estimate{PollID: poll.ID, Value: 8, VoterID: voterID}).Error)
+	require.NoError(t, db.Create(&entity.Availability{
+		PollID: poll.ID, OptionID: poll.Options[0].ID, Name: "Alice", Answer: entity.AvailabilityYes, VoterID: voterID,
+	}).Error)
+	return quiz
+}
+
+// voterIDs returns the voter IDs stored in every table keeping one
+func voterIDs(t *testing.T, db *gorm.DB) map[string][]string {
+	t.Helper()
+	ids := make(map[string][]string)
+	for table, model := range map[string]interface{}{
+		"votes": &entity.Vote{}, "text_responses": &entity.TextResponse{},
+		"estimates": &entity.Estimate{}, "availabilities": &entity.Availability{},
+		"waitlist_entries": &entity.WaitlistEntry{}, "quiz_players": &entity.QuizPlayer{},
+	} {
+		var values []string
+		require.NoError(t, db.Model(model).Pluck("voter_id", &values).Error)
+		ids[table] = values
+	}
+	return ids
+}
+
+func TestRetentionRepository_AnonymizeVoterData(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewRetentionRepository(db, nil)
+	poll := createPoll(t, db, nil)
+	createAnswers(t, db, poll, "voter")
+
+	cutoff := time.Now().Add(time.Hour)
+	count, err := repo.CountVoterData(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+
+	anonymized, err := repo.AnonymizeVoterData(ctx, cutoff, repository.VoterDataHash, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), anonymized)
+
+	var votes []entity.Vote
+	require.NoError(t, db.Find(&votes).Error)
+	for _, vote := range votes {
+		assert.True(t, strings.HasPrefix(vote.IPAddress, "sha256:"))
+		assert.NotContains(t, vote.IPAddress, "203.0.113.7")
+	}
+	var entry entity.WaitlistEntry
+	require.NoError(t, db.First(&entry).Error)
+	assert.Equal(t, privacy.Pseudonymize("203.0.113.7"), entry.IPAddress)
+	assert.Equal(t, privacy.Pseudonymize("Mozilla/5.0"), entry.UserAgent)
+	var player entity.QuizPlayer
+	require.NoError(t, db.First(&player).Error)
+	assert.Equal(t, privacy.Pseudonymize("203.0.113.7"), player.IPAddress)
+	assert.Equal(t, privacy.Pseudonymize("Mozilla/5.0"), player.UserAgent)
+
+	// Sans clé, chaque table garde l'empreinte du votant, que les contrôles de doublon reconnaissent
+	for table, ids := range voterIDs(t, db) {
+		require.NotEmpty(t, ids, table)
+		for _, id := range ids {
+			assert.Equal(t, privacy.Pseudonymize("voter"), id, table)
+			assert.Contains(t, (*privacy.IPHasher)(nil).Candidates(poll.IPSalt, "voter"), id, table)
+		}
+	}
+
+	// Les valeurs déjà hachées ne sont pas retraitées
+	remaining, err := repo.CountVoterData(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+
+	_, err = repo.AnonymizeVoterData(ctx, cutoff, repository.VoterDataAction("encrypt"), 1)
+	assert.Error(t, err)
+}
+
+func TestRetentionRepository_AnonymizeVoterData_HashedIP(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	hasher := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"key"}})
+	repo := database.NewRetentionRepository(db, hasher)
+
+	// Des bulletins enregistrés en clair avant l'activation du hachage
+	poll := createPoll(t, db, nil)
+	quiz := createAnswers(t, db, poll, "voter")
+	survey := &entity.Survey{Title: "Offsite", Questions: []entity.Poll{
+		{Title: "Where?", Options: []entity.Option{{Text: "Lyon"}}},
+	}}
+	require.NoError(t, db.Create(survey).Error)
+	question := survey.Questions[0]
+	require.NoError(t, db.Create(&entity.Vote{
+		PollID: question.ID, OptionID: question.Options[0].ID, VoterID: "voter", IPAddress: "203.0.113.7",
+	}).Error)
+
+	anonymized, err := repo.AnonymizeVoterData(ctx, time.Now().Add(time.Hour), repository.VoterDataClear, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), anonymized)
+
+	var entry entity.WaitlistEntry
+	require.NoError(t, db.First(&entry).Error)
+	assert.Empty(t, entry.IPAddress)
+	assert.Empty(t, entry.UserAgent)
+
+	// Les joueurs d'un quiz sont salés par le quiz
+	var player entity.QuizPlayer
+	require.NoError(t, db.First(&player).Error)
+	assert.Empty(t, player.IPAddress)
+	assert.Equal(t, hasher.Identity(quiz.IPSalt, "voter"), player.VoterID)
+
+	var votes []entity.Vote
+	require.NoError(t, db.Find(&votes).Error)
+	for _, vote := range votes {
+		assert.Empty(t, vote.IPAddress)
+		assert.Empty(t, vote.UserAgent)
+		// Les questions d'un questionnaire sont salées par le questionnaire
+		salt := poll.IPSalt
+		if vote.PollID == question.ID {
+			salt = survey.IPSalt
+		}
+		assert.Equal(t, hasher.Identity(salt, "voter"), vote.VoterID)
+	}
+	for table, ids := range voterIDs(t, db) {
+		if table == "votes" || table == "quiz_players" {
+			continue
+		}
+		for _, id := range ids {
+			assert.Equal(t, hasher.Identity(poll.IPSalt, "voter"), id, table)
+		}
+	}
+}
+
+func TestRetentionRepository_ArchiveClosedPolls(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewRetentionRepository(db, nil)
+
+	closedAt := time.Now().Add(-48 * time.Hour)
+	closed := createPoll(t, db, &closedAt)
+	createPoll(t, db, nil)
+	require.NoError(t, db.Create(&entity.WaitlistEntry{
+		PollID: closed.ID, OptionID: closed.Options[0].ID, Position: 1, VoterID: "waiting",
+	}).Error)
+
+	polls, ballots, err := repo.CountArchivableBallots(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), polls)
+	assert.Equal(t, int64(2), ballots)
+
+	polls, ballots, err = repo.ArchiveClosedPolls(ctx, time.Now().Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), polls)
+	assert.Equal(t, int64(2), ballots)
+
+	// Les résultats restent identiques une fois les bulletins agrégés
+	results, err := database.NewPollRepository(database.NewResolver(db)).GetByIDWithResults(ctx, closed.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, results.ArchivedAt)
+	for _, option := range results.Options {
+		assert.Equal(t, 1, option.VoteCount)
+	}
+
+	// La liste d'attente d'un sondage clos disparaît avec ses bulletins
+	var waiting int64
+	db.Model(&entity.WaitlistEntry{}).Where("poll_id = ?", closed.ID).Count(&waiting)
+	assert.Zero(t, waiting)
+
+	polls, _, err = repo.ArchiveClosedPolls(ctx, time.Now().Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Zero(t, polls)
+}
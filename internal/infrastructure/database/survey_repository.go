@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type surveyRepository struct {
+	db    *gorm.DB
+	reads *Resolver
+}
+
+// NewSurveyRepository writes to the primary of reads and serves surveys
+// and their results from its replicas
+func NewSurveyRepository(reads *Resolver) repository.SurveyRepository {
+	return &surveyRepository{db: reads.Primary(), reads: reads}
+}
+
+func (r *surveyRepository) Create(ctx context.Context, survey *entity.Survey) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(survey).Error
+	})
+}
+
+func (r *surveyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Survey, error) {
+	var survey entity.Survey
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		survey = entity.Survey{}
+		return loadSurvey(db, &survey, id)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrSurveyNotFound
+		}
+		return nil, err
+	}
+	return &survey, nil
+}
+
+func (r *surveyRepository) GetByIDWithResults(ctx context.Context, id uuid.UUID) (*entity.Survey, error) {
+	var survey entity.Survey
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		survey = entity.Survey{}
+		if err := loadSurvey(db, &survey, id); err != nil {
+			return err
+		}
+
+		questionIDs := make([]uuid.UUID, 0, len(survey.Questions))
+		for _, question := range survey.Questions {
+			questionIDs = append(questionIDs, question.ID)
+		}
+		var counts []struct {
+			OptionID uuid.UUID
+			Count    int
+		}
+		err := db.Model(&entity.Vote{}).
+			Select("option_id, COUNT(*) AS count").
+			Where("poll_id IN ?", questionIDs).
+			Group("option_id").
+			Scan(&counts).Error
+		if err != nil {
+			return err
+		}
+		votes := make(map[uuid.UUID]int, len(counts))
+		for _, count := range counts {
+			votes[count.OptionID] = count.Count
+		}
+
+		for i := range survey.Questions {
+			options := survey.Questions[i].Options
+			for j := range options {
+				options[j].VoteCount = votes[options[j].ID] + options[j].ArchivedVotes
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.ErrSurveyNotFound
+		}
+		return nil, err
+	}
+	return &survey, nil
+}
+
+// loadSurvey reads the survey with its questions and options in display order
+func loadSurvey(db *gorm.DB, survey *entity.Survey, id uuid.UUID) error {
+	err := db.Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Questions.Options").First(survey, "id = ?", id).Error
+	if err != nil {
+		return err
+	}
+	for i := range survey.Questions {
+		options := survey.Questions[i].Options
+		sort.SliceStable(options, func(a, b int) bool {
+			return options[a].Order < options[b].Order
+		})
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+)
+
+type textResponseRepository struct {
+	db    *gorm.DB
+	reads *Resolver
+}
+
+// NewTextResponseRepository checks duplicates and moderates on the primary
+// of reads and serves listings and word clouds from its replicas
+func NewTextResponseRepository(reads *Resolver) repository.TextResponseRepository {
+	return &textResponseRepository{db: reads.Primary(), reads: reads}
+}
+
+func (r *textResponseRepository) Create(ctx context.Context, response *entity.TextResponse) error {
+	return r.db.WithContext(ctx).Create(response).Error
+}
+
+func (r *textResponseRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.TextResponse, error) {
+	var response entity.TextResponse
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&response).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, entity.ErrResponseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (r *textResponseRepository) HasResponded(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.TextResponse{}).
+		Where("poll_id = ? AND voter_id = ?", pollID, voterID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *textResponseRepository) ListByPoll(ctx context.Context, pollID uuid.UUID, status string, offset, limit int) ([]*entity.TextResponse, int64, error) {
+	var (
+		responses []*entity.TextResponse
+		total     int64
+	)
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		query := db.Model(&entity.TextResponse{}).Where("poll_id = ? AND status = ?", pollID, status)
+		if err := query.Count(&total).Error; err != nil {
+			return err
+		}
+		responses = nil
+		return db.Where("poll_id = ? AND status = ?", pollID, status).
+			Order("created_at DESC").
+			Order("id ASC").
+			Offset(offset).
+			Limit(limit).
+			Find(&responses).Error
+	})
+	return responses, total, err
+}
+
+func (r *textResponseRepository) ListTexts(ctx context.Context, pollID uuid.UUID, status string) ([]string, error) {
+	var texts []string
+	err := r.reads.Read(ctx, func(db *gorm.DB) error {
+		texts = nil
+		return db.Model(&entity.TextResponse{}).
+			Where("poll_id = ? AND status = ?", pollID, status).
+			Pluck("text", &texts).Error
+	})
+	return texts, err
+}
+
+func (r *textResponseRepository) UpdateStatus(ctx context.Context, response *entity.TextResponse) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.TextResponse{}).
+		Where("id = ?", response.ID).
+		Updates(map[string]interface{}{
+			"status":       response.Status,
+			"moderated_at": response.ModeratedAt,
+		}).Error
+}
@@ -2,9 +2,11 @@ package database
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"microservice-go-gin/internal/domain/entity"
 	"microservice-go-gin/internal/domain/repository"
 )
@@ -13,6 +15,8 @@ type voteRepository struct {
 	db *gorm.DB
 }
 
+// NewVoteRepository keeps every query on the primary: duplicate vote checks
+// must never read from a lagging replica
 func NewVoteRepository(db *gorm.DB) repository.VoteRepository {
 	return &voteRepository{db: db}
 }
@@ -21,6 +25,12 @@ func (r *voteRepository) Create(ctx context.Context, vote *entity.Vote) error {
 	return r.db.WithContext(ctx).Create(vote).Error
 }
 
+func (r *voteRepository) CreateBatch(ctx context.Context, votes []*entity.Vote) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&votes).Error
+	})
+}
+
 func (r *voteRepository) GetByPollAndVoter(ctx context.Context, pollID uuid.UUID, voterID string) ([]*entity.Vote, error) {
 	var votes []*entity.Vote
 	err := r.db.WithContext(ctx).
@@ -61,5 +71,301 @@ func (r *voteRepository) HasVoted(ctx context.Context, pollID uuid.UUID, voterID
 		Model(&entity.Vote{}).
 		Where("poll_id = ? AND voter_id = ?", pollID, voterID).
 		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	// Un votant en liste d'attente a déjà déposé son bulletin
+	err = r.db.WithContext(ctx).
+		Model(&entity.WaitlistEntry{}).
+		Where("poll_id = ? AND voter_id = ?", pollID, voterID).
+		Count(&count).Error
 	return count > 0, err
+}
+
+func (r *voteRepository) CreateWithinCapacity(ctx context.Context, votes []*entity.Vote, waitlist bool) ([]*entity.WaitlistEntry, error) {
+	var entries []*entity.WaitlistEntry
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		entries, err = createWithinCapacity(tx, votes, waitlist)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// CreateWithWriteIn locks the poll so that two write-ins sent at once can
+// neither duplicate an option nor go over MaxOptions
+func (r *voteRepository) CreateWithWriteIn(ctx context.Context, option *entity.Option, votes []*entity.Vote, waitlist bool) (*entity.WriteIn, error) {
+	var writeIn *entity.WriteIn
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		resolved, created, err := addWriteIn(tx, option)
+		if err != nil {
+			return err
+		}
+		if resolved.Pending && !created {
+			return entity.ErrOptionPending
+		}
+
+		// Une option déjà choisie par son identifiant ne reçoit pas un second vote
+		chosen := false
+		for _, vote := range votes {
+			chosen = chosen || vote.OptionID == resolved.ID
+		}
+		ballot := make([]*entity.Vote, 0, len(votes))
+		for _, vote := range votes {
+			if vote.OptionID == uuid.Nil {
+				if chosen {
+					continue
+				}
+				vote.OptionID = resolved.ID
+			}
+			ballot = append(ballot, vote)
+		}
+
+		entries, err := createWithinCapacity(tx, ballot, waitlist)
+		if err != nil {
+			return err
+		}
+		writeIn = &entity.WriteIn{Option: resolved, Created: created, Waitlisted: entries}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return writeIn, nil
+}
+
+func (r *voteRepository) Withdraw(ctx context.Context, pollID uuid.UUID, voterIDs []string) (*entity.Withdrawal, error) {
+	var withdrawal *entity.Withdrawal
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		withdrawal = &entity.Withdrawal{}
+		var votes []entity.Vote
+		if err := tx.Where("poll_id = ? AND voter_id IN ?", pollID, voterIDs).Find(&votes).Error; err != nil {
+			return err
+		}
+		var entries []entity.WaitlistEntry
+		if err := tx.Where("poll_id = ? AND voter_id IN ?", pollID, voterIDs).Find(&entries).Error; err != nil {
+			return err
+		}
+		if len(votes) == 0 && len(entries) == 0 {
+			return entity.ErrVoteNotFound
+		}
+
+		freed := make([]uuid.UUID, 0, len(votes))
+		for _, vote := range votes {
+			freed = append(freed, vote.OptionID)
+		}
+		options, err := lockOptions(tx, freed)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("poll_id = ? AND voter_id IN ?", pollID, voterIDs).Delete(&entity.Vote{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("poll_id = ? AND voter_id IN ?", pollID, voterIDs).Delete(&entity.WaitlistEntry{}).Error; err != nil {
+			return err
+		}
+
+		seen := make(map[uuid.UUID]bool)
+		for _, optionID := range freed {
+			if seen[optionID] {
+				continue
+			}
+			seen[optionID] = true
+			withdrawal.OptionIDs = append(withdrawal.OptionIDs, optionID)
+
+			option := options[optionID]
+			if option == nil || option.MaxVotes == nil {
+				continue
+			}
+			promoted, err := promote(tx, option)
+			if err != nil {
+				return err
+			}
+			withdrawal.Promoted = append(withdrawal.Promoted, promoted...)
+		}
+		for _, entry := range entries {
+			if !seen[entry.OptionID] {
+				seen[entry.OptionID] = true
+				withdrawal.OptionIDs = append(withdrawal.OptionIDs, entry.OptionID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return withdrawal, nil
+}
+
+// lockOptions loads the options with SELECT ... FOR UPDATE, in the order of
+// their IDs so that two ballots never wait on each other. SQLite ignores the
+// clause: its writers are serialized anyway.
+// createWithinCapacity saves votes, or queues those for full options when
+// waitlist is set, after locking the options they go to
+func createWithinCapacity(tx *gorm.DB, votes []*entity.Vote, waitlist bool) ([]*entity.WaitlistEntry, error) {
+	optionIDs := make([]uuid.UUID, len(votes))
+	for i, vote := range votes {
+		optionIDs[i] = vote.OptionID
+	}
+	options, err := lockOptions(tx, optionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*entity.WaitlistEntry
+	for _, vote := range votes {
+		full, err := optionFull(tx, options[vote.OptionID])
+		if err != nil {
+			return nil, err
+		}
+		if !full {
+			if err := tx.Create(vote).Error; err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !waitlist {
+			return nil, entity.ErrOptionFull
+		}
+		entry, err := enqueue(tx, vote)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// addWriteIn returns the option of the poll that duplicates option, whatever
+// the case, or saves option after the existing ones and reports it was
+// created. The poll stays locked until the end of tx.
+func addWriteIn(tx *gorm.DB, option *entity.Option) (*entity.Option, bool, error) {
+	var poll entity.Poll
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&poll, "id = ?", option.PollID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, entity.ErrPollNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var options []entity.Option
+	if err := tx.Where("poll_id = ?", option.PollID).Find(&options).Error; err != nil {
+		return nil, false, err
+	}
+	order := 0
+	for i := range options {
+		if entity.OptionKey(options[i].Text) == entity.OptionKey(option.Text) {
+			return &options[i], false, nil
+		}
+		if options[i].Order >= order {
+			order = options[i].Order + 1
+		}
+	}
+	if len(options) >= entity.MaxOptions {
+		return nil, false, entity.ErrOptionLimit
+	}
+
+	option.Order = order
+	if err := tx.Create(option).Error; err != nil {
+		return nil, false, err
+	}
+	return option, true, nil
+}
+
+func lockOptions(tx *gorm.DB, optionIDs []uuid.UUID) (map[uuid.UUID]*entity.Option, error) {
+	options := make(map[uuid.UUID]*entity.Option, len(optionIDs))
+	if len(optionIDs) == 0 {
+		return options, nil
+	}
+	var found []entity.Option
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", optionIDs).
+		Order("id").
+		Find(&found).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range found {
+		options[found[i].ID] = &found[i]
+	}
+	return options, nil
+}
+
+// optionFull reports whether a locked option has no places left; options
+// without a capacity never are
+func optionFull(tx *gorm.DB, option *entity.Option) (bool, error) {
+	if option == nil || option.MaxVotes == nil {
+		return false, nil
+	}
+	var count int64
+	err := tx.Model(&entity.Vote{}).Where("option_id = ?", option.ID).Count(&count).Error
+	return count >= int64(*option.MaxVotes), err
+}
+
+// enqueue adds the voter of a vote for a full option at the end of its
+// waitlist; the option must be locked
+func enqueue(tx *gorm.DB, vote *entity.Vote) (*entity.WaitlistEntry, error) {
+	var last struct {
+		Position int64
+		Count    int64
+	}
+	err := tx.Model(&entity.WaitlistEntry{}).
+		Select("COALESCE(MAX(position), 0) AS position, COUNT(*) AS count").
+		Where("option_id = ?", vote.OptionID).
+		Scan(&last).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &entity.WaitlistEntry{
+		PollID:    vote.PollID,
+		OptionID:  vote.OptionID,
+		Position:  last.Position + 1,
+		Rank:      int(last.Count) + 1,
+		VoterID:   vote.VoterID,
+		IPAddress: vote.IPAddress,
+		UserAgent: vote.UserAgent,
+	}
+	if err := tx.Create(entry).Error; err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// promote turns the first waitlist entries of a locked option into votes
+// until it is full again or its waitlist is empty
+func promote(tx *gorm.DB, option *entity.Option) ([]*entity.Vote, error) {
+	var promoted []*entity.Vote
+	for {
+		full, err := optionFull(tx, option)
+		if err != nil || full {
+			return promoted, err
+		}
+
+		var entry entity.WaitlistEntry
+		err = tx.Where("option_id = ?", option.ID).Order("position").First(&entry).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return promoted, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		vote := entry.Vote()
+		if err := tx.Create(vote).Error; err != nil {
+			return nil, err
+		}
+		if err := tx.Delete(&entry).Error; err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, vote)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,292 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/database"
+)
+
+func createSignUpSheet(t *testing.T, db *gorm.DB, capacity int) *entity.Poll {
+	t.Helper()
+	poll := &entity.Poll{
+		Title:    "Workshops",
+		Waitlist: true,
+		Options:  []entity.Option{{Text: "Kubernetes 101", MaxVotes: &capacity}, {Text: "Go tour", Order: 1}},
+	}
+	require.NoError(t, db.Create(poll).Error)
+	return poll
+}
+
+func signUp(poll *entity.Poll, voterID string) []*entity.Vote {
+	return []*entity.Vote{{PollID: poll.ID, OptionID: poll.Options[0].ID, VoterID: voterID, IPAddress: voterID}}
+}
+
+// writeIn is the ballot of voterID for optionIDs and for the option they write in
+func writeIn(poll *entity.Poll, voterID string, optionIDs ...uuid.UUID) []*entity.Vote {
+	votes := []*entity.Vote{{PollID: poll.ID, VoterID: voterID}}
+	for _, optionID := range optionIDs {
+		votes = append(votes, &entity.Vote{PollID: poll.ID, OptionID: optionID, VoterID: voterID})
+	}
+	return votes
+}
+
+func TestVoteRepository_CreateWithinCapacity(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewVoteRepository(db)
+	poll := createSignUpSheet(t, db, 1)
+
+	entries, err := repo.CreateWithinCapacity(ctx, signUp(poll, "alice"), true)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = repo.CreateWithinCapacity(ctx, signUp(poll, "bob"), true)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 1, entries[0].Rank)
+
+	entries, err = repo.CreateWithinCapacity(ctx, signUp(poll, "carol"), true)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 2, entries[0].Rank)
+
+	// Sans liste d'attente, le bulletin entier est refusé
+	votes := append(signUp(poll, "dave"), &entity.Vote{PollID: poll.ID, OptionID: poll.Options[1].ID, VoterID: "dave"})
+	_, err = repo.CreateWithinCapacity(ctx, votes, false)
+	assert.ErrorIs(t, err, entity.ErrOptionFull)
+	voted, err := repo.HasVoted(ctx, poll.ID, "dave")
+	require.NoError(t, err)
+	assert.False(t, voted)
+
+	// Une personne en liste d'attente a déjà voté
+	voted, err = repo.HasVoted(ctx, poll.ID, "carol")
+	require.NoError(t, err)
+	assert.True(t, voted)
+
+	reloaded, err := database.NewPollRepository(database.NewResolver(db)).GetByIDWithResults(ctx, poll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.OptionCapacity{{
+		OptionID: poll.Options[0].ID, MaxVotes: 1, Votes: 1, Remaining: 0, Waitlisted: 2,
+	}}, reloaded.Capacities())
+}
+
+func TestVoteRepository_Withdraw(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewVoteRepository(db)
+	poll := createSignUpSheet(t, db, 1)
+
+	for _, voter := range []string{"alice", "bob", "carol"} {
+		_, err := repo.CreateWithinCapacity(ctx, signUp(poll, voter), true)
+		require.NoError(t, err)
+	}
+
+	// Quitter la liste d'attente ne libère aucune place
+	withdrawal, err := repo.Withdraw(ctx, poll.ID, []string{"bob"})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{poll.Options[0].ID}, withdrawal.OptionIDs)
+	assert.Empty(t, withdrawal.Promoted)
+
+	// La place libérée revient à la première personne encore en attente
+	withdrawal, err = repo.Withdraw(ctx, poll.ID, []string{"alice"})
+	require.NoError(t, err)
+	require.Len(t, withdrawal.Promoted, 1)
+	assert.Equal(t, "carol", withdrawal.Promoted[0].VoterID)
+	assert.Equal(t, "carol", withdrawal.Promoted[0].IPAddress)
+
+	votes, err := repo.GetVotesByPoll(ctx, poll.ID)
+	require.NoError(t, err)
+	require.Len(t, votes, 1)
+	assert.Equal(t, "carol", votes[0].VoterID)
+	var waiting int64
+	db.Model(&entity.WaitlistEntry{}).Where("poll_id = ?", poll.ID).Count(&waiting)
+	assert.Zero(t, waiting)
+
+	_, err = repo.Withdraw(ctx, poll.ID, []string{"alice"})
+	assert.ErrorIs(t, err, entity.ErrVoteNotFound)
+}
+
+func TestVoteRepository_CreateWithinCapacity_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewConnection(&config.DatabaseConfig{
+		Type:         "sqlite",
+		Path:         filepath.Join(t.TempDir(), "quickpoll.db"),
+		MaxIdleConns: 8,
+		MaxOpenConns: 8,
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewVoteRepository(db)
+	poll := createSignUpSheet(t, db, 3)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 12)
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := repo.CreateWithinCapacity(ctx, signUp(poll, fmt.Sprintf("voter%d", i)), true)
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	count, err := repo.CountByOption(ctx, poll.Options[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	var positions []int64
+	db.Model(&entity.WaitlistEntry{}).Where("option_id = ?", poll.Options[0].ID).Order("position").Pluck("position", &positions)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9}, positions)
+}
+
+func TestVoteRepository_CreateWithWriteIn(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewVoteRepository(db)
+	poll := createPoll(t, db, nil)
+
+	votesOf := func(voterID string) []entity.Vote {
+		var votes []entity.Vote
+		require.NoError(t, db.Where("poll_id = ? AND voter_id = ?", poll.ID, voterID).Find(&votes).Error)
+		return votes
+	}
+
+	t.Run("existing option whatever the case", func(t *testing.T) {
+		result, err := repo.CreateWithWriteIn(ctx, &entity.Option{PollID: poll.ID, Text: " a "}, writeIn(poll, "alice"), false)
+
+		require.NoError(t, err)
+		assert.False(t, result.Created)
+		assert.Equal(t, poll.Options[0].ID, result.Option.ID)
+		votes := votesOf("alice")
+		require.Len(t, votes, 1)
+		assert.Equal(t, poll.Options[0].ID, votes[0].OptionID)
+	})
+
+	t.Run("one vote for an option also chosen by ID", func(t *testing.T) {
+		_, err := repo.CreateWithWriteIn(ctx, &entity.Option{PollID: poll.ID, Text: "A"}, writeIn(poll, "bob", poll.Options[0].ID), false)
+
+		require.NoError(t, err)
+		assert.Len(t, votesOf("bob"), 1)
+	})
+
+	t.Run("new option goes last", func(t *testing.T) {
+		result, err := repo.CreateWithWriteIn(ctx, &entity.Option{PollID: poll.ID, Text: "C", Pending: true}, writeIn(poll, "carol"), false)
+
+		require.NoError(t, err)
+		assert.True(t, result.Created)
+		assert.Equal(t, poll.Options[1].Order+1, result.Option.Order)
+
+		var stored entity.Option
+		require.NoError(t, db.First(&stored, "id = ?", result.Option.ID).Error)
+		assert.True(t, stored.Pending)
+		votes := votesOf("carol")
+		require.Len(t, votes, 1)
+		assert.Equal(t, stored.ID, votes[0].OptionID)
+	})
+
+	t.Run("pending option of another voter", func(t *testing.T) {
+		_, err := repo.CreateWithWriteIn(ctx, &entity.Option{PollID: poll.ID, Text: "c", Pending: true}, writeIn(poll, "dave"), false)
+
+		assert.ErrorIs(t, err, entity.ErrOptionPending)
+		assert.Empty(t, votesOf("dave"))
+	})
+
+	t.Run("cap counts pending options", func(t *testing.T) {
+		for i := 3; i < entity.MaxOptions; i++ {
+			_, err := repo.CreateWithWriteIn(ctx, &entity.Option{PollID: poll.ID, Text: fmt.Sprintf("Option %d", i)}, writeIn(poll, fmt.Sprintf("voter%d", i)), false)
+			require.NoError(t, err)
+		}
+
+		_, err := repo.CreateWithWriteIn(ctx, &entity.Option{PollID: poll.ID, Text: "One too many"}, writeIn(poll, "erin"), false)
+
+		assert.ErrorIs(t, err, entity.ErrOptionLimit)
+		assert.Empty(t, votesOf("erin"))
+	})
+}
+
+func TestVoteRepository_CreateWithWriteIn_RefusedBallot(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewVoteRepository(db)
+	poll := createSignUpSheet(t, db, 1)
+	_, err := repo.CreateWithinCapacity(ctx, signUp(poll, "alice"), false)
+	require.NoError(t, err)
+
+	// Atelier complet sans liste d'attente : l'option libre n'est pas créée
+	_, err = repo.CreateWithWriteIn(ctx, &entity.Option{PollID: poll.ID, Text: "Rust"}, writeIn(poll, "bob", poll.Options[0].ID), false)
+
+	assert.ErrorIs(t, err, entity.ErrOptionFull)
+	var options int64
+	require.NoError(t, db.Model(&entity.Option{}).Where("poll_id = ?", poll.ID).Count(&options).Error)
+	assert.Equal(t, int64(len(poll.Options)), options)
+	voted, err := repo.HasVoted(ctx, poll.ID, "bob")
+	require.NoError(t, err)
+	assert.False(t, voted)
+}
+
+func TestVoteRepository_CreateWithWriteIn_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewConnection(&config.DatabaseConfig{
+		Type:         "sqlite",
+		Path:         filepath.Join(t.TempDir(), "quickpoll.db"),
+		MaxIdleConns: 8,
+		MaxOpenConns: 8,
+	})
+	require.NoError(t, err)
+	require.NoError(t, database.Migrate(db))
+	repo := database.NewVoteRepository(db)
+	poll := createPoll(t, db, nil)
+
+	// Même option écrite avec des casses différentes, et plus d'options que la limite
+	texts := []string{"Zig", "zig", "ZIG", " zig "}
+	for i := 0; len(texts) < entity.MaxOptions+4; i++ {
+		texts = append(texts, fmt.Sprintf("Option %d", i))
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(texts))
+	for i, text := range texts {
+		wg.Add(1)
+		go func(i int, text string) {
+			defer wg.Done()
+			_, err := repo.CreateWithWriteIn(ctx, &entity.Option{PollID: poll.ID, Text: text}, writeIn(poll, fmt.Sprintf("voter%d", i)), false)
+			if !errors.Is(err, entity.ErrOptionLimit) {
+				errs <- err
+			}
+		}(i, text)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var options []entity.Option
+	require.NoError(t, db.Where("poll_id = ?", poll.ID).Find(&options).Error)
+	assert.Len(t, options, entity.MaxOptions)
+	keys := make(map[string]bool, len(options))
+	for _, option := range options {
+		assert.False(t, keys[entity.OptionKey(option.Text)], "duplicate option %q", option.Text)
+		keys[entity.OptionKey(option.Text)] = true
+	}
+}
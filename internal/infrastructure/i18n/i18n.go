@@ -0,0 +1,242 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+	"microservice-go-gin/internal/domain/apperror"
+)
+
+// DefaultLanguage is used when the configured default has no catalog
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Catalog holds the messages of one language, keyed by domain error code,
+// by "field.rule" (indexes and keys replaced by "[]", e.g. "options[].max") and by
+// validation rule. Messages may reference {field}, {param} and {index}.
+type Catalog struct {
+	Errors map[string]string `json:"errors"`
+	Fields map[string]string `json:"fields"`
+	Rules  map[string]string `json:"rules"`
+}
+
+// Bundle is the set of catalogs shipped with the service
+type Bundle struct {
+	catalogs map[string]*Catalog
+	tags     []language.Tag
+	matcher  language.Matcher
+	fallback string
+}
+
+// NewBundle loads the embedded catalogs. defaultLanguage answers requests
+// without a supported Accept-Language; an unknown value falls back to English.
+func NewBundle(defaultLanguage string) *Bundle {
+	b := &Bundle{catalogs: make(map[string]*Catalog)}
+	files, _ := locales.ReadDir("locales")
+	for _, file := range files {
+		lang := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		catalog, err := loadCatalog(file.Name())
+		if err != nil {
+			// Les catalogues sont embarqués : une erreur ici est un bug du build
+			panic(err)
+		}
+		b.catalogs[lang] = catalog
+	}
+
+	b.fallback = DefaultLanguage
+	if defaultLanguage != "" {
+		if _, ok := b.catalogs[defaultLanguage]; ok {
+			b.fallback = defaultLanguage
+		} else {
+			slog.Warn("no catalog for the default language, using English", "language", defaultLanguage)
+		}
+	}
+
+	// Le premier tag est celui choisi quand aucune langue demandée n'est disponible
+	b.tags = []language.Tag{language.Make(b.fallback)}
+	for _, lang := range b.Languages() {
+		if lang != b.fallback {
+			b.tags = append(b.tags, language.Make(lang))
+		}
+	}
+	b.matcher = language.NewMatcher(b.tags)
+	return b
+}
+
+func loadCatalog(name string) (*Catalog, error) {
+	raw, err := locales.ReadFile("locales/" + name)
+	if err != nil {
+		return nil, err
+	}
+	var catalog Catalog
+	if err := json.Unmarshal(raw, &catalog); err != nil {
+		return nil, fmt.Errorf("invalid catalog %s: %w", name, err)
+	}
+	return &catalog, nil
+}
+
+// Languages lists the languages that have a catalog
+func (b *Bundle) Languages() []string {
+	langs := make([]string, 0, len(b.catalogs))
+	for lang := range b.catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Match returns the supported language that best fits an Accept-Language header
+func (b *Bundle) Match(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return b.fallback
+	}
+	_, index, confidence := b.matcher.Match(tags...)
+	if confidence == language.No {
+		return b.fallback
+	}
+	base, _ := b.tags[index].Base()
+	return base.String()
+}
+
+// Negotiate returns the locale of available that best fits preference, an
+// Accept-Language header or a single locale such as "fr-CA". The first
+// available locale is the default.
+func Negotiate(preference string, available []string) string {
+	if len(available) == 0 {
+		return ""
+	}
+	tags, _, err := language.ParseAcceptLanguage(preference)
+	if err != nil || len(tags) == 0 {
+		return available[0]
+	}
+	supported := make([]language.Tag, len(available))
+	for i, locale := range available {
+		supported[i] = language.Make(locale)
+	}
+	_, index, confidence := language.NewMatcher(supported).Match(tags...)
+	if confidence == language.No {
+		return available[0]
+	}
+	return available[index]
+}
+
+// CanonicalLocale validates locale and returns its canonical BCP 47 form, e.g. "fr-CA"
+func CanonicalLocale(locale string) (string, bool) {
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// Localizer returns the translator of lang, falling back to the default language
+func (b *Bundle) Localizer(lang string) *Localizer {
+	catalog, ok := b.catalogs[lang]
+	if !ok {
+		lang, catalog = b.fallback, b.catalogs[b.fallback]
+	}
+	return &Localizer{lang: lang, catalog: catalog, fallback: b.catalogs[b.fallback]}
+}
+
+// Localizer translates domain errors into one language. Keys missing from
+// its catalog are looked up in the default language, then the message of
+// the error is kept. A nil Localizer keeps every message.
+type Localizer struct {
+	lang     string
+	catalog  *Catalog
+	fallback *Catalog
+}
+
+// Language is the language of the messages, empty for a nil Localizer
+func (l *Localizer) Language() string {
+	if l == nil {
+		return ""
+	}
+	return l.lang
+}
+
+// Error returns the message of the error code, or message when it has no translation
+func (l *Localizer) Error(code, message string) string {
+	if l == nil {
+		return message
+	}
+	if text, ok := l.lookup(func(c *Catalog) map[string]string { return c.Errors }, code); ok {
+		return text
+	}
+	return message
+}
+
+var (
+	keyPattern   = regexp.MustCompile(`\[[^\]]*\]`)
+	indexPattern = regexp.MustCompile(`\[(\d+)\]`)
+)
+
+// Field returns the message of a field that failed validation
+func (l *Localizer) Field(fieldError apperror.FieldError) string {
+	if l == nil {
+		return fieldError.Message
+	}
+
+	// options[1] -> options[], {index} valant 2 (numérotation affichée) ;
+	// translations[fr].title -> translations[].title
+	key := keyPattern.ReplaceAllString(fieldError.Field, "[]")
+	index := ""
+	if matches := indexPattern.FindAllStringSubmatch(fieldError.Field, -1); matches != nil {
+		n, _ := strconv.Atoi(matches[len(matches)-1][1])
+		index = strconv.Itoa(n + 1)
+	}
+
+	text, ok := l.lookup(func(c *Catalog) map[string]string { return c.Fields }, key+"."+fieldError.Rule)
+	if !ok {
+		text, ok = l.lookup(func(c *Catalog) map[string]string { return c.Rules }, fieldError.Rule)
+	}
+	if !ok {
+		// Règle sans message dédié (uuid, email...) : message générique
+		text, ok = l.lookup(func(c *Catalog) map[string]string { return c.Rules }, "invalid")
+	}
+	if !ok {
+		return fieldError.Message
+	}
+	return strings.NewReplacer(
+		"{field}", fieldError.Field,
+		"{param}", fieldError.Param,
+		"{index}", index,
+	).Replace(text)
+}
+
+func (l *Localizer) lookup(messages func(*Catalog) map[string]string, key string) (string, bool) {
+	for _, catalog := range []*Catalog{l.catalog, l.fallback} {
+		if catalog == nil {
+			continue
+		}
+		if text, ok := messages(catalog)[key]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+type contextKey struct{}
+
+// WithLocalizer stores the localizer of the request in ctx
+func WithLocalizer(ctx context.Context, l *Localizer) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the localizer of the request, nil if none was chosen
+func FromContext(ctx context.Context) *Localizer {
+	l, _ := ctx.Value(contextKey{}).(*Localizer)
+	return l
+}
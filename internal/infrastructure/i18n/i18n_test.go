@@ -0,0 +1,87 @@
+package i18n_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/infrastructure/i18n"
+)
+
+func TestBundle_Match(t *testing.T) {
+	bundle := i18n.NewBundle("en")
+	assert.Equal(t, []string{"en", "fr"}, bundle.Languages())
+
+	tests := map[string]string{
+		"":                        "en",
+		"fr":                      "fr",
+		"fr-CA,fr;q=0.9,en;q=0.8": "fr",
+		"de-DE,de;q=0.9,fr;q=0.5": "fr",
+		"en-GB,en;q=0.9":          "en",
+		"de":                      "en",
+		"not a language tag ;;; ": "en",
+	}
+	for header, want := range tests {
+		assert.Equal(t, want, bundle.Match(header), header)
+	}
+
+	assert.Equal(t, "fr", i18n.NewBundle("fr").Match("de"))
+	assert.Equal(t, "en", i18n.NewBundle("xx").Match("de"))
+}
+
+func TestLocalizer_Error(t *testing.T) {
+	fr := i18n.NewBundle("en").Localizer("fr")
+	assert.Equal(t, "fr", fr.Language())
+	assert.Equal(t, "sondage introuvable", fr.Error("poll_not_found", "poll not found"))
+	assert.Equal(t, "keep me", fr.Error("unknown_code", "keep me"))
+
+	var none *i18n.Localizer
+	assert.Equal(t, "poll not found", none.Error("poll_not_found", "poll not found"))
+	assert.Nil(t, i18n.FromContext(context.Background()))
+}
+
+func TestLocalizer_Field(t *testing.T) {
+	fr := i18n.NewBundle("en").Localizer("fr")
+
+	assert.Equal(t, "l'option 2 ne peut pas être vide", fr.Field(apperror.FieldError{
+		Field: "options[1]", Rule: "required", Message: "option 2 cannot be empty",
+	}))
+	assert.Equal(t, "le titre doit contenir au moins 3 caractères", fr.Field(apperror.FieldError{
+		Field: "title", Rule: "min", Param: "3",
+	}))
+	// Les clés entre crochets sont ignorées, seul l'index numérique est affiché
+	assert.Equal(t, "l'option traduite 2 ne peut pas être vide", fr.Field(apperror.FieldError{
+		Field: "translations[de].options[1]", Rule: "required",
+	}))
+	// Champ sans message dédié : message de la règle
+	assert.Equal(t, "le champ note est obligatoire", fr.Field(apperror.FieldError{
+		Field: "note", Rule: "required",
+	}))
+	assert.Equal(t, "le champ email est invalide", fr.Field(apperror.FieldError{
+		Field: "email", Rule: "email",
+	}))
+}
+
+// Chaque catalogue doit traduire les mêmes clés
+func TestCatalogs_SameKeys(t *testing.T) {
+	keys := func(lang string) map[string][]string {
+		raw, err := os.ReadFile("locales/" + lang + ".json")
+		require.NoError(t, err)
+		var catalog map[string]map[string]string
+		require.NoError(t, json.Unmarshal(raw, &catalog))
+		sections := make(map[string][]string)
+		for section, messages := range catalog {
+			for key := range messages {
+				sections[section] = append(sections[section], key)
+			}
+			sort.Strings(sections[section])
+		}
+		return sections
+	}
+	assert.Equal(t, keys("en"), keys("fr"))
+}
@@ -0,0 +1,95 @@
+// Package ics writes iCalendar (RFC 5545) files, to add the winning slot of
+// a scheduling poll to a calendar
+package ics
+
+import (
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of an iCalendar file
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineLength bounds a content line in octets, CRLF excluded
+const maxLineLength = 75
+
+// Event is a single calendar event
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	// Stamp is when the event was generated
+	Stamp time.Time
+}
+
+// Encode renders event as an iCalendar file with a single VEVENT. Times
+// are written in UTC so that no VTIMEZONE component is needed.
+func Encode(event Event) []byte {
+	var b strings.Builder
+	line := func(name, value string) {
+		writeFolded(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//QuickPoll//Scheduling//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("BEGIN", "VEVENT")
+	line("UID", escape(event.UID))
+	line("DTSTAMP", formatTime(event.Stamp))
+	line("DTSTART", formatTime(event.Start))
+	line("DTEND", formatTime(event.End))
+	line("SUMMARY", escape(event.Summary))
+	if event.Description != "" {
+		line("DESCRIPTION", escape(event.Description))
+	}
+	if event.URL != "" {
+		line("URL", event.URL)
+	}
+	line("END", "VEVENT")
+	line("END", "VCALENDAR")
+	return []byte(b.String())
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escape protects the characters with a meaning in TEXT values
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(text)
+}
+
+// writeFolded writes a content line, folded every 75 octets without
+// splitting a UTF-8 sequence; continuation lines start with a space
+func writeFolded(b *strings.Builder, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// L'espace de continuation compte dans la longueur de la ligne
+		limit = maxLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
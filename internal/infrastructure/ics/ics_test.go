@@ -0,0 +1,54 @@
+package ics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"microservice-go-gin/internal/infrastructure/ics"
+)
+
+func TestEncode(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+
+	calendar := string(ics.Encode(ics.Event{
+		UID:         "550e8400-e29b-41d4-a716-446655440001@quickpoll",
+		Summary:     "Sprint review; planning, retro",
+		Description: "Line one\nLine two",
+		URL:         "http://localhost:8080/poll/550e8400-e29b-41d4-a716-446655440000",
+		Start:       time.Date(2024, 1, 15, 10, 0, 0, 0, paris),
+		End:         time.Date(2024, 1, 15, 11, 30, 0, 0, paris),
+		Stamp:       time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC),
+	}))
+
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(calendar, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, calendar, "\r\nDTSTART:20240115T090000Z\r\n")
+	assert.Contains(t, calendar, "\r\nDTEND:20240115T103000Z\r\n")
+	assert.Contains(t, calendar, "\r\nDTSTAMP:20240110T080000Z\r\n")
+	assert.Contains(t, calendar, `SUMMARY:Sprint review\; planning\, retro`)
+	assert.Contains(t, calendar, `DESCRIPTION:Line one\nLine two`)
+}
+
+func TestEncode_FoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("Réunion d'équipe ", 12)
+
+	calendar := string(ics.Encode(ics.Event{UID: "uid", Summary: summary}))
+
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+		assert.True(t, utf8.ValidString(line), "folding must not split a character")
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+		} else {
+			unfolded.WriteString("\n" + line)
+		}
+	}
+	assert.Contains(t, unfolded.String(), "\nSUMMARY:"+summary+"\n")
+}
@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// Redacted replaces the value of sensitive attributes
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys lists attribute keys whose values must never reach the logs
+var sensitiveKeys = map[string]bool{
+	"ip":            true,
+	"ip_address":    true,
+	"client_ip":     true,
+	"remote_addr":   true,
+	"voter_id":      true,
+	"user_agent":    true,
+	"authorization": true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+type requestIDKey struct{}
+
+// New builds the application logger from the app configuration.
+// Production defaults to JSON output, other environments to text.
+func New(cfg config.AppConfig) *slog.Logger {
+	return NewWithWriter(cfg, os.Stdout)
+}
+
+// NewWithWriter is like New but writes to w
+func NewWithWriter(cfg config.AppConfig, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       ParseLevel(cfg.LogLevel),
+		ReplaceAttr: redact,
+	}
+
+	format := strings.ToLower(cfg.LogFormat)
+	if format == "" {
+		format = "text"
+		if cfg.Environment == "production" {
+			format = "json"
+		}
+	}
+
+	var handler slog.Handler
+	if format == "json" {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}).With(
+		"service", cfg.Name,
+		"version", cfg.Version,
+	)
+}
+
+// ParseLevel converts a textual level to a slog.Level, defaulting to info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, if any
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler enriches every record with values carried by the context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if traceID, spanID := tracing.IDs(ctx); traceID != "" {
+		r.AddAttrs(slog.String("trace_id", traceID), slog.String("span_id", spanID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// redact masks the value of sensitive attributes, whatever group they are in
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, Redacted)
+	}
+	return a
+}
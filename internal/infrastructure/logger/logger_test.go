@@ -0,0 +1,54 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/logger"
+)
+
+func TestNew_JSONWithRequestIDAndRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewWithWriter(config.AppConfig{Name: "QuickPoll", Environment: "production", LogLevel: "info"}, &buf)
+
+	ctx := logger.WithRequestID(context.Background(), "req-123")
+	log.InfoContext(ctx, "vote recorded", "poll_id", "p1", "ip_address", "192.168.1.10", "user_agent", "curl/8.0")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+
+	assert.Equal(t, "vote recorded", record["msg"])
+	assert.Equal(t, "req-123", record["request_id"])
+	assert.Equal(t, "p1", record["poll_id"])
+	assert.Equal(t, logger.Redacted, record["ip_address"])
+	assert.Equal(t, logger.Redacted, record["user_agent"])
+	assert.NotContains(t, buf.String(), "192.168.1.10")
+}
+
+func TestNew_LevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewWithWriter(config.AppConfig{Environment: "development", LogLevel: "warn"}, &buf)
+
+	log.Info("hidden")
+	assert.Empty(t, buf.String())
+
+	log.Warn("visible")
+	assert.Contains(t, buf.String(), "msg=visible")
+}
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, logger.ParseLevel("DEBUG"))
+	assert.Equal(t, slog.LevelWarn, logger.ParseLevel("warning"))
+	assert.Equal(t, slog.LevelError, logger.ParseLevel("error"))
+	assert.Equal(t, slog.LevelInfo, logger.ParseLevel("unknown"))
+}
+
+func TestRequestIDFromContext_Missing(t *testing.T) {
+	assert.Empty(t, logger.RequestIDFromContext(context.Background()))
+}
@@ -0,0 +1,189 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "quickpoll"
+
+// Vote rejection reasons used as the "reason" label of VotesRejected
+const (
+	ReasonPollNotFound   = "poll_not_found"
+	ReasonPollExpired    = "poll_expired"
+	ReasonAuthRequired   = "auth_required"
+	ReasonAlreadyVoted   = "already_voted"
+	ReasonTooManyOptions = "too_many_options"
+	ReasonInvalidOption  = "invalid_option"
+	ReasonSurveyQuestion = "survey_question"
+	ReasonTextPoll       = "text_poll"
+	ReasonChoicePoll     = "choice_poll"
+	ReasonWriteIn        = "write_in_disabled"
+	ReasonOptionLimit    = "option_limit"
+	ReasonOptionPending  = "option_pending"
+	ReasonNumericPoll    = "numeric_poll"
+	ReasonNotNumeric     = "not_numeric_poll"
+
+	ReasonSchedulePoll = "schedule_poll"
+	ReasonNotSchedule  = "not_schedule_poll"
+
+	ReasonOptionFull   = "option_full"
+	ReasonQuizQuestion = "quiz_question"
+)
+
+// Outcomes of a quiz answer used as the "result" label of QuizAnswers
+const (
+	QuizAnswerCorrect = "correct"
+	QuizAnswerWrong   = "wrong"
+	QuizAnswerLate    = "late"
+)
+
+var (
+	// HTTPRequestsTotal counts handled HTTP requests by route template and status
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests handled.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes HTTP request latency by route template and status
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// PollsCreated counts successfully created polls
+	PollsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "polls_created_total",
+		Help:      "Total number of polls created.",
+	})
+
+	// VotesCast counts successfully submitted ballots
+	VotesCast = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "votes_cast_total",
+		Help:      "Total number of ballots successfully submitted.",
+	})
+
+	// SurveyResponses counts successfully submitted survey responses
+	SurveyResponses = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "survey_responses_total",
+		Help:      "Total number of survey responses successfully submitted.",
+	})
+
+	// TextResponses counts successfully submitted free-text responses
+	TextResponses = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "text_responses_total",
+		Help:      "Total number of free-text responses successfully submitted.",
+	})
+
+	// Estimates counts successfully submitted numeric estimates
+	Estimates = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "estimates_total",
+		Help:      "Total number of numeric estimates successfully submitted.",
+	})
+
+	// Availabilities counts the participants who answered a scheduling poll
+	Availabilities = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "availabilities_total",
+		Help:      "Total number of participants who answered a scheduling poll.",
+	})
+
+	// WaitlistEntries counts the voters queued for a full option of a sign-up sheet
+	WaitlistEntries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "waitlist_entries_total",
+		Help:      "Total number of voters put on the waitlist of a full option.",
+	})
+
+	// WaitlistPromotions counts the waitlisted voters given a freed place
+	WaitlistPromotions = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "waitlist_promotions_total",
+		Help:      "Total number of waitlisted voters promoted to a freed place.",
+	})
+
+	// VotesWithdrawn counts the ballots withdrawn by their voter
+	VotesWithdrawn = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "votes_withdrawn_total",
+		Help:      "Total number of ballots withdrawn by their voter.",
+	})
+
+	// QuizPlayers counts the players who joined a quiz
+	QuizPlayers = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "quiz_players_total",
+		Help:      "Total number of players who joined a quiz.",
+	})
+
+	// QuizAnswers counts the answers to quiz questions, by result: correct,
+	// wrong, or late when rejected after the time limit
+	QuizAnswers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "quiz_answers_total",
+		Help:      "Total number of answers to quiz questions, by result.",
+	}, []string{"result"})
+
+	// VotesRejected counts rejected ballots by reason
+	VotesRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "votes_rejected_total",
+		Help:      "Total number of ballots rejected, by reason.",
+	}, []string{"reason"})
+
+	// WebSocketClients tracks currently connected websocket clients
+	WebSocketClients = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "websocket_clients",
+		Help:      "Number of connected websocket clients.",
+	})
+
+	// WebSocketRooms tracks polls with at least one connected websocket client
+	WebSocketRooms = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "websocket_rooms",
+		Help:      "Number of polls with at least one websocket subscriber.",
+	})
+
+	// WebSocketDroppedClients counts clients disconnected because their send buffer was full
+	WebSocketDroppedClients = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "websocket_dropped_clients_total",
+		Help:      "Total number of slow websocket clients dropped by the hub.",
+	})
+
+	// DBReplicaUp reports 1 when a read replica passes its health check
+	DBReplicaUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "db_replica_up",
+		Help:      "Whether the read replica is healthy (1) or bypassed (0).",
+	}, []string{"replica"})
+
+	// DBReplicaFallbacks counts reads retried on the primary after a replica error
+	DBReplicaFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_replica_fallbacks_total",
+		Help:      "Total number of reads retried on the primary after a replica failure.",
+	}, []string{"replica"})
+
+	// RetentionRows counts rows purged, anonymized or archived by the retention job
+	RetentionRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "retention_rows_total",
+		Help:      "Total number of rows processed by the retention policies, by policy.",
+	}, []string{"policy"})
+)
+
+// RegisterDBStats exposes the connection pool statistics of db under the go_sql_* metrics
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+}
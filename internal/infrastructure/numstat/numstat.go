@@ -0,0 +1,94 @@
+package numstat
+
+import (
+	"math"
+	"sort"
+
+	"microservice-go-gin/internal/domain/entity"
+)
+
+// Results are the published results of a numeric poll: the estimate count
+// alone while they are hidden, their distribution otherwise
+func Results(poll *entity.Poll, values []float64) *entity.EstimateResults {
+	results := &entity.EstimateResults{Count: len(values), Hidden: poll.EstimatesHidden()}
+	if results.Hidden || poll.Numeric == nil {
+		return results
+	}
+	results.Stats = Describe(values, poll.Numeric.Min, poll.Numeric.Max, poll.Numeric.Buckets)
+	return results
+}
+
+// Describe summarizes values: mean, median, quartiles, population standard
+// deviation and a histogram of buckets of equal width between min and max.
+// Quartiles are interpolated between the closest ranks. It returns nil when
+// there are no values.
+func Describe(values []float64, min, max float64, buckets int) *entity.Distribution {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, value := range sorted {
+		sum += value
+	}
+	mean := sum / float64(len(sorted))
+	var squares float64
+	for _, value := range sorted {
+		squares += (value - mean) * (value - mean)
+	}
+
+	return &entity.Distribution{
+		Min:       sorted[0],
+		Max:       sorted[len(sorted)-1],
+		Mean:      mean,
+		Median:    Quantile(sorted, 0.5),
+		Q1:        Quantile(sorted, 0.25),
+		Q3:        Quantile(sorted, 0.75),
+		StdDev:    math.Sqrt(squares / float64(len(sorted))),
+		Histogram: Histogram(sorted, min, max, buckets),
+	}
+}
+
+// Quantile returns the q quantile of sorted values, interpolating linearly
+// between the closest ranks
+func Quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	rank := q * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// Histogram counts values in buckets of equal width between min and max;
+// the last bucket includes max. Values outside the range are left out.
+func Histogram(values []float64, min, max float64, buckets int) []entity.Bucket {
+	if buckets < 1 || max <= min {
+		buckets = 1
+	}
+	width := (max - min) / float64(buckets)
+	histogram := make([]entity.Bucket, buckets)
+	for i := range histogram {
+		histogram[i].From = min + float64(i)*width
+		histogram[i].To = min + float64(i+1)*width
+	}
+	histogram[buckets-1].To = max
+
+	for _, value := range values {
+		if value < min || value > max {
+			continue
+		}
+		i := buckets - 1
+		if width > 0 {
+			i = int((value - min) / width)
+		}
+		if i >= buckets {
+			i = buckets - 1
+		}
+		histogram[i].Count++
+	}
+	return histogram
+}
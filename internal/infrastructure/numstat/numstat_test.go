@@ -0,0 +1,77 @@
+package numstat_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/numstat"
+)
+
+func TestDescribe(t *testing.T) {
+	distribution := numstat.Describe([]float64{8, 1, 3, 5, 13, 2, 5, 3}, 0, 20, 4)
+
+	require.NotNil(t, distribution)
+	assert.Equal(t, 1.0, distribution.Min)
+	assert.Equal(t, 13.0, distribution.Max)
+	assert.Equal(t, 5.0, distribution.Mean)
+	assert.Equal(t, 4.0, distribution.Median)
+	assert.Equal(t, 2.75, distribution.Q1)
+	assert.Equal(t, 5.75, distribution.Q3)
+	assert.InDelta(t, 3.640, distribution.StdDev, 0.001)
+	assert.Equal(t, []entity.Bucket{
+		{From: 0, To: 5, Count: 4},
+		{From: 5, To: 10, Count: 3},
+		{From: 10, To: 15, Count: 1},
+		{From: 15, To: 20, Count: 0},
+	}, distribution.Histogram)
+}
+
+func TestDescribe_NoValues(t *testing.T) {
+	assert.Nil(t, numstat.Describe(nil, 0, 10, 5))
+}
+
+func TestQuantile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []float64
+		q      float64
+		want   float64
+	}{
+		{name: "single value", sorted: []float64{7}, q: 0.25, want: 7},
+		{name: "odd count median", sorted: []float64{1, 2, 9}, q: 0.5, want: 2},
+		{name: "even count median", sorted: []float64{1, 2, 4, 9}, q: 0.5, want: 3},
+		{name: "interpolated quartile", sorted: []float64{1, 2, 4, 9}, q: 0.75, want: 5.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, numstat.Quantile(tt.sorted, tt.q))
+		})
+	}
+}
+
+func TestHistogram_MaxInLastBucket(t *testing.T) {
+	histogram := numstat.Histogram([]float64{0, 10, 10, 11}, 0, 10, 2)
+
+	assert.Equal(t, []entity.Bucket{{From: 0, To: 5, Count: 1}, {From: 5, To: 10, Count: 2}}, histogram)
+}
+
+func TestResults_HiddenUntilRevealed(t *testing.T) {
+	poll := &entity.Poll{
+		Type:    entity.PollTypeNumeric,
+		Numeric: &entity.NumericSettings{Min: 0, Max: 13, Buckets: 13, HideUntilReveal: true},
+	}
+
+	hidden := numstat.Results(poll, []float64{3, 5})
+	assert.Equal(t, &entity.EstimateResults{Count: 2, Hidden: true}, hidden)
+
+	now := time.Now()
+	poll.RevealedAt = &now
+	revealed := numstat.Results(poll, []float64{3, 5})
+	assert.False(t, revealed.Hidden)
+	require.NotNil(t, revealed.Stats)
+	assert.Equal(t, 4.0, revealed.Stats.Mean)
+}
@@ -0,0 +1,160 @@
+package privacy
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/auth"
+)
+
+const (
+	// IPModeClear stores client IP addresses as received
+	IPModeClear = "clear"
+	// IPModeHMAC stores client IP addresses only as a keyed, per-poll salted HMAC
+	IPModeHMAC = "hmac"
+
+	// HMACPrefix marks values produced by IPHasher
+	HMACPrefix = "hmac:"
+
+	ipv6PrefixBits = 64
+)
+
+// IPHasher derives the stored identity of a client from its IP address.
+// A nil *IPHasher is valid and keeps addresses in clear.
+type IPHasher struct {
+	// keys[0] signs new values, older keys are only used to recognize
+	// the ballots recorded before a rotation
+	keys [][]byte
+}
+
+// NewIPHasher returns the hasher configured by cfg, or nil when IP addresses
+// are stored in clear
+func NewIPHasher(cfg config.PrivacyConfig) *IPHasher {
+	if cfg.IPMode != IPModeHMAC {
+		return nil
+	}
+	h := &IPHasher{}
+	for _, key := range cfg.IPHashKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			h.keys = append(h.keys, []byte(key))
+		}
+	}
+	return h
+}
+
+// Identity returns the value to persist for ip in the poll salted with salt
+func (h *IPHasher) Identity(salt, ip string) string {
+	if h == nil || ip == "" {
+		return ip
+	}
+	return sign(h.keys[0], salt, ip)
+}
+
+// Candidates returns every value ip may have been stored as in the poll
+// salted with salt, the current one first. Without a key this is the clear
+// value and the digest a retention pass replaces it with.
+func (h *IPHasher) Candidates(salt, ip string) []string {
+	if ip == "" {
+		return []string{ip}
+	}
+	if h == nil {
+		return []string{ip, Pseudonymize(ip)}
+	}
+	candidates := make([]string, 0, len(h.keys))
+	for _, key := range h.keys {
+		candidates = append(candidates, sign(key, salt, ip))
+	}
+	return candidates
+}
+
+// Recorded reports whether lookup finds ip in the poll salted with salt
+// under any of its candidate identities, so that rotating the hash key or
+// anonymizing old data does not open the door to a second answer
+func (h *IPHasher) Recorded(ctx context.Context, salt string, pollID uuid.UUID, ip string, lookup func(ctx context.Context, pollID uuid.UUID, identity string) (bool, error)) (bool, error) {
+	for _, candidate := range h.Candidates(salt, ip) {
+		found, err := lookup(ctx, pollID, candidate)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// IsCreator reports whether the caller created the poll, survey or quiz
+// recorded with createdBy and salted with salt: by the API key or JWT subject
+// of ctx when authenticated, else by the client IP address requester under
+// any identity it may have been stored as. What was recorded without a
+// creator belongs to nobody.
+func (h *IPHasher) IsCreator(ctx context.Context, createdBy, salt, requester string) bool {
+	if createdBy == "" {
+		return false
+	}
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		return principal.Actor == createdBy
+	}
+	if requester == "" {
+		return false
+	}
+	for _, candidate := range h.Candidates(salt, requester) {
+		if candidate == createdBy {
+			return true
+		}
+	}
+	return false
+}
+
+// Anonymize returns the value a retention pass keeps in place of a voter
+// identity stored in clear: the keyed identity when IP addresses are hashed,
+// else an unkeyed digest. Either way Candidates still finds it, so that
+// duplicate checks keep working. Values already hashed are returned unchanged.
+func (h *IPHasher) Anonymize(salt, value string) string {
+	if value == "" || strings.HasPrefix(value, HashedPrefix) || strings.HasPrefix(value, HMACPrefix) {
+		return value
+	}
+	if h == nil {
+		return Pseudonymize(value)
+	}
+	return h.Identity(salt, value)
+}
+
+// Pseudonym identifies value in logs and audit trails without revealing it.
+// The keyed hash is preferred: an unkeyed digest of an IPv4 address is easy to reverse.
+func (h *IPHasher) Pseudonym(value string) string {
+	if h == nil {
+		return Pseudonymize(value)
+	}
+	return h.Identity("", value)
+}
+
+// Enabled reports whether IP addresses are hashed
+func (h *IPHasher) Enabled() bool {
+	return h != nil
+}
+
+func sign(key []byte, salt, ip string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(salt))
+	mac.Write([]byte{0})
+	mac.Write([]byte(TruncateIP(ip)))
+	return HMACPrefix + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// TruncateIP keeps IPv4 addresses whole and reduces IPv6 addresses to their
+// /64 network, which a single subscriber usually controls entirely.
+// Values that are not IP addresses are returned unchanged.
+func TruncateIP(value string) string {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return value
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return v4.String()
+	}
+	return ip.Mask(net.CIDRMask(ipv6PrefixBits, 8*net.IPv6len)).String()
+}
@@ -0,0 +1,126 @@
+package privacy_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/privacy"
+)
+
+func TestTruncateIP(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "ipv4 kept whole", value: "203.0.113.7", want: "203.0.113.7"},
+		{name: "ipv4-mapped ipv6", value: "::ffff:203.0.113.7", want: "203.0.113.7"},
+		{name: "ipv6 truncated to /64", value: "2001:db8:1:2:3:4:5:6", want: "2001:db8:1:2::"},
+		{name: "not an address", value: "user-42", want: "user-42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, privacy.TruncateIP(tt.value))
+		})
+	}
+}
+
+func TestIPHasher(t *testing.T) {
+	hasher := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"current", " ", "previous"}})
+	require.True(t, hasher.Enabled())
+
+	identity := hasher.Identity("salt", "203.0.113.7")
+	assert.True(t, strings.HasPrefix(identity, privacy.HMACPrefix))
+	assert.NotContains(t, identity, "203.0.113.7")
+	assert.LessOrEqual(t, len(identity), 45, "must fit in votes.ip_address")
+	assert.Equal(t, identity, hasher.Identity("salt", "203.0.113.7"))
+
+	// Le sel change d'un sondage à l'autre : les identités ne sont pas reliables
+	assert.NotEqual(t, identity, hasher.Identity("other-salt", "203.0.113.7"))
+
+	// Deux adresses du même /64 ont la même identité
+	assert.Equal(t, hasher.Identity("salt", "2001:db8::1"), hasher.Identity("salt", "2001:db8::2"))
+	assert.NotEqual(t, hasher.Identity("salt", "2001:db8::1"), hasher.Identity("salt", "2001:db8:0:1::1"))
+
+	candidates := hasher.Candidates("salt", "203.0.113.7")
+	require.Len(t, candidates, 2)
+	assert.Equal(t, identity, candidates[0])
+
+	// La rétention remplace une identité en clair par l'identité courante, sans rehacher
+	assert.Equal(t, identity, hasher.Anonymize("salt", "203.0.113.7"))
+	assert.Equal(t, identity, hasher.Anonymize("salt", identity))
+}
+
+func TestIPHasher_ClearMode(t *testing.T) {
+	hasher := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeClear})
+
+	assert.Nil(t, hasher)
+	assert.False(t, hasher.Enabled())
+	assert.Equal(t, "203.0.113.7", hasher.Identity("salt", "203.0.113.7"))
+	// L'empreinte laissée par la rétention reste reconnue
+	assert.Equal(t, []string{"203.0.113.7", privacy.Pseudonymize("203.0.113.7")}, hasher.Candidates("salt", "203.0.113.7"))
+	assert.Equal(t, privacy.Pseudonymize("203.0.113.7"), hasher.Anonymize("salt", "203.0.113.7"))
+}
+
+func TestIPHasher_Recorded(t *testing.T) {
+	hasher := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"current", "previous"}})
+	previous := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"previous"}})
+	pollID := uuid.New()
+	stored := map[string]bool{previous.Identity("salt", "203.0.113.7"): true}
+
+	var looked []string
+	lookup := func(ctx context.Context, id uuid.UUID, identity string) (bool, error) {
+		assert.Equal(t, pollID, id)
+		looked = append(looked, identity)
+		return stored[identity], nil
+	}
+
+	// Une réponse signée avec l'ancienne clé est retrouvée après la rotation
+	found, err := hasher.Recorded(context.Background(), "salt", pollID, "203.0.113.7", lookup)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, hasher.Candidates("salt", "203.0.113.7"), looked)
+
+	found, err = hasher.Recorded(context.Background(), "salt", pollID, "198.51.100.4", lookup)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// La première erreur arrête la recherche
+	looked = nil
+	_, err = hasher.Recorded(context.Background(), "salt", pollID, "203.0.113.7", func(ctx context.Context, id uuid.UUID, identity string) (bool, error) {
+		looked = append(looked, identity)
+		return false, errors.New("database error")
+	})
+	assert.Error(t, err)
+	assert.Len(t, looked, 1)
+}
+
+func TestIPHasher_IsCreator(t *testing.T) {
+	hasher := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"current", "previous"}})
+	previous := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"previous"}})
+	ctx := context.Background()
+	createdBy := previous.Identity("salt", "203.0.113.7")
+
+	// L'adresse du créateur est reconnue sous toutes les clés actives, avec le sel de l'objet
+	assert.True(t, hasher.IsCreator(ctx, createdBy, "salt", "203.0.113.7"))
+	assert.False(t, hasher.IsCreator(ctx, createdBy, "other-salt", "203.0.113.7"))
+	assert.False(t, hasher.IsCreator(ctx, createdBy, "salt", "198.51.100.4"))
+	assert.False(t, hasher.IsCreator(ctx, createdBy, "salt", ""))
+	assert.False(t, hasher.IsCreator(ctx, "", "salt", ""))
+
+	// Un appelant authentifié n'est reconnu que par son principal
+	authenticated := auth.WithPrincipal(ctx, &auth.Principal{Actor: "api-key:ci"})
+	assert.True(t, hasher.IsCreator(authenticated, "api-key:ci", "salt", "198.51.100.4"))
+	assert.False(t, hasher.IsCreator(authenticated, createdBy, "salt", "203.0.113.7"))
+
+	var clear *privacy.IPHasher
+	assert.True(t, clear.IsCreator(ctx, "203.0.113.7", "salt", "203.0.113.7"))
+}
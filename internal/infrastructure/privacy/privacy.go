@@ -0,0 +1,21 @@
+package privacy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// HashedPrefix marks values that have already been pseudonymized
+const HashedPrefix = "sha256:"
+
+// Pseudonymize replaces value with a truncated SHA-256 digest so that equal
+// values still compare equal without being stored in clear. Values already
+// hashed by IPHasher are kept as is.
+func Pseudonymize(value string) string {
+	if value == "" || strings.HasPrefix(value, HashedPrefix) || strings.HasPrefix(value, HMACPrefix) {
+		return value
+	}
+	sum := sha256.Sum256([]byte(value))
+	return HashedPrefix + hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,56 @@
+package textstat
+
+// stopWords are the English and French words too common to characterize a
+// response. Elisions such as "l'" or "qu'" are split off by Terms.
+var stopWords = map[string]bool{
+	// English
+	"about": true, "above": true, "after": true, "again": true, "against": true, "all": true,
+	"also": true, "am": true, "an": true, "and": true, "any": true, "are": true, "aren": true,
+	"as": true, "at": true, "be": true, "because": true, "been": true, "before": true, "being": true,
+	"below": true, "between": true, "both": true, "but": true, "by": true, "can": true,
+	"cannot": true, "could": true, "couldn": true, "did": true, "didn": true, "do": true,
+	"does": true, "doesn": true, "doing": true, "don": true, "down": true, "during": true,
+	"each": true, "few": true, "for": true, "from": true, "further": true, "get": true, "got": true,
+	"had": true, "hadn": true, "has": true, "hasn": true, "have": true, "haven": true, "having": true,
+	"he": true, "her": true, "here": true, "hers": true, "herself": true, "him": true,
+	"himself": true, "his": true, "how": true, "if": true, "in": true, "into": true, "is": true,
+	"isn": true, "it": true, "its": true, "itself": true, "just": true, "let": true, "like": true,
+	"ll": true, "lot": true, "me": true, "more": true, "most": true, "much": true, "my": true,
+	"myself": true, "no": true, "nor": true, "not": true, "now": true, "of": true, "off": true,
+	"on": true, "once": true, "only": true, "or": true, "other": true, "ought": true, "our": true,
+	"ours": true, "ourselves": true, "out": true, "over": true, "own": true, "re": true,
+	"really": true, "same": true, "shan": true, "she": true, "should": true, "shouldn": true,
+	"so": true, "some": true, "such": true, "than": true, "that": true, "the": true, "their": true,
+	"theirs": true, "them": true, "themselves": true, "then": true, "there": true, "these": true,
+	"they": true, "thing": true, "things": true, "this": true, "those": true, "through": true,
+	"to": true, "too": true, "under": true, "until": true, "up": true, "us": true, "ve": true,
+	"very": true, "was": true, "wasn": true, "we": true, "were": true, "weren": true, "what": true,
+	"when": true, "where": true, "which": true, "while": true, "who": true, "whom": true, "why": true,
+	"will": true, "with": true, "won": true, "would": true, "wouldn": true, "you": true, "your": true,
+	"yours": true, "yourself": true, "yourselves": true,
+
+	// Français
+	"ai": true, "aie": true, "aient": true, "aies": true, "ait": true, "alors": true, "au": true,
+	"aucun": true, "aussi": true, "autre": true, "aux": true, "avaient": true, "avais": true,
+	"avait": true, "avec": true, "avez": true, "aviez": true, "avions": true, "avoir": true,
+	"avons": true, "ayant": true, "ayez": true, "ayons": true, "bon": true, "ce": true, "ceci": true,
+	"cela": true, "celle": true, "celles": true, "celui": true, "ces": true, "cet": true,
+	"cette": true, "ceux": true, "chaque": true, "ci": true, "comme": true, "comment": true,
+	"dans": true, "de": true, "des": true, "donc": true, "dont": true, "du": true, "elle": true,
+	"elles": true, "en": true, "encore": true, "es": true, "est": true, "et": true, "eu": true,
+	"eue": true, "eues": true, "eurent": true, "eus": true, "eusse": true, "eut": true, "eux": true,
+	"eûmes": true, "faire": true, "fait": true, "fois": true, "font": true, "furent": true,
+	"fus": true, "fut": true, "ici": true, "il": true, "ils": true, "je": true, "jusqu": true,
+	"la": true, "le": true, "les": true, "leur": true, "leurs": true, "lui": true, "là": true,
+	"ma": true, "mais": true, "mes": true, "moi": true, "moins": true, "mon": true, "même": true,
+	"ne": true, "ni": true, "nos": true, "notre": true, "nous": true, "ont": true, "ou": true,
+	"où": true, "par": true, "pas": true, "peu": true, "peut": true, "plus": true, "pour": true,
+	"pourquoi": true, "qu": true, "quand": true, "que": true, "quel": true, "quelle": true,
+	"quelles": true, "quels": true, "qui": true, "sa": true, "sans": true, "se": true, "sera": true,
+	"serait": true, "ses": true, "si": true, "sien": true, "son": true, "sont": true, "sous": true,
+	"soyez": true, "sur": true, "ta": true, "tandis": true, "te": true, "tes": true, "toi": true,
+	"ton": true, "tous": true, "tout": true, "toute": true, "toutes": true, "très": true, "tu": true,
+	"un": true, "une": true, "unes": true, "uns": true, "vos": true, "votre": true, "vous": true,
+	"vu": true, "ça": true, "çà": true, "étaient": true, "étais": true, "était": true, "étant": true,
+	"étiez": true, "étions": true, "été": true, "êtes": true, "être": true,
+}
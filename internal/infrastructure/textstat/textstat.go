@@ -0,0 +1,66 @@
+package textstat
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"microservice-go-gin/internal/domain/entity"
+)
+
+// Frequencies counts the terms of texts and returns the limit most frequent,
+// most frequent first then alphabetically. Terms are lower-cased, split on
+// anything but letters, digits and inner hyphens, and English and French
+// stop words, numbers and single letters are left out.
+func Frequencies(texts []string, limit int) []entity.Term {
+	counts := make(map[string]int)
+	for _, text := range texts {
+		for _, term := range Terms(text) {
+			counts[term]++
+		}
+	}
+
+	terms := make([]entity.Term, 0, len(counts))
+	for term, count := range counts {
+		terms = append(terms, entity.Term{Term: term, Count: count})
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	if limit > 0 && len(terms) > limit {
+		terms = terms[:limit]
+	}
+	return terms
+}
+
+// Terms returns the normalized terms of text that are worth counting
+func Terms(text string) []string {
+	text = strings.ToLower(norm.NFC.String(text))
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.Trim(word, "-")
+		if utf8.RuneCountInString(word) < 2 || isNumber(word) || stopWords[word] {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) && r != '-' {
+			return false
+		}
+	}
+	return true
+}
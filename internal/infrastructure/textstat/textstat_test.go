@@ -0,0 +1,45 @@
+package textstat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/textstat"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "English stop words", text: "The onboarding is too slow", want: []string{"onboarding", "slow"}},
+		{name: "French elisions", text: "L'équipe n'a qu'une idée : l'autonomie", want: []string{"équipe", "idée", "autonomie"}},
+		{name: "case and composed accents", text: "CAFÉ Café", want: []string{"café", "café"}},
+		{name: "inner hyphens", text: "week-end, -remote- 2024 x", want: []string{"week-end", "remote"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, textstat.Terms(tt.text))
+		})
+	}
+}
+
+func TestFrequencies(t *testing.T) {
+	texts := []string{
+		"Faster builds",
+		"faster CI and better docs",
+		"Des builds plus rapides",
+		"docs",
+	}
+
+	assert.Equal(t, []entity.Term{
+		{Term: "builds", Count: 2},
+		{Term: "docs", Count: 2},
+		{Term: "faster", Count: 2},
+	}, textstat.Frequencies(texts, 3))
+	assert.Len(t, textstat.Frequencies(texts, 0), 5)
+	assert.Empty(t, textstat.Frequencies(nil, 10))
+}
@@ -0,0 +1,50 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+func TestSetup_FileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := tracing.Setup(context.Background(), config.TracingConfig{
+		Enabled:     true,
+		Exporter:    "file",
+		FilePath:    path,
+		SampleRatio: 1,
+	}, config.AppConfig{Name: "QuickPoll", Version: "test"})
+	require.NoError(t, err)
+
+	ctx, span := tracing.Start(context.Background(), "CreateVoteUseCase.Execute")
+	traceID, spanID := tracing.IDs(ctx)
+	assert.NotEmpty(t, traceID)
+	assert.NotEmpty(t, spanID)
+	tracing.End(span, errors.New("poll has expired"))
+
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "CreateVoteUseCase.Execute")
+	assert.Contains(t, string(data), traceID)
+	assert.Contains(t, string(data), "poll has expired")
+}
+
+func TestSetup_UnsupportedExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), config.TracingConfig{Enabled: true, Exporter: "zipkin"}, config.AppConfig{})
+	assert.Error(t, err)
+}
+
+func TestIDs_WithoutSpan(t *testing.T) {
+	traceID, spanID := tracing.IDs(context.Background())
+	assert.Empty(t, traceID)
+	assert.Empty(t, spanID)
+}
@@ -0,0 +1,176 @@
+package admin
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/tracing"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+// lastUsedResolution limits the writes caused by a busy key to one per minute
+const lastUsedResolution = time.Minute
+
+var (
+	ErrAPIKeyNotFound  = apperror.NotFound("api_key_not_found", "api key not found")
+	ErrAPIKeyRevoked   = apperror.Conflict("api_key_revoked", "api key already revoked")
+	ErrInvalidKeyScope = apperror.Invalid("invalid_scope", "unknown scope, expected polls:write, votes:read or export")
+)
+
+// CreateAPIKeyInput describes a new API key. ExpiresIn is in minutes; a nil value never expires.
+type CreateAPIKeyInput struct {
+	Name      string   `json:"name" binding:"required,max=100" example:"sprint-retro-bot"`
+	Scopes    []string `json:"scopes" binding:"required,min=1" example:"polls:write,votes:read"`
+	ExpiresIn *int     `json:"expires_in" binding:"omitempty,min=1" example:"525600"`
+}
+
+// CreatedAPIKey is returned once, at creation: Key cannot be retrieved afterwards
+type CreatedAPIKey struct {
+	*entity.APIKey
+	Key string `json:"key" example:"qp_Zk3v9QaB..."`
+}
+
+// APIKeyUseCase manages the API keys used by other services and
+// authenticates the requests that carry them
+type APIKeyUseCase struct {
+	keyRepo  repository.APIKeyRepository
+	recorder *audit.Recorder
+	now      func() time.Time
+}
+
+func NewAPIKeyUseCase(keyRepo repository.APIKeyRepository, recorder *audit.Recorder) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		keyRepo:  keyRepo,
+		recorder: recorder,
+		now:      time.Now,
+	}
+}
+
+func (uc *APIKeyUseCase) Create(ctx context.Context, input CreateAPIKeyInput) (_ *CreatedAPIKey, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUseCase.Create")
+	defer func() { tracing.End(span, err) }()
+
+	scopes, err := normalizeScopes(input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	token, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now().UTC()
+	key := &entity.APIKey{
+		Name:      input.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		CreatedBy: auth.ActorFromContext(ctx),
+		CreatedAt: now,
+	}
+	if input.ExpiresIn != nil {
+		expiresAt := now.Add(time.Duration(*input.ExpiresIn) * time.Minute)
+		key.ExpiresAt = &expiresAt
+	}
+	if err := uc.keyRepo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	uc.recorder.Record(ctx, audit.Event{Action: entity.AuditKeyCreated, Target: key.Actor(), Changes: map[string]interface{}{
+		"name":       key.Name,
+		"prefix":     key.Prefix,
+		"scopes":     key.Scopes,
+		"expires_at": key.ExpiresAt,
+	}})
+	return &CreatedAPIKey{APIKey: key, Key: token}, nil
+}
+
+func (uc *APIKeyUseCase) List(ctx context.Context, offset, limit int) ([]*entity.APIKey, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 || limit > maxPageSize {
+		limit = defaultPageSize
+	}
+	keys, err := uc.keyRepo.List(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = []*entity.APIKey{}
+	}
+	return keys, nil
+}
+
+func (uc *APIKeyUseCase) Revoke(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyUseCase.Revoke")
+	defer func() { tracing.End(span, err) }()
+
+	key, err := uc.keyRepo.GetByID(ctx, id)
+	if err != nil {
+		return ErrAPIKeyNotFound
+	}
+	if key.RevokedAt != nil {
+		return ErrAPIKeyRevoked
+	}
+	now := uc.now().UTC()
+	if err := uc.keyRepo.Revoke(ctx, id, now); err != nil {
+		return err
+	}
+
+	uc.recorder.Record(ctx, audit.Event{Action: entity.AuditKeyRevoked, Target: key.Actor(), Changes: map[string]interface{}{
+		"name":       key.Name,
+		"revoked_at": now,
+	}})
+	return nil
+}
+
+// AuthenticateKey resolves token to the principal of an active key
+func (uc *APIKeyUseCase) AuthenticateKey(ctx context.Context, token string) (*auth.Principal, error) {
+	if !auth.IsAPIKey(token) {
+		return nil, auth.ErrInvalidCredentials
+	}
+	key, err := uc.keyRepo.FindByHash(ctx, auth.HashAPIKey(token))
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now().UTC()
+	if key == nil || !key.IsActive(now) {
+		return nil, auth.ErrInvalidCredentials
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := uc.keyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			slog.WarnContext(ctx, "failed to record api key usage", "key_id", key.ID, "error", err)
+		}
+	}
+	// Une clé sans scope ne doit pas être traitée comme non restreinte
+	scopes := append([]string{}, key.Scopes...)
+	return &auth.Principal{Actor: key.Actor(), Scopes: scopes}, nil
+}
+
+// normalizeScopes rejects unknown scopes and removes duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		known := false
+		for _, s := range auth.Scopes {
+			known = known || s == scope
+		}
+		if !known {
+			return nil, ErrInvalidKeyScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
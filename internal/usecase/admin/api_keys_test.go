@@ -0,0 +1,99 @@
+package admin_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/usecase/admin"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+func TestAPIKeyUseCase_Create(t *testing.T) {
+	keyRepo := new(mocks.MockAPIKeyRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+	useCase := admin.NewAPIKeyUseCase(keyRepo, audit.NewRecorder(auditRepo))
+
+	var stored *entity.APIKey
+	keyRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.APIKey")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.APIKey) }).
+		Return(nil)
+	auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+		return event.Action == entity.AuditKeyCreated && event.Actor == "api-key:ops" &&
+			!strings.Contains(event.Changes, stored.KeyHash)
+	})).Return(nil)
+
+	expiresIn := 60
+	created, err := useCase.Create(adminContext(), admin.CreateAPIKeyInput{
+		Name:      "sprint-retro-bot",
+		Scopes:    []string{auth.ScopePollsWrite, auth.ScopePollsWrite, auth.ScopeExport},
+		ExpiresIn: &expiresIn,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, auth.APIKeyPrefix))
+	assert.Equal(t, auth.HashAPIKey(created.Key), stored.KeyHash)
+	assert.True(t, strings.HasPrefix(created.Key, stored.Prefix))
+	assert.Equal(t, []string{auth.ScopePollsWrite, auth.ScopeExport}, stored.Scopes)
+	assert.NotNil(t, stored.ExpiresAt)
+	auditRepo.AssertExpectations(t)
+
+	_, err = useCase.Create(adminContext(), admin.CreateAPIKeyInput{Name: "bad", Scopes: []string{"admin"}})
+	assert.ErrorIs(t, err, admin.ErrInvalidKeyScope)
+}
+
+func TestAPIKeyUseCase_AuthenticateKey(t *testing.T) {
+	token, prefix, hash, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		key     *entity.APIKey
+		wantErr bool
+	}{
+		{name: "active key", key: &entity.APIKey{Prefix: prefix, KeyHash: hash, Scopes: []string{auth.ScopePollsWrite}}},
+		{name: "unknown key", key: nil, wantErr: true},
+		{name: "revoked key", key: &entity.APIKey{KeyHash: hash, RevokedAt: &past}, wantErr: true},
+		{name: "expired key", key: &entity.APIKey{KeyHash: hash, ExpiresAt: &past}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyRepo := new(mocks.MockAPIKeyRepository)
+			useCase := admin.NewAPIKeyUseCase(keyRepo, nil)
+			if tt.key == nil {
+				keyRepo.On("FindByHash", mock.Anything, hash).Return(nil, nil)
+			} else {
+				keyRepo.On("FindByHash", mock.Anything, hash).Return(tt.key, nil)
+			}
+			keyRepo.On("TouchLastUsed", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			principal, err := useCase.AuthenticateKey(context.Background(), token)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.key.Actor(), principal.Actor)
+			assert.True(t, principal.HasScope(auth.ScopePollsWrite))
+			assert.False(t, principal.HasScope(auth.ScopeExport))
+			keyRepo.AssertCalled(t, "TouchLastUsed", mock.Anything, tt.key.ID, mock.Anything)
+		})
+	}
+
+	t.Run("jwt-looking tokens are not looked up", func(t *testing.T) {
+		keyRepo := new(mocks.MockAPIKeyRepository)
+		_, err := admin.NewAPIKeyUseCase(keyRepo, nil).AuthenticateKey(context.Background(), "eyJhbGciOi...")
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+		keyRepo.AssertNotCalled(t, "FindByHash", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,163 @@
+package admin
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+// banSalt separates the hashes of banned addresses from those of the ballots
+const banSalt = "ban"
+
+var (
+	ErrBanNotFound   = apperror.NotFound("ban_not_found", "ban not found")
+	ErrAlreadyBanned = apperror.Conflict("already_banned", "already banned")
+	ErrInvalidBan    = apperror.Invalid("invalid_ban", "kind must be ip or user, and an ip ban needs a valid IP address")
+)
+
+// CreateBanInput describes a new ban. ExpiresIn is in minutes; a nil value bans permanently.
+type CreateBanInput struct {
+	Kind      string `json:"kind" binding:"required,oneof=ip user" example:"ip"`
+	Value     string `json:"value" binding:"required,max=100" example:"203.0.113.7"`
+	Reason    string `json:"reason" binding:"max=255" example:"vote flooding"`
+	ExpiresIn *int   `json:"expires_in" binding:"omitempty,min=1" example:"1440"`
+}
+
+// BanUseCase manages banned IP addresses and users and checks requests against them
+type BanUseCase struct {
+	banRepo  repository.BanRepository
+	recorder *audit.Recorder
+	ipHasher *privacy.IPHasher
+	now      func() time.Time
+}
+
+// NewBanUseCase creates the use case; ipHasher must be the one used to record
+// ballots, nil when IP addresses are stored in clear
+func NewBanUseCase(banRepo repository.BanRepository, recorder *audit.Recorder, ipHasher *privacy.IPHasher) *BanUseCase {
+	return &BanUseCase{
+		banRepo:  banRepo,
+		recorder: recorder,
+		ipHasher: ipHasher,
+		now:      time.Now,
+	}
+}
+
+func (uc *BanUseCase) Create(ctx context.Context, input CreateBanInput) (_ *entity.Ban, err error) {
+	ctx, span := tracing.Start(ctx, "BanUseCase.Create")
+	defer func() { tracing.End(span, err) }()
+
+	value := strings.TrimSpace(input.Value)
+	switch input.Kind {
+	case entity.BanIP:
+		if net.ParseIP(value) == nil {
+			return nil, ErrInvalidBan
+		}
+		// Une adresse IPv6 est bannie avec tout son /64, comme pour la détection des doubles votes
+		value = uc.ipHasher.Identity(banSalt, privacy.TruncateIP(value))
+	case entity.BanUser:
+		if value == "" {
+			return nil, ErrInvalidBan
+		}
+	default:
+		return nil, ErrInvalidBan
+	}
+
+	now := uc.now().UTC()
+	existing, err := uc.banRepo.FindByValue(ctx, input.Kind, value)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.IsActive(now) {
+			return nil, ErrAlreadyBanned
+		}
+		if err := uc.banRepo.Delete(ctx, existing.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	ban := &entity.Ban{
+		Kind:      input.Kind,
+		Value:     value,
+		Reason:    input.Reason,
+		CreatedBy: auth.ActorFromContext(ctx),
+		CreatedAt: now,
+	}
+	if input.ExpiresIn != nil {
+		expiresAt := now.Add(time.Duration(*input.ExpiresIn) * time.Minute)
+		ban.ExpiresAt = &expiresAt
+	}
+	if err := uc.banRepo.Create(ctx, ban); err != nil {
+		return nil, err
+	}
+
+	uc.recorder.Record(ctx, audit.Event{Action: entity.AuditBanCreated, Target: "ban:" + ban.ID.String(), Changes: map[string]interface{}{
+		"kind":       ban.Kind,
+		"value":      ban.Value,
+		"reason":     ban.Reason,
+		"expires_at": ban.ExpiresAt,
+	}})
+	return ban, nil
+}
+
+func (uc *BanUseCase) List(ctx context.Context, offset, limit int) ([]*entity.Ban, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 || limit > maxPageSize {
+		limit = defaultPageSize
+	}
+	bans, err := uc.banRepo.List(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	if bans == nil {
+		bans = []*entity.Ban{}
+	}
+	return bans, nil
+}
+
+func (uc *BanUseCase) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "BanUseCase.Delete")
+	defer func() { tracing.End(span, err) }()
+
+	ban, err := uc.banRepo.GetByID(ctx, id)
+	if err != nil {
+		return ErrBanNotFound
+	}
+	if err := uc.banRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.recorder.Record(ctx, audit.Event{Action: entity.AuditBanDeleted, Target: "ban:" + id.String(), Changes: map[string]interface{}{
+		"kind":  ban.Kind,
+		"value": ban.Value,
+	}})
+	return nil
+}
+
+// IsBanned reports whether the client IP address or the user is banned.
+// user may be empty for anonymous requests.
+func (uc *BanUseCase) IsBanned(ctx context.Context, ip, user string) (bool, error) {
+	now := uc.now().UTC()
+	if ip != "" {
+		banned, err := uc.banRepo.IsBanned(ctx, entity.BanIP, uc.ipHasher.Candidates(banSalt, privacy.TruncateIP(ip)), now)
+		if err != nil || banned {
+			return banned, err
+		}
+	}
+	if user != "" {
+		return uc.banRepo.IsBanned(ctx, entity.BanUser, []string{user}, now)
+	}
+	return false, nil
+}
@@ -0,0 +1,97 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/admin"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+func TestBanUseCase_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    admin.CreateBanInput
+		existing *entity.Ban
+		wantErr  error
+	}{
+		{
+			name:  "ban an ip",
+			input: admin.CreateBanInput{Kind: entity.BanIP, Value: "203.0.113.7", Reason: "flooding"},
+		},
+		{
+			name:    "invalid ip",
+			input:   admin.CreateBanInput{Kind: entity.BanIP, Value: "not-an-ip"},
+			wantErr: admin.ErrInvalidBan,
+		},
+		{
+			name:     "already banned",
+			input:    admin.CreateBanInput{Kind: entity.BanUser, Value: "user-42"},
+			existing: &entity.Ban{Kind: entity.BanUser, Value: "user-42"},
+			wantErr:  admin.ErrAlreadyBanned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banRepo := new(mocks.MockBanRepository)
+			auditRepo := new(mocks.MockAuditRepository)
+			useCase := admin.NewBanUseCase(banRepo, audit.NewRecorder(auditRepo), nil)
+
+			if tt.wantErr != admin.ErrInvalidBan {
+				banRepo.On("FindByValue", mock.Anything, tt.input.Kind, tt.input.Value).Return(tt.existing, nil)
+			}
+			if tt.wantErr == nil {
+				banRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Ban")).Return(nil)
+				auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+					return event.Action == entity.AuditBanCreated && event.Actor == "api-key:ops"
+				})).Return(nil)
+			}
+
+			ban, err := useCase.Create(adminContext(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, ban)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "api-key:ops", ban.CreatedBy)
+			}
+			banRepo.AssertExpectations(t)
+			auditRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBanUseCase_IsBannedHashedIPv6(t *testing.T) {
+	hasher := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"key"}})
+	banRepo := new(mocks.MockBanRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+	useCase := admin.NewBanUseCase(banRepo, audit.NewRecorder(auditRepo), hasher)
+
+	var stored string
+	banRepo.On("FindByValue", mock.Anything, entity.BanIP, mock.Anything).Return(nil, nil)
+	banRepo.On("Create", mock.Anything, mock.MatchedBy(func(ban *entity.Ban) bool {
+		stored = ban.Value
+		return true
+	})).Return(nil)
+	auditRepo.On("Append", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := useCase.Create(adminContext(), admin.CreateBanInput{Kind: entity.BanIP, Value: "2001:db8:1:2::1"})
+	require.NoError(t, err)
+	assert.NotContains(t, stored, "2001")
+
+	// Une autre adresse du même /64 est couverte par le ban
+	banRepo.On("IsBanned", mock.Anything, entity.BanIP, []string{stored}, mock.AnythingOfType("time.Time")).Return(true, nil)
+	banned, err := useCase.IsBanned(context.Background(), "2001:db8:1:2::ffff", "")
+	require.NoError(t, err)
+	assert.True(t, banned)
+
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/tracing"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var (
+	ErrPollNotFound      = entity.ErrPollNotFound
+	ErrPollAlreadyClosed = apperror.Conflict("poll_already_closed", "poll is already closed")
+	ErrInvalidStatus     = apperror.Invalid("invalid_status", "status must be one of open, closed, deleted, all")
+)
+
+// PollPage is one page of the admin poll listing
+type PollPage struct {
+	Polls  []*entity.Poll `json:"polls"`
+	Total  int64          `json:"total"`
+	Offset int            `json:"offset"`
+	Limit  int            `json:"limit"`
+}
+
+// PollAdminUseCase lets operators search, close and delete any poll
+type PollAdminUseCase struct {
+	pollRepo  repository.PollRepository
+	adminRepo repository.AdminRepository
+	recorder  *audit.Recorder
+	now       func() time.Time
+}
+
+func NewPollAdminUseCase(pollRepo repository.PollRepository, adminRepo repository.AdminRepository, recorder *audit.Recorder) *PollAdminUseCase {
+	return &PollAdminUseCase{
+		pollRepo:  pollRepo,
+		adminRepo: adminRepo,
+		recorder:  recorder,
+		now:       time.Now,
+	}
+}
+
+func (uc *PollAdminUseCase) Search(ctx context.Context, filter repository.PollFilter) (_ *PollPage, err error) {
+	ctx, span := tracing.Start(ctx, "PollAdminUseCase.Search")
+	defer func() { tracing.End(span, err) }()
+
+	switch filter.Status {
+	case "", repository.PollStatusOpen, repository.PollStatusClosed, repository.PollStatusDeleted, repository.PollStatusAll:
+	default:
+		return nil, ErrInvalidStatus
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+
+	polls, total, err := uc.adminRepo.SearchPolls(ctx, filter, uc.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if polls == nil {
+		polls = []*entity.Poll{}
+	}
+	return &PollPage{Polls: polls, Total: total, Offset: filter.Offset, Limit: filter.Limit}, nil
+}
+
+// Close ends the poll now; ballots already cast are kept
+func (uc *PollAdminUseCase) Close(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "PollAdminUseCase.Close", trace.WithAttributes(
+		attribute.String("poll.id", id.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	poll, err := uc.pollRepo.GetByID(ctx, id)
+	if err != nil {
+		return ErrPollNotFound
+	}
+	if poll.IsExpired() {
+		return ErrPollAlreadyClosed
+	}
+
+	now := uc.now().UTC()
+	if err := uc.adminRepo.ClosePoll(ctx, id, now); err != nil {
+		return err
+	}
+
+	changes := audit.Diff{}
+	changes.Set("expires_at", poll.ExpiresAt, now)
+	uc.recorder.Record(ctx, audit.Event{Action: entity.AuditPollClosed, Target: audit.PollTarget(id), PollID: &id, Changes: changes})
+	return nil
+}
+
+// Delete soft-deletes the poll; the retention job purges it later
+func (uc *PollAdminUseCase) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "PollAdminUseCase.Delete", trace.WithAttributes(
+		attribute.String("poll.id", id.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uc.pollRepo.GetByID(ctx, id); err != nil {
+		return ErrPollNotFound
+	}
+	if err := uc.pollRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	changes := audit.Diff{}
+	changes.Set("deleted_at", nil, uc.now().UTC())
+	uc.recorder.Record(ctx, audit.Event{Action: entity.AuditPollDeleted, Target: audit.PollTarget(id), PollID: &id, Changes: changes})
+	return nil
+}
+
+// Stats returns the tallies and voter counts of a poll, deleted or not,
+// whatever its type
+func (uc *PollAdminUseCase) Stats(ctx context.Context, id uuid.UUID) (_ *repository.PollStats, err error) {
+	ctx, span := tracing.Start(ctx, "PollAdminUseCase.Stats", trace.WithAttributes(
+		attribute.String("poll.id", id.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return uc.adminRepo.PollStats(ctx, id)
+}
+
+// Totals counts polls, ballots and active bans
+func (uc *PollAdminUseCase) Totals(ctx context.Context) (*repository.Totals, error) {
+	return uc.adminRepo.Totals(ctx, uc.now().UTC())
+}
@@ -0,0 +1,135 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/usecase/admin"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+func adminContext() context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Actor: "api-key:ops", Roles: []string{auth.RoleAdmin}})
+}
+
+func TestPollAdminUseCase_Close(t *testing.T) {
+	pollID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		mockPoll *entity.Poll
+		mockErr  error
+		wantErr  error
+	}{
+		{
+			name:     "closes an open poll",
+			mockPoll: &entity.Poll{ID: pollID, Title: "Open"},
+		},
+		{
+			name:     "already closed",
+			mockPoll: &entity.Poll{ID: pollID, Title: "Closed", ExpiresAt: &past},
+			wantErr:  admin.ErrPollAlreadyClosed,
+		},
+		{
+			name:    "poll not found",
+			mockErr: errors.New("poll not found"),
+			wantErr: admin.ErrPollNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pollRepo := new(mocks.MockPollRepository)
+			adminRepo := new(mocks.MockAdminRepository)
+			auditRepo := new(mocks.MockAuditRepository)
+			useCase := admin.NewPollAdminUseCase(pollRepo, adminRepo, audit.NewRecorder(auditRepo))
+
+			pollRepo.On("GetByID", mock.Anything, pollID).Return(tt.mockPoll, tt.mockErr)
+			if tt.wantErr == nil {
+				adminRepo.On("ClosePoll", mock.Anything, pollID, mock.AnythingOfType("time.Time")).Return(nil)
+				auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+					return event.Action == entity.AuditPollClosed &&
+						event.Actor == "api-key:ops" &&
+						event.PollID != nil && *event.PollID == pollID
+				})).Return(nil)
+			}
+
+			err := useCase.Close(adminContext(), pollID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			adminRepo.AssertExpectations(t)
+			auditRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPollAdminUseCase_Delete(t *testing.T) {
+	pollID := uuid.New()
+	pollRepo := new(mocks.MockPollRepository)
+	adminRepo := new(mocks.MockAdminRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+	useCase := admin.NewPollAdminUseCase(pollRepo, adminRepo, audit.NewRecorder(auditRepo))
+
+	pollRepo.On("GetByID", mock.Anything, pollID).Return(&entity.Poll{ID: pollID, Title: "Spam"}, nil)
+	pollRepo.On("Delete", mock.Anything, pollID).Return(nil)
+	// L'échec de l'audit n'annule pas la suppression
+	auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+		return event.Action == entity.AuditPollDeleted && strings.Contains(event.Changes, `"deleted_at":{"from":null`)
+	})).Return(errors.New("database error"))
+
+	err := useCase.Delete(adminContext(), pollID)
+
+	assert.NoError(t, err)
+	pollRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestPollAdminUseCase_Stats(t *testing.T) {
+	pollID := uuid.New()
+	adminRepo := new(mocks.MockAdminRepository)
+	useCase := admin.NewPollAdminUseCase(new(mocks.MockPollRepository), adminRepo, audit.NewRecorder(new(mocks.MockAuditRepository)))
+
+	// Un sondage sans option (texte, numérique) a quand même des statistiques
+	adminRepo.On("PollStats", mock.Anything, pollID).Return(&repository.PollStats{PollID: pollID, Type: entity.PollTypeText, Responses: 3}, nil)
+	stats, err := useCase.Stats(adminContext(), pollID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), stats.Responses)
+
+	missing := uuid.New()
+	adminRepo.On("PollStats", mock.Anything, missing).Return(nil, entity.ErrPollNotFound)
+	_, err = useCase.Stats(adminContext(), missing)
+	assert.ErrorIs(t, err, admin.ErrPollNotFound)
+}
+
+func TestPollAdminUseCase_Search(t *testing.T) {
+	pollRepo := new(mocks.MockPollRepository)
+	adminRepo := new(mocks.MockAdminRepository)
+	useCase := admin.NewPollAdminUseCase(pollRepo, adminRepo, nil)
+
+	adminRepo.On("SearchPolls", mock.Anything, repository.PollFilter{Query: "go", Limit: 100}, mock.Anything).
+		Return(nil, int64(0), nil)
+
+	page, err := useCase.Search(context.Background(), repository.PollFilter{Query: "go", Limit: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, 100, page.Limit)
+	assert.NotNil(t, page.Polls)
+
+	_, err = useCase.Search(context.Background(), repository.PollFilter{Status: "archived"})
+	assert.ErrorIs(t, err, admin.ErrInvalidStatus)
+	adminRepo.AssertExpectations(t)
+}
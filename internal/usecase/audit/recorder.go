@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/logger"
+)
+
+// Change is the value of a field before and after an action
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Diff maps the name of each changed field to its change
+type Diff map[string]Change
+
+// Set records the change of field
+func (d Diff) Set(field string, from, to interface{}) {
+	d[field] = Change{From: from, To: to}
+}
+
+// Event is an action to record. Actor defaults to the authenticated caller.
+type Event struct {
+	Actor   string
+	Action  string
+	Target  string
+	PollID  *uuid.UUID
+	Changes interface{}
+}
+
+// Recorder appends events to the audit log. A nil *Recorder records nothing.
+type Recorder struct {
+	auditRepo repository.AuditRepository
+	now       func() time.Time
+}
+
+func NewRecorder(auditRepo repository.AuditRepository) *Recorder {
+	return &Recorder{auditRepo: auditRepo, now: time.Now}
+}
+
+// Record appends event with the request ID of ctx. The action has already
+// been applied, so a failure is logged rather than returned.
+func (r *Recorder) Record(ctx context.Context, event Event) {
+	if r == nil {
+		return
+	}
+	if err := r.Append(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "failed to record action in the audit log", "action", event.Action, "error", err)
+	}
+}
+
+// Append is like Record but returns the error, for actions that must not
+// happen without a trace
+func (r *Recorder) Append(ctx context.Context, event Event) error {
+	if r == nil {
+		return nil
+	}
+	payload, err := json.Marshal(event.Changes)
+	if err != nil {
+		return err
+	}
+	actor := event.Actor
+	if actor == "" {
+		actor = auth.ActorFromContext(ctx)
+	}
+
+	return r.auditRepo.Append(ctx, &entity.AuditEvent{
+		Actor:     actor,
+		Action:    event.Action,
+		Target:    event.Target,
+		PollID:    event.PollID,
+		Changes:   string(payload),
+		RequestID: logger.RequestIDFromContext(ctx),
+		CreatedAt: r.now().UTC(),
+	})
+}
+
+// PollTarget is the audit target of a poll
+func PollTarget(id uuid.UUID) string {
+	return "poll:" + id.String()
+}
+
+// OptionTarget is the audit target of a write-in option
+func OptionTarget(id uuid.UUID) string {
+	return "option:" + id.String()
+}
+
+// ResponseTarget is the audit target of a text response
+func ResponseTarget(id uuid.UUID) string {
+	return "response:" + id.String()
+}
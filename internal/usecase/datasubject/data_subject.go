@@ -0,0 +1,374 @@
+package datasubject
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/logger"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+var (
+	ErrMissingIdentity = apperror.Invalid("missing_identity", "voter identity is required")
+	// ErrAuthenticationRequired keeps an IP address alone, which a NAT or a
+	// proxy shares between many people, from exporting or erasing ballots
+	ErrAuthenticationRequired = apperror.Unauthorized("authentication_required", "authentication required")
+)
+
+// ExportedVote is one ballot of the data subject with the poll it belongs to
+type ExportedVote struct {
+	PollID     uuid.UUID `json:"poll_id"`
+	PollTitle  string    `json:"poll_title"`
+	OptionID   uuid.UUID `json:"option_id"`
+	OptionText string    `json:"option_text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExportedResponse is one free-text response of the data subject
+type ExportedResponse struct {
+	PollID    uuid.UUID `json:"poll_id"`
+	PollTitle string    `json:"poll_title"`
+	Text      string    `json:"text"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportedEstimate is one numeric estimate of the data subject
+type ExportedEstimate struct {
+	PollID    uuid.UUID `json:"poll_id"`
+	PollTitle string    `json:"poll_title"`
+	Value     float64   `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportedAvailability is the answer of the data subject to one time slot
+// of a scheduling poll
+type ExportedAvailability struct {
+	PollID    uuid.UUID `json:"poll_id"`
+	PollTitle string    `json:"poll_title"`
+	OptionID  uuid.UUID `json:"option_id"`
+	Slot      string    `json:"slot"`
+	Name      string    `json:"name"`
+	Answer    string    `json:"answer"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportedWaitlistEntry is a place of the data subject in the waitlist of a
+// full option of a sign-up sheet
+type ExportedWaitlistEntry struct {
+	PollID     uuid.UUID `json:"poll_id"`
+	PollTitle  string    `json:"poll_title"`
+	OptionID   uuid.UUID `json:"option_id"`
+	OptionText string    `json:"option_text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExportedQuizPlayer is the data subject as a player of a quiz, with the
+// number of questions they answered and their score
+type ExportedQuizPlayer struct {
+	QuizID    uuid.UUID `json:"quiz_id"`
+	QuizTitle string    `json:"quiz_title"`
+	Nickname  string    `json:"nickname"`
+	Answers   int       `json:"answers"`
+	Score     int       `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Export is everything stored about a data subject
+type Export struct {
+	VoterID    string         `json:"voter_id"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Votes      []ExportedVote `json:"votes"`
+	Polls      []*entity.Poll `json:"polls"`
+
+	Surveys []*entity.Survey `json:"surveys"`
+	Quizzes []*entity.Quiz   `json:"quizzes"`
+
+	Responses []ExportedResponse `json:"responses"`
+	Estimates []ExportedEstimate `json:"estimates"`
+
+	Availabilities []ExportedAvailability `json:"availabilities"`
+
+	Waitlist []ExportedWaitlistEntry `json:"waitlist"`
+
+	QuizPlayers []ExportedQuizPlayer `json:"quiz_players"`
+}
+
+// DataSubjectUseCase serves the access and erasure requests of a voter.
+// Every request is recorded in the audit log under a pseudonymized actor.
+type DataSubjectUseCase struct {
+	subjectRepo repository.DataSubjectRepository
+	auditRepo   repository.AuditRepository
+	ipHasher    *privacy.IPHasher
+	now         func() time.Time
+}
+
+// NewDataSubjectUseCase creates the use case; ipHasher must be the one used
+// to record ballots, nil when voter IP addresses are stored in clear
+func NewDataSubjectUseCase(subjectRepo repository.DataSubjectRepository, auditRepo repository.AuditRepository, ipHasher *privacy.IPHasher) *DataSubjectUseCase {
+	return &DataSubjectUseCase{
+		subjectRepo: subjectRepo,
+		auditRepo:   auditRepo,
+		ipHasher:    ipHasher,
+		now:         time.Now,
+	}
+}
+
+// identities returns every value the authenticated caller and its IP
+// address voterID may be stored as: the principal, which signs the polls it
+// creates, then with hashed IP addresses one value per salt and per hash key
+func (uc *DataSubjectUseCase) identities(ctx context.Context, voterID string) ([]string, error) {
+	if voterID == "" {
+		return nil, ErrMissingIdentity
+	}
+	principal := auth.PrincipalFromContext(ctx)
+	if principal == nil {
+		return nil, ErrAuthenticationRequired
+	}
+	identities := []string{principal.Actor}
+
+	if !uc.ipHasher.Enabled() {
+		// Sans clé, l'identité ne dépend pas du sel : la valeur en clair ou son empreinte de rétention
+		return append(identities, uc.ipHasher.Candidates("", voterID)...), nil
+	}
+	salts, err := uc.subjectRepo.ListIPSalts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, salt := range salts {
+		identities = append(identities, uc.ipHasher.Candidates(salt, voterID)...)
+	}
+	return identities, nil
+}
+
+// Export returns the ballots cast, the text responses, the estimates, the
+// availabilities, the waitlist entries, the quiz players and the polls,
+// surveys and quizzes created by the caller. Ballots are found by the IP address voterID, which
+// others may share: the export leaves out their IP address and user agent.
+func (uc *DataSubjectUseCase) Export(ctx context.Context, voterID string) (_ *Export, err error) {
+	ctx, span := tracing.Start(ctx, "DataSubjectUseCase.Export")
+	defer func() { tracing.End(span, err) }()
+
+	identities, err := uc.identities(ctx, voterID)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := uc.subjectRepo.FindVotes(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	polls, err := uc.subjectRepo.FindPolls(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	surveys, err := uc.subjectRepo.FindSurveys(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	quizzes, err := uc.subjectRepo.FindQuizzes(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := uc.subjectRepo.FindTextResponses(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	estimates, err := uc.subjectRepo.FindEstimates(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	availabilities, err := uc.subjectRepo.FindAvailabilities(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := uc.subjectRepo.FindWaitlistEntries(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	players, err := uc.subjectRepo.FindQuizPlayers(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		VoterID:    voterID,
+		ExportedAt: uc.now().UTC(),
+		Votes:      make([]ExportedVote, 0, len(votes)),
+		Polls:      polls,
+		Surveys:    surveys,
+		Quizzes:    quizzes,
+		Responses:  make([]ExportedResponse, 0, len(responses)),
+		Estimates:  make([]ExportedEstimate, 0, len(estimates)),
+
+		Availabilities: make([]ExportedAvailability, 0, len(availabilities)),
+		Waitlist:       make([]ExportedWaitlistEntry, 0, len(entries)),
+		QuizPlayers:    make([]ExportedQuizPlayer, 0, len(players)),
+	}
+	if export.Polls == nil {
+		export.Polls = []*entity.Poll{}
+	}
+	if export.Surveys == nil {
+		export.Surveys = []*entity.Survey{}
+	}
+	if export.Quizzes == nil {
+		export.Quizzes = []*entity.Quiz{}
+	}
+	for _, vote := range votes {
+		exported := ExportedVote{
+			PollID:    vote.PollID,
+			OptionID:  vote.OptionID,
+			CreatedAt: vote.CreatedAt,
+		}
+		if vote.Poll != nil {
+			exported.PollTitle = vote.Poll.Title
+		}
+		if vote.Option != nil {
+			exported.OptionText = vote.Option.Text
+		}
+		export.Votes = append(export.Votes, exported)
+	}
+	for _, response := range responses {
+		exported := ExportedResponse{
+			PollID:    response.PollID,
+			Text:      response.Text,
+			Status:    response.Status,
+			CreatedAt: response.CreatedAt,
+		}
+		if response.Poll != nil {
+			exported.PollTitle = response.Poll.Title
+		}
+		export.Responses = append(export.Responses, exported)
+	}
+	for _, estimate := range estimates {
+		exported := ExportedEstimate{
+			PollID:    estimate.PollID,
+			Value:     estimate.Value,
+			CreatedAt: estimate.CreatedAt,
+		}
+		if estimate.Poll != nil {
+			exported.PollTitle = estimate.Poll.Title
+		}
+		export.Estimates = append(export.Estimates, exported)
+	}
+	for _, availability := range availabilities {
+		exported := ExportedAvailability{
+			PollID:    availability.PollID,
+			OptionID:  availability.OptionID,
+			Name:      availability.Name,
+			Answer:    availability.Answer,
+			CreatedAt: availability.CreatedAt,
+		}
+		if availability.Poll != nil {
+			exported.PollTitle = availability.Poll.Title
+		}
+		if availability.Option != nil {
+			exported.Slot = availability.Option.Text
+		}
+		export.Availabilities = append(export.Availabilities, exported)
+	}
+	for _, entry := range entries {
+		exported := ExportedWaitlistEntry{
+			PollID:    entry.PollID,
+			OptionID:  entry.OptionID,
+			CreatedAt: entry.CreatedAt,
+		}
+		if entry.Poll != nil {
+			exported.PollTitle = entry.Poll.Title
+		}
+		if entry.Option != nil {
+			exported.OptionText = entry.Option.Text
+		}
+		export.Waitlist = append(export.Waitlist, exported)
+	}
+	for _, player := range players {
+		exported := ExportedQuizPlayer{
+			QuizID:    player.QuizID,
+			Nickname:  player.Nickname,
+			Answers:   len(player.Answers),
+			CreatedAt: player.CreatedAt,
+		}
+		if player.Quiz != nil {
+			exported.QuizTitle = player.Quiz.Title
+		}
+		for _, answer := range player.Answers {
+			exported.Score += answer.Points
+		}
+		export.QuizPlayers = append(export.QuizPlayers, exported)
+	}
+
+	err = uc.audit(ctx, entity.AuditDataExported, voterID, map[string]int{
+		"votes":     len(export.Votes),
+		"polls":     len(export.Polls),
+		"surveys":   len(export.Surveys),
+		"quizzes":   len(export.Quizzes),
+		"responses": len(export.Responses),
+		"estimates": len(export.Estimates),
+
+		"availabilities": len(export.Availabilities),
+		"waitlist":       len(export.Waitlist),
+		"quiz_players":   len(export.QuizPlayers),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Erase removes the ballots, text responses, estimates, availabilities,
+// waitlist entries and quiz players of the caller in closed polls and finished
+// quizzes and detaches the polls, surveys and quizzes it created. Closed polls keep their
+// published tallies. In polls and quizzes still open the ballots are only
+// anonymized, so that erasing cannot be used to vote again.
+func (uc *DataSubjectUseCase) Erase(ctx context.Context, voterID string) (_ *repository.ErasureResult, err error) {
+	ctx, span := tracing.Start(ctx, "DataSubjectUseCase.Erase")
+	defer func() { tracing.End(span, err) }()
+
+	identities, err := uc.identities(ctx, voterID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := uc.subjectRepo.Erase(ctx, identities, uc.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.audit(ctx, entity.AuditDataErased, voterID, result); err != nil {
+		// Les données sont déjà effacées : l'échec de l'audit ne doit pas le masquer
+		slog.ErrorContext(ctx, "failed to record erasure in the audit log", "error", err)
+	}
+	slog.InfoContext(ctx, "data subject erased",
+		"anonymized_ballots", result.AnonymizedBallots,
+		"archived_votes", result.ArchivedVotes,
+		"anonymized_polls", result.AnonymizedPolls,
+		"anonymized_surveys", result.AnonymizedSurveys,
+		"anonymized_quizzes", result.AnonymizedQuizzes,
+		"deleted_responses", result.DeletedResponses,
+		"deleted_estimates", result.DeletedEstimates,
+		"deleted_availabilities", result.DeletedAvailabilities,
+		"deleted_waitlist_entries", result.DeletedWaitlistEntries,
+		"deleted_quiz_players", result.DeletedQuizPlayers,
+	)
+	return result, nil
+}
+
+func (uc *DataSubjectUseCase) audit(ctx context.Context, action, voterID string, changes interface{}) error {
+	payload, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	return uc.auditRepo.Append(ctx, &entity.AuditEvent{
+		Actor:     "voter:" + uc.ipHasher.Pseudonym(voterID),
+		Action:    action,
+		Changes:   string(payload),
+		RequestID: logger.RequestIDFromContext(ctx),
+		CreatedAt: uc.now().UTC(),
+	})
+}
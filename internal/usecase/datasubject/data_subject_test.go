@@ -0,0 +1,239 @@
+package datasubject_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/datasubject"
+)
+
+func TestDataSubjectUseCase_Export(t *testing.T) {
+	subjectRepo := new(mocks.MockDataSubjectRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+	useCase := datasubject.NewDataSubjectUseCase(subjectRepo, auditRepo, nil)
+
+	// Les données déjà passées par la rétention sont aussi retrouvées
+	identities := []string{"api-key:ci", "203.0.113.7", privacy.Pseudonymize("203.0.113.7")}
+	pollID := uuid.New()
+	optionID := uuid.New()
+	subjectRepo.On("FindVotes", mock.Anything, identities).Return([]*entity.Vote{{
+		PollID:    pollID,
+		OptionID:  optionID,
+		IPAddress: "198.51.100.4",
+		UserAgent: "Mozilla/5.0",
+		Poll:      &entity.Poll{ID: pollID, Title: "Favorite language"},
+		Option:    &entity.Option{ID: optionID, Text: "Go"},
+	}}, nil)
+	subjectRepo.On("FindPolls", mock.Anything, identities).Return(nil, nil)
+	subjectRepo.On("FindSurveys", mock.Anything, identities).Return([]*entity.Survey{{Title: "Team offsite"}}, nil)
+	subjectRepo.On("FindQuizzes", mock.Anything, identities).Return(nil, nil)
+	subjectRepo.On("FindTextResponses", mock.Anything, identities).Return([]*entity.TextResponse{{
+		PollID: pollID,
+		Text:   "Faster builds",
+		Status: entity.ResponseApproved,
+		Poll:   &entity.Poll{ID: pollID, Title: "What should we improve?"},
+	}}, nil)
+	subjectRepo.On("FindEstimates", mock.Anything, identities).Return([]*entity.Estimate{{
+		PollID: pollID,
+		Value:  8,
+		Poll:   &entity.Poll{ID: pollID, Title: "How many story points?"},
+	}}, nil)
+	subjectRepo.On("FindAvailabilities", mock.Anything, identities).Return([]*entity.Availability{{
+		PollID:   pollID,
+		OptionID: optionID,
+		Name:     "Alice",
+		Answer:   entity.AvailabilityIfNeedBe,
+		Poll:     &entity.Poll{ID: pollID, Title: "Sprint review"},
+		Option:   &entity.Option{ID: optionID, Text: "Mon 15 Jan 2024 10:00–11:00 CET"},
+	}}, nil)
+	subjectRepo.On("FindWaitlistEntries", mock.Anything, identities).Return([]*entity.WaitlistEntry{{
+		PollID:   pollID,
+		OptionID: optionID,
+		Poll:     &entity.Poll{ID: pollID, Title: "Workshop sign-up"},
+		Option:   &entity.Option{ID: optionID, Text: "Kubernetes 101"},
+	}}, nil)
+	subjectRepo.On("FindQuizPlayers", mock.Anything, identities).Return([]*entity.QuizPlayer{{
+		Nickname: "Ada",
+		Quiz:     &entity.Quiz{Title: "Onboarding quiz"},
+		Answers:  []entity.QuizAnswer{{Points: 900, Correct: true}, {Points: 0}},
+	}}, nil)
+	auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+		return event.Action == entity.AuditDataExported &&
+			strings.HasPrefix(event.Actor, "voter:sha256:") &&
+			!strings.Contains(event.Actor, "203.0.113.7")
+	})).Return(nil)
+
+	export, err := useCase.Export(authenticated(), "203.0.113.7")
+
+	require.NoError(t, err)
+	require.Len(t, export.Votes, 1)
+	assert.Equal(t, "Favorite language", export.Votes[0].PollTitle)
+	assert.Equal(t, "Go", export.Votes[0].OptionText)
+	// Une adresse partagée ne livre pas l'IP ni le navigateur des autres votants
+	exported, err := json.Marshal(export)
+	require.NoError(t, err)
+	assert.NotContains(t, string(exported), "198.51.100.4")
+	assert.NotContains(t, string(exported), "Mozilla")
+	assert.NotNil(t, export.Polls)
+	require.Len(t, export.Surveys, 1)
+	assert.Equal(t, "Team offsite", export.Surveys[0].Title)
+	assert.NotNil(t, export.Quizzes)
+	require.Len(t, export.Responses, 1)
+	assert.Equal(t, "What should we improve?", export.Responses[0].PollTitle)
+	assert.Equal(t, "Faster builds", export.Responses[0].Text)
+	require.Len(t, export.Estimates, 1)
+	assert.Equal(t, "How many story points?", export.Estimates[0].PollTitle)
+	assert.Equal(t, 8.0, export.Estimates[0].Value)
+	require.Len(t, export.Availabilities, 1)
+	assert.Equal(t, "Sprint review", export.Availabilities[0].PollTitle)
+	assert.Equal(t, "Mon 15 Jan 2024 10:00–11:00 CET", export.Availabilities[0].Slot)
+	assert.Equal(t, "Alice", export.Availabilities[0].Name)
+	assert.Equal(t, entity.AvailabilityIfNeedBe, export.Availabilities[0].Answer)
+	require.Len(t, export.Waitlist, 1)
+	assert.Equal(t, "Workshop sign-up", export.Waitlist[0].PollTitle)
+	assert.Equal(t, "Kubernetes 101", export.Waitlist[0].OptionText)
+	require.Len(t, export.QuizPlayers, 1)
+	assert.Equal(t, "Onboarding quiz", export.QuizPlayers[0].QuizTitle)
+	assert.Equal(t, "Ada", export.QuizPlayers[0].Nickname)
+	assert.Equal(t, 2, export.QuizPlayers[0].Answers)
+	assert.Equal(t, 900, export.QuizPlayers[0].Score)
+	subjectRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+// authenticated returns a context carrying the principal of an API key
+func authenticated() context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Actor: "api-key:ci"})
+}
+
+func TestDataSubjectUseCase_RequiresAuthentication(t *testing.T) {
+	subjectRepo := new(mocks.MockDataSubjectRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+	useCase := datasubject.NewDataSubjectUseCase(subjectRepo, auditRepo, nil)
+
+	// Une adresse IP seule, partagée derrière un NAT ou un proxy, ne suffit pas
+	_, err := useCase.Export(context.Background(), "203.0.113.7")
+	assert.ErrorIs(t, err, datasubject.ErrAuthenticationRequired)
+	_, err = useCase.Erase(context.Background(), "203.0.113.7")
+	assert.ErrorIs(t, err, datasubject.ErrAuthenticationRequired)
+
+	subjectRepo.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything, mock.Anything)
+	subjectRepo.AssertNotCalled(t, "FindVotes", mock.Anything, mock.Anything)
+}
+
+func TestDataSubjectUseCase_Erase(t *testing.T) {
+	tests := []struct {
+		name     string
+		voterID  string
+		eraseErr error
+		auditErr error
+		wantErr  bool
+	}{
+		{
+			name:    "successful erasure",
+			voterID: "203.0.113.7",
+		},
+		{
+			name:     "audit failure does not fail the erasure",
+			voterID:  "203.0.113.7",
+			auditErr: errors.New("database error"),
+		},
+		{
+			name:     "repository error",
+			voterID:  "203.0.113.7",
+			eraseErr: errors.New("database error"),
+			wantErr:  true,
+		},
+		{
+			name:    "missing identity",
+			voterID: "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subjectRepo := new(mocks.MockDataSubjectRepository)
+			auditRepo := new(mocks.MockAuditRepository)
+			useCase := datasubject.NewDataSubjectUseCase(subjectRepo, auditRepo, nil)
+
+			if tt.voterID != "" {
+				if tt.eraseErr != nil {
+					subjectRepo.On("Erase", mock.Anything, []string{"api-key:ci", tt.voterID, privacy.Pseudonymize(tt.voterID)}, mock.Anything).Return(nil, tt.eraseErr)
+				} else {
+					subjectRepo.On("Erase", mock.Anything, []string{"api-key:ci", tt.voterID, privacy.Pseudonymize(tt.voterID)}, mock.Anything).
+						Return(&repository.ErasureResult{AnonymizedBallots: 1, ArchivedVotes: 2}, nil)
+					auditRepo.On("Append", mock.Anything, mock.AnythingOfType("*entity.AuditEvent")).Return(tt.auditErr)
+				}
+			}
+
+			result, err := useCase.Erase(authenticated(), tt.voterID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), result.AnonymizedBallots)
+				assert.Equal(t, int64(2), result.ArchivedVotes)
+			}
+			subjectRepo.AssertExpectations(t)
+			auditRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDataSubjectUseCase_EraseHashedIdentities(t *testing.T) {
+	subjectRepo := new(mocks.MockDataSubjectRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+	hasher := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"current", "previous"}})
+	useCase := datasubject.NewDataSubjectUseCase(subjectRepo, auditRepo, hasher)
+
+	subjectRepo.On("ListIPSalts", mock.Anything).Return([]string{"salt-a", "salt-b"}, nil)
+	subjectRepo.On("Erase", mock.Anything, mock.MatchedBy(func(identities []string) bool {
+		// Une identité par sel et par clé, jamais l'adresse en clair
+		return len(identities) == 5 && identities[0] == "api-key:ci" &&
+			identities[1] == hasher.Identity("salt-a", "203.0.113.7") &&
+			identities[3] == hasher.Identity("salt-b", "203.0.113.7")
+	}), mock.Anything).Return(&repository.ErasureResult{AnonymizedBallots: 1}, nil)
+	auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+		return strings.HasPrefix(event.Actor, "voter:"+privacy.HMACPrefix)
+	})).Return(nil)
+
+	result, err := useCase.Erase(authenticated(), "203.0.113.7")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.AnonymizedBallots)
+	subjectRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestDataSubjectUseCase_EraseAuthenticated(t *testing.T) {
+	subjectRepo := new(mocks.MockDataSubjectRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+	useCase := datasubject.NewDataSubjectUseCase(subjectRepo, auditRepo, nil)
+
+	// Les sondages créés sous le principal sont couverts en plus de ceux de l'adresse
+	subjectRepo.On("Erase", mock.Anything, []string{"api-key:ci", "203.0.113.7", privacy.Pseudonymize("203.0.113.7")}, mock.Anything).
+		Return(&repository.ErasureResult{AnonymizedPolls: 2}, nil)
+	auditRepo.On("Append", mock.Anything, mock.AnythingOfType("*entity.AuditEvent")).Return(nil)
+
+	result, err := useCase.Erase(authenticated(), "203.0.113.7")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.AnonymizedPolls)
+	subjectRepo.AssertExpectations(t)
+}
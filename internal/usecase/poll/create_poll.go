@@ -2,25 +2,67 @@ package poll
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"microservice-go-gin/internal/domain/apperror"
 	"microservice-go-gin/internal/domain/entity"
 	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+	"microservice-go-gin/internal/usecase/audit"
 )
 
 // CreatePollInput represents the input for creating a new poll
 type CreatePollInput struct {
 	Title       string   `json:"title" binding:"required,min=3,max=255" validate:"required,min=3,max=255" example:"What's your favorite programming language?"`
 	Description string   `json:"description" binding:"max=500" validate:"max=500" example:"Choose your preferred programming language"`
-	Options     []string `json:"options" binding:"required,min=2,max=10,dive,required,min=1,max=255" validate:"required,min=2,max=10,dive,required,min=1,max=255" example:"Go,Python,JavaScript,Rust"`
+	Options     []string `json:"options" binding:"omitempty,min=2,max=10,dive,required,min=1,max=255" validate:"omitempty,min=2,max=10,dive,required,min=1,max=255" example:"Go,Python,JavaScript,Rust"`
 	MultiChoice bool     `json:"multi_choice" example:"false"`
 	RequireAuth bool     `json:"require_auth" example:"false"`
 	ExpiresIn   *int     `json:"expires_in" validate:"omitempty,min=1,max=10080" example:"60"`
-	CreatedBy   string   `json:"-" validate:"max=100"`
+	// Language is the locale of the title, description and options,
+	// required to add translations
+	Language string `json:"language" example:"en"`
+	// Translations of the poll keyed by locale, e.g. "fr"
+	Translations map[string]TranslationInput `json:"translations"`
+	// Type is "choice", the default, "text" for free-text responses,
+	// "numeric" for estimates or "schedule" for time slots; only choice
+	// polls take options
+	Type string `json:"type" binding:"omitempty,oneof=choice text numeric schedule" example:"choice"`
+	// Moderated holds the responses of a text poll, or the write-in
+	// options, until the creator approves them
+	Moderated bool `json:"moderated" example:"false"`
+	// AllowWriteIn lets voters add an option of their own, up to 10 options
+	AllowWriteIn bool `json:"allow_write_in" example:"false"`
+	// Numeric sets the bounds, step and histogram buckets of a numeric poll
+	Numeric *entity.NumericSettings `json:"numeric"`
+	// Slots are the time slots of a scheduling poll, with their UTC offset
+	Slots []SlotInput `json:"slots"`
+	// TimeZone is the IANA time zone the slots of a scheduling poll are
+	// shown in, UTC by default
+	TimeZone string `json:"time_zone" example:"Europe/Paris"`
+	// MaxVotes turns a choice poll into a sign-up sheet: the capacity of each
+	// option, in the order of options, 0 for no limit
+	MaxVotes []int `json:"max_votes" example:"20,20,0"`
+	// Waitlist queues the voters of full options instead of turning them away
+	Waitlist bool `json:"waitlist" example:"false"`
+	// CreatedBy is the client IP address, ignored when the caller is
+	// authenticated: the poll is then attributed to its API key or JWT subject
+	CreatedBy string `json:"-" validate:"max=100"`
+}
+
+// SlotInput is a time slot of a scheduling poll
+type SlotInput struct {
+	StartsAt time.Time `json:"starts_at" example:"2024-01-15T10:00:00+01:00"`
+	EndsAt   time.Time `json:"ends_at" example:"2024-01-15T11:00:00+01:00"`
 }
 
 // CreatePollOutput represents the output after creating a poll
@@ -33,26 +75,69 @@ type CreatePollOutput struct {
 type CreatePollUseCase struct {
 	pollRepo repository.PollRepository
 	baseURL  string
+	ipHasher *privacy.IPHasher
+	recorder *audit.Recorder
 }
 
-func NewCreatePollUseCase(pollRepo repository.PollRepository, baseURL string) *CreatePollUseCase {
+// NewCreatePollUseCase creates the use case; ipHasher may be nil to keep
+// the creator IP address in clear
+func NewCreatePollUseCase(pollRepo repository.PollRepository, baseURL string, ipHasher *privacy.IPHasher, recorder *audit.Recorder) *CreatePollUseCase {
 	return &CreatePollUseCase{
 		pollRepo: pollRepo,
 		baseURL:  baseURL,
+		ipHasher: ipHasher,
+		recorder: recorder,
 	}
 }
 
-func (uc *CreatePollUseCase) Execute(ctx context.Context, input CreatePollInput) (*CreatePollOutput, error) {
+func (uc *CreatePollUseCase) Execute(ctx context.Context, input CreatePollInput) (_ *CreatePollOutput, err error) {
+	ctx, span := tracing.Start(ctx, "CreatePollUseCase.Execute")
+	defer func() { tracing.End(span, err) }()
+
 	if err := uc.validateInput(input); err != nil {
 		return nil, err
 	}
 
+	salt := entity.NewIPSalt()
 	poll := &entity.Poll{
 		Title:       input.Title,
 		Description: input.Description,
 		MultiChoice: input.MultiChoice,
 		RequireAuth: input.RequireAuth,
-		CreatedBy:   input.CreatedBy,
+		CreatedBy:   uc.ipHasher.Identity(salt, input.CreatedBy),
+		IPSalt:      salt,
+	}
+	poll.Type = entity.PollTypeChoice
+	poll.AllowWriteIn = input.AllowWriteIn
+	if input.Type == entity.PollTypeText || input.Type == entity.PollTypeNumeric || input.Type == entity.PollTypeSchedule {
+		poll.Type = input.Type
+		poll.MultiChoice = false
+		poll.AllowWriteIn = false
+	}
+	if poll.IsNumeric() {
+		settings := *input.Numeric
+		if settings.Buckets == 0 {
+			settings.Buckets = entity.DefaultBuckets
+		}
+		poll.Numeric = &settings
+	}
+	// Créneaux enregistrés en UTC, libellés dans le fuseau horaire du sondage
+	if poll.IsSchedule() {
+		poll.TimeZone = input.TimeZone
+		location := poll.Location()
+		for i, slot := range input.Slots {
+			startsAt, endsAt := slot.StartsAt.UTC(), slot.EndsAt.UTC()
+			poll.Options = append(poll.Options, entity.Option{
+				Text:     entity.SlotLabel(startsAt, endsAt, location),
+				Order:    i,
+				StartsAt: &startsAt,
+				EndsAt:   &endsAt,
+			})
+		}
+	}
+	poll.Moderated = input.Moderated && (poll.IsText() || poll.AllowWriteIn)
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		poll.CreatedBy = principal.Actor
 	}
 
 	if input.ExpiresIn != nil && *input.ExpiresIn > 0 {
@@ -65,13 +150,68 @@ func (uc *CreatePollUseCase) Execute(ctx context.Context, input CreatePollInput)
 			Text:  optionText,
 			Order: i,
 		}
+		if i < len(input.MaxVotes) && input.MaxVotes[i] > 0 {
+			maxVotes := input.MaxVotes[i]
+			option.MaxVotes = &maxVotes
+		}
 		poll.Options = append(poll.Options, option)
 	}
+	poll.Waitlist = input.Waitlist && poll.HasCapacity()
+
+	lang, translations, _ := normalizeTranslations(input.Language, input.Translations, len(input.Options))
+	applyTranslations(poll, poll.Options, lang, translations)
 
 	if err := uc.pollRepo.Create(ctx, poll); err != nil {
 		return nil, err
 	}
 
+	changes := audit.Diff{}
+	changes.Set("title", nil, poll.Title)
+	changes.Set("description", nil, poll.Description)
+	changes.Set("options", nil, input.Options)
+	changes.Set("expires_at", nil, poll.ExpiresAt)
+	if poll.Type != entity.PollTypeChoice {
+		changes.Set("type", nil, poll.Type)
+	}
+	if poll.Numeric != nil {
+		changes.Set("numeric", nil, poll.Numeric)
+	}
+	if poll.IsSchedule() {
+		changes.Set("slots", nil, input.Slots)
+		if poll.TimeZone != "" {
+			changes.Set("time_zone", nil, poll.TimeZone)
+		}
+	}
+	if poll.HasCapacity() {
+		changes.Set("max_votes", nil, input.MaxVotes)
+	}
+	if poll.Waitlist {
+		changes.Set("waitlist", nil, poll.Waitlist)
+	}
+	if poll.AllowWriteIn {
+		changes.Set("allow_write_in", nil, poll.AllowWriteIn)
+	}
+	if poll.Moderated {
+		changes.Set("moderated", nil, poll.Moderated)
+	}
+	if poll.Language != "" {
+		changes.Set("language", nil, poll.Language)
+	}
+	if len(translations) > 0 {
+		changes.Set("translations", nil, translations)
+	}
+	uc.recorder.Record(ctx, audit.Event{
+		Actor:   creatorActor(ctx, uc.ipHasher, input.CreatedBy),
+		Action:  entity.AuditPollCreated,
+		Target:  audit.PollTarget(poll.ID),
+		PollID:  &poll.ID,
+		Changes: changes,
+	})
+
+	metrics.PollsCreated.Inc()
+	span.SetAttributes(attribute.String("poll.id", poll.ID.String()))
+	slog.InfoContext(ctx, "poll created", "poll_id", poll.ID, "options", len(poll.Options))
+
 	output := &CreatePollOutput{
 		ID:        poll.ID,
 		ShareURL:  uc.baseURL + "/poll/" + poll.ID.String(),
@@ -83,66 +223,181 @@ func (uc *CreatePollUseCase) Execute(ctx context.Context, input CreatePollInput)
 
 // validateInput validates the poll creation input
 func (uc *CreatePollUseCase) validateInput(input CreatePollInput) error {
-	var validationErrors []string
+	var fields []apperror.FieldError
+	reject := func(field, rule, param, message string) {
+		fields = append(fields, apperror.FieldError{Field: field, Rule: rule, Param: param, Message: message})
+	}
 
 	// Validate title
 	if strings.TrimSpace(input.Title) == "" {
-		validationErrors = append(validationErrors, "title cannot be empty")
+		reject("title", "required", "", "title cannot be empty")
 	}
 	if len(input.Title) < 3 {
-		validationErrors = append(validationErrors, "title must be at least 3 characters long")
+		reject("title", "min", "3", "title must be at least 3 characters long")
 	}
 	if len(input.Title) > 255 {
-		validationErrors = append(validationErrors, "title must be no more than 255 characters long")
+		reject("title", "max", "255", "title must be no more than 255 characters long")
 	}
 
 	// Validate description
 	if len(input.Description) > 500 {
-		validationErrors = append(validationErrors, "description must be no more than 500 characters long")
+		reject("description", "max", "500", "description must be no more than 500 characters long")
 	}
 
 	// Validate options
-	if len(input.Options) < 2 {
-		validationErrors = append(validationErrors, "poll must have at least 2 options")
+	if input.Type == entity.PollTypeText || input.Type == entity.PollTypeNumeric || input.Type == entity.PollTypeSchedule {
+		if len(input.Options) > 0 {
+			reject("options", "excluded", "", "only choice polls take options")
+		}
+	} else if len(input.Options) == 0 {
+		reject("options", "required", "", "options are required")
+	} else if len(input.Options) < 2 {
+		reject("options", "min", "2", "poll must have at least 2 options")
 	}
-	if len(input.Options) > 10 {
-		validationErrors = append(validationErrors, "poll can have at most 10 options")
+	if len(input.Options) > entity.MaxOptions {
+		reject("options", "max", "10", "poll can have at most 10 options")
 	}
 
 	// Validate each option
 	for i, option := range input.Options {
+		field := fmt.Sprintf("options[%d]", i)
 		if strings.TrimSpace(option) == "" {
-			validationErrors = append(validationErrors, fmt.Sprintf("option %d cannot be empty", i+1))
+			reject(field, "required", "", fmt.Sprintf("option %d cannot be empty", i+1))
 		}
 		if len(option) > 255 {
-			validationErrors = append(validationErrors, fmt.Sprintf("option %d must be no more than 255 characters long", i+1))
+			reject(field, "max", "255", fmt.Sprintf("option %d must be no more than 255 characters long", i+1))
 		}
 	}
 
 	// Check for duplicate options
 	optionMap := make(map[string]bool)
-	for _, option := range input.Options {
-		cleanOption := strings.TrimSpace(strings.ToLower(option))
+	for i, option := range input.Options {
+		cleanOption := entity.OptionKey(option)
 		if optionMap[cleanOption] {
-			validationErrors = append(validationErrors, "duplicate options are not allowed")
+			reject(fmt.Sprintf("options[%d]", i), "unique", "", "duplicate options are not allowed")
 			break
 		}
 		optionMap[cleanOption] = true
 	}
 
+	// Validate capacities
+	if len(input.MaxVotes) > 0 {
+		if input.Type != "" && input.Type != entity.PollTypeChoice {
+			reject("max_votes", "excluded", "", "only choice polls take capacities")
+		} else if len(input.MaxVotes) != len(input.Options) {
+			param := strconv.Itoa(len(input.Options))
+			reject("max_votes", "len", param, "max_votes must give a capacity for each of the "+param+" options")
+		}
+		for i, maxVotes := range input.MaxVotes {
+			if maxVotes < 0 {
+				reject(fmt.Sprintf("max_votes[%d]", i), "min", "0", fmt.Sprintf("capacity %d cannot be negative", i+1))
+			}
+		}
+	}
+
+	// Validate numeric settings
+	if input.Type == entity.PollTypeNumeric {
+		fields = append(fields, validateNumeric(input.Numeric)...)
+	} else if input.Numeric != nil {
+		reject("numeric", "excluded", "", "only numeric polls take numeric settings")
+	}
+
+	// Validate time slots
+	if input.Type == entity.PollTypeSchedule {
+		fields = append(fields, validateSlots(input.Slots, input.TimeZone)...)
+	} else {
+		if len(input.Slots) > 0 {
+			reject("slots", "excluded", "", "only scheduling polls take time slots")
+		}
+		if input.TimeZone != "" {
+			reject("time_zone", "excluded", "", "only scheduling polls take a time zone")
+		}
+	}
+
 	// Validate expires_in
 	if input.ExpiresIn != nil {
 		if *input.ExpiresIn < 1 {
-			validationErrors = append(validationErrors, "expires_in must be at least 1 minute")
+			reject("expires_in", "min", "1", "expires_in must be at least 1 minute")
 		}
 		if *input.ExpiresIn > 10080 { // 1 week in minutes
-			validationErrors = append(validationErrors, "expires_in cannot be more than 1 week (10080 minutes)")
+			reject("expires_in", "max", "10080", "expires_in cannot be more than 1 week (10080 minutes)")
 		}
 	}
 
-	if len(validationErrors) > 0 {
-		return errors.New(strings.Join(validationErrors, "; "))
+	// Validate translations
+	_, _, translationErrors := normalizeTranslations(input.Language, input.Translations, len(input.Options))
+	fields = append(fields, translationErrors...)
+
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// validateNumeric checks the bounds, step and histogram buckets of a numeric poll
+func validateNumeric(settings *entity.NumericSettings) []apperror.FieldError {
+	if settings == nil {
+		return []apperror.FieldError{{Field: "numeric", Rule: "required", Message: "numeric polls need numeric settings"}}
+	}
+	var fields []apperror.FieldError
+	if settings.Max <= settings.Min {
+		fields = append(fields, apperror.FieldError{Field: "numeric.max", Rule: "gtfield", Param: "min", Message: "max must be greater than min"})
+	}
+	if settings.Step < 0 {
+		fields = append(fields, apperror.FieldError{Field: "numeric.step", Rule: "min", Param: "0", Message: "step cannot be negative"})
+	} else if settings.Max > settings.Min && settings.Step > settings.Max-settings.Min {
+		param := strconv.FormatFloat(settings.Max-settings.Min, 'f', -1, 64)
+		fields = append(fields, apperror.FieldError{Field: "numeric.step", Rule: "max", Param: param, Message: "step must be no more than " + param})
+	}
+	if settings.Buckets < 0 {
+		fields = append(fields, apperror.FieldError{Field: "numeric.buckets", Rule: "min", Param: "1", Message: "buckets must be at least 1"})
+	}
+	if settings.Buckets > entity.MaxBuckets {
+		fields = append(fields, apperror.FieldError{Field: "numeric.buckets", Rule: "max", Param: strconv.Itoa(entity.MaxBuckets), Message: "buckets must be no more than 50"})
+	}
+	return fields
+}
+
+// validateSlots checks the time slots and the time zone of a scheduling poll
+func validateSlots(slots []SlotInput, timeZone string) []apperror.FieldError {
+	var fields []apperror.FieldError
+	reject := func(field, rule, param, message string) {
+		fields = append(fields, apperror.FieldError{Field: field, Rule: rule, Param: param, Message: message})
+	}
+
+	if len(slots) == 0 {
+		reject("slots", "required", "", "scheduling polls need time slots")
+	} else if len(slots) < 2 {
+		reject("slots", "min", "2", "poll must have at least 2 time slots")
+	}
+	if len(slots) > entity.MaxOptions {
+		reject("slots", "max", "10", "poll can have at most 10 time slots")
+	}
+
+	seen := make(map[[2]int64]bool, len(slots))
+	for i, slot := range slots {
+		field := fmt.Sprintf("slots[%d]", i)
+		if slot.StartsAt.IsZero() {
+			reject(field+".starts_at", "required", "", fmt.Sprintf("time slot %d needs a start time", i+1))
+		}
+		if slot.EndsAt.IsZero() {
+			reject(field+".ends_at", "required", "", fmt.Sprintf("time slot %d needs an end time", i+1))
+		} else if !slot.EndsAt.After(slot.StartsAt) {
+			reject(field+".ends_at", "gtfield", "starts_at", fmt.Sprintf("time slot %d must end after it starts", i+1))
+		}
+		key := [2]int64{slot.StartsAt.UnixNano(), slot.EndsAt.UnixNano()}
+		if seen[key] {
+			reject(field, "unique", "", "duplicate time slots are not allowed")
+		}
+		seen[key] = true
+	}
+
+	// "Local" dépendrait du fuseau horaire du serveur
+	if timeZone != "" {
+		if _, err := time.LoadLocation(timeZone); err != nil || timeZone == "Local" {
+			reject("time_zone", "timezone", "", "time_zone must be an IANA time zone such as Europe/Paris")
+		}
+	}
+	return fields
+}
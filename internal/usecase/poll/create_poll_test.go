@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/apperror"
 	"microservice-go-gin/internal/domain/entity"
 	"microservice-go-gin/internal/domain/repository/mocks"
 	"microservice-go-gin/internal/usecase/poll"
@@ -63,7 +64,7 @@ func TestCreatePollUseCase_Execute(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(mocks.MockPollRepository)
-			useCase := poll.NewCreatePollUseCase(mockRepo, "http://localhost:8080")
+			useCase := poll.NewCreatePollUseCase(mockRepo, "http://localhost:8080", nil, nil)
 
 			if !tt.wantErr {
 				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Poll")).
@@ -106,6 +107,313 @@ func TestCreatePollUseCase_Execute(t *testing.T) {
 	}
 }
 
+func TestCreatePollUseCase_Translations(t *testing.T) {
+	t.Run("translations share the options", func(t *testing.T) {
+		mockRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewCreatePollUseCase(mockRepo, "http://localhost:8080", nil, nil)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Poll) bool {
+			return p.Language == "en" &&
+				p.Translations["fr-CA"].Title == "Langage préféré ?" &&
+				len(p.Options) == 2 &&
+				p.Options[0].Translations["fr-CA"] == "Aller" &&
+				p.Options[1].Translations["fr-CA"] == "Rouille"
+		})).Return(nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "Favorite language?",
+			Options:  []string{"Go", "Rust"},
+			Language: "en",
+			Translations: map[string]poll.TranslationInput{
+				"fr-ca": {Title: "Langage préféré ?", Options: []string{"Aller", "Rouille"}},
+			},
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid translations are rejected field by field", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "Favorite language?",
+			Options:  []string{"Go", "Rust"},
+			Language: "en",
+			Translations: map[string]poll.TranslationInput{
+				"fr": {Title: "", Options: []string{"Aller"}},
+			},
+		})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, apperror.CodeValidation, validation.Code)
+		fields := map[string]string{}
+		for _, field := range validation.Fields {
+			fields[field.Field] = field.Rule
+		}
+		assert.Equal(t, map[string]string{
+			"translations[fr].title":   "required",
+			"translations[fr].options": "len",
+		}, fields)
+	})
+
+	t.Run("translations need the poll language", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:   "Favorite language?",
+			Options: []string{"Go", "Rust"},
+			Translations: map[string]poll.TranslationInput{
+				"fr": {Title: "Langage préféré ?", Options: []string{"Aller", "Rouille"}},
+			},
+		})
+
+		assert.EqualError(t, err, "language is required to add translations")
+	})
+}
+
+func TestCreatePollUseCase_TextPoll(t *testing.T) {
+	t.Run("text poll without options", func(t *testing.T) {
+		mockRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewCreatePollUseCase(mockRepo, "http://localhost:8080", nil, nil)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Poll) bool {
+			return p.Type == entity.PollTypeText && p.Moderated && len(p.Options) == 0
+		})).Return(nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:     "What should we improve?",
+			Type:      entity.PollTypeText,
+			Moderated: true,
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("text poll with options", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:   "What should we improve?",
+			Type:    entity.PollTypeText,
+			Options: []string{"Go", "Rust"},
+		})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, []apperror.FieldError{{Field: "options", Rule: "excluded", Message: "only choice polls take options"}}, validation.Fields)
+	})
+
+	t.Run("numeric poll with inverted bounds", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:   "How many story points?",
+			Type:    entity.PollTypeNumeric,
+			Numeric: &entity.NumericSettings{Min: 10, Max: 1, Buckets: 60},
+		})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, []apperror.FieldError{
+			{Field: "numeric.max", Rule: "gtfield", Param: "min", Message: "max must be greater than min"},
+			{Field: "numeric.buckets", Rule: "max", Param: "50", Message: "buckets must be no more than 50"},
+		}, validation.Fields)
+	})
+
+	t.Run("numeric poll without settings", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{Title: "Q3 revenue?", Type: entity.PollTypeNumeric})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, "numeric", validation.Fields[0].Field)
+		assert.Equal(t, "required", validation.Fields[0].Rule)
+	})
+
+	t.Run("numeric poll gets the default buckets", func(t *testing.T) {
+		mockRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewCreatePollUseCase(mockRepo, "http://localhost:8080", nil, nil)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Poll) bool {
+			return p.IsNumeric() && len(p.Options) == 0 && p.Numeric.Buckets == entity.DefaultBuckets
+		})).Return(nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:   "How many story points?",
+			Type:    entity.PollTypeNumeric,
+			Numeric: &entity.NumericSettings{Min: 0, Max: 21, Step: 1, HideUntilReveal: true},
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("choice poll without options", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{Title: "Favorite language?"})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, "options", validation.Fields[0].Field)
+		assert.Equal(t, "required", validation.Fields[0].Rule)
+	})
+}
+
+func TestCreatePollUseCase_SchedulePoll(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	t.Run("slots are stored in UTC and labelled in the time zone of the poll", func(t *testing.T) {
+		if _, err := time.LoadLocation("America/New_York"); err != nil {
+			t.Skip("time zone database unavailable")
+		}
+		mockRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewCreatePollUseCase(mockRepo, "http://localhost:8080", nil, nil)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Poll) bool {
+			return p.IsSchedule() && p.TimeZone == "America/New_York" && len(p.Options) == 2 &&
+				p.Options[0].StartsAt.Location() == time.UTC &&
+				p.Options[0].StartsAt.Equal(monday) &&
+				p.Options[0].Text == "Mon 15 Jan 2024 04:00–05:00 EST" &&
+				p.Options[1].Order == 1
+		})).Return(nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "Sprint review",
+			Type:     entity.PollTypeSchedule,
+			TimeZone: "America/New_York",
+			Slots: []poll.SlotInput{
+				{StartsAt: monday, EndsAt: monday.Add(time.Hour)},
+				{StartsAt: monday.Add(24 * time.Hour), EndsAt: monday.Add(25 * time.Hour)},
+			},
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid slots and time zone", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "Sprint review",
+			Type:     entity.PollTypeSchedule,
+			TimeZone: "Mars/Olympus_Mons",
+			Slots: []poll.SlotInput{
+				{StartsAt: monday, EndsAt: monday.Add(time.Hour)},
+				{StartsAt: monday, EndsAt: monday},
+				{EndsAt: monday},
+				{StartsAt: monday, EndsAt: monday.Add(time.Hour)},
+			},
+		})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, []apperror.FieldError{
+			{Field: "slots[1].ends_at", Rule: "gtfield", Param: "starts_at", Message: "time slot 2 must end after it starts"},
+			{Field: "slots[2].starts_at", Rule: "required", Message: "time slot 3 needs a start time"},
+			{Field: "slots[3]", Rule: "unique", Message: "duplicate time slots are not allowed"},
+			{Field: "time_zone", Rule: "timezone", Message: "time_zone must be an IANA time zone such as Europe/Paris"},
+		}, validation.Fields)
+	})
+
+	t.Run("only scheduling polls take slots", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "Favorite language?",
+			Options:  []string{"Go", "Rust"},
+			TimeZone: "Europe/Paris",
+			Slots:    []poll.SlotInput{{StartsAt: monday, EndsAt: monday.Add(time.Hour)}},
+		})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, []apperror.FieldError{
+			{Field: "slots", Rule: "excluded", Message: "only scheduling polls take time slots"},
+			{Field: "time_zone", Rule: "excluded", Message: "only scheduling polls take a time zone"},
+		}, validation.Fields)
+	})
+}
+
+func TestCreatePollUseCase_SignUpSheet(t *testing.T) {
+	t.Run("options get their capacity, 0 for no limit", func(t *testing.T) {
+		mockRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewCreatePollUseCase(mockRepo, "http://localhost:8080", nil, nil)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Poll) bool {
+			return p.Waitlist && len(p.Options) == 2 &&
+				p.Options[0].MaxVotes != nil && *p.Options[0].MaxVotes == 12 &&
+				p.Options[1].MaxVotes == nil
+		})).Return(nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "Workshops",
+			Options:  []string{"Kubernetes 101", "Go tour"},
+			MaxVotes: []int{12, 0},
+			Waitlist: true,
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("a waitlist needs a capacity", func(t *testing.T) {
+		mockRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewCreatePollUseCase(mockRepo, "http://localhost:8080", nil, nil)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Poll) bool {
+			return !p.Waitlist && !p.HasCapacity()
+		})).Return(nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "Favorite language?",
+			Options:  []string{"Go", "Rust"},
+			Waitlist: true,
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("one capacity per option, none negative", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "Workshops",
+			Options:  []string{"Kubernetes 101", "Go tour", "Rust intro"},
+			MaxVotes: []int{12, -1},
+		})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, []apperror.FieldError{
+			{Field: "max_votes", Rule: "len", Param: "3", Message: "max_votes must give a capacity for each of the 3 options"},
+			{Field: "max_votes[1]", Rule: "min", Param: "0", Message: "capacity 2 cannot be negative"},
+		}, validation.Fields)
+	})
+
+	t.Run("only choice polls take capacities", func(t *testing.T) {
+		useCase := poll.NewCreatePollUseCase(new(mocks.MockPollRepository), "http://localhost:8080", nil, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.CreatePollInput{
+			Title:    "What should we improve?",
+			Type:     entity.PollTypeText,
+			MaxVotes: []int{10},
+		})
+
+		validation, ok := apperror.As(err)
+		assert.True(t, ok)
+		assert.Equal(t, []apperror.FieldError{
+			{Field: "max_votes", Rule: "excluded", Message: "only choice polls take capacities"},
+		}, validation.Fields)
+	})
+}
+
 func intPtr(i int) *int {
 	return &i
 }
\ No newline at end of file
@@ -0,0 +1,88 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/numstat"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+// RevealUseCase lets the creator of a planning poker poll reveal the
+// estimates once everyone has voted
+type RevealUseCase struct {
+	pollRepo     repository.PollRepository
+	estimateRepo repository.EstimateRepository
+	recorder     *audit.Recorder
+	ipHasher     *privacy.IPHasher
+	now          func() time.Time
+}
+
+func NewRevealUseCase(pollRepo repository.PollRepository, estimateRepo repository.EstimateRepository, recorder *audit.Recorder, ipHasher *privacy.IPHasher) *RevealUseCase {
+	return &RevealUseCase{
+		pollRepo:     pollRepo,
+		estimateRepo: estimateRepo,
+		recorder:     recorder,
+		ipHasher:     ipHasher,
+		now:          time.Now,
+	}
+}
+
+// Execute reveals the estimates of a poll and returns their distribution.
+// Estimates of polls that do not hide them are already public.
+func (uc *RevealUseCase) Execute(ctx context.Context, pollID uuid.UUID, requester string) (_ *entity.EstimateResults, err error) {
+	ctx, span := tracing.Start(ctx, "RevealUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", pollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	poll, err := uc.pollRepo.GetByID(ctx, pollID)
+	if errors.Is(err, entity.ErrPollNotFound) {
+		return nil, ErrPollNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !uc.ipHasher.IsCreator(ctx, poll.CreatedBy, poll.IPSalt, requester) {
+		return nil, ErrNotPollCreator
+	}
+	if !poll.IsNumeric() {
+		return nil, entity.ErrNotNumericPoll
+	}
+	if !poll.EstimatesHidden() {
+		return nil, entity.ErrAlreadyRevealed
+	}
+
+	revealedAt := uc.now().UTC()
+	if err := uc.pollRepo.Reveal(ctx, poll.ID, revealedAt); err != nil {
+		return nil, err
+	}
+	poll.RevealedAt = &revealedAt
+
+	values, err := uc.estimateRepo.ListValues(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := audit.Diff{}
+	changes.Set("revealed_at", nil, revealedAt)
+	uc.recorder.Record(ctx, audit.Event{
+		Actor:   creatorActor(ctx, uc.ipHasher, requester),
+		Action:  entity.AuditPollRevealed,
+		Target:  audit.PollTarget(poll.ID),
+		PollID:  &poll.ID,
+		Changes: changes,
+	})
+	slog.InfoContext(ctx, "estimates revealed", "poll_id", poll.ID, "estimates", len(values))
+
+	return numstat.Results(poll, values), nil
+}
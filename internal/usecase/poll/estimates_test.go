@@ -0,0 +1,79 @@
+package poll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/audit"
+	"microservice-go-gin/internal/usecase/poll"
+)
+
+func newPlanningPoker() *entity.Poll {
+	return &entity.Poll{
+		ID:        uuid.New(),
+		Title:     "How many story points?",
+		Type:      entity.PollTypeNumeric,
+		Numeric:   &entity.NumericSettings{Min: 0, Max: 13, Buckets: 13, HideUntilReveal: true},
+		CreatedBy: "203.0.113.7",
+	}
+}
+
+func TestRevealUseCase_Execute(t *testing.T) {
+	t.Run("creator reveals the distribution and the reveal is audited", func(t *testing.T) {
+		planningPoker := newPlanningPoker()
+		pollRepo := new(mocks.MockPollRepository)
+		estimateRepo := new(mocks.MockEstimateRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewRevealUseCase(pollRepo, estimateRepo, audit.NewRecorder(auditRepo), nil)
+
+		pollRepo.On("GetByID", mock.Anything, planningPoker.ID).Return(planningPoker, nil)
+		pollRepo.On("Reveal", mock.Anything, planningPoker.ID, mock.AnythingOfType("time.Time")).Return(nil)
+		estimateRepo.On("ListValues", mock.Anything, planningPoker.ID).Return([]float64{3, 5, 8}, nil)
+		auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+			return event.Action == entity.AuditPollRevealed
+		})).Return(nil)
+
+		results, err := useCase.Execute(context.Background(), planningPoker.ID, "203.0.113.7")
+
+		require.NoError(t, err)
+		assert.False(t, results.Hidden)
+		assert.Equal(t, 3, results.Count)
+		assert.Equal(t, 5.0, results.Stats.Median)
+		pollRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("only the creator can reveal", func(t *testing.T) {
+		planningPoker := newPlanningPoker()
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewRevealUseCase(pollRepo, new(mocks.MockEstimateRepository), nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, planningPoker.ID).Return(planningPoker, nil)
+
+		_, err := useCase.Execute(context.Background(), planningPoker.ID, "198.51.100.1")
+
+		assert.ErrorIs(t, err, poll.ErrNotPollCreator)
+		pollRepo.AssertNotCalled(t, "Reveal", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("estimates already revealed", func(t *testing.T) {
+		planningPoker := newPlanningPoker()
+		revealedAt := time.Now()
+		planningPoker.RevealedAt = &revealedAt
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewRevealUseCase(pollRepo, new(mocks.MockEstimateRepository), nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, planningPoker.ID).Return(planningPoker, nil)
+
+		_, err := useCase.Execute(context.Background(), planningPoker.ID, "203.0.113.7")
+
+		assert.ErrorIs(t, err, entity.ErrAlreadyRevealed)
+	})
+}
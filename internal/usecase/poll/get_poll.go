@@ -4,27 +4,105 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"microservice-go-gin/internal/domain/entity"
 	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/numstat"
+	"microservice-go-gin/internal/infrastructure/textstat"
+	"microservice-go-gin/internal/infrastructure/tracing"
 )
 
+// maxTerms bounds the word cloud of a text poll
+const maxTerms = 50
+
 type GetPollUseCase struct {
-	pollRepo repository.PollRepository
-	voteRepo repository.VoteRepository
+	pollRepo     repository.PollRepository
+	voteRepo     repository.VoteRepository
+	responseRepo repository.TextResponseRepository
+	estimateRepo repository.EstimateRepository
+
+	availabilityRepo repository.AvailabilityRepository
 }
 
-func NewGetPollUseCase(pollRepo repository.PollRepository, voteRepo repository.VoteRepository) *GetPollUseCase {
+func NewGetPollUseCase(pollRepo repository.PollRepository, voteRepo repository.VoteRepository, responseRepo repository.TextResponseRepository, estimateRepo repository.EstimateRepository, availabilityRepo repository.AvailabilityRepository) *GetPollUseCase {
 	return &GetPollUseCase{
-		pollRepo: pollRepo,
-		voteRepo: voteRepo,
+		pollRepo:     pollRepo,
+		voteRepo:     voteRepo,
+		responseRepo: responseRepo,
+		estimateRepo: estimateRepo,
+
+		availabilityRepo: availabilityRepo,
 	}
 }
 
-func (uc *GetPollUseCase) Execute(ctx context.Context, pollID uuid.UUID) (*entity.Poll, error) {
+func (uc *GetPollUseCase) Execute(ctx context.Context, pollID uuid.UUID) (_ *entity.Poll, err error) {
+	ctx, span := tracing.Start(ctx, "GetPollUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", pollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	poll, err := uc.pollRepo.GetByIDWithResults(ctx, pollID)
 	if err != nil {
 		return nil, err
 	}
+	poll.HidePendingOptions()
+	// Les bonnes réponses d'un quiz ne sont publiées que par le quiz, question terminée
+	if poll.QuizID != nil {
+		poll.HideCorrect()
+	}
+
+	// Sondage à réponse libre : nombre de réponses approuvées et nuage de mots
+	if poll.IsText() {
+		texts, err := uc.responseRepo.ListTexts(ctx, poll.ID, entity.ResponseApproved)
+		if err != nil {
+			return nil, err
+		}
+		poll.ResponseCount = len(texts)
+		poll.Terms = textstat.Frequencies(texts, maxTerms)
+	}
+
+	// Sondage numérique : distribution des estimations, masquée jusqu'à leur révélation
+	if poll.IsNumeric() {
+		values, err := uc.estimateRepo.ListValues(ctx, poll.ID)
+		if err != nil {
+			return nil, err
+		}
+		poll.Estimates = numstat.Results(poll, values)
+	}
+
+	// Sondage de planification : créneaux classés par disponibilité, dans le fuseau du sondage
+	if poll.IsSchedule() {
+		answers, err := uc.availabilityRepo.ListByPoll(ctx, poll.ID)
+		if err != nil {
+			return nil, err
+		}
+		location := poll.Location()
+		for i := range poll.Options {
+			if poll.Options[i].StartsAt != nil && poll.Options[i].EndsAt != nil {
+				startsAt, endsAt := poll.Options[i].StartsAt.In(location), poll.Options[i].EndsAt.In(location)
+				poll.Options[i].StartsAt, poll.Options[i].EndsAt = &startsAt, &endsAt
+			}
+		}
+		poll.Slots = entity.RankSlots(poll, answers)
+	}
 
 	return poll, nil
-}
\ No newline at end of file
+}
+
+// WinningSlot returns a scheduling poll with the slot the most
+// participants are available for
+func (uc *GetPollUseCase) WinningSlot(ctx context.Context, pollID uuid.UUID) (*entity.Poll, *entity.SlotResult, error) {
+	poll, err := uc.Execute(ctx, pollID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !poll.IsSchedule() {
+		return nil, nil, entity.ErrNotSchedulePoll
+	}
+	slot := poll.WinningSlot()
+	if slot == nil {
+		return nil, nil, entity.ErrNoAvailableSlot
+	}
+	return poll, slot, nil
+}
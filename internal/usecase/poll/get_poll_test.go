@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -64,7 +65,7 @@ func TestGetPollUseCase_Execute(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockPollRepo := new(mocks.MockPollRepository)
 			mockVoteRepo := new(mocks.MockVoteRepository)
-			useCase := poll.NewGetPollUseCase(mockPollRepo, mockVoteRepo)
+			useCase := poll.NewGetPollUseCase(mockPollRepo, mockVoteRepo, new(mocks.MockTextResponseRepository), new(mocks.MockEstimateRepository), new(mocks.MockAvailabilityRepository))
 
 			mockPollRepo.On("GetByIDWithResults", mock.Anything, tt.pollID).
 				Return(tt.mockPoll, tt.mockErr)
@@ -85,4 +86,113 @@ func TestGetPollUseCase_Execute(t *testing.T) {
 			mockPollRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestGetPollUseCase_TextPoll(t *testing.T) {
+	textPoll := &entity.Poll{ID: uuid.New(), Type: entity.PollTypeText}
+	mockPollRepo := new(mocks.MockPollRepository)
+	mockResponseRepo := new(mocks.MockTextResponseRepository)
+	useCase := poll.NewGetPollUseCase(mockPollRepo, new(mocks.MockVoteRepository), mockResponseRepo, new(mocks.MockEstimateRepository), new(mocks.MockAvailabilityRepository))
+
+	mockPollRepo.On("GetByIDWithResults", mock.Anything, textPoll.ID).Return(textPoll, nil)
+	mockResponseRepo.On("ListTexts", mock.Anything, textPoll.ID, entity.ResponseApproved).
+		Return([]string{"Faster builds", "Better docs", "faster CI"}, nil)
+
+	result, err := useCase.Execute(context.Background(), textPoll.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.ResponseCount)
+	assert.Equal(t, entity.Term{Term: "faster", Count: 2}, result.Terms[0])
+	mockResponseRepo.AssertExpectations(t)
+}
+
+func TestGetPollUseCase_NumericPoll(t *testing.T) {
+	numericPoll := &entity.Poll{
+		ID:      uuid.New(),
+		Type:    entity.PollTypeNumeric,
+		Numeric: &entity.NumericSettings{Min: 0, Max: 20, Buckets: 2, HideUntilReveal: true},
+	}
+	mockPollRepo := new(mocks.MockPollRepository)
+	mockEstimateRepo := new(mocks.MockEstimateRepository)
+	useCase := poll.NewGetPollUseCase(mockPollRepo, new(mocks.MockVoteRepository), new(mocks.MockTextResponseRepository), mockEstimateRepo, new(mocks.MockAvailabilityRepository))
+
+	mockPollRepo.On("GetByIDWithResults", mock.Anything, numericPoll.ID).Return(numericPoll, nil)
+	mockEstimateRepo.On("ListValues", mock.Anything, numericPoll.ID).Return([]float64{3, 5, 13}, nil)
+
+	result, err := useCase.Execute(context.Background(), numericPoll.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.EstimateResults{Count: 3, Hidden: true}, result.Estimates)
+
+	revealedAt := time.Now()
+	numericPoll.RevealedAt = &revealedAt
+	result, err = useCase.Execute(context.Background(), numericPoll.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, result.Estimates.Stats.Median)
+	assert.Equal(t, []entity.Bucket{{From: 0, To: 10, Count: 2}, {From: 10, To: 20, Count: 1}}, result.Estimates.Stats.Histogram)
+}
+
+func TestGetPollUseCase_SchedulePoll(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	slotID := uuid.New()
+	newSchedulePoll := func() *entity.Poll {
+		return &entity.Poll{
+			ID:       uuid.New(),
+			Type:     entity.PollTypeSchedule,
+			TimeZone: "Asia/Tokyo",
+			Options:  []entity.Option{{ID: slotID, StartsAt: &start, EndsAt: &end}},
+		}
+	}
+
+	t.Run("ranks the slots in the time zone of the poll", func(t *testing.T) {
+		if _, err := time.LoadLocation("Asia/Tokyo"); err != nil {
+			t.Skip("time zone database unavailable")
+		}
+		schedulePoll := newSchedulePoll()
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockAvailabilityRepo := new(mocks.MockAvailabilityRepository)
+		useCase := poll.NewGetPollUseCase(mockPollRepo, new(mocks.MockVoteRepository), new(mocks.MockTextResponseRepository), new(mocks.MockEstimateRepository), mockAvailabilityRepo)
+
+		mockPollRepo.On("GetByIDWithResults", mock.Anything, schedulePoll.ID).Return(schedulePoll, nil)
+		mockAvailabilityRepo.On("ListByPoll", mock.Anything, schedulePoll.ID).Return([]*entity.Availability{
+			{OptionID: slotID, Name: "Alice", Answer: entity.AvailabilityYes},
+		}, nil)
+
+		result, slot, err := useCase.WinningSlot(context.Background(), schedulePoll.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 18, result.Options[0].StartsAt.Hour())
+		assert.Equal(t, slotID, slot.OptionID)
+		assert.Equal(t, []string{"Alice"}, slot.Yes)
+		assert.True(t, slot.StartsAt.Equal(start))
+	})
+
+	t.Run("no winning slot before anyone is available", func(t *testing.T) {
+		schedulePoll := newSchedulePoll()
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockAvailabilityRepo := new(mocks.MockAvailabilityRepository)
+		useCase := poll.NewGetPollUseCase(mockPollRepo, new(mocks.MockVoteRepository), new(mocks.MockTextResponseRepository), new(mocks.MockEstimateRepository), mockAvailabilityRepo)
+
+		mockPollRepo.On("GetByIDWithResults", mock.Anything, schedulePoll.ID).Return(schedulePoll, nil)
+		mockAvailabilityRepo.On("ListByPoll", mock.Anything, schedulePoll.ID).Return([]*entity.Availability{
+			{OptionID: slotID, Name: "Alice", Answer: entity.AvailabilityNo},
+		}, nil)
+
+		_, _, err := useCase.WinningSlot(context.Background(), schedulePoll.ID)
+
+		assert.ErrorIs(t, err, entity.ErrNoAvailableSlot)
+	})
+
+	t.Run("choice polls have no slots", func(t *testing.T) {
+		choicePoll := &entity.Poll{ID: uuid.New(), Type: entity.PollTypeChoice}
+		mockPollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewGetPollUseCase(mockPollRepo, new(mocks.MockVoteRepository), new(mocks.MockTextResponseRepository), new(mocks.MockEstimateRepository), new(mocks.MockAvailabilityRepository))
+
+		mockPollRepo.On("GetByIDWithResults", mock.Anything, choicePoll.ID).Return(choicePoll, nil)
+
+		_, _, err := useCase.WinningSlot(context.Background(), choicePoll.ID)
+
+		assert.ErrorIs(t, err, entity.ErrNotSchedulePoll)
+	})
+}
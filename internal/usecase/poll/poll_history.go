@@ -0,0 +1,133 @@
+package poll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 200
+)
+
+// HistoryEntry is one action recorded in the audit log of a poll
+type HistoryEntry struct {
+	ID        uuid.UUID       `json:"id"`
+	Actor     string          `json:"actor" example:"creator:sha256:4f1c..."`
+	Action    string          `json:"action" example:"poll.updated"`
+	Changes   json.RawMessage `json:"changes,omitempty" swaggertype:"object"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// HistoryPage is a page of the audit log of a poll, oldest entry first
+type HistoryPage struct {
+	Entries []HistoryEntry `json:"entries"`
+	Total   int64          `json:"total"`
+	Offset  int            `json:"offset"`
+	Limit   int            `json:"limit"`
+}
+
+// HistoryQuery selects the page of history to return. Admin skips the
+// creator check and includes deleted polls.
+type HistoryQuery struct {
+	PollID    uuid.UUID
+	Requester string
+	Admin     bool
+	Offset    int
+	Limit     int
+}
+
+type PollHistoryUseCase struct {
+	pollRepo  repository.PollRepository
+	auditRepo repository.AuditRepository
+	ipHasher  *privacy.IPHasher
+}
+
+func NewPollHistoryUseCase(pollRepo repository.PollRepository, auditRepo repository.AuditRepository, ipHasher *privacy.IPHasher) *PollHistoryUseCase {
+	return &PollHistoryUseCase{
+		pollRepo:  pollRepo,
+		auditRepo: auditRepo,
+		ipHasher:  ipHasher,
+	}
+}
+
+func (uc *PollHistoryUseCase) Execute(ctx context.Context, query HistoryQuery) (_ *HistoryPage, err error) {
+	ctx, span := tracing.Start(ctx, "PollHistoryUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", query.PollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if !query.Admin {
+		poll, err := uc.pollRepo.GetByID(ctx, query.PollID)
+		if errors.Is(err, entity.ErrPollNotFound) {
+			return nil, ErrPollNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !uc.ipHasher.IsCreator(ctx, poll.CreatedBy, poll.IPSalt, query.Requester) {
+			return nil, ErrNotPollCreator
+		}
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultHistoryLimit
+	}
+	if query.Limit > maxHistoryLimit {
+		query.Limit = maxHistoryLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	events, total, err := uc.auditRepo.ListByPoll(ctx, query.PollID, query.Offset, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if query.Admin && total == 0 {
+		// Distinguer un sondage inconnu d'un sondage créé avant la mise en place du journal
+		_, err := uc.pollRepo.GetByID(ctx, query.PollID)
+		if errors.Is(err, entity.ErrPollNotFound) {
+			return nil, ErrPollNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page := &HistoryPage{
+		Entries: make([]HistoryEntry, 0, len(events)),
+		Total:   total,
+		Offset:  query.Offset,
+		Limit:   query.Limit,
+	}
+	for _, event := range events {
+		page.Entries = append(page.Entries, newHistoryEntry(event))
+	}
+	return page, nil
+}
+
+func newHistoryEntry(event *entity.AuditEvent) HistoryEntry {
+	entry := HistoryEntry{
+		ID:        event.ID,
+		Actor:     event.Actor,
+		Action:    event.Action,
+		RequestID: event.RequestID,
+		CreatedAt: event.CreatedAt,
+	}
+	if event.Changes != "" && json.Valid([]byte(event.Changes)) {
+		entry.Changes = json.RawMessage(event.Changes)
+	}
+	return entry
+}
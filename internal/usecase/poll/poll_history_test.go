@@ -0,0 +1,90 @@
+package poll_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/poll"
+)
+
+func TestPollHistoryUseCase_Execute(t *testing.T) {
+	existing := newEditablePoll()
+	events := []*entity.AuditEvent{{
+		ID:      uuid.New(),
+		Actor:   "creator:sha256:abc",
+		Action:  entity.AuditPollUpdated,
+		PollID:  &existing.ID,
+		Changes: `{"title":{"from":"Before","to":"After"}}`,
+	}}
+
+	t.Run("creator reads the history", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewPollHistoryUseCase(pollRepo, auditRepo, nil)
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+		auditRepo.On("ListByPoll", mock.Anything, existing.ID, 0, 50).Return(events, int64(1), nil)
+
+		page, err := useCase.Execute(context.Background(), poll.HistoryQuery{PollID: existing.ID, Requester: "203.0.113.7"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), page.Total)
+		assert.Len(t, page.Entries, 1)
+		assert.JSONEq(t, events[0].Changes, string(page.Entries[0].Changes))
+	})
+
+	t.Run("other clients are refused", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewPollHistoryUseCase(pollRepo, auditRepo, nil)
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.HistoryQuery{PollID: existing.ID, Requester: "198.51.100.1"})
+
+		assert.ErrorIs(t, err, poll.ErrNotPollCreator)
+		auditRepo.AssertNotCalled(t, "ListByPoll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("admins read the history of deleted polls", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewPollHistoryUseCase(pollRepo, auditRepo, nil)
+		auditRepo.On("ListByPoll", mock.Anything, existing.ID, 0, 200).Return(events, int64(1), nil)
+
+		page, err := useCase.Execute(context.Background(), poll.HistoryQuery{PollID: existing.ID, Admin: true, Limit: 1000})
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Entries, 1)
+		pollRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown poll", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewPollHistoryUseCase(pollRepo, auditRepo, nil)
+		auditRepo.On("ListByPoll", mock.Anything, existing.ID, 0, 50).Return([]*entity.AuditEvent{}, int64(0), nil)
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(nil, entity.ErrPollNotFound)
+
+		_, err := useCase.Execute(context.Background(), poll.HistoryQuery{PollID: existing.ID, Admin: true})
+
+		assert.ErrorIs(t, err, poll.ErrPollNotFound)
+	})
+
+	t.Run("database error is not a missing poll", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewPollHistoryUseCase(pollRepo, auditRepo, nil)
+		dbErr := errors.New("connection refused")
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(nil, dbErr)
+
+		_, err := useCase.Execute(context.Background(), poll.HistoryQuery{PollID: existing.ID, Requester: "creator"})
+
+		assert.ErrorIs(t, err, dbErr)
+		assert.NotErrorIs(t, err, poll.ErrPollNotFound)
+	})
+}
@@ -0,0 +1,93 @@
+package poll
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// Ballot is one vote as shown to the creator of the poll, without the
+// identity of the voter
+type Ballot struct {
+	OptionID  uuid.UUID `json:"option_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PollOwnerDataUseCase serves the ballots and results of a poll to its creator
+type PollOwnerDataUseCase struct {
+	pollRepo repository.PollRepository
+	voteRepo repository.VoteRepository
+	ipHasher *privacy.IPHasher
+}
+
+func NewPollOwnerDataUseCase(pollRepo repository.PollRepository, voteRepo repository.VoteRepository, ipHasher *privacy.IPHasher) *PollOwnerDataUseCase {
+	return &PollOwnerDataUseCase{
+		pollRepo: pollRepo,
+		voteRepo: voteRepo,
+		ipHasher: ipHasher,
+	}
+}
+
+// Ballots returns the ballots of the poll in the order they were cast
+func (uc *PollOwnerDataUseCase) Ballots(ctx context.Context, pollID uuid.UUID, requester string) (_ []Ballot, err error) {
+	ctx, span := tracing.Start(ctx, "PollOwnerDataUseCase.Ballots", trace.WithAttributes(
+		attribute.String("poll.id", pollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uc.ownedPoll(ctx, pollID, requester); err != nil {
+		return nil, err
+	}
+	votes, err := uc.voteRepo.GetVotesByPoll(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	ballots := make([]Ballot, 0, len(votes))
+	for _, vote := range votes {
+		ballots = append(ballots, Ballot{OptionID: vote.OptionID, CreatedAt: vote.CreatedAt})
+	}
+	sort.SliceStable(ballots, func(i, j int) bool {
+		return ballots[i].CreatedAt.Before(ballots[j].CreatedAt)
+	})
+	return ballots, nil
+}
+
+// Results returns the poll with its vote counts, options in display order
+func (uc *PollOwnerDataUseCase) Results(ctx context.Context, pollID uuid.UUID, requester string) (_ *entity.Poll, err error) {
+	ctx, span := tracing.Start(ctx, "PollOwnerDataUseCase.Results", trace.WithAttributes(
+		attribute.String("poll.id", pollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uc.ownedPoll(ctx, pollID, requester); err != nil {
+		return nil, err
+	}
+	poll, err := uc.pollRepo.GetByIDWithResults(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(poll.Options, func(i, j int) bool {
+		return poll.Options[i].Order < poll.Options[j].Order
+	})
+	return poll, nil
+}
+
+func (uc *PollOwnerDataUseCase) ownedPoll(ctx context.Context, pollID uuid.UUID, requester string) (*entity.Poll, error) {
+	poll, err := uc.pollRepo.GetByID(ctx, pollID)
+	if err != nil {
+		return nil, ErrPollNotFound
+	}
+	if !uc.ipHasher.IsCreator(ctx, poll.CreatedBy, poll.IPSalt, requester) {
+		return nil, ErrNotPollCreator
+	}
+	return poll, nil
+}
@@ -0,0 +1,184 @@
+package poll
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+const (
+	defaultResponseLimit = 50
+	maxResponseLimit     = 200
+)
+
+// ResponsePage is a page of the text responses of a poll, newest first
+type ResponsePage struct {
+	Responses []*entity.TextResponse `json:"responses"`
+	Total     int64                  `json:"total"`
+	Offset    int                    `json:"offset"`
+	Limit     int                    `json:"limit"`
+}
+
+// ResponseQuery selects the responses to list. Approved responses are
+// public; pending and rejected ones are reserved to the creator.
+type ResponseQuery struct {
+	PollID    uuid.UUID
+	Status    string
+	Requester string
+	Offset    int
+	Limit     int
+}
+
+// ModerateInput approves or rejects a response of a moderated poll
+type ModerateInput struct {
+	PollID     uuid.UUID `json:"-"`
+	ResponseID uuid.UUID `json:"-"`
+	Status     string    `json:"status" binding:"required,oneof=approved rejected" example:"approved"`
+	Requester  string    `json:"-"`
+}
+
+type TextResponsesUseCase struct {
+	pollRepo     repository.PollRepository
+	responseRepo repository.TextResponseRepository
+	recorder     *audit.Recorder
+	ipHasher     *privacy.IPHasher
+	now          func() time.Time
+}
+
+func NewTextResponsesUseCase(pollRepo repository.PollRepository, responseRepo repository.TextResponseRepository, recorder *audit.Recorder, ipHasher *privacy.IPHasher) *TextResponsesUseCase {
+	return &TextResponsesUseCase{
+		pollRepo:     pollRepo,
+		responseRepo: responseRepo,
+		recorder:     recorder,
+		ipHasher:     ipHasher,
+		now:          time.Now,
+	}
+}
+
+// List returns a page of the responses of a text poll with query.Status,
+// approved by default
+func (uc *TextResponsesUseCase) List(ctx context.Context, query ResponseQuery) (_ *ResponsePage, err error) {
+	ctx, span := tracing.Start(ctx, "TextResponsesUseCase.List", trace.WithAttributes(
+		attribute.String("poll.id", query.PollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if query.Status == "" {
+		query.Status = entity.ResponseApproved
+	}
+	if !validStatus(query.Status) {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "status", Rule: "oneof", Param: "pending approved rejected",
+			Message: "status must be one of pending, approved or rejected",
+		})
+	}
+
+	poll, err := uc.pollRepo.GetByID(ctx, query.PollID)
+	if err != nil {
+		return nil, ErrPollNotFound
+	}
+	if !poll.IsText() {
+		return nil, entity.ErrChoicePoll
+	}
+	if query.Status != entity.ResponseApproved && !uc.ipHasher.IsCreator(ctx, poll.CreatedBy, poll.IPSalt, query.Requester) {
+		return nil, ErrNotPollCreator
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultResponseLimit
+	}
+	if query.Limit > maxResponseLimit {
+		query.Limit = maxResponseLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	responses, total, err := uc.responseRepo.ListByPoll(ctx, poll.ID, query.Status, query.Offset, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if responses == nil {
+		responses = []*entity.TextResponse{}
+	}
+	return &ResponsePage{
+		Responses: responses,
+		Total:     total,
+		Offset:    query.Offset,
+		Limit:     query.Limit,
+	}, nil
+}
+
+// Moderate lets the creator of a text poll approve or reject one of its
+// responses. Rejected responses leave the results and the word cloud.
+func (uc *TextResponsesUseCase) Moderate(ctx context.Context, input ModerateInput) (_ *entity.TextResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TextResponsesUseCase.Moderate", trace.WithAttributes(
+		attribute.String("poll.id", input.PollID.String()),
+		attribute.String("response.id", input.ResponseID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if input.Status != entity.ResponseApproved && input.Status != entity.ResponseRejected {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "status", Rule: "oneof", Param: "approved rejected",
+			Message: "status must be approved or rejected",
+		})
+	}
+
+	poll, err := uc.pollRepo.GetByID(ctx, input.PollID)
+	if err != nil {
+		return nil, ErrPollNotFound
+	}
+	if !uc.ipHasher.IsCreator(ctx, poll.CreatedBy, poll.IPSalt, input.Requester) {
+		return nil, ErrNotPollCreator
+	}
+	response, err := uc.responseRepo.GetByID(ctx, input.ResponseID)
+	if err != nil {
+		return nil, err
+	}
+	if response.PollID != poll.ID {
+		return nil, entity.ErrResponseNotFound
+	}
+	if response.Status == input.Status {
+		return response, nil
+	}
+
+	previous := response.Status
+	moderatedAt := uc.now().UTC()
+	response.Status = input.Status
+	response.ModeratedAt = &moderatedAt
+	if err := uc.responseRepo.UpdateStatus(ctx, response); err != nil {
+		return nil, err
+	}
+
+	changes := audit.Diff{}
+	changes.Set("status", previous, response.Status)
+	uc.recorder.Record(ctx, audit.Event{
+		Actor:   creatorActor(ctx, uc.ipHasher, input.Requester),
+		Action:  entity.AuditResponseModerated,
+		Target:  audit.ResponseTarget(response.ID),
+		PollID:  &poll.ID,
+		Changes: changes,
+	})
+	slog.InfoContext(ctx, "text response moderated", "poll_id", poll.ID, "response_id", response.ID, "status", response.Status)
+
+	return response, nil
+}
+
+func validStatus(status string) bool {
+	switch status {
+	case entity.ResponsePending, entity.ResponseApproved, entity.ResponseRejected:
+		return true
+	}
+	return false
+}
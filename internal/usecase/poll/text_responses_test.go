@@ -0,0 +1,129 @@
+package poll_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/audit"
+	"microservice-go-gin/internal/usecase/poll"
+)
+
+func newTextPoll() *entity.Poll {
+	return &entity.Poll{
+		ID:        uuid.New(),
+		Title:     "What should we improve?",
+		Type:      entity.PollTypeText,
+		Moderated: true,
+		CreatedBy: "203.0.113.7",
+	}
+}
+
+func TestTextResponsesUseCase_List(t *testing.T) {
+	t.Run("approved responses are public", func(t *testing.T) {
+		textPoll := newTextPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		responseRepo := new(mocks.MockTextResponseRepository)
+		useCase := poll.NewTextResponsesUseCase(pollRepo, responseRepo, nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, textPoll.ID).Return(textPoll, nil)
+		responseRepo.On("ListByPoll", mock.Anything, textPoll.ID, entity.ResponseApproved, 0, 50).
+			Return([]*entity.TextResponse{{Text: "Faster builds"}}, int64(1), nil)
+
+		page, err := useCase.List(context.Background(), poll.ResponseQuery{PollID: textPoll.ID, Requester: "198.51.100.1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), page.Total)
+		assert.Equal(t, "Faster builds", page.Responses[0].Text)
+	})
+
+	t.Run("pending responses are reserved to the creator", func(t *testing.T) {
+		textPoll := newTextPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewTextResponsesUseCase(pollRepo, new(mocks.MockTextResponseRepository), nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, textPoll.ID).Return(textPoll, nil)
+
+		_, err := useCase.List(context.Background(), poll.ResponseQuery{
+			PollID: textPoll.ID, Status: entity.ResponsePending, Requester: "198.51.100.1",
+		})
+
+		assert.ErrorIs(t, err, poll.ErrNotPollCreator)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		useCase := poll.NewTextResponsesUseCase(new(mocks.MockPollRepository), new(mocks.MockTextResponseRepository), nil, nil)
+
+		_, err := useCase.List(context.Background(), poll.ResponseQuery{PollID: uuid.New(), Status: "spam"})
+
+		assert.EqualError(t, err, "status must be one of pending, approved or rejected")
+	})
+}
+
+func TestTextResponsesUseCase_Moderate(t *testing.T) {
+	t.Run("creator approves a pending response and the decision is audited", func(t *testing.T) {
+		textPoll := newTextPoll()
+		response := &entity.TextResponse{ID: uuid.New(), PollID: textPoll.ID, Text: "More coffee", Status: entity.ResponsePending}
+		pollRepo := new(mocks.MockPollRepository)
+		responseRepo := new(mocks.MockTextResponseRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewTextResponsesUseCase(pollRepo, responseRepo, audit.NewRecorder(auditRepo), nil)
+
+		pollRepo.On("GetByID", mock.Anything, textPoll.ID).Return(textPoll, nil)
+		responseRepo.On("GetByID", mock.Anything, response.ID).Return(response, nil)
+		responseRepo.On("UpdateStatus", mock.Anything, response).Return(nil)
+		auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+			return event.Action == entity.AuditResponseModerated &&
+				event.Target == "response:"+response.ID.String() &&
+				event.Changes == `{"status":{"from":"pending","to":"approved"}}`
+		})).Return(nil)
+
+		moderated, err := useCase.Moderate(context.Background(), poll.ModerateInput{
+			PollID: textPoll.ID, ResponseID: response.ID, Status: entity.ResponseApproved, Requester: "203.0.113.7",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.ResponseApproved, moderated.Status)
+		assert.NotNil(t, moderated.ModeratedAt)
+		responseRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("only the creator can moderate", func(t *testing.T) {
+		textPoll := newTextPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		responseRepo := new(mocks.MockTextResponseRepository)
+		useCase := poll.NewTextResponsesUseCase(pollRepo, responseRepo, nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, textPoll.ID).Return(textPoll, nil)
+
+		_, err := useCase.Moderate(context.Background(), poll.ModerateInput{
+			PollID: textPoll.ID, ResponseID: uuid.New(), Status: entity.ResponseRejected, Requester: "198.51.100.1",
+		})
+
+		assert.ErrorIs(t, err, poll.ErrNotPollCreator)
+		responseRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("response of another poll", func(t *testing.T) {
+		textPoll := newTextPoll()
+		response := &entity.TextResponse{ID: uuid.New(), PollID: uuid.New(), Status: entity.ResponsePending}
+		pollRepo := new(mocks.MockPollRepository)
+		responseRepo := new(mocks.MockTextResponseRepository)
+		useCase := poll.NewTextResponsesUseCase(pollRepo, responseRepo, nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, textPoll.ID).Return(textPoll, nil)
+		responseRepo.On("GetByID", mock.Anything, response.ID).Return(response, nil)
+
+		_, err := useCase.Moderate(context.Background(), poll.ModerateInput{
+			PollID: textPoll.ID, ResponseID: response.ID, Status: entity.ResponseApproved, Requester: "203.0.113.7",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrResponseNotFound)
+	})
+}
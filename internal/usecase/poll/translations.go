@@ -0,0 +1,136 @@
+package poll
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/i18n"
+)
+
+// maxTranslations bounds the number of locales of a poll besides its own
+const maxTranslations = 10
+
+// TranslationInput is the content of a poll in another locale. Options
+// holds one text per option, in the order of the poll options, so that
+// every locale shares the same option IDs and tallies.
+type TranslationInput struct {
+	Title       string   `json:"title" example:"Quel est votre langage de programmation préféré ?"`
+	Description string   `json:"description" example:"Choisissez votre langage préféré"`
+	Options     []string `json:"options" example:"Go,Python,JavaScript,Rust"`
+}
+
+// normalizeTranslations validates the language and translations of a poll
+// with optionCount options and returns them keyed by canonical locale
+func normalizeTranslations(lang string, translations map[string]TranslationInput, optionCount int) (string, map[string]TranslationInput, []apperror.FieldError) {
+	var fields []apperror.FieldError
+	reject := func(field, rule, param, message string) {
+		fields = append(fields, apperror.FieldError{Field: field, Rule: rule, Param: param, Message: message})
+	}
+
+	if lang == "" {
+		if len(translations) > 0 {
+			reject("language", "required", "", "language is required to add translations")
+		}
+		return "", nil, fields
+	}
+	canonical, ok := i18n.CanonicalLocale(lang)
+	if !ok {
+		reject("language", "bcp47", "", "language must be a locale such as en or fr-CA")
+		return "", nil, fields
+	}
+	if len(translations) > maxTranslations {
+		reject("translations", "max", strconv.Itoa(maxTranslations), fmt.Sprintf("a poll can have at most %d translations", maxTranslations))
+		return canonical, nil, fields
+	}
+
+	normalized := make(map[string]TranslationInput, len(translations))
+	for locale, translation := range translations {
+		field := "translations[" + locale + "]"
+		key, ok := i18n.CanonicalLocale(locale)
+		if !ok {
+			reject(field, "bcp47", "", locale+" is not a valid locale")
+			continue
+		}
+		if _, exists := normalized[key]; exists || key == canonical {
+			reject(field, "unique", "", "the poll already has content in "+key)
+			continue
+		}
+
+		title := strings.TrimSpace(translation.Title)
+		switch {
+		case title == "":
+			reject(field+".title", "required", "", "translated title cannot be empty")
+		case len(translation.Title) < 3:
+			reject(field+".title", "min", "3", "translated title must be at least 3 characters long")
+		case len(translation.Title) > 255:
+			reject(field+".title", "max", "255", "translated title must be no more than 255 characters long")
+		}
+		if len(translation.Description) > 500 {
+			reject(field+".description", "max", "500", "translated description must be no more than 500 characters long")
+		}
+		if len(translation.Options) != optionCount {
+			reject(field+".options", "len", strconv.Itoa(optionCount), fmt.Sprintf("a translation must have exactly %d options", optionCount))
+		}
+		for i, option := range translation.Options {
+			optionField := fmt.Sprintf("%s.options[%d]", field, i)
+			if strings.TrimSpace(option) == "" {
+				reject(optionField, "required", "", fmt.Sprintf("translated option %d cannot be empty", i+1))
+			}
+			if len(option) > 255 {
+				reject(optionField, "max", "255", fmt.Sprintf("translated option %d must be no more than 255 characters long", i+1))
+			}
+		}
+		normalized[key] = translation
+	}
+	return canonical, normalized, fields
+}
+
+// applyTranslations stores lang and translations on poll and its options,
+// which must follow the order of the translated option texts
+func applyTranslations(poll *entity.Poll, options []entity.Option, lang string, translations map[string]TranslationInput) {
+	poll.Language = lang
+	poll.Translations = nil
+	for i := range options {
+		options[i].Translations = nil
+	}
+	if len(translations) == 0 {
+		return
+	}
+
+	poll.Translations = make(map[string]entity.PollTranslation, len(translations))
+	for locale, translation := range translations {
+		poll.Translations[locale] = entity.PollTranslation{
+			Title:       translation.Title,
+			Description: translation.Description,
+		}
+		for i := range options {
+			if options[i].Translations == nil {
+				options[i].Translations = make(map[string]string, len(translations))
+			}
+			options[i].Translations[locale] = translation.Options[i]
+		}
+	}
+}
+
+// translationInputs rebuilds the translations of poll as they were submitted
+func translationInputs(poll *entity.Poll) map[string]TranslationInput {
+	if len(poll.Translations) == 0 {
+		return nil
+	}
+	inputs := make(map[string]TranslationInput, len(poll.Translations))
+	for locale, translation := range poll.Translations {
+		input := TranslationInput{
+			Title:       translation.Title,
+			Description: translation.Description,
+			Options:     make([]string, len(poll.Options)),
+		}
+		for i, option := range poll.Options {
+			input.Options[i] = option.Translations[locale]
+		}
+		inputs[locale] = input
+	}
+	return inputs
+}
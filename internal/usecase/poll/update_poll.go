@@ -0,0 +1,251 @@
+package poll
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+var (
+	ErrPollNotFound   = entity.ErrPollNotFound
+	ErrNotPollCreator = apperror.Forbidden("not_poll_creator", "only the creator of the poll can do this")
+	ErrPollClosed     = apperror.Expired("poll_closed", "poll is closed")
+	ErrOptionsLocked  = apperror.Conflict("options_locked", "options cannot be changed once votes have been cast")
+)
+
+// UpdatePollInput represents the changes to apply to a poll; omitted fields are kept
+type UpdatePollInput struct {
+	PollID      uuid.UUID `json:"-"`
+	Title       *string   `json:"title" binding:"omitempty,min=3,max=255" example:"What's your favorite programming language?"`
+	Description *string   `json:"description" binding:"omitempty,max=500" example:"Choose your preferred programming language"`
+	Options     []string  `json:"options" binding:"omitempty,min=2,max=10,dive,required,min=1,max=255" example:"Go,Python,JavaScript,Rust"`
+	ExpiresIn   *int      `json:"expires_in" binding:"omitempty,min=1,max=10080" example:"60"`
+	Requester   string    `json:"-"`
+
+	// Language and Translations replace the locales of the poll; translations
+	// must be sent again when the options change
+	Language     *string                     `json:"language" example:"en"`
+	Translations map[string]TranslationInput `json:"translations"`
+}
+
+type UpdatePollUseCase struct {
+	pollRepo repository.PollRepository
+	voteRepo repository.VoteRepository
+	recorder *audit.Recorder
+	ipHasher *privacy.IPHasher
+	now      func() time.Time
+}
+
+func NewUpdatePollUseCase(pollRepo repository.PollRepository, voteRepo repository.VoteRepository, recorder *audit.Recorder, ipHasher *privacy.IPHasher) *UpdatePollUseCase {
+	return &UpdatePollUseCase{
+		pollRepo: pollRepo,
+		voteRepo: voteRepo,
+		recorder: recorder,
+		ipHasher: ipHasher,
+		now:      time.Now,
+	}
+}
+
+// Execute lets the creator of an open poll change its title, description
+// and expiry. Options can only be replaced while nobody has voted.
+func (uc *UpdatePollUseCase) Execute(ctx context.Context, input UpdatePollInput) (_ *entity.Poll, err error) {
+	ctx, span := tracing.Start(ctx, "UpdatePollUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", input.PollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	poll, err := uc.pollRepo.GetByID(ctx, input.PollID)
+	if err != nil {
+		return nil, ErrPollNotFound
+	}
+	if !uc.ipHasher.IsCreator(ctx, poll.CreatedBy, poll.IPSalt, input.Requester) {
+		return nil, ErrNotPollCreator
+	}
+	now := uc.now()
+	if poll.IsExpired() {
+		return nil, ErrPollClosed
+	}
+
+	changes := audit.Diff{}
+	if input.Title != nil && *input.Title != poll.Title {
+		if strings.TrimSpace(*input.Title) == "" {
+			return nil, apperror.Validation(apperror.FieldError{
+				Field: "title", Rule: "required", Message: "title cannot be empty",
+			})
+		}
+		changes.Set("title", poll.Title, *input.Title)
+		poll.Title = *input.Title
+	}
+	if input.Description != nil && *input.Description != poll.Description {
+		changes.Set("description", poll.Description, *input.Description)
+		poll.Description = *input.Description
+	}
+	if input.ExpiresIn != nil {
+		expiresAt := now.Add(time.Duration(*input.ExpiresIn) * time.Minute)
+		changes.Set("expires_at", poll.ExpiresAt, expiresAt)
+		poll.ExpiresAt = &expiresAt
+	}
+
+	if len(input.Options) > 0 && (poll.IsText() || poll.IsNumeric() || poll.IsSchedule()) {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "options", Rule: "excluded", Message: "only choice polls take options",
+		})
+	}
+	var options []entity.Option
+	if input.Options != nil && !sameOptions(poll.Options, input.Options) {
+		if err := validateOptions(input.Options); err != nil {
+			return nil, err
+		}
+		votes, err := uc.voteRepo.CountByPoll(ctx, poll.ID)
+		if err != nil {
+			return nil, err
+		}
+		if votes > 0 || hasArchivedVotes(poll) {
+			return nil, ErrOptionsLocked
+		}
+		previous := make([]string, len(poll.Options))
+		capacities := make(map[string]*int, len(poll.Options))
+		for i, option := range poll.Options {
+			previous[i] = option.Text
+			capacities[entity.OptionKey(option.Text)] = option.MaxVotes
+		}
+		changes.Set("options", previous, input.Options)
+		// Une option conservée garde sa capacité sur une feuille d'inscription
+		options = make([]entity.Option, len(input.Options))
+		for i, text := range input.Options {
+			options[i] = entity.Option{Text: text, Order: i, MaxVotes: capacities[entity.OptionKey(text)]}
+		}
+	}
+
+	if input.Language != nil || input.Translations != nil || (options != nil && poll.Language != "") {
+		if err := uc.translate(poll, options, input, changes); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(changes) == 0 {
+		return poll, nil
+	}
+	if err := uc.pollRepo.UpdateDetails(ctx, poll, options); err != nil {
+		return nil, err
+	}
+
+	uc.recorder.Record(ctx, audit.Event{
+		Actor:   creatorActor(ctx, uc.ipHasher, input.Requester),
+		Action:  entity.AuditPollUpdated,
+		Target:  audit.PollTarget(poll.ID),
+		PollID:  &poll.ID,
+		Changes: changes,
+	})
+	slog.InfoContext(ctx, "poll updated", "poll_id", poll.ID, "fields", len(changes))
+
+	return poll, nil
+}
+
+// translate applies the language and translations of input to poll and to
+// options, the new options if they are replaced, and records the changes
+func (uc *UpdatePollUseCase) translate(poll *entity.Poll, options []entity.Option, input UpdatePollInput, changes audit.Diff) error {
+	lang := poll.Language
+	if input.Language != nil {
+		lang = *input.Language
+	}
+	submitted := input.Translations
+	if submitted == nil {
+		if options != nil && len(poll.Translations) > 0 {
+			return apperror.Validation(apperror.FieldError{
+				Field: "translations", Rule: "required",
+				Message: "translations must be sent again when the options change",
+			})
+		}
+		submitted = translationInputs(poll)
+	}
+
+	targets := options
+	if targets == nil {
+		targets = poll.Options
+	}
+	lang, translations, fields := normalizeTranslations(lang, submitted, len(targets))
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+
+	previousLang, previous := poll.Language, translationInputs(poll)
+	applyTranslations(poll, targets, lang, translations)
+	if lang != previousLang {
+		changes.Set("language", previousLang, lang)
+	}
+	current := translationInputs(&entity.Poll{Translations: poll.Translations, Options: targets})
+	if options != nil || !reflect.DeepEqual(previous, current) {
+		if len(previous) > 0 || len(current) > 0 {
+			changes.Set("translations", previous, current)
+		}
+	}
+	return nil
+}
+
+// creatorActor is the audit actor of an action taken by the creator of a poll
+func creatorActor(ctx context.Context, ipHasher *privacy.IPHasher, requester string) string {
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		return principal.Actor
+	}
+	if requester == "" {
+		return "anonymous"
+	}
+	return "creator:" + ipHasher.Pseudonym(requester)
+}
+
+func sameOptions(current []entity.Option, texts []string) bool {
+	if len(current) != len(texts) {
+		return false
+	}
+	for i, option := range current {
+		if option.Text != texts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasArchivedVotes(poll *entity.Poll) bool {
+	for _, option := range poll.Options {
+		if option.ArchivedVotes > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// validateOptions applies the option rules of poll creation
+func validateOptions(options []string) error {
+	seen := make(map[string]bool, len(options))
+	for i, option := range options {
+		field := fmt.Sprintf("options[%d]", i)
+		if strings.TrimSpace(option) == "" {
+			return apperror.Validation(apperror.FieldError{
+				Field: field, Rule: "required", Message: "options cannot be empty",
+			})
+		}
+		key := entity.OptionKey(option)
+		if seen[key] {
+			return apperror.Validation(apperror.FieldError{
+				Field: field, Rule: "unique", Message: "duplicate options are not allowed",
+			})
+		}
+		seen[key] = true
+	}
+	return nil
+}
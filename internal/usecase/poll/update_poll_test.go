@@ -0,0 +1,195 @@
+package poll_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/audit"
+	"microservice-go-gin/internal/usecase/poll"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func newEditablePoll() *entity.Poll {
+	return &entity.Poll{
+		ID:        uuid.New(),
+		Title:     "Before",
+		CreatedBy: "203.0.113.7",
+		Options:   []entity.Option{{ID: uuid.New(), Text: "A"}, {ID: uuid.New(), Text: "B"}},
+	}
+}
+
+func TestUpdatePollUseCase_Execute(t *testing.T) {
+	t.Run("creator renames the poll and the change is audited", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		voteRepo := new(mocks.MockVoteRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewUpdatePollUseCase(pollRepo, voteRepo, audit.NewRecorder(auditRepo), nil)
+		existing := newEditablePoll()
+
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+		pollRepo.On("UpdateDetails", mock.Anything, existing, []entity.Option(nil)).Return(nil)
+		auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+			return event.Action == entity.AuditPollUpdated &&
+				*event.PollID == existing.ID &&
+				strings.HasPrefix(event.Actor, "creator:sha256:") &&
+				event.Changes == `{"title":{"from":"Before","to":"After"}}`
+		})).Return(nil)
+
+		updated, err := useCase.Execute(context.Background(), poll.UpdatePollInput{
+			PollID:    existing.ID,
+			Title:     strPtr("After"),
+			Requester: "203.0.113.7",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "After", updated.Title)
+		pollRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("only the creator can edit", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewUpdatePollUseCase(pollRepo, new(mocks.MockVoteRepository), nil, nil)
+		existing := newEditablePoll()
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.UpdatePollInput{
+			PollID:    existing.ID,
+			Title:     strPtr("After"),
+			Requester: "198.51.100.1",
+		})
+
+		assert.ErrorIs(t, err, poll.ErrNotPollCreator)
+		pollRepo.AssertNotCalled(t, "UpdateDetails", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("closed polls cannot be edited", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewUpdatePollUseCase(pollRepo, new(mocks.MockVoteRepository), nil, nil)
+		existing := newEditablePoll()
+		expired := time.Now().Add(-time.Minute)
+		existing.ExpiresAt = &expired
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.UpdatePollInput{
+			PollID:    existing.ID,
+			Title:     strPtr("After"),
+			Requester: "203.0.113.7",
+		})
+
+		assert.ErrorIs(t, err, poll.ErrPollClosed)
+	})
+
+	t.Run("options are locked once votes are cast", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		voteRepo := new(mocks.MockVoteRepository)
+		useCase := poll.NewUpdatePollUseCase(pollRepo, voteRepo, nil, nil)
+		existing := newEditablePoll()
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+		voteRepo.On("CountByPoll", mock.Anything, existing.ID).Return(int64(1), nil)
+
+		_, err := useCase.Execute(context.Background(), poll.UpdatePollInput{
+			PollID:    existing.ID,
+			Options:   []string{"C", "D"},
+			Requester: "203.0.113.7",
+		})
+
+		assert.ErrorIs(t, err, poll.ErrOptionsLocked)
+		pollRepo.AssertNotCalled(t, "UpdateDetails", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("options are replaced before the first vote", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		voteRepo := new(mocks.MockVoteRepository)
+		useCase := poll.NewUpdatePollUseCase(pollRepo, voteRepo, nil, nil)
+		existing := newEditablePoll()
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+		voteRepo.On("CountByPoll", mock.Anything, existing.ID).Return(int64(0), nil)
+		pollRepo.On("UpdateDetails", mock.Anything, existing, mock.MatchedBy(func(options []entity.Option) bool {
+			return len(options) == 3 && options[2].Text == "E" && options[2].Order == 2
+		})).Return(nil)
+
+		_, err := useCase.Execute(context.Background(), poll.UpdatePollInput{
+			PollID:    existing.ID,
+			Options:   []string{"C", "D", "E"},
+			Requester: "203.0.113.7",
+		})
+
+		assert.NoError(t, err)
+		pollRepo.AssertExpectations(t)
+	})
+
+	t.Run("unchanged fields are not saved", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewUpdatePollUseCase(pollRepo, new(mocks.MockVoteRepository), nil, nil)
+		existing := newEditablePoll()
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+
+		_, err := useCase.Execute(context.Background(), poll.UpdatePollInput{
+			PollID:    existing.ID,
+			Title:     strPtr("Before"),
+			Options:   []string{"A", "B"},
+			Requester: "203.0.113.7",
+		})
+
+		assert.NoError(t, err)
+		pollRepo.AssertNotCalled(t, "UpdateDetails", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdatePollUseCase_Translations(t *testing.T) {
+	t.Run("translations are added without touching the options", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewUpdatePollUseCase(pollRepo, new(mocks.MockVoteRepository), nil, nil)
+		existing := newEditablePoll()
+		optionIDs := []uuid.UUID{existing.Options[0].ID, existing.Options[1].ID}
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+		pollRepo.On("UpdateDetails", mock.Anything, existing, []entity.Option(nil)).Return(nil)
+
+		updated, err := useCase.Execute(context.Background(), poll.UpdatePollInput{
+			PollID:   existing.ID,
+			Language: strPtr("en"),
+			Translations: map[string]poll.TranslationInput{
+				"fr": {Title: "Avant", Options: []string{"A fr", "B fr"}},
+			},
+			Requester: "203.0.113.7",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "en", updated.Language)
+		assert.Equal(t, "Avant", updated.Translations["fr"].Title)
+		assert.Equal(t, optionIDs[1], updated.Options[1].ID)
+		assert.Equal(t, "B fr", updated.Options[1].Translations["fr"])
+		pollRepo.AssertExpectations(t)
+	})
+
+	t.Run("translations must be resent when the options change", func(t *testing.T) {
+		pollRepo := new(mocks.MockPollRepository)
+		voteRepo := new(mocks.MockVoteRepository)
+		useCase := poll.NewUpdatePollUseCase(pollRepo, voteRepo, nil, nil)
+		existing := newEditablePoll()
+		existing.Language = "en"
+		existing.Translations = map[string]entity.PollTranslation{"fr": {Title: "Avant"}}
+		pollRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+		voteRepo.On("CountByPoll", mock.Anything, existing.ID).Return(int64(0), nil)
+
+		_, err := useCase.Execute(context.Background(), poll.UpdatePollInput{
+			PollID:    existing.ID,
+			Options:   []string{"C", "D"},
+			Requester: "203.0.113.7",
+		})
+
+		assert.EqualError(t, err, "translations must be sent again when the options change")
+		pollRepo.AssertNotCalled(t, "UpdateDetails", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,122 @@
+package poll
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+	"microservice-go-gin/internal/usecase/audit"
+)
+
+// ModerateOptionInput approves or rejects a write-in option awaiting approval
+type ModerateOptionInput struct {
+	PollID    uuid.UUID `json:"-"`
+	OptionID  uuid.UUID `json:"-"`
+	Status    string    `json:"status" binding:"required,oneof=approved rejected" example:"approved"`
+	Requester string    `json:"-"`
+}
+
+// WriteInsUseCase lets the creator of a moderated poll review the options
+// written in by voters
+type WriteInsUseCase struct {
+	pollRepo repository.PollRepository
+	recorder *audit.Recorder
+	ipHasher *privacy.IPHasher
+}
+
+func NewWriteInsUseCase(pollRepo repository.PollRepository, recorder *audit.Recorder, ipHasher *privacy.IPHasher) *WriteInsUseCase {
+	return &WriteInsUseCase{
+		pollRepo: pollRepo,
+		recorder: recorder,
+		ipHasher: ipHasher,
+	}
+}
+
+// Pending returns the write-in options of a poll awaiting approval
+func (uc *WriteInsUseCase) Pending(ctx context.Context, pollID uuid.UUID, requester string) (_ []entity.Option, err error) {
+	ctx, span := tracing.Start(ctx, "WriteInsUseCase.Pending", trace.WithAttributes(
+		attribute.String("poll.id", pollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	poll, err := uc.pollRepo.GetByIDWithResults(ctx, pollID)
+	if err != nil {
+		return nil, ErrPollNotFound
+	}
+	if !uc.ipHasher.IsCreator(ctx, poll.CreatedBy, poll.IPSalt, requester) {
+		return nil, ErrNotPollCreator
+	}
+
+	pending := []entity.Option{}
+	for _, option := range poll.Options {
+		if option.Pending {
+			pending = append(pending, option)
+		}
+	}
+	return pending, nil
+}
+
+// Moderate publishes a pending write-in option, or deletes it with its
+// ballots when it is rejected
+func (uc *WriteInsUseCase) Moderate(ctx context.Context, input ModerateOptionInput) (_ *entity.Option, err error) {
+	ctx, span := tracing.Start(ctx, "WriteInsUseCase.Moderate", trace.WithAttributes(
+		attribute.String("poll.id", input.PollID.String()),
+		attribute.String("option.id", input.OptionID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if input.Status != entity.ResponseApproved && input.Status != entity.ResponseRejected {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "status", Rule: "oneof", Param: "approved rejected",
+			Message: "status must be approved or rejected",
+		})
+	}
+
+	poll, err := uc.pollRepo.GetByID(ctx, input.PollID)
+	if err != nil {
+		return nil, ErrPollNotFound
+	}
+	if !uc.ipHasher.IsCreator(ctx, poll.CreatedBy, poll.IPSalt, input.Requester) {
+		return nil, ErrNotPollCreator
+	}
+	var option *entity.Option
+	for i := range poll.Options {
+		if poll.Options[i].ID == input.OptionID && poll.Options[i].Pending {
+			option = &poll.Options[i]
+		}
+	}
+	if option == nil {
+		return nil, entity.ErrOptionNotFound
+	}
+
+	if input.Status == entity.ResponseApproved {
+		err = uc.pollRepo.ApproveOption(ctx, option.ID)
+		option.Pending = false
+	} else {
+		err = uc.pollRepo.DeleteOption(ctx, option.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changes := audit.Diff{}
+	changes.Set("status", entity.ResponsePending, input.Status)
+	changes.Set("text", nil, option.Text)
+	uc.recorder.Record(ctx, audit.Event{
+		Actor:   creatorActor(ctx, uc.ipHasher, input.Requester),
+		Action:  entity.AuditOptionModerated,
+		Target:  audit.OptionTarget(option.ID),
+		PollID:  &poll.ID,
+		Changes: changes,
+	})
+	slog.InfoContext(ctx, "write-in option moderated", "poll_id", poll.ID, "option_id", option.ID, "status", input.Status)
+
+	return option, nil
+}
@@ -0,0 +1,129 @@
+package poll_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/audit"
+	"microservice-go-gin/internal/usecase/poll"
+)
+
+func newWriteInPoll() *entity.Poll {
+	return &entity.Poll{
+		ID:           uuid.New(),
+		Title:        "Favourite language?",
+		AllowWriteIn: true,
+		Moderated:    true,
+		CreatedBy:    "203.0.113.7",
+		Options: []entity.Option{
+			{ID: uuid.New(), Text: "Go"},
+			{ID: uuid.New(), Text: "Zig", Pending: true},
+		},
+	}
+}
+
+func TestWriteInsUseCase_Pending(t *testing.T) {
+	t.Run("creator sees the options awaiting approval", func(t *testing.T) {
+		writeInPoll := newWriteInPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewWriteInsUseCase(pollRepo, nil, nil)
+
+		pollRepo.On("GetByIDWithResults", mock.Anything, writeInPoll.ID).Return(writeInPoll, nil)
+
+		pending, err := useCase.Pending(context.Background(), writeInPoll.ID, "203.0.113.7")
+
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "Zig", pending[0].Text)
+	})
+
+	t.Run("reserved to the creator", func(t *testing.T) {
+		writeInPoll := newWriteInPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewWriteInsUseCase(pollRepo, nil, nil)
+
+		pollRepo.On("GetByIDWithResults", mock.Anything, writeInPoll.ID).Return(writeInPoll, nil)
+
+		_, err := useCase.Pending(context.Background(), writeInPoll.ID, "198.51.100.1")
+
+		assert.ErrorIs(t, err, poll.ErrNotPollCreator)
+	})
+}
+
+func TestWriteInsUseCase_Moderate(t *testing.T) {
+	t.Run("approval publishes the option and is audited", func(t *testing.T) {
+		writeInPoll := newWriteInPoll()
+		pending := writeInPoll.Options[1]
+		pollRepo := new(mocks.MockPollRepository)
+		auditRepo := new(mocks.MockAuditRepository)
+		useCase := poll.NewWriteInsUseCase(pollRepo, audit.NewRecorder(auditRepo), nil)
+
+		pollRepo.On("GetByID", mock.Anything, writeInPoll.ID).Return(writeInPoll, nil)
+		pollRepo.On("ApproveOption", mock.Anything, pending.ID).Return(nil)
+		auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(event *entity.AuditEvent) bool {
+			return event.Action == entity.AuditOptionModerated &&
+				event.Target == "option:"+pending.ID.String()
+		})).Return(nil)
+
+		option, err := useCase.Moderate(context.Background(), poll.ModerateOptionInput{
+			PollID: writeInPoll.ID, OptionID: pending.ID, Status: entity.ResponseApproved, Requester: "203.0.113.7",
+		})
+
+		require.NoError(t, err)
+		assert.False(t, option.Pending)
+		pollRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejection deletes the option", func(t *testing.T) {
+		writeInPoll := newWriteInPoll()
+		pending := writeInPoll.Options[1]
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewWriteInsUseCase(pollRepo, nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, writeInPoll.ID).Return(writeInPoll, nil)
+		pollRepo.On("DeleteOption", mock.Anything, pending.ID).Return(nil)
+
+		_, err := useCase.Moderate(context.Background(), poll.ModerateOptionInput{
+			PollID: writeInPoll.ID, OptionID: pending.ID, Status: entity.ResponseRejected, Requester: "203.0.113.7",
+		})
+
+		require.NoError(t, err)
+		pollRepo.AssertExpectations(t)
+	})
+
+	t.Run("published options cannot be moderated", func(t *testing.T) {
+		writeInPoll := newWriteInPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewWriteInsUseCase(pollRepo, nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, writeInPoll.ID).Return(writeInPoll, nil)
+
+		_, err := useCase.Moderate(context.Background(), poll.ModerateOptionInput{
+			PollID: writeInPoll.ID, OptionID: writeInPoll.Options[0].ID, Status: entity.ResponseRejected, Requester: "203.0.113.7",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrOptionNotFound)
+		pollRepo.AssertNotCalled(t, "DeleteOption", mock.Anything, mock.Anything)
+	})
+
+	t.Run("only the creator can moderate", func(t *testing.T) {
+		writeInPoll := newWriteInPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := poll.NewWriteInsUseCase(pollRepo, nil, nil)
+
+		pollRepo.On("GetByID", mock.Anything, writeInPoll.ID).Return(writeInPoll, nil)
+
+		_, err := useCase.Moderate(context.Background(), poll.ModerateOptionInput{
+			PollID: writeInPoll.ID, OptionID: writeInPoll.Options[1].ID, Status: entity.ResponseApproved, Requester: "198.51.100.1",
+		})
+
+		assert.ErrorIs(t, err, poll.ErrNotPollCreator)
+	})
+}
@@ -0,0 +1,157 @@
+package quiz
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// AnswerQuestionInput is the answer of a player to the current question
+// of a quiz
+type AnswerQuestionInput struct {
+	QuizID uuid.UUID `json:"-"`
+	// PlayerID is the ID returned to the player when they joined
+	PlayerID   uuid.UUID   `json:"player_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440006"`
+	QuestionID uuid.UUID   `json:"question_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OptionIDs  []uuid.UUID `json:"option_ids" example:"550e8400-e29b-41d4-a716-446655440001"`
+
+	// ReceivedAt is when the answer reached the server, before any I/O;
+	// the response time runs until then. Zero means now.
+	ReceivedAt time.Time `json:"-"`
+}
+
+type AnswerQuestionUseCase struct {
+	quizRepo repository.QuizRepository
+	now      func() time.Time
+}
+
+func NewAnswerQuestionUseCase(quizRepo repository.QuizRepository) *AnswerQuestionUseCase {
+	return &AnswerQuestionUseCase{
+		quizRepo: quizRepo,
+		now:      time.Now,
+	}
+}
+
+// Execute scores and records the answer of a player to the current
+// question. Answers received after the time limit, or once the presenter
+// closed the question, are rejected whether or not the players were told.
+func (uc *AnswerQuestionUseCase) Execute(ctx context.Context, input AnswerQuestionInput) (_ *entity.QuizAnswer, err error) {
+	ctx, span := tracing.Start(ctx, "AnswerQuestionUseCase.Execute", trace.WithAttributes(
+		attribute.String("quiz.id", input.QuizID.String()),
+		attribute.String("quiz.question_id", input.QuestionID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	// Le chrono s'arrête à la réception de la réponse, pas après les lectures en base
+	answeredAt := input.ReceivedAt
+	if answeredAt.IsZero() {
+		answeredAt = uc.now()
+	}
+
+	quiz, err := uc.quizRepo.GetByID(ctx, input.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz.IsFinished() {
+		return nil, entity.ErrQuizFinished
+	}
+
+	question := quiz.Current()
+	if question == nil || question.ID != input.QuestionID {
+		return nil, notCurrent(quiz, input.QuestionID)
+	}
+	if !quiz.Open(answeredAt) {
+		metrics.QuizAnswers.WithLabelValues(metrics.QuizAnswerLate).Inc()
+		return nil, entity.ErrQuestionClosed
+	}
+
+	player, err := uc.quizRepo.GetPlayer(ctx, quiz.ID, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	chosen, err := validateChoice(question, input.OptionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	elapsed := answeredAt.Sub(*quiz.QuestionStartedAt)
+	correct, points := entity.Score(question, chosen, elapsed)
+	answer := &entity.QuizAnswer{
+		QuizID:       quiz.ID,
+		QuestionID:   question.ID,
+		PlayerID:     player.ID,
+		OptionIDs:    chosen,
+		Correct:      correct,
+		Points:       points,
+		ResponseTime: elapsed.Milliseconds(),
+	}
+	if err := uc.quizRepo.CreateAnswer(ctx, answer); err != nil {
+		return nil, err
+	}
+
+	result := metrics.QuizAnswerWrong
+	if correct {
+		result = metrics.QuizAnswerCorrect
+	}
+	metrics.QuizAnswers.WithLabelValues(result).Inc()
+	slog.InfoContext(ctx, "quiz answer recorded", "quiz_id", quiz.ID, "question_id", question.ID, "response_time_ms", answer.ResponseTime)
+	return answer, nil
+}
+
+// notCurrent explains why questionID cannot be answered now: an earlier
+// question is over, a later one has not started
+func notCurrent(quiz *entity.Quiz, questionID uuid.UUID) error {
+	for _, question := range quiz.Questions {
+		if question.ID != questionID {
+			continue
+		}
+		if quiz.CurrentQuestion != nil && question.Position < *quiz.CurrentQuestion {
+			return entity.ErrQuestionClosed
+		}
+		return entity.ErrQuestionNotStarted
+	}
+	return apperror.Validation(apperror.FieldError{
+		Field: "question_id", Rule: "invalid", Message: "the quiz has no such question",
+	})
+}
+
+// validateChoice checks the options chosen for question and drops the
+// duplicates
+func validateChoice(question *entity.Poll, optionIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(optionIDs) == 0 {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "option_ids", Rule: "required", Message: "select at least one option",
+		})
+	}
+
+	options := make(map[uuid.UUID]bool, len(question.Options))
+	for _, option := range question.Options {
+		options[option.ID] = true
+	}
+	seen := make(map[uuid.UUID]bool, len(optionIDs))
+	chosen := make([]uuid.UUID, 0, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if !options[optionID] {
+			return nil, entity.ErrInvalidOption
+		}
+		if seen[optionID] {
+			continue
+		}
+		seen[optionID] = true
+		chosen = append(chosen, optionID)
+	}
+	if !question.MultiChoice && len(chosen) > 1 {
+		return nil, entity.ErrSingleChoice
+	}
+	return chosen, nil
+}
@@ -0,0 +1,170 @@
+package quiz_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/quiz"
+)
+
+// live est un quiz de deux questions dont la première est en cours depuis
+// cinq secondes
+type live struct {
+	quiz          *entity.Quiz
+	first, second *entity.Poll
+	right, wrong  uuid.UUID
+	player        *entity.QuizPlayer
+}
+
+func newLive() live {
+	l := live{right: uuid.New(), wrong: uuid.New()}
+	current := 0
+	startedAt := time.Now().Add(-5 * time.Second)
+	endsAt := startedAt.Add(20 * time.Second)
+	l.quiz = &entity.Quiz{
+		ID: uuid.New(), CreatedBy: "creator", IPSalt: "salt",
+		CurrentQuestion: &current, QuestionStartedAt: &startedAt, QuestionEndsAt: &endsAt,
+		Questions: []entity.Poll{
+			{ID: uuid.New(), Position: 0, TimeLimit: 20, Options: []entity.Option{{ID: l.right, Correct: true}, {ID: l.wrong}}},
+			{ID: uuid.New(), Position: 1, TimeLimit: 20, Options: []entity.Option{{ID: uuid.New(), Correct: true}, {ID: uuid.New()}}},
+		},
+	}
+	l.first, l.second = &l.quiz.Questions[0], &l.quiz.Questions[1]
+	l.player = &entity.QuizPlayer{ID: uuid.New(), QuizID: l.quiz.ID, Nickname: "Ada"}
+	return l
+}
+
+func TestAnswerQuestionUseCase_Execute(t *testing.T) {
+	l := newLive()
+	mockRepo := new(mocks.MockQuizRepository)
+	useCase := quiz.NewAnswerQuestionUseCase(mockRepo)
+
+	mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+	mockRepo.On("GetPlayer", mock.Anything, l.quiz.ID, l.player.ID).Return(l.player, nil)
+	mockRepo.On("CreateAnswer", mock.Anything, mock.AnythingOfType("*entity.QuizAnswer")).Return(nil)
+
+	answer, err := useCase.Execute(context.Background(), quiz.AnswerQuestionInput{
+		QuizID: l.quiz.ID, PlayerID: l.player.ID, QuestionID: l.first.ID, OptionIDs: []uuid.UUID{l.right, l.right},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{l.right}, answer.OptionIDs)
+	assert.True(t, answer.Correct)
+	// Cinq secondes sur vingt : un peu moins de 875 points le temps que le test s'exécute
+	assert.InDelta(t, 875, answer.Points, 5)
+	assert.InDelta(t, 5000, answer.ResponseTime, 100)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAnswerQuestionUseCase_ReceivedAt(t *testing.T) {
+	l := newLive()
+	mockRepo := new(mocks.MockQuizRepository)
+	useCase := quiz.NewAnswerQuestionUseCase(mockRepo)
+
+	mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+	mockRepo.On("GetPlayer", mock.Anything, l.quiz.ID, l.player.ID).Return(l.player, nil)
+	mockRepo.On("CreateAnswer", mock.Anything, mock.AnythingOfType("*entity.QuizAnswer")).Return(nil)
+
+	// Reçue à deux secondes, la réponse est notée comme telle quelle que soit la durée des lectures
+	answer, err := useCase.Execute(context.Background(), quiz.AnswerQuestionInput{
+		QuizID: l.quiz.ID, PlayerID: l.player.ID, QuestionID: l.first.ID, OptionIDs: []uuid.UUID{l.right},
+		ReceivedAt: l.quiz.QuestionStartedAt.Add(2 * time.Second),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), answer.ResponseTime)
+	assert.Equal(t, 950, answer.Points)
+}
+
+func TestAnswerQuestionUseCase_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(l *live) quiz.AnswerQuestionInput
+		code    string
+	}{
+		{
+			name: "question not started",
+			prepare: func(l *live) quiz.AnswerQuestionInput {
+				return quiz.AnswerQuestionInput{QuestionID: l.second.ID, OptionIDs: []uuid.UUID{l.second.Options[0].ID}}
+			},
+			code: "question_not_started",
+		},
+		{
+			name: "earlier question",
+			prepare: func(l *live) quiz.AnswerQuestionInput {
+				current := 1
+				l.quiz.CurrentQuestion = &current
+				return quiz.AnswerQuestionInput{QuestionID: l.first.ID, OptionIDs: []uuid.UUID{l.right}}
+			},
+			code: "question_closed",
+		},
+		{
+			name: "time is up",
+			prepare: func(l *live) quiz.AnswerQuestionInput {
+				endsAt := time.Now().Add(-time.Second)
+				l.quiz.QuestionEndsAt = &endsAt
+				return quiz.AnswerQuestionInput{QuestionID: l.first.ID, OptionIDs: []uuid.UUID{l.right}}
+			},
+			code: "question_closed",
+		},
+		{
+			name: "closed by the presenter",
+			prepare: func(l *live) quiz.AnswerQuestionInput {
+				closedAt := time.Now()
+				l.quiz.QuestionClosedAt = &closedAt
+				return quiz.AnswerQuestionInput{QuestionID: l.first.ID, OptionIDs: []uuid.UUID{l.right}}
+			},
+			code: "question_closed",
+		},
+		{
+			name: "quiz over",
+			prepare: func(l *live) quiz.AnswerQuestionInput {
+				finishedAt := time.Now()
+				l.quiz.FinishedAt = &finishedAt
+				return quiz.AnswerQuestionInput{QuestionID: l.first.ID, OptionIDs: []uuid.UUID{l.right}}
+			},
+			code: "quiz_finished",
+		},
+		{
+			name: "several options on a single choice question",
+			prepare: func(l *live) quiz.AnswerQuestionInput {
+				return quiz.AnswerQuestionInput{QuestionID: l.first.ID, OptionIDs: []uuid.UUID{l.right, l.wrong}}
+			},
+			code: entity.ErrSingleChoice.Code,
+		},
+		{
+			name: "option of another question",
+			prepare: func(l *live) quiz.AnswerQuestionInput {
+				return quiz.AnswerQuestionInput{QuestionID: l.first.ID, OptionIDs: []uuid.UUID{l.second.Options[0].ID}}
+			},
+			code: entity.ErrInvalidOption.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLive()
+			input := tt.prepare(&l)
+			input.QuizID, input.PlayerID = l.quiz.ID, l.player.ID
+			mockRepo := new(mocks.MockQuizRepository)
+			useCase := quiz.NewAnswerQuestionUseCase(mockRepo)
+			mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+			mockRepo.On("GetPlayer", mock.Anything, l.quiz.ID, l.player.ID).Return(l.player, nil).Maybe()
+
+			_, err := useCase.Execute(context.Background(), input)
+
+			var appErr *apperror.Error
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+			mockRepo.AssertNotCalled(t, "CreateAnswer", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package quiz
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// maxQuestions bounds the number of questions of a quiz
+const maxQuestions = 50
+
+// Bounds of the time limit of a question, in seconds
+const (
+	defaultTimeLimit = 20
+	minTimeLimit     = 5
+	maxTimeLimit     = 300
+)
+
+// CreateQuizInput represents the input for creating a quiz
+type CreateQuizInput struct {
+	Title       string          `json:"title" example:"Onboarding quiz"`
+	Description string          `json:"description" example:"How well do you know our tooling?"`
+	Questions   []QuestionInput `json:"questions"`
+	// CreatedBy is the client IP address, ignored when the caller is authenticated
+	CreatedBy string `json:"-"`
+}
+
+// QuestionInput is one question of a quiz with its correct options
+type QuestionInput struct {
+	Title   string   `json:"title" example:"Which command formats Go code?"`
+	Options []string `json:"options" example:"gofmt,go vet,golint"`
+	// Correct lists the positions of the correct options; with several,
+	// players must pick all of them
+	Correct []int `json:"correct" example:"0"`
+	// TimeLimit is the number of seconds to answer, 20 when omitted
+	TimeLimit int `json:"time_limit" example:"20"`
+}
+
+// CreateQuizOutput represents the output after creating a quiz
+type CreateQuizOutput struct {
+	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	ShareURL  string    `json:"share_url" example:"http://localhost:8080/quiz/550e8400-e29b-41d4-a716-446655440003"`
+	QRCodeURL string    `json:"qr_code_url" example:"http://localhost:8080/api/v1/quizzes/550e8400-e29b-41d4-a716-446655440003/qr"`
+}
+
+type CreateQuizUseCase struct {
+	quizRepo repository.QuizRepository
+	baseURL  string
+	ipHasher *privacy.IPHasher
+}
+
+// NewCreateQuizUseCase creates the use case; ipHasher may be nil to keep
+// the creator IP address in clear
+func NewCreateQuizUseCase(quizRepo repository.QuizRepository, baseURL string, ipHasher *privacy.IPHasher) *CreateQuizUseCase {
+	return &CreateQuizUseCase{
+		quizRepo: quizRepo,
+		baseURL:  baseURL,
+		ipHasher: ipHasher,
+	}
+}
+
+func (uc *CreateQuizUseCase) Execute(ctx context.Context, input CreateQuizInput) (_ *CreateQuizOutput, err error) {
+	ctx, span := tracing.Start(ctx, "CreateQuizUseCase.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateInput(input); err != nil {
+		return nil, err
+	}
+
+	salt := entity.NewIPSalt()
+	createdBy := uc.ipHasher.Identity(salt, input.CreatedBy)
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		createdBy = principal.Actor
+	}
+
+	quiz := &entity.Quiz{
+		Title:       input.Title,
+		Description: input.Description,
+		CreatedBy:   createdBy,
+		IPSalt:      salt,
+	}
+	// Chaque question est un sondage du quiz, à choix multiple dès qu'elle a plusieurs bonnes réponses
+	for i, question := range input.Questions {
+		timeLimit := question.TimeLimit
+		if timeLimit == 0 {
+			timeLimit = defaultTimeLimit
+		}
+		correct := make(map[int]bool, len(question.Correct))
+		for _, position := range question.Correct {
+			correct[position] = true
+		}
+		poll := entity.Poll{
+			Title:       question.Title,
+			CreatedBy:   createdBy,
+			MultiChoice: len(correct) > 1,
+			IPSalt:      salt,
+			Position:    i,
+			Type:        entity.PollTypeChoice,
+			TimeLimit:   timeLimit,
+		}
+		for j, text := range question.Options {
+			poll.Options = append(poll.Options, entity.Option{Text: text, Order: j, Correct: correct[j]})
+		}
+		quiz.Questions = append(quiz.Questions, poll)
+	}
+
+	if err := uc.quizRepo.Create(ctx, quiz); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("quiz.id", quiz.ID.String()))
+	slog.InfoContext(ctx, "quiz created", "quiz_id", quiz.ID, "questions", len(quiz.Questions))
+
+	return &CreateQuizOutput{
+		ID:        quiz.ID,
+		ShareURL:  uc.baseURL + "/quiz/" + quiz.ID.String(),
+		QRCodeURL: uc.baseURL + "/api/v1/quizzes/" + quiz.ID.String() + "/qr",
+	}, nil
+}
+
+// validateInput validates the quiz creation input
+func validateInput(input CreateQuizInput) error {
+	var fields []apperror.FieldError
+	reject := func(field, rule, param, message string) {
+		fields = append(fields, apperror.FieldError{Field: field, Rule: rule, Param: param, Message: message})
+	}
+
+	switch {
+	case strings.TrimSpace(input.Title) == "":
+		reject("title", "required", "", "title cannot be empty")
+	case len(input.Title) < 3:
+		reject("title", "min", "3", "title must be at least 3 characters long")
+	case len(input.Title) > 255:
+		reject("title", "max", "255", "title must be no more than 255 characters long")
+	}
+	if len(input.Description) > 500 {
+		reject("description", "max", "500", "description must be no more than 500 characters long")
+	}
+
+	if len(input.Questions) == 0 {
+		reject("questions", "required", "", "a quiz needs at least one question")
+	}
+	if len(input.Questions) > maxQuestions {
+		reject("questions", "max", strconv.Itoa(maxQuestions), fmt.Sprintf("a quiz can have at most %d questions", maxQuestions))
+	}
+
+	for i, question := range input.Questions {
+		field := fmt.Sprintf("questions[%d]", i)
+		number := i + 1
+
+		switch {
+		case strings.TrimSpace(question.Title) == "":
+			reject(field+".title", "required", "", fmt.Sprintf("question %d needs a title", number))
+		case len(question.Title) < 3:
+			reject(field+".title", "min", "3", fmt.Sprintf("title of question %d must be at least 3 characters long", number))
+		case len(question.Title) > 255:
+			reject(field+".title", "max", "255", fmt.Sprintf("title of question %d must be no more than 255 characters long", number))
+		}
+
+		if len(question.Options) < 2 {
+			reject(field+".options", "min", "2", fmt.Sprintf("question %d must have at least 2 options", number))
+		}
+		if len(question.Options) > entity.MaxOptions {
+			reject(field+".options", "max", strconv.Itoa(entity.MaxOptions), fmt.Sprintf("question %d can have at most %d options", number, entity.MaxOptions))
+		}
+		seen := make(map[string]bool, len(question.Options))
+		for j, option := range question.Options {
+			optionField := fmt.Sprintf("%s.options[%d]", field, j)
+			clean := entity.OptionKey(option)
+			switch {
+			case clean == "":
+				reject(optionField, "required", "", fmt.Sprintf("option %d of question %d cannot be empty", j+1, number))
+			case len(option) > 255:
+				reject(optionField, "max", "255", fmt.Sprintf("option %d of question %d must be no more than 255 characters long", j+1, number))
+			case seen[clean]:
+				reject(optionField, "unique", "", fmt.Sprintf("question %d has duplicate options", number))
+			}
+			seen[clean] = true
+		}
+
+		if len(question.Correct) == 0 {
+			reject(field+".correct", "required", "", fmt.Sprintf("question %d needs at least one correct option", number))
+		}
+		correct := make(map[int]bool, len(question.Correct))
+		for _, position := range question.Correct {
+			if position < 0 || position >= len(question.Options) {
+				reject(field+".correct", "oneof", "", fmt.Sprintf("question %d marks an unknown option as correct", number))
+				break
+			}
+			if correct[position] {
+				reject(field+".correct", "unique", "", fmt.Sprintf("question %d marks the same option as correct twice", number))
+				break
+			}
+			correct[position] = true
+		}
+
+		if question.TimeLimit != 0 && question.TimeLimit < minTimeLimit {
+			reject(field+".time_limit", "min", strconv.Itoa(minTimeLimit), fmt.Sprintf("question %d needs at least %d seconds to answer", number, minTimeLimit))
+		}
+		if question.TimeLimit > maxTimeLimit {
+			reject(field+".time_limit", "max", strconv.Itoa(maxTimeLimit), fmt.Sprintf("question %d cannot give more than %d seconds to answer", number, maxTimeLimit))
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
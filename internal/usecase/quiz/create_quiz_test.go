@@ -0,0 +1,91 @@
+package quiz_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/quiz"
+)
+
+func TestCreateQuizUseCase_Execute(t *testing.T) {
+	mockRepo := new(mocks.MockQuizRepository)
+	useCase := quiz.NewCreateQuizUseCase(mockRepo, "http://localhost:8080", nil)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Quiz")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			q := args.Get(1).(*entity.Quiz)
+			// Simulate GORM BeforeCreate hook
+			q.ID = uuid.New()
+
+			require.Len(t, q.Questions, 2)
+			first, second := q.Questions[0], q.Questions[1]
+			assert.False(t, first.MultiChoice)
+			assert.Equal(t, 20, first.TimeLimit)
+			assert.True(t, first.Options[1].Correct)
+			assert.False(t, first.Options[0].Correct)
+
+			// Plusieurs bonnes réponses : la question devient à choix multiple
+			assert.Equal(t, 1, second.Position)
+			assert.True(t, second.MultiChoice)
+			assert.Equal(t, 45, second.TimeLimit)
+			assert.Equal(t, q.IPSalt, second.IPSalt)
+			assert.Equal(t, "creator", second.CreatedBy)
+		})
+
+	output, err := useCase.Execute(context.Background(), quiz.CreateQuizInput{
+		Title: "Onboarding quiz",
+		Questions: []quiz.QuestionInput{
+			{Title: "Which command formats Go code?", Options: []string{"go vet", "gofmt"}, Correct: []int{1}},
+			{Title: "Which are Go keywords?", Options: []string{"defer", "select", "lambda"}, Correct: []int{0, 1}, TimeLimit: 45},
+		},
+		CreatedBy: "creator",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/quiz/"+output.ID.String(), output.ShareURL)
+	assert.Equal(t, "http://localhost:8080/api/v1/quizzes/"+output.ID.String()+"/qr", output.QRCodeURL)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateQuizUseCase_Validation(t *testing.T) {
+	question := func(correct []int, timeLimit int) []quiz.QuestionInput {
+		return []quiz.QuestionInput{{Title: "Which command formats Go code?", Options: []string{"go vet", "gofmt"}, Correct: correct, TimeLimit: timeLimit}}
+	}
+	tests := []struct {
+		name  string
+		input quiz.CreateQuizInput
+		field string
+		rule  string
+	}{
+		{"no question", quiz.CreateQuizInput{Title: "Onboarding quiz"}, "questions", "required"},
+		{"no correct option", quiz.CreateQuizInput{Title: "Onboarding quiz", Questions: question(nil, 0)}, "questions[0].correct", "required"},
+		{"unknown correct option", quiz.CreateQuizInput{Title: "Onboarding quiz", Questions: question([]int{2}, 0)}, "questions[0].correct", "oneof"},
+		{"same correct option twice", quiz.CreateQuizInput{Title: "Onboarding quiz", Questions: question([]int{1, 1}, 0)}, "questions[0].correct", "unique"},
+		{"time limit too short", quiz.CreateQuizInput{Title: "Onboarding quiz", Questions: question([]int{1}, 2)}, "questions[0].time_limit", "min"},
+		{"time limit too long", quiz.CreateQuizInput{Title: "Onboarding quiz", Questions: question([]int{1}, 600)}, "questions[0].time_limit", "max"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockQuizRepository)
+			useCase := quiz.NewCreateQuizUseCase(mockRepo, "http://localhost:8080", nil)
+
+			_, err := useCase.Execute(context.Background(), tt.input)
+
+			var appErr *apperror.Error
+			require.ErrorAs(t, err, &appErr)
+			require.NotEmpty(t, appErr.Fields)
+			assert.Equal(t, tt.field, appErr.Fields[0].Field)
+			assert.Equal(t, tt.rule, appErr.Fields[0].Rule)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
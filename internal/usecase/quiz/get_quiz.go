@@ -0,0 +1,94 @@
+package quiz
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+type GetQuizUseCase struct {
+	quizRepo repository.QuizRepository
+	now      func() time.Time
+}
+
+func NewGetQuizUseCase(quizRepo repository.QuizRepository) *GetQuizUseCase {
+	return &GetQuizUseCase{
+		quizRepo: quizRepo,
+		now:      time.Now,
+	}
+}
+
+// Execute returns the quiz with the questions started so far; the correct
+// options of the current question stay hidden until it is over
+func (uc *GetQuizUseCase) Execute(ctx context.Context, quizID uuid.UUID) (_ *entity.Quiz, err error) {
+	ctx, span := tracing.Start(ctx, "GetQuizUseCase.Execute", trace.WithAttributes(
+		attribute.String("quiz.id", quizID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	quiz, err := uc.quizRepo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	quiz.HideUpcoming(uc.now())
+	return quiz, nil
+}
+
+// Leaderboard ranks the players of the quiz by score, leaving out the
+// current question while it is open
+func (uc *GetQuizUseCase) Leaderboard(ctx context.Context, quizID uuid.UUID) (_ *entity.Leaderboard, err error) {
+	ctx, span := tracing.Start(ctx, "GetQuizUseCase.Leaderboard", trace.WithAttributes(
+		attribute.String("quiz.id", quizID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	quiz, err := uc.quizRepo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	board, err := leaderboard(ctx, uc.quizRepo, quiz, now)
+	if err != nil {
+		return nil, err
+	}
+	board.QuestionDue = quiz.Due(now)
+	return board, nil
+}
+
+// leaderboard ranks the players of quiz at the given time, final once the
+// quiz is over
+func leaderboard(ctx context.Context, quizRepo repository.QuizRepository, quiz *entity.Quiz, at time.Time) (*entity.Leaderboard, error) {
+	scores, err := quizRepo.ListScores(ctx, quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Les points de la question en cours trahiraient les bonnes réponses avant la fin du chrono
+	if quiz.Open(at) {
+		answers, err := quizRepo.ListAnswers(ctx, quiz.Current().ID)
+		if err != nil {
+			return nil, err
+		}
+		pending := make(map[uuid.UUID]*entity.QuizAnswer, len(answers))
+		for _, answer := range answers {
+			pending[answer.PlayerID] = answer
+		}
+		for i := range scores {
+			if answer, ok := pending[scores[i].PlayerID]; ok {
+				scores[i].Score -= answer.Points
+				if answer.Correct {
+					scores[i].Correct--
+				}
+			}
+		}
+	}
+	return &entity.Leaderboard{
+		Final:   quiz.IsFinished(),
+		Entries: entity.RankPlayers(scores),
+	}, nil
+}
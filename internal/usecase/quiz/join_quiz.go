@@ -0,0 +1,87 @@
+package quiz
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// JoinQuizInput carries the nickname of a new player and their raw
+// identity, hashed before being persisted in IP privacy mode
+type JoinQuizInput struct {
+	QuizID    uuid.UUID `json:"-"`
+	Nickname  string    `json:"nickname" example:"Ada"`
+	VoterID   string    `json:"-"`
+	IPAddress string    `json:"-"`
+	UserAgent string    `json:"-"`
+}
+
+type JoinQuizUseCase struct {
+	quizRepo repository.QuizRepository
+	ipHasher *privacy.IPHasher
+}
+
+// NewJoinQuizUseCase creates the use case; ipHasher may be nil to keep
+// player IP addresses in clear
+func NewJoinQuizUseCase(quizRepo repository.QuizRepository, ipHasher *privacy.IPHasher) *JoinQuizUseCase {
+	return &JoinQuizUseCase{
+		quizRepo: quizRepo,
+		ipHasher: ipHasher,
+	}
+}
+
+// Execute adds a player to the quiz under a nickname unique within it.
+// Players may join until the quiz is over.
+func (uc *JoinQuizUseCase) Execute(ctx context.Context, input JoinQuizInput) (_ *entity.QuizPlayer, err error) {
+	ctx, span := tracing.Start(ctx, "JoinQuizUseCase.Execute", trace.WithAttributes(
+		attribute.String("quiz.id", input.QuizID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	quiz, err := uc.quizRepo.GetByID(ctx, input.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz.IsFinished() {
+		return nil, entity.ErrQuizFinished
+	}
+
+	nickname := entity.SanitizeResponse(input.Nickname)
+	if nickname == "" {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "nickname", Rule: "required", Message: "nickname cannot be empty",
+		})
+	}
+	if utf8.RuneCountInString(nickname) > entity.MaxNicknameLength {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "nickname", Rule: "max", Param: strconv.Itoa(entity.MaxNicknameLength),
+			Message: "nickname must be no more than 30 characters long",
+		})
+	}
+
+	player := &entity.QuizPlayer{
+		QuizID:    quiz.ID,
+		Nickname:  nickname,
+		VoterID:   uc.ipHasher.Identity(quiz.IPSalt, input.VoterID),
+		IPAddress: uc.ipHasher.Identity(quiz.IPSalt, input.IPAddress),
+		UserAgent: input.UserAgent,
+	}
+	if err := uc.quizRepo.CreatePlayer(ctx, player); err != nil {
+		return nil, err
+	}
+
+	metrics.QuizPlayers.Inc()
+	slog.InfoContext(ctx, "quiz player joined", "quiz_id", quiz.ID, "player_id", player.ID)
+	return player, nil
+}
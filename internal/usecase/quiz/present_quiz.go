@@ -0,0 +1,226 @@
+package quiz
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+var ErrNotQuizCreator = apperror.Forbidden("not_quiz_creator", "only the creator of the quiz can do this")
+
+// QuizStep is what happened when the quiz moved on: the question that
+// ended, the question that started and the leaderboard after the ended
+// question, each nil when it does not apply
+type QuizStep struct {
+	Ended       *entity.QuestionResult `json:"ended,omitempty"`
+	Started     *entity.QuestionStart  `json:"started,omitempty"`
+	Leaderboard *entity.Leaderboard    `json:"leaderboard,omitempty"`
+}
+
+// PresentQuizUseCase lets the creator of a quiz run it question after
+// question, and closes the questions whose time is up
+type PresentQuizUseCase struct {
+	quizRepo repository.QuizRepository
+	ipHasher *privacy.IPHasher
+	now      func() time.Time
+}
+
+func NewPresentQuizUseCase(quizRepo repository.QuizRepository, ipHasher *privacy.IPHasher) *PresentQuizUseCase {
+	return &PresentQuizUseCase{
+		quizRepo: quizRepo,
+		ipHasher: ipHasher,
+		now:      time.Now,
+	}
+}
+
+// Next ends the current question if still open and starts the next one;
+// after the last question it finishes the quiz with the final leaderboard
+func (uc *PresentQuizUseCase) Next(ctx context.Context, quizID uuid.UUID, requester string) (_ *QuizStep, err error) {
+	ctx, span := tracing.Start(ctx, "PresentQuizUseCase.Next", trace.WithAttributes(
+		attribute.String("quiz.id", quizID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	quiz, err := uc.quizRepo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if !uc.ipHasher.IsCreator(ctx, quiz.CreatedBy, quiz.IPSalt, requester) {
+		return nil, ErrNotQuizCreator
+	}
+	if quiz.IsFinished() {
+		return nil, entity.ErrQuizFinished
+	}
+
+	now := uc.now()
+	step := &QuizStep{}
+	if current := quiz.Current(); current != nil && quiz.QuestionClosedAt == nil {
+		if step.Ended, err = uc.close(ctx, quiz, current, now); err != nil {
+			return nil, err
+		}
+		if step.Ended != nil {
+			if step.Leaderboard, err = leaderboard(ctx, uc.quizRepo, quiz, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	next := quiz.Next()
+	if next == nil {
+		if err := uc.quizRepo.Finish(ctx, quiz.ID, now); err != nil {
+			return nil, err
+		}
+		quiz.FinishedAt = &now
+		if step.Leaderboard, err = leaderboard(ctx, uc.quizRepo, quiz, now); err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, "quiz finished", "quiz_id", quiz.ID)
+		return step, nil
+	}
+
+	endsAt := now.Add(time.Duration(next.TimeLimit) * time.Second)
+	started, err := uc.quizRepo.StartQuestion(ctx, quiz.ID, next.Position, now, endsAt)
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, entity.ErrQuizMovedOn
+	}
+
+	// Copie de la question : les bonnes réponses ne partent pas avec l'énoncé
+	question := *next
+	question.Options = append([]entity.Option(nil), next.Options...)
+	question.HideCorrect()
+	step.Started = &entity.QuestionStart{
+		Question:  &question,
+		TimeLimit: next.TimeLimit,
+		StartedAt: now,
+		EndsAt:    endsAt,
+	}
+	slog.InfoContext(ctx, "quiz question started", "quiz_id", quiz.ID, "position", next.Position)
+	return step, nil
+}
+
+// Close ends the current question before its time is up
+func (uc *PresentQuizUseCase) Close(ctx context.Context, quizID uuid.UUID, requester string) (_ *QuizStep, err error) {
+	ctx, span := tracing.Start(ctx, "PresentQuizUseCase.Close", trace.WithAttributes(
+		attribute.String("quiz.id", quizID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	quiz, err := uc.quizRepo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if !uc.ipHasher.IsCreator(ctx, quiz.CreatedBy, quiz.IPSalt, requester) {
+		return nil, ErrNotQuizCreator
+	}
+	if quiz.IsFinished() {
+		return nil, entity.ErrQuizFinished
+	}
+	current := quiz.Current()
+	if current == nil {
+		return nil, entity.ErrQuestionNotStarted
+	}
+	if quiz.QuestionClosedAt != nil {
+		return nil, entity.ErrQuestionClosed
+	}
+
+	ended, err := uc.close(ctx, quiz, current, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	if ended == nil {
+		return nil, entity.ErrQuestionClosed
+	}
+	return uc.withLeaderboard(ctx, quiz, ended)
+}
+
+// Expire ends the question at position once its time is up. It returns a
+// nil step when the question was already closed or the quiz moved on.
+func (uc *PresentQuizUseCase) Expire(ctx context.Context, quizID uuid.UUID, position int) (_ *QuizStep, err error) {
+	ctx, span := tracing.Start(ctx, "PresentQuizUseCase.Expire", trace.WithAttributes(
+		attribute.String("quiz.id", quizID.String()),
+		attribute.Int("quiz.position", position),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	quiz, err := uc.quizRepo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	current := quiz.Current()
+	if quiz.IsFinished() || current == nil || current.Position != position || quiz.QuestionClosedAt != nil {
+		return nil, nil
+	}
+	return uc.expire(ctx, quiz, current)
+}
+
+// ExpireDue ends the current question of the quiz if its time is up but
+// nobody closed it yet. It returns a nil step when there is nothing to end.
+func (uc *PresentQuizUseCase) ExpireDue(ctx context.Context, quizID uuid.UUID) (_ *QuizStep, err error) {
+	ctx, span := tracing.Start(ctx, "PresentQuizUseCase.ExpireDue", trace.WithAttributes(
+		attribute.String("quiz.id", quizID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	quiz, err := uc.quizRepo.GetByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if !quiz.Due(uc.now()) {
+		return nil, nil
+	}
+	return uc.expire(ctx, quiz, quiz.Current())
+}
+
+// expire ends question, the current one of quiz, with the leaderboard it
+// leads to; nil when another call closed it first
+func (uc *PresentQuizUseCase) expire(ctx context.Context, quiz *entity.Quiz, question *entity.Poll) (*QuizStep, error) {
+	ended, err := uc.close(ctx, quiz, question, uc.now())
+	if err != nil || ended == nil {
+		return nil, err
+	}
+	return uc.withLeaderboard(ctx, quiz, ended)
+}
+
+// withLeaderboard completes the step of a question that ended with the
+// leaderboard it leads to
+func (uc *PresentQuizUseCase) withLeaderboard(ctx context.Context, quiz *entity.Quiz, ended *entity.QuestionResult) (*QuizStep, error) {
+	board, err := leaderboard(ctx, uc.quizRepo, quiz, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	return &QuizStep{Ended: ended, Leaderboard: board}, nil
+}
+
+// close ends question at the given time, or at its deadline if already
+// past, and tallies its answers. It returns nil when another call closed
+// the question first.
+func (uc *PresentQuizUseCase) close(ctx context.Context, quiz *entity.Quiz, question *entity.Poll, at time.Time) (*entity.QuestionResult, error) {
+	closedAt := at
+	if quiz.QuestionEndsAt != nil && closedAt.After(*quiz.QuestionEndsAt) {
+		closedAt = *quiz.QuestionEndsAt
+	}
+	closed, err := uc.quizRepo.CloseQuestion(ctx, quiz.ID, question.Position, closedAt)
+	if err != nil || !closed {
+		return nil, err
+	}
+	quiz.QuestionClosedAt = &closedAt
+
+	answers, err := uc.quizRepo.ListAnswers(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "quiz question closed", "quiz_id", quiz.ID, "position", question.Position, "answers", len(answers))
+	return entity.NewQuestionResult(question, answers), nil
+}
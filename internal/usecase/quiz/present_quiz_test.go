@@ -0,0 +1,218 @@
+package quiz_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/quiz"
+)
+
+func TestPresentQuizUseCase_Next(t *testing.T) {
+	l := newLive()
+	mockRepo := new(mocks.MockQuizRepository)
+	useCase := quiz.NewPresentQuizUseCase(mockRepo, nil)
+
+	mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+	mockRepo.On("CloseQuestion", mock.Anything, l.quiz.ID, 0, mock.Anything).Return(true, nil)
+	mockRepo.On("ListAnswers", mock.Anything, l.first.ID).Return([]*entity.QuizAnswer{
+		{PlayerID: l.player.ID, OptionIDs: []uuid.UUID{l.right}, Correct: true, Points: 900},
+	}, nil)
+	mockRepo.On("ListScores", mock.Anything, l.quiz.ID).Return([]entity.PlayerScore{
+		{PlayerID: l.player.ID, Nickname: "Ada", Score: 900, Correct: 1},
+	}, nil)
+	mockRepo.On("StartQuestion", mock.Anything, l.quiz.ID, 1, mock.Anything, mock.Anything).Return(true, nil)
+
+	step, err := useCase.Next(context.Background(), l.quiz.ID, "creator")
+
+	require.NoError(t, err)
+	require.NotNil(t, step.Ended)
+	assert.Equal(t, []uuid.UUID{l.right}, step.Ended.Correct)
+	assert.Equal(t, 1, step.Ended.CorrectAnswers)
+	require.NotNil(t, step.Leaderboard)
+	assert.False(t, step.Leaderboard.Final)
+	assert.Equal(t, []entity.LeaderboardEntry{{Rank: 1, Nickname: "Ada", Score: 900, Correct: 1}}, step.Leaderboard.Entries)
+
+	require.NotNil(t, step.Started)
+	assert.Equal(t, l.second.ID, step.Started.Question.ID)
+	assert.Equal(t, 20*time.Second, step.Started.EndsAt.Sub(step.Started.StartedAt))
+	// La question diffusée n'a pas ses bonnes réponses, le quiz en mémoire les garde
+	assert.False(t, step.Started.Question.Options[0].Correct)
+	assert.True(t, l.second.Options[0].Correct)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPresentQuizUseCase_NextAfterLastQuestion(t *testing.T) {
+	l := newLive()
+	current := 1
+	closedAt := time.Now()
+	l.quiz.CurrentQuestion, l.quiz.QuestionClosedAt = &current, &closedAt
+	mockRepo := new(mocks.MockQuizRepository)
+	useCase := quiz.NewPresentQuizUseCase(mockRepo, nil)
+
+	mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+	mockRepo.On("Finish", mock.Anything, l.quiz.ID, mock.Anything).Return(nil)
+	mockRepo.On("ListScores", mock.Anything, l.quiz.ID).Return([]entity.PlayerScore{{Nickname: "Ada", Score: 1800, Correct: 2}}, nil)
+
+	step, err := useCase.Next(context.Background(), l.quiz.ID, "creator")
+
+	require.NoError(t, err)
+	assert.Nil(t, step.Ended)
+	assert.Nil(t, step.Started)
+	require.NotNil(t, step.Leaderboard)
+	assert.True(t, step.Leaderboard.Final)
+	mockRepo.AssertNotCalled(t, "CloseQuestion", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPresentQuizUseCase_NextRejections(t *testing.T) {
+	t.Run("not the creator", func(t *testing.T) {
+		l := newLive()
+		mockRepo := new(mocks.MockQuizRepository)
+		mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+
+		_, err := quiz.NewPresentQuizUseCase(mockRepo, nil).Next(context.Background(), l.quiz.ID, "someone else")
+
+		assert.ErrorIs(t, err, quiz.ErrNotQuizCreator)
+		mockRepo.AssertNotCalled(t, "StartQuestion", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("moved on in the meantime", func(t *testing.T) {
+		l := newLive()
+		mockRepo := new(mocks.MockQuizRepository)
+		mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+		mockRepo.On("CloseQuestion", mock.Anything, l.quiz.ID, 0, mock.Anything).Return(false, nil)
+		mockRepo.On("StartQuestion", mock.Anything, l.quiz.ID, 1, mock.Anything, mock.Anything).Return(false, nil)
+
+		_, err := quiz.NewPresentQuizUseCase(mockRepo, nil).Next(context.Background(), l.quiz.ID, "creator")
+
+		assert.ErrorIs(t, err, entity.ErrQuizMovedOn)
+	})
+}
+
+func TestPresentQuizUseCase_Expire(t *testing.T) {
+	t.Run("time is up", func(t *testing.T) {
+		l := newLive()
+		endsAt := time.Now().Add(-time.Second)
+		l.quiz.QuestionEndsAt = &endsAt
+		mockRepo := new(mocks.MockQuizRepository)
+		mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+		// La question est close à son échéance, même si le minuteur se déclenche en retard
+		mockRepo.On("CloseQuestion", mock.Anything, l.quiz.ID, 0, endsAt).Return(true, nil)
+		mockRepo.On("ListAnswers", mock.Anything, l.first.ID).Return([]*entity.QuizAnswer{}, nil)
+		mockRepo.On("ListScores", mock.Anything, l.quiz.ID).Return([]entity.PlayerScore{}, nil)
+
+		step, err := quiz.NewPresentQuizUseCase(mockRepo, nil).Expire(context.Background(), l.quiz.ID, 0)
+
+		require.NoError(t, err)
+		require.NotNil(t, step)
+		assert.Equal(t, l.first.ID, step.Ended.QuestionID)
+		assert.NotNil(t, step.Leaderboard)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("already closed", func(t *testing.T) {
+		l := newLive()
+		closedAt := time.Now()
+		l.quiz.QuestionClosedAt = &closedAt
+		mockRepo := new(mocks.MockQuizRepository)
+		mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+
+		step, err := quiz.NewPresentQuizUseCase(mockRepo, nil).Expire(context.Background(), l.quiz.ID, 0)
+
+		require.NoError(t, err)
+		assert.Nil(t, step)
+		mockRepo.AssertNotCalled(t, "CloseQuestion", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPresentQuizUseCase_ExpireDue(t *testing.T) {
+	t.Run("time is up", func(t *testing.T) {
+		l := newLive()
+		endsAt := time.Now().Add(-time.Minute)
+		l.quiz.QuestionEndsAt = &endsAt
+		mockRepo := new(mocks.MockQuizRepository)
+		mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+		// Sans minuteur (redémarrage, autre instance), la lecture suivante clôt la question à son échéance
+		mockRepo.On("CloseQuestion", mock.Anything, l.quiz.ID, 0, endsAt).Return(true, nil)
+		mockRepo.On("ListAnswers", mock.Anything, l.first.ID).Return([]*entity.QuizAnswer{}, nil)
+		mockRepo.On("ListScores", mock.Anything, l.quiz.ID).Return([]entity.PlayerScore{}, nil)
+
+		step, err := quiz.NewPresentQuizUseCase(mockRepo, nil).ExpireDue(context.Background(), l.quiz.ID)
+
+		require.NoError(t, err)
+		require.NotNil(t, step)
+		assert.Equal(t, l.first.ID, step.Ended.QuestionID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("question still open", func(t *testing.T) {
+		l := newLive()
+		mockRepo := new(mocks.MockQuizRepository)
+		mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+
+		step, err := quiz.NewPresentQuizUseCase(mockRepo, nil).ExpireDue(context.Background(), l.quiz.ID)
+
+		require.NoError(t, err)
+		assert.Nil(t, step)
+		mockRepo.AssertNotCalled(t, "CloseQuestion", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("closed by another call", func(t *testing.T) {
+		l := newLive()
+		endsAt := time.Now().Add(-time.Minute)
+		l.quiz.QuestionEndsAt = &endsAt
+		mockRepo := new(mocks.MockQuizRepository)
+		mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+		mockRepo.On("CloseQuestion", mock.Anything, l.quiz.ID, 0, endsAt).Return(false, nil)
+
+		step, err := quiz.NewPresentQuizUseCase(mockRepo, nil).ExpireDue(context.Background(), l.quiz.ID)
+
+		require.NoError(t, err)
+		assert.Nil(t, step)
+		mockRepo.AssertNotCalled(t, "ListAnswers", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetQuizUseCase_LeaderboardHidesOpenQuestion(t *testing.T) {
+	l := newLive()
+	mockRepo := new(mocks.MockQuizRepository)
+	mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+	mockRepo.On("ListScores", mock.Anything, l.quiz.ID).Return([]entity.PlayerScore{
+		{PlayerID: l.player.ID, Nickname: "Ada", Score: 1700, Correct: 2},
+	}, nil)
+	mockRepo.On("ListAnswers", mock.Anything, l.first.ID).Return([]*entity.QuizAnswer{
+		{PlayerID: l.player.ID, Correct: true, Points: 900},
+	}, nil)
+
+	board, err := quiz.NewGetQuizUseCase(mockRepo).Leaderboard(context.Background(), l.quiz.ID)
+
+	// Les points de la question en cours ne comptent qu'une fois le chrono écoulé
+	require.NoError(t, err)
+	assert.Equal(t, []entity.LeaderboardEntry{{Rank: 1, Nickname: "Ada", Score: 800, Correct: 1}}, board.Entries)
+	assert.False(t, board.QuestionDue)
+}
+
+func TestGetQuizUseCase_LeaderboardQuestionDue(t *testing.T) {
+	l := newLive()
+	endsAt := time.Now().Add(-time.Second)
+	l.quiz.QuestionEndsAt = &endsAt
+	mockRepo := new(mocks.MockQuizRepository)
+	mockRepo.On("GetByID", mock.Anything, l.quiz.ID).Return(l.quiz, nil)
+	mockRepo.On("ListScores", mock.Anything, l.quiz.ID).Return([]entity.PlayerScore{
+		{PlayerID: l.player.ID, Nickname: "Ada", Score: 1700, Correct: 2},
+	}, nil)
+
+	board, err := quiz.NewGetQuizUseCase(mockRepo).Leaderboard(context.Background(), l.quiz.ID)
+
+	// Chrono écoulé sans clôture : le handler doit clore la question
+	require.NoError(t, err)
+	assert.True(t, board.QuestionDue)
+	assert.Equal(t, 1700, board.Entries[0].Score)
+}
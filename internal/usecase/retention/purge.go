@@ -0,0 +1,140 @@
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+const day = 24 * time.Hour
+
+// Policy holds the retention periods; a period of 0 days disables the rule
+type Policy struct {
+	DeletedPollDays   int
+	VoterDataDays     int
+	VoterDataAction   repository.VoterDataAction
+	ArchiveClosedDays int
+	BatchSize         int
+}
+
+// PurgeInput selects between applying the policy and only reporting its effect
+type PurgeInput struct {
+	DryRun bool
+}
+
+// PurgeReport lists the rows affected, or that would be affected in a dry run
+type PurgeReport struct {
+	DryRun             bool      `json:"dry_run"`
+	RanAt              time.Time `json:"ran_at"`
+	DeletedPolls       int64     `json:"deleted_polls"`
+	AnonymizedBallots  int64     `json:"anonymized_ballots"`
+	ArchivedPolls      int64     `json:"archived_polls"`
+	ArchivedBallots    int64     `json:"archived_ballots"`
+	DeletedPollsBefore time.Time `json:"deleted_polls_before,omitempty"`
+	VoterDataBefore    time.Time `json:"voter_data_before,omitempty"`
+	ClosedBefore       time.Time `json:"closed_before,omitempty"`
+}
+
+type PurgeUseCase struct {
+	retentionRepo repository.RetentionRepository
+	policy        Policy
+	now           func() time.Time
+}
+
+func NewPurgeUseCase(retentionRepo repository.RetentionRepository, policy Policy) *PurgeUseCase {
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = 500
+	}
+	return &PurgeUseCase{
+		retentionRepo: retentionRepo,
+		policy:        policy,
+		now:           time.Now,
+	}
+}
+
+func (uc *PurgeUseCase) Execute(ctx context.Context, input PurgeInput) (_ *PurgeReport, err error) {
+	ctx, span := tracing.Start(ctx, "PurgeUseCase.Execute", trace.WithAttributes(
+		attribute.Bool("retention.dry_run", input.DryRun),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	now := uc.now().UTC()
+	report := &PurgeReport{DryRun: input.DryRun, RanAt: now}
+
+	if uc.policy.DeletedPollDays > 0 {
+		report.DeletedPollsBefore = now.Add(-time.Duration(uc.policy.DeletedPollDays) * day)
+		if input.DryRun {
+			report.DeletedPolls, err = uc.retentionRepo.CountDeletedPolls(ctx, report.DeletedPollsBefore)
+		} else {
+			report.DeletedPolls, err = uc.retentionRepo.PurgeDeletedPolls(ctx, report.DeletedPollsBefore, uc.policy.BatchSize)
+		}
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if uc.policy.VoterDataDays > 0 {
+		report.VoterDataBefore = now.Add(-time.Duration(uc.policy.VoterDataDays) * day)
+		if input.DryRun {
+			report.AnonymizedBallots, err = uc.retentionRepo.CountVoterData(ctx, report.VoterDataBefore)
+		} else {
+			report.AnonymizedBallots, err = uc.retentionRepo.AnonymizeVoterData(ctx, report.VoterDataBefore, uc.policy.VoterDataAction, uc.policy.BatchSize)
+		}
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if uc.policy.ArchiveClosedDays > 0 {
+		report.ClosedBefore = now.Add(-time.Duration(uc.policy.ArchiveClosedDays) * day)
+		if input.DryRun {
+			report.ArchivedPolls, report.ArchivedBallots, err = uc.retentionRepo.CountArchivableBallots(ctx, report.ClosedBefore)
+		} else {
+			report.ArchivedPolls, report.ArchivedBallots, err = uc.retentionRepo.ArchiveClosedPolls(ctx, report.ClosedBefore, uc.policy.BatchSize)
+		}
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if !input.DryRun {
+		metrics.RetentionRows.WithLabelValues("deleted_polls").Add(float64(report.DeletedPolls))
+		metrics.RetentionRows.WithLabelValues("anonymized_ballots").Add(float64(report.AnonymizedBallots))
+		metrics.RetentionRows.WithLabelValues("archived_ballots").Add(float64(report.ArchivedBallots))
+	}
+	slog.InfoContext(ctx, "retention policies applied",
+		"dry_run", input.DryRun,
+		"deleted_polls", report.DeletedPolls,
+		"anonymized_ballots", report.AnonymizedBallots,
+		"archived_polls", report.ArchivedPolls,
+		"archived_ballots", report.ArchivedBallots,
+	)
+	return report, nil
+}
+
+// Run applies the policies every interval until ctx is cancelled
+func (uc *PurgeUseCase) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = day
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.Execute(ctx, PurgeInput{}); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "retention job failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
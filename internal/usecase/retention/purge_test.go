@@ -0,0 +1,65 @@
+package retention_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/retention"
+)
+
+var policy = retention.Policy{
+	DeletedPollDays:   30,
+	VoterDataDays:     90,
+	VoterDataAction:   repository.VoterDataHash,
+	ArchiveClosedDays: 180,
+	BatchSize:         100,
+}
+
+func TestPurgeUseCase_DryRunOnlyCounts(t *testing.T) {
+	repo := new(mocks.MockRetentionRepository)
+	repo.On("CountDeletedPolls", mock.Anything, mock.Anything).Return(int64(2), nil)
+	repo.On("CountVoterData", mock.Anything, mock.Anything).Return(int64(40), nil)
+	repo.On("CountArchivableBallots", mock.Anything, mock.Anything).Return(int64(3), int64(120), nil)
+
+	report, err := retention.NewPurgeUseCase(repo, policy).Execute(context.Background(), retention.PurgeInput{DryRun: true})
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, int64(2), report.DeletedPolls)
+	assert.Equal(t, int64(40), report.AnonymizedBallots)
+	assert.Equal(t, int64(3), report.ArchivedPolls)
+	assert.Equal(t, int64(120), report.ArchivedBallots)
+	assert.True(t, report.VoterDataBefore.Before(report.DeletedPollsBefore))
+	repo.AssertNotCalled(t, "PurgeDeletedPolls", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func TestPurgeUseCase_AppliesPolicies(t *testing.T) {
+	repo := new(mocks.MockRetentionRepository)
+	repo.On("PurgeDeletedPolls", mock.Anything, mock.Anything, 100).Return(int64(2), nil)
+	repo.On("AnonymizeVoterData", mock.Anything, mock.Anything, repository.VoterDataHash, 100).Return(int64(40), nil)
+	repo.On("ArchiveClosedPolls", mock.Anything, mock.Anything, 100).Return(int64(3), int64(120), nil)
+
+	report, err := retention.NewPurgeUseCase(repo, policy).Execute(context.Background(), retention.PurgeInput{})
+	require.NoError(t, err)
+
+	assert.False(t, report.DryRun)
+	assert.Equal(t, int64(120), report.ArchivedBallots)
+	repo.AssertExpectations(t)
+}
+
+func TestPurgeUseCase_SkipsDisabledPolicies(t *testing.T) {
+	repo := new(mocks.MockRetentionRepository)
+	repo.On("CountVoterData", mock.Anything, mock.Anything).Return(int64(0), errors.New("database error"))
+
+	_, err := retention.NewPurgeUseCase(repo, retention.Policy{VoterDataDays: 1}).Execute(context.Background(), retention.PurgeInput{DryRun: true})
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "CountDeletedPolls", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "CountArchivableBallots", mock.Anything, mock.Anything)
+}
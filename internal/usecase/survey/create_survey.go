@@ -0,0 +1,228 @@
+package survey
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/auth"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// maxQuestions bounds the number of questions of a survey
+const maxQuestions = 20
+
+// CreateSurveyInput represents the input for creating a survey. Every
+// question shares the expiry and authentication requirement of the survey.
+type CreateSurveyInput struct {
+	Title       string          `json:"title" example:"Team offsite"`
+	Description string          `json:"description" example:"Help us plan the next offsite"`
+	RequireAuth bool            `json:"require_auth" example:"false"`
+	ExpiresIn   *int            `json:"expires_in" example:"1440"`
+	Questions   []QuestionInput `json:"questions"`
+	// CreatedBy is the client IP address, ignored when the caller is authenticated
+	CreatedBy string `json:"-"`
+}
+
+// QuestionInput is one question of a survey, asked like a poll
+type QuestionInput struct {
+	Title       string   `json:"title" example:"Which month suits you?"`
+	Description string   `json:"description" example:"Pick every month that works"`
+	Options     []string `json:"options" example:"June,July,September"`
+	MultiChoice bool     `json:"multi_choice" example:"true"`
+	// Optional questions may be left unanswered
+	Optional bool `json:"optional" example:"false"`
+	// ShowIf asks the question only when the answer to an earlier question
+	// includes one of the given options, both referenced by position
+	ShowIf *entity.Condition `json:"show_if"`
+}
+
+// CreateSurveyOutput represents the output after creating a survey
+type CreateSurveyOutput struct {
+	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	ShareURL  string    `json:"share_url" example:"http://localhost:8080/survey/550e8400-e29b-41d4-a716-446655440002"`
+	QRCodeURL string    `json:"qr_code_url" example:"http://localhost:8080/api/v1/surveys/550e8400-e29b-41d4-a716-446655440002/qr"`
+}
+
+type CreateSurveyUseCase struct {
+	surveyRepo repository.SurveyRepository
+	baseURL    string
+	ipHasher   *privacy.IPHasher
+}
+
+// NewCreateSurveyUseCase creates the use case; ipHasher may be nil to keep
+// the creator IP address in clear
+func NewCreateSurveyUseCase(surveyRepo repository.SurveyRepository, baseURL string, ipHasher *privacy.IPHasher) *CreateSurveyUseCase {
+	return &CreateSurveyUseCase{
+		surveyRepo: surveyRepo,
+		baseURL:    baseURL,
+		ipHasher:   ipHasher,
+	}
+}
+
+func (uc *CreateSurveyUseCase) Execute(ctx context.Context, input CreateSurveyInput) (_ *CreateSurveyOutput, err error) {
+	ctx, span := tracing.Start(ctx, "CreateSurveyUseCase.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateInput(input); err != nil {
+		return nil, err
+	}
+
+	salt := entity.NewIPSalt()
+	createdBy := uc.ipHasher.Identity(salt, input.CreatedBy)
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		createdBy = principal.Actor
+	}
+
+	var expiresAt *time.Time
+	if input.ExpiresIn != nil {
+		at := time.Now().Add(time.Duration(*input.ExpiresIn) * time.Minute)
+		expiresAt = &at
+	}
+
+	survey := &entity.Survey{
+		Title:       input.Title,
+		Description: input.Description,
+		CreatedBy:   createdBy,
+		RequireAuth: input.RequireAuth,
+		IPSalt:      salt,
+		ExpiresAt:   expiresAt,
+	}
+	// Chaque question est un sondage : même sel, pour reconnaître un votant d'une question à l'autre
+	for i, question := range input.Questions {
+		poll := entity.Poll{
+			Title:       question.Title,
+			Description: question.Description,
+			CreatedBy:   createdBy,
+			MultiChoice: question.MultiChoice,
+			RequireAuth: input.RequireAuth,
+			ExpiresAt:   expiresAt,
+			IPSalt:      salt,
+			Position:    i,
+			Optional:    question.Optional,
+			ShowIf:      question.ShowIf,
+			Type:        entity.PollTypeChoice,
+		}
+		for j, text := range question.Options {
+			poll.Options = append(poll.Options, entity.Option{Text: text, Order: j})
+		}
+		survey.Questions = append(survey.Questions, poll)
+	}
+
+	if err := uc.surveyRepo.Create(ctx, survey); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("survey.id", survey.ID.String()))
+	slog.InfoContext(ctx, "survey created", "survey_id", survey.ID, "questions", len(survey.Questions))
+
+	return &CreateSurveyOutput{
+		ID:        survey.ID,
+		ShareURL:  uc.baseURL + "/survey/" + survey.ID.String(),
+		QRCodeURL: uc.baseURL + "/api/v1/surveys/" + survey.ID.String() + "/qr",
+	}, nil
+}
+
+// validateInput validates the survey creation input
+func validateInput(input CreateSurveyInput) error {
+	var fields []apperror.FieldError
+	reject := func(field, rule, param, message string) {
+		fields = append(fields, apperror.FieldError{Field: field, Rule: rule, Param: param, Message: message})
+	}
+
+	switch {
+	case strings.TrimSpace(input.Title) == "":
+		reject("title", "required", "", "title cannot be empty")
+	case len(input.Title) < 3:
+		reject("title", "min", "3", "title must be at least 3 characters long")
+	case len(input.Title) > 255:
+		reject("title", "max", "255", "title must be no more than 255 characters long")
+	}
+	if len(input.Description) > 500 {
+		reject("description", "max", "500", "description must be no more than 500 characters long")
+	}
+	if input.ExpiresIn != nil {
+		if *input.ExpiresIn < 1 {
+			reject("expires_in", "min", "1", "expires_in must be at least 1 minute")
+		}
+		if *input.ExpiresIn > 10080 {
+			reject("expires_in", "max", "10080", "expires_in cannot be more than 1 week (10080 minutes)")
+		}
+	}
+
+	if len(input.Questions) == 0 {
+		reject("questions", "required", "", "a survey needs at least one question")
+	}
+	if len(input.Questions) > maxQuestions {
+		reject("questions", "max", strconv.Itoa(maxQuestions), fmt.Sprintf("a survey can have at most %d questions", maxQuestions))
+	}
+
+	for i, question := range input.Questions {
+		field := fmt.Sprintf("questions[%d]", i)
+		number := i + 1
+
+		switch {
+		case strings.TrimSpace(question.Title) == "":
+			reject(field+".title", "required", "", fmt.Sprintf("question %d needs a title", number))
+		case len(question.Title) < 3:
+			reject(field+".title", "min", "3", fmt.Sprintf("title of question %d must be at least 3 characters long", number))
+		case len(question.Title) > 255:
+			reject(field+".title", "max", "255", fmt.Sprintf("title of question %d must be no more than 255 characters long", number))
+		}
+		if len(question.Description) > 500 {
+			reject(field+".description", "max", "500", fmt.Sprintf("description of question %d must be no more than 500 characters long", number))
+		}
+
+		if len(question.Options) < 2 {
+			reject(field+".options", "min", "2", fmt.Sprintf("question %d must have at least 2 options", number))
+		}
+		if len(question.Options) > 10 {
+			reject(field+".options", "max", "10", fmt.Sprintf("question %d can have at most 10 options", number))
+		}
+		seen := make(map[string]bool, len(question.Options))
+		for j, option := range question.Options {
+			optionField := fmt.Sprintf("%s.options[%d]", field, j)
+			clean := strings.TrimSpace(strings.ToLower(option))
+			switch {
+			case clean == "":
+				reject(optionField, "required", "", fmt.Sprintf("option %d of question %d cannot be empty", j+1, number))
+			case len(option) > 255:
+				reject(optionField, "max", "255", fmt.Sprintf("option %d of question %d must be no more than 255 characters long", j+1, number))
+			case seen[clean]:
+				reject(optionField, "unique", "", fmt.Sprintf("question %d has duplicate options", number))
+			}
+			seen[clean] = true
+		}
+
+		if condition := question.ShowIf; condition != nil {
+			if condition.Question < 0 || condition.Question >= i {
+				reject(field+".show_if.question", "earlier", "", fmt.Sprintf("question %d can only depend on an earlier question", number))
+				continue
+			}
+			if len(condition.Options) == 0 {
+				reject(field+".show_if.options", "required", "", fmt.Sprintf("the condition of question %d needs at least one option", number))
+			}
+			previous := input.Questions[condition.Question]
+			for _, option := range condition.Options {
+				if option < 0 || option >= len(previous.Options) {
+					reject(field+".show_if.options", "oneof", "", fmt.Sprintf("the condition of question %d refers to an unknown option of question %d", number, condition.Question+1))
+					break
+				}
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package survey_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/usecase/survey"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestCreateSurveyUseCase_Execute(t *testing.T) {
+	mockRepo := new(mocks.MockSurveyRepository)
+	useCase := survey.NewCreateSurveyUseCase(mockRepo, "http://localhost:8080", nil)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Survey")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			s := args.Get(1).(*entity.Survey)
+			// Simulate GORM BeforeCreate hook
+			s.ID = uuid.New()
+
+			require.Len(t, s.Questions, 2)
+			first, second := s.Questions[0], s.Questions[1]
+			assert.Equal(t, 0, first.Position)
+			assert.Equal(t, 1, second.Position)
+			assert.True(t, second.Optional)
+			assert.Equal(t, &entity.Condition{Question: 0, Options: []int{0}}, second.ShowIf)
+			assert.Equal(t, 2, second.Options[2].Order)
+
+			// Les questions héritent de l'expiration, du créateur et du sel du questionnaire
+			require.NotNil(t, s.ExpiresAt)
+			assert.Equal(t, s.ExpiresAt, first.ExpiresAt)
+			assert.Equal(t, "creator", second.CreatedBy)
+			assert.Equal(t, s.IPSalt, second.IPSalt)
+			assert.NotEmpty(t, s.IPSalt)
+		})
+
+	output, err := useCase.Execute(context.Background(), survey.CreateSurveyInput{
+		Title:     "Team offsite",
+		ExpiresIn: intPtr(60),
+		Questions: []survey.QuestionInput{
+			{Title: "Are you coming?", Options: []string{"Yes", "No"}},
+			{Title: "Which month?", Options: []string{"June", "July", "September"}, MultiChoice: true, Optional: true,
+				ShowIf: &entity.Condition{Question: 0, Options: []int{0}}},
+		},
+		CreatedBy: "creator",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/survey/"+output.ID.String(), output.ShareURL)
+	assert.Equal(t, "http://localhost:8080/api/v1/surveys/"+output.ID.String()+"/qr", output.QRCodeURL)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateSurveyUseCase_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input survey.CreateSurveyInput
+		field string
+		rule  string
+	}{
+		{
+			name:  "no question",
+			input: survey.CreateSurveyInput{Title: "Team offsite"},
+			field: "questions",
+			rule:  "required",
+		},
+		{
+			name: "question with one option",
+			input: survey.CreateSurveyInput{Title: "Team offsite", Questions: []survey.QuestionInput{
+				{Title: "Are you coming?", Options: []string{"Yes"}},
+			}},
+			field: "questions[0].options",
+			rule:  "min",
+		},
+		{
+			name: "duplicate options",
+			input: survey.CreateSurveyInput{Title: "Team offsite", Questions: []survey.QuestionInput{
+				{Title: "Are you coming?", Options: []string{"Yes", "yes "}},
+			}},
+			field: "questions[0].options[1]",
+			rule:  "unique",
+		},
+		{
+			name: "condition on a later question",
+			input: survey.CreateSurveyInput{Title: "Team offsite", Questions: []survey.QuestionInput{
+				{Title: "Are you coming?", Options: []string{"Yes", "No"}, ShowIf: &entity.Condition{Question: 1, Options: []int{0}}},
+				{Title: "Which month?", Options: []string{"June", "July"}},
+			}},
+			field: "questions[0].show_if.question",
+			rule:  "earlier",
+		},
+		{
+			name: "condition on an unknown option",
+			input: survey.CreateSurveyInput{Title: "Team offsite", Questions: []survey.QuestionInput{
+				{Title: "Are you coming?", Options: []string{"Yes", "No"}},
+				{Title: "Which month?", Options: []string{"June", "July"}, ShowIf: &entity.Condition{Question: 0, Options: []int{2}}},
+			}},
+			field: "questions[1].show_if.options",
+			rule:  "oneof",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockSurveyRepository)
+			useCase := survey.NewCreateSurveyUseCase(mockRepo, "http://localhost:8080", nil)
+
+			_, err := useCase.Execute(context.Background(), tt.input)
+
+			var appErr *apperror.Error
+			require.ErrorAs(t, err, &appErr)
+			require.NotEmpty(t, appErr.Fields)
+			assert.Equal(t, tt.field, appErr.Fields[0].Field)
+			assert.Equal(t, tt.rule, appErr.Fields[0].Rule)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package survey
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+type GetSurveyUseCase struct {
+	surveyRepo repository.SurveyRepository
+}
+
+func NewGetSurveyUseCase(surveyRepo repository.SurveyRepository) *GetSurveyUseCase {
+	return &GetSurveyUseCase{surveyRepo: surveyRepo}
+}
+
+// Execute returns the survey with the results of each question
+func (uc *GetSurveyUseCase) Execute(ctx context.Context, surveyID uuid.UUID) (_ *entity.Survey, err error) {
+	ctx, span := tracing.Start(ctx, "GetSurveyUseCase.Execute", trace.WithAttributes(
+		attribute.String("survey.id", surveyID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return uc.surveyRepo.GetByIDWithResults(ctx, surveyID)
+}
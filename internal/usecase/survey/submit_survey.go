@@ -0,0 +1,190 @@
+package survey
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// SubmitSurveyInput carries the answers of one respondent and their raw
+// identity, hashed before being persisted in IP privacy mode
+type SubmitSurveyInput struct {
+	SurveyID uuid.UUID `json:"-"`
+	// Answers holds the chosen option IDs keyed by question ID
+	Answers   map[uuid.UUID][]uuid.UUID `json:"answers" binding:"required"`
+	VoterID   string                    `json:"-"`
+	IPAddress string                    `json:"-"`
+	UserAgent string                    `json:"-"`
+}
+
+type SubmitSurveyUseCase struct {
+	surveyRepo repository.SurveyRepository
+	voteRepo   repository.VoteRepository
+	ipHasher   *privacy.IPHasher
+}
+
+// NewSubmitSurveyUseCase creates the use case; ipHasher may be nil to keep
+// voter IP addresses in clear
+func NewSubmitSurveyUseCase(surveyRepo repository.SurveyRepository, voteRepo repository.VoteRepository, ipHasher *privacy.IPHasher) *SubmitSurveyUseCase {
+	return &SubmitSurveyUseCase{
+		surveyRepo: surveyRepo,
+		voteRepo:   voteRepo,
+		ipHasher:   ipHasher,
+	}
+}
+
+// Execute checks every answer against the questions asked to the respondent,
+// then records them all at once: a rejected answer records none.
+func (uc *SubmitSurveyUseCase) Execute(ctx context.Context, input SubmitSurveyInput) (err error) {
+	ctx, span := tracing.Start(ctx, "SubmitSurveyUseCase.Execute", trace.WithAttributes(
+		attribute.String("survey.id", input.SurveyID.String()),
+		attribute.Int("survey.answers", len(input.Answers)),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	survey, err := uc.surveyRepo.GetByID(ctx, input.SurveyID)
+	if err != nil {
+		return err
+	}
+	if survey.IsExpired() {
+		return entity.ErrSurveyExpired
+	}
+	if survey.RequireAuth && input.VoterID == "" {
+		return entity.ErrAuthRequired
+	}
+
+	responded, err := uc.hasResponded(ctx, survey, input.VoterID)
+	if err != nil {
+		return err
+	}
+	if responded {
+		return entity.ErrAlreadyResponded
+	}
+
+	votes, err := uc.ballots(survey, input)
+	if err != nil {
+		return err
+	}
+	if err := uc.voteRepo.CreateBatch(ctx, votes); err != nil {
+		return err
+	}
+
+	metrics.SurveyResponses.Inc()
+	slog.InfoContext(ctx, "survey response recorded", "survey_id", survey.ID, "votes", len(votes))
+	return nil
+}
+
+// HasResponded reports whether voterID has already answered the survey
+func (uc *SubmitSurveyUseCase) HasResponded(ctx context.Context, surveyID uuid.UUID, voterID string) (bool, error) {
+	survey, err := uc.surveyRepo.GetByID(ctx, surveyID)
+	if err != nil {
+		return false, err
+	}
+	return uc.hasResponded(ctx, survey, voterID)
+}
+
+// hasResponded looks for a ballot of voterID in any question, under every
+// identity it may have been stored as
+func (uc *SubmitSurveyUseCase) hasResponded(ctx context.Context, survey *entity.Survey, voterID string) (bool, error) {
+	for _, question := range survey.Questions {
+		voted, err := uc.ipHasher.Recorded(ctx, survey.IPSalt, question.ID, voterID, uc.voteRepo.HasVoted)
+		if err != nil || voted {
+			return voted, err
+		}
+	}
+	return false, nil
+}
+
+// ballots validates the answers of input and returns the votes to record
+func (uc *SubmitSurveyUseCase) ballots(survey *entity.Survey, input SubmitSurveyInput) ([]*entity.Vote, error) {
+	var fields []apperror.FieldError
+	reject := func(questionID uuid.UUID, rule, param, message string) {
+		fields = append(fields, apperror.FieldError{
+			Field: "answers[" + questionID.String() + "]", Rule: rule, Param: param, Message: message,
+		})
+	}
+
+	asked := make(map[uuid.UUID]bool, len(survey.Questions))
+	for _, question := range survey.Questions {
+		asked[question.ID] = true
+	}
+	var unknown []uuid.UUID
+	for questionID := range input.Answers {
+		if !asked[questionID] {
+			unknown = append(unknown, questionID)
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].String() < unknown[j].String() })
+	for _, questionID := range unknown {
+		reject(questionID, "invalid", "", "the survey has no such question")
+	}
+
+	var votes []*entity.Vote
+	for i := range survey.Questions {
+		question := &survey.Questions[i]
+		chosen := input.Answers[question.ID]
+		number := question.Position + 1
+
+		if !survey.Visible(question, input.Answers) {
+			if len(chosen) > 0 {
+				reject(question.ID, "hidden", "", fmt.Sprintf("question %d is not asked given the previous answers", number))
+			}
+			continue
+		}
+		if len(chosen) == 0 {
+			if !question.Optional {
+				reject(question.ID, "required", "", fmt.Sprintf("question %d requires an answer", number))
+			}
+			continue
+		}
+		if !question.MultiChoice && len(chosen) > 1 {
+			reject(question.ID, "max", "1", fmt.Sprintf("only one option can be selected for question %d", number))
+			continue
+		}
+
+		options := make(map[uuid.UUID]bool, len(question.Options))
+		for _, option := range question.Options {
+			options[option.ID] = true
+		}
+		seen := make(map[uuid.UUID]bool, len(chosen))
+		for _, optionID := range chosen {
+			if !options[optionID] {
+				reject(question.ID, "oneof", "", fmt.Sprintf("invalid option selected for question %d", number))
+				break
+			}
+			// Une option choisie deux fois ne compte qu'une fois
+			if seen[optionID] {
+				continue
+			}
+			seen[optionID] = true
+			votes = append(votes, &entity.Vote{
+				PollID:    question.ID,
+				OptionID:  optionID,
+				VoterID:   uc.ipHasher.Identity(survey.IPSalt, input.VoterID),
+				IPAddress: uc.ipHasher.Identity(survey.IPSalt, input.IPAddress),
+				UserAgent: input.UserAgent,
+			})
+		}
+	}
+
+	if len(fields) == 0 && len(votes) == 0 {
+		fields = append(fields, apperror.FieldError{
+			Field: "answers", Rule: "required", Message: "answer at least one question",
+		})
+	}
+	if len(fields) > 0 {
+		return nil, apperror.Validation(fields...)
+	}
+	return votes, nil
+}
@@ -0,0 +1,181 @@
+package survey_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/survey"
+)
+
+// offsite est un questionnaire de trois questions : la deuxième, facultative,
+// n'est posée qu'aux participants qui viennent
+type offsite struct {
+	survey                  *entity.Survey
+	coming, month, feedback *entity.Poll
+	yes, no, june, july     uuid.UUID
+	good, bad               uuid.UUID
+}
+
+func newOffsite() offsite {
+	o := offsite{
+		yes: uuid.New(), no: uuid.New(), june: uuid.New(), july: uuid.New(), good: uuid.New(), bad: uuid.New(),
+	}
+	o.survey = &entity.Survey{ID: uuid.New(), IPSalt: "salt", Questions: []entity.Poll{
+		{ID: uuid.New(), Position: 0, Options: []entity.Option{{ID: o.yes, Order: 0}, {ID: o.no, Order: 1}}},
+		{ID: uuid.New(), Position: 1, MultiChoice: true, Optional: true, ShowIf: &entity.Condition{Question: 0, Options: []int{0}},
+			Options: []entity.Option{{ID: o.june, Order: 0}, {ID: o.july, Order: 1}}},
+		{ID: uuid.New(), Position: 2, Options: []entity.Option{{ID: o.good, Order: 0}, {ID: o.bad, Order: 1}}},
+	}}
+	o.coming, o.month, o.feedback = &o.survey.Questions[0], &o.survey.Questions[1], &o.survey.Questions[2]
+	return o
+}
+
+func TestSubmitSurveyUseCase_Execute(t *testing.T) {
+	o := newOffsite()
+	surveyRepo := new(mocks.MockSurveyRepository)
+	voteRepo := new(mocks.MockVoteRepository)
+	useCase := survey.NewSubmitSurveyUseCase(surveyRepo, voteRepo, nil)
+
+	surveyRepo.On("GetByID", mock.Anything, o.survey.ID).Return(o.survey, nil)
+	voteRepo.On("HasVoted", mock.Anything, mock.Anything, "192.168.1.1").Return(false, nil)
+	voteRepo.On("HasVoted", mock.Anything, mock.Anything, privacy.Pseudonymize("192.168.1.1")).Return(false, nil).Maybe()
+	voteRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		votes := args.Get(1).([]*entity.Vote)
+		require.Len(t, votes, 4)
+		assert.Equal(t, o.coming.ID, votes[0].PollID)
+		assert.Equal(t, o.month.ID, votes[2].PollID)
+		assert.Equal(t, o.july, votes[2].OptionID)
+		assert.Equal(t, o.feedback.ID, votes[3].PollID)
+		assert.Equal(t, "192.168.1.1", votes[3].VoterID)
+	})
+
+	err := useCase.Execute(context.Background(), survey.SubmitSurveyInput{
+		SurveyID: o.survey.ID,
+		Answers: map[uuid.UUID][]uuid.UUID{
+			o.coming.ID:   {o.yes},
+			o.month.ID:    {o.june, o.july, o.july},
+			o.feedback.ID: {o.good},
+		},
+		VoterID:   "192.168.1.1",
+		IPAddress: "192.168.1.1",
+	})
+
+	require.NoError(t, err)
+	voteRepo.AssertExpectations(t)
+}
+
+func TestSubmitSurveyUseCase_RejectsWholeResponse(t *testing.T) {
+	o := newOffsite()
+	tests := []struct {
+		name     string
+		answers  map[uuid.UUID][]uuid.UUID
+		question uuid.UUID
+		rule     string
+	}{
+		{
+			name:     "answer to a question hidden by its condition",
+			answers:  map[uuid.UUID][]uuid.UUID{o.coming.ID: {o.no}, o.month.ID: {o.june}, o.feedback.ID: {o.good}},
+			question: o.month.ID,
+			rule:     "hidden",
+		},
+		{
+			name:     "required question left unanswered",
+			answers:  map[uuid.UUID][]uuid.UUID{o.coming.ID: {o.yes}},
+			question: o.feedback.ID,
+			rule:     "required",
+		},
+		{
+			name:     "several options for a single choice question",
+			answers:  map[uuid.UUID][]uuid.UUID{o.coming.ID: {o.yes, o.no}, o.feedback.ID: {o.good}},
+			question: o.coming.ID,
+			rule:     "max",
+		},
+		{
+			name:     "option of another question",
+			answers:  map[uuid.UUID][]uuid.UUID{o.coming.ID: {o.no}, o.feedback.ID: {o.june}},
+			question: o.feedback.ID,
+			rule:     "oneof",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			surveyRepo := new(mocks.MockSurveyRepository)
+			voteRepo := new(mocks.MockVoteRepository)
+			useCase := survey.NewSubmitSurveyUseCase(surveyRepo, voteRepo, nil)
+
+			surveyRepo.On("GetByID", mock.Anything, o.survey.ID).Return(o.survey, nil)
+			voteRepo.On("HasVoted", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+			err := useCase.Execute(context.Background(), survey.SubmitSurveyInput{
+				SurveyID: o.survey.ID,
+				Answers:  tt.answers,
+				VoterID:  "192.168.1.1",
+			})
+
+			var appErr *apperror.Error
+			require.ErrorAs(t, err, &appErr)
+			require.Len(t, appErr.Fields, 1)
+			assert.Equal(t, "answers["+tt.question.String()+"]", appErr.Fields[0].Field)
+			assert.Equal(t, tt.rule, appErr.Fields[0].Rule)
+			// Aucune réponse n'est enregistrée quand l'une d'elles est refusée
+			voteRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSubmitSurveyUseCase_Refusals(t *testing.T) {
+	o := newOffsite()
+	answers := map[uuid.UUID][]uuid.UUID{o.coming.ID: {o.no}, o.feedback.ID: {o.good}}
+
+	t.Run("already responded", func(t *testing.T) {
+		surveyRepo := new(mocks.MockSurveyRepository)
+		voteRepo := new(mocks.MockVoteRepository)
+		useCase := survey.NewSubmitSurveyUseCase(surveyRepo, voteRepo, nil)
+
+		surveyRepo.On("GetByID", mock.Anything, o.survey.ID).Return(o.survey, nil)
+		voteRepo.On("HasVoted", mock.Anything, o.coming.ID, "192.168.1.1").Return(false, nil)
+		voteRepo.On("HasVoted", mock.Anything, o.coming.ID, privacy.Pseudonymize("192.168.1.1")).Return(false, nil).Maybe()
+		voteRepo.On("HasVoted", mock.Anything, o.month.ID, "192.168.1.1").Return(true, nil)
+		voteRepo.On("HasVoted", mock.Anything, o.month.ID, privacy.Pseudonymize("192.168.1.1")).Return(false, nil).Maybe()
+
+		err := useCase.Execute(context.Background(), survey.SubmitSurveyInput{SurveyID: o.survey.ID, Answers: answers, VoterID: "192.168.1.1"})
+
+		assert.ErrorIs(t, err, entity.ErrAlreadyResponded)
+		voteRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired survey", func(t *testing.T) {
+		expired := *o.survey
+		past := time.Now().Add(-time.Hour)
+		expired.ExpiresAt = &past
+		surveyRepo := new(mocks.MockSurveyRepository)
+		useCase := survey.NewSubmitSurveyUseCase(surveyRepo, new(mocks.MockVoteRepository), nil)
+
+		surveyRepo.On("GetByID", mock.Anything, o.survey.ID).Return(&expired, nil)
+
+		err := useCase.Execute(context.Background(), survey.SubmitSurveyInput{SurveyID: o.survey.ID, Answers: answers, VoterID: "192.168.1.1"})
+
+		assert.ErrorIs(t, err, entity.ErrSurveyExpired)
+	})
+
+	t.Run("survey not found", func(t *testing.T) {
+		surveyRepo := new(mocks.MockSurveyRepository)
+		useCase := survey.NewSubmitSurveyUseCase(surveyRepo, new(mocks.MockVoteRepository), nil)
+
+		surveyRepo.On("GetByID", mock.Anything, o.survey.ID).Return(nil, entity.ErrSurveyNotFound)
+
+		err := useCase.Execute(context.Background(), survey.SubmitSurveyInput{SurveyID: o.survey.ID, Answers: answers})
+
+		assert.ErrorIs(t, err, entity.ErrSurveyNotFound)
+	})
+}
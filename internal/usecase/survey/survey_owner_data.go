@@ -0,0 +1,46 @@
+package survey
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+var ErrNotSurveyCreator = apperror.Forbidden("not_survey_creator", "only the creator of the survey can do this")
+
+// SurveyOwnerDataUseCase serves the combined results of a survey to its creator
+type SurveyOwnerDataUseCase struct {
+	surveyRepo repository.SurveyRepository
+	ipHasher   *privacy.IPHasher
+}
+
+func NewSurveyOwnerDataUseCase(surveyRepo repository.SurveyRepository, ipHasher *privacy.IPHasher) *SurveyOwnerDataUseCase {
+	return &SurveyOwnerDataUseCase{
+		surveyRepo: surveyRepo,
+		ipHasher:   ipHasher,
+	}
+}
+
+// Results returns the survey with the vote counts of every question
+func (uc *SurveyOwnerDataUseCase) Results(ctx context.Context, surveyID uuid.UUID, requester string) (_ *entity.Survey, err error) {
+	ctx, span := tracing.Start(ctx, "SurveyOwnerDataUseCase.Results", trace.WithAttributes(
+		attribute.String("survey.id", surveyID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	survey, err := uc.surveyRepo.GetByIDWithResults(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	if !uc.ipHasher.IsCreator(ctx, survey.CreatedBy, survey.IPSalt, requester) {
+		return nil, ErrNotSurveyCreator
+	}
+	return survey, nil
+}
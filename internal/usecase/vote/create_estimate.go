@@ -0,0 +1,140 @@
+package vote
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/numstat"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// CreateEstimateInput is the numeric answer of a voter to a numeric poll;
+// the voter identity is hashed like the one of a ballot
+type CreateEstimateInput struct {
+	PollID   uuid.UUID `json:"-"`
+	Estimate *float64  `json:"estimate" binding:"required" example:"8"`
+	VoterID  string    `json:"-"`
+}
+
+// CreateEstimateOutput is the recorded estimate with the updated results
+// of its poll, to be pushed to the websocket subscribers
+type CreateEstimateOutput struct {
+	Estimate *entity.Estimate
+	Results  *entity.EstimateResults
+}
+
+type CreateEstimateUseCase struct {
+	pollRepo     repository.PollRepository
+	estimateRepo repository.EstimateRepository
+	ipHasher     *privacy.IPHasher
+}
+
+// NewCreateEstimateUseCase creates the use case; ipHasher may be nil to
+// keep voter IP addresses in clear
+func NewCreateEstimateUseCase(pollRepo repository.PollRepository, estimateRepo repository.EstimateRepository, ipHasher *privacy.IPHasher) *CreateEstimateUseCase {
+	return &CreateEstimateUseCase{
+		pollRepo:     pollRepo,
+		estimateRepo: estimateRepo,
+		ipHasher:     ipHasher,
+	}
+}
+
+// Execute records the estimate of a voter, which must lie between the
+// bounds of the poll and on one of its steps
+func (uc *CreateEstimateUseCase) Execute(ctx context.Context, input CreateEstimateInput) (_ *CreateEstimateOutput, err error) {
+	ctx, span := tracing.Start(ctx, "CreateEstimateUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", input.PollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if input.Estimate == nil {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "estimate", Rule: "required", Message: "estimate is required",
+		})
+	}
+
+	poll, err := uc.pollRepo.GetByID(ctx, input.PollID)
+	if errors.Is(err, entity.ErrPollNotFound) {
+		return nil, reject(ctx, input.PollID, metrics.ReasonPollNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !poll.IsNumeric() || poll.Numeric == nil {
+		return nil, reject(ctx, input.PollID, metrics.ReasonNotNumeric, entity.ErrNotNumericPoll)
+	}
+
+	if poll.IsExpired() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonPollExpired, entity.ErrPollExpired)
+	}
+
+	if poll.RequireAuth && input.VoterID == "" {
+		return nil, reject(ctx, input.PollID, metrics.ReasonAuthRequired, entity.ErrAuthRequired)
+	}
+
+	estimated, err := uc.ipHasher.Recorded(ctx, poll.IPSalt, poll.ID, input.VoterID, uc.estimateRepo.HasEstimated)
+	if err != nil {
+		return nil, err
+	}
+	if estimated {
+		return nil, reject(ctx, input.PollID, metrics.ReasonAlreadyVoted, entity.ErrAlreadyVoted)
+	}
+
+	if err := validateEstimate(poll.Numeric, *input.Estimate); err != nil {
+		return nil, err
+	}
+
+	estimate := &entity.Estimate{
+		PollID:  poll.ID,
+		Value:   *input.Estimate,
+		VoterID: uc.ipHasher.Identity(poll.IPSalt, input.VoterID),
+	}
+	if err := uc.estimateRepo.Create(ctx, estimate); err != nil {
+		return nil, err
+	}
+
+	values, err := uc.estimateRepo.ListValues(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Estimates.Inc()
+	slog.InfoContext(ctx, "estimate recorded", "poll_id", poll.ID)
+
+	return &CreateEstimateOutput{
+		Estimate: estimate,
+		Results:  numstat.Results(poll, values),
+	}, nil
+}
+
+// validateEstimate checks value against the bounds and step of the poll
+func validateEstimate(settings *entity.NumericSettings, value float64) error {
+	if settings.Accepts(value) {
+		return nil
+	}
+	format := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	field := apperror.FieldError{Field: "estimate"}
+	switch {
+	case value < settings.Min:
+		field.Rule, field.Param = "min", format(settings.Min)
+		field.Message = "estimate must be at least " + field.Param
+	case value > settings.Max:
+		field.Rule, field.Param = "max", format(settings.Max)
+		field.Message = "estimate must be no more than " + field.Param
+	default:
+		field.Rule, field.Param = "step", format(settings.Step)
+		field.Message = "estimate must be a multiple of " + field.Param + " from " + format(settings.Min)
+	}
+	return apperror.Validation(field)
+}
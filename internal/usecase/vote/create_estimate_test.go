@@ -0,0 +1,160 @@
+package vote_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/vote"
+)
+
+func newNumericPoll() *entity.Poll {
+	return &entity.Poll{
+		ID:      uuid.New(),
+		Title:   "How many story points?",
+		Type:    entity.PollTypeNumeric,
+		Numeric: &entity.NumericSettings{Min: 0, Max: 20, Step: 0.5, Buckets: 4},
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestCreateEstimateUseCase_Execute(t *testing.T) {
+	t.Run("records the estimate and returns the updated distribution", func(t *testing.T) {
+		numericPoll := newNumericPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		estimateRepo := new(mocks.MockEstimateRepository)
+		useCase := vote.NewCreateEstimateUseCase(pollRepo, estimateRepo, nil)
+
+		pollRepo.On("GetByID", mock.Anything, numericPoll.ID).Return(numericPoll, nil)
+		estimateRepo.On("HasEstimated", mock.Anything, numericPoll.ID, "voter1").Return(false, nil)
+		estimateRepo.On("HasEstimated", mock.Anything, numericPoll.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		estimateRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *entity.Estimate) bool {
+			return e.Value == 8.5 && e.VoterID == "voter1"
+		})).Return(nil)
+		estimateRepo.On("ListValues", mock.Anything, numericPoll.ID).Return([]float64{3, 8.5}, nil)
+
+		output, err := useCase.Execute(context.Background(), vote.CreateEstimateInput{
+			PollID: numericPoll.ID, Estimate: floatPtr(8.5), VoterID: "voter1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 8.5, output.Estimate.Value)
+		assert.Equal(t, 2, output.Results.Count)
+		assert.Equal(t, 5.75, output.Results.Stats.Mean)
+		estimateRepo.AssertExpectations(t)
+	})
+
+	t.Run("hides the distribution until the estimates are revealed", func(t *testing.T) {
+		numericPoll := newNumericPoll()
+		numericPoll.Numeric.HideUntilReveal = true
+		pollRepo := new(mocks.MockPollRepository)
+		estimateRepo := new(mocks.MockEstimateRepository)
+		useCase := vote.NewCreateEstimateUseCase(pollRepo, estimateRepo, nil)
+
+		pollRepo.On("GetByID", mock.Anything, numericPoll.ID).Return(numericPoll, nil)
+		estimateRepo.On("HasEstimated", mock.Anything, numericPoll.ID, "voter1").Return(false, nil)
+		estimateRepo.On("HasEstimated", mock.Anything, numericPoll.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		estimateRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		estimateRepo.On("ListValues", mock.Anything, numericPoll.ID).Return([]float64{3, 8}, nil)
+
+		output, err := useCase.Execute(context.Background(), vote.CreateEstimateInput{
+			PollID: numericPoll.ID, Estimate: floatPtr(8), VoterID: "voter1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &entity.EstimateResults{Count: 2, Hidden: true}, output.Results)
+	})
+
+	t.Run("one estimate per voter", func(t *testing.T) {
+		numericPoll := newNumericPoll()
+		pollRepo := new(mocks.MockPollRepository)
+		estimateRepo := new(mocks.MockEstimateRepository)
+		useCase := vote.NewCreateEstimateUseCase(pollRepo, estimateRepo, nil)
+
+		pollRepo.On("GetByID", mock.Anything, numericPoll.ID).Return(numericPoll, nil)
+		estimateRepo.On("HasEstimated", mock.Anything, numericPoll.ID, "voter1").Return(true, nil)
+		estimateRepo.On("HasEstimated", mock.Anything, numericPoll.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+
+		_, err := useCase.Execute(context.Background(), vote.CreateEstimateInput{
+			PollID: numericPoll.ID, Estimate: floatPtr(3), VoterID: "voter1",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrAlreadyVoted)
+		estimateRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not a numeric poll", func(t *testing.T) {
+		choicePoll := &entity.Poll{ID: uuid.New(), Type: entity.PollTypeChoice}
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := vote.NewCreateEstimateUseCase(pollRepo, new(mocks.MockEstimateRepository), nil)
+
+		pollRepo.On("GetByID", mock.Anything, choicePoll.ID).Return(choicePoll, nil)
+
+		_, err := useCase.Execute(context.Background(), vote.CreateEstimateInput{
+			PollID: choicePoll.ID, Estimate: floatPtr(3), VoterID: "voter1",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrNotNumericPoll)
+	})
+}
+
+func TestCreateEstimateUseCase_Bounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		estimate float64
+		want     apperror.FieldError
+	}{
+		{name: "below min", estimate: -1, want: apperror.FieldError{Field: "estimate", Rule: "min", Param: "0", Message: "estimate must be at least 0"}},
+		{name: "above max", estimate: 21, want: apperror.FieldError{Field: "estimate", Rule: "max", Param: "20", Message: "estimate must be no more than 20"}},
+		{name: "off step", estimate: 3.2, want: apperror.FieldError{Field: "estimate", Rule: "step", Param: "0.5", Message: "estimate must be a multiple of 0.5 from 0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numericPoll := newNumericPoll()
+			pollRepo := new(mocks.MockPollRepository)
+			estimateRepo := new(mocks.MockEstimateRepository)
+			useCase := vote.NewCreateEstimateUseCase(pollRepo, estimateRepo, nil)
+
+			pollRepo.On("GetByID", mock.Anything, numericPoll.ID).Return(numericPoll, nil)
+			estimateRepo.On("HasEstimated", mock.Anything, numericPoll.ID, "voter1").Return(false, nil)
+			estimateRepo.On("HasEstimated", mock.Anything, numericPoll.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+
+			_, err := useCase.Execute(context.Background(), vote.CreateEstimateInput{
+				PollID: numericPoll.ID, Estimate: floatPtr(tt.estimate), VoterID: "voter1",
+			})
+
+			validation, ok := apperror.As(err)
+			require.True(t, ok)
+			assert.Equal(t, []apperror.FieldError{tt.want}, validation.Fields)
+			estimateRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateVoteUseCase_NumericPoll(t *testing.T) {
+	numericPoll := newNumericPoll()
+	mockPollRepo := new(mocks.MockPollRepository)
+	mockVoteRepo := new(mocks.MockVoteRepository)
+	useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+	mockPollRepo.On("GetByID", mock.Anything, numericPoll.ID).Return(numericPoll, nil)
+
+	_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+		PollID:    numericPoll.ID,
+		OptionIDs: []uuid.UUID{uuid.New()},
+		VoterID:   "voter1",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrNumericPoll)
+}
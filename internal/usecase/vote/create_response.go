@@ -0,0 +1,117 @@
+package vote
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// CreateResponseInput is a free-text response to a text poll; the voter
+// identity is hashed like the one of a ballot
+type CreateResponseInput struct {
+	PollID  uuid.UUID `json:"-"`
+	Text    string    `json:"text" binding:"required" example:"Faster onboarding"`
+	VoterID string    `json:"-"`
+}
+
+type CreateResponseUseCase struct {
+	pollRepo     repository.PollRepository
+	responseRepo repository.TextResponseRepository
+	ipHasher     *privacy.IPHasher
+}
+
+// NewCreateResponseUseCase creates the use case; ipHasher may be nil to
+// keep voter IP addresses in clear
+func NewCreateResponseUseCase(pollRepo repository.PollRepository, responseRepo repository.TextResponseRepository, ipHasher *privacy.IPHasher) *CreateResponseUseCase {
+	return &CreateResponseUseCase{
+		pollRepo:     pollRepo,
+		responseRepo: responseRepo,
+		ipHasher:     ipHasher,
+	}
+}
+
+// Execute records the sanitized response of a voter to a text poll. It is
+// approved right away unless the poll is moderated.
+func (uc *CreateResponseUseCase) Execute(ctx context.Context, input CreateResponseInput) (_ *entity.TextResponse, err error) {
+	ctx, span := tracing.Start(ctx, "CreateResponseUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", input.PollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	poll, err := uc.pollRepo.GetByID(ctx, input.PollID)
+	if errors.Is(err, entity.ErrPollNotFound) {
+		return nil, reject(ctx, input.PollID, metrics.ReasonPollNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.IsNumeric() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonNumericPoll, entity.ErrNumericPoll)
+	}
+	if poll.IsSchedule() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonSchedulePoll, entity.ErrSchedulePoll)
+	}
+	if !poll.IsText() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonChoicePoll, entity.ErrChoicePoll)
+	}
+
+	if poll.IsExpired() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonPollExpired, entity.ErrPollExpired)
+	}
+
+	if poll.RequireAuth && input.VoterID == "" {
+		return nil, reject(ctx, input.PollID, metrics.ReasonAuthRequired, entity.ErrAuthRequired)
+	}
+
+	responded, err := uc.ipHasher.Recorded(ctx, poll.IPSalt, poll.ID, input.VoterID, uc.responseRepo.HasResponded)
+	if err != nil {
+		return nil, err
+	}
+	if responded {
+		return nil, reject(ctx, input.PollID, metrics.ReasonAlreadyVoted, entity.ErrAlreadyVoted)
+	}
+
+	text := entity.SanitizeResponse(input.Text)
+	if text == "" {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "text", Rule: "required", Message: "text cannot be empty",
+		})
+	}
+	if utf8.RuneCountInString(text) > entity.MaxResponseLength {
+		return nil, apperror.Validation(apperror.FieldError{
+			Field: "text", Rule: "max", Param: strconv.Itoa(entity.MaxResponseLength),
+			Message: "text must be no more than 500 characters long",
+		})
+	}
+
+	response := &entity.TextResponse{
+		PollID:  poll.ID,
+		Text:    text,
+		Status:  entity.ResponseApproved,
+		VoterID: uc.ipHasher.Identity(poll.IPSalt, input.VoterID),
+	}
+	if poll.Moderated {
+		response.Status = entity.ResponsePending
+	}
+	if err := uc.responseRepo.Create(ctx, response); err != nil {
+		return nil, err
+	}
+
+	metrics.TextResponses.Inc()
+	slog.InfoContext(ctx, "text response recorded", "poll_id", poll.ID, "status", response.Status)
+
+	return response, nil
+}
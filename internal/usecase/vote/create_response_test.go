@@ -0,0 +1,116 @@
+package vote_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/vote"
+)
+
+func TestCreateResponseUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		moderated  bool
+		text       string
+		wantText   string
+		wantStatus string
+	}{
+		{
+			name:       "approved right away",
+			text:       "  Faster <b>builds</b>\n",
+			wantText:   "Faster builds",
+			wantStatus: entity.ResponseApproved,
+		},
+		{
+			name:       "held for moderation",
+			moderated:  true,
+			text:       "More coffee",
+			wantText:   "More coffee",
+			wantStatus: entity.ResponsePending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := &entity.Poll{ID: uuid.New(), Type: entity.PollTypeText, Moderated: tt.moderated}
+			mockPollRepo := new(mocks.MockPollRepository)
+			mockResponseRepo := new(mocks.MockTextResponseRepository)
+			useCase := vote.NewCreateResponseUseCase(mockPollRepo, mockResponseRepo, nil)
+
+			mockPollRepo.On("GetByID", mock.Anything, poll.ID).Return(poll, nil)
+			mockResponseRepo.On("HasResponded", mock.Anything, poll.ID, "192.168.1.1").Return(false, nil)
+			mockResponseRepo.On("HasResponded", mock.Anything, poll.ID, privacy.Pseudonymize("192.168.1.1")).Return(false, nil).Maybe()
+			mockResponseRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.TextResponse")).Return(nil)
+
+			response, err := useCase.Execute(context.Background(), vote.CreateResponseInput{
+				PollID:  poll.ID,
+				Text:    tt.text,
+				VoterID: "192.168.1.1",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantText, response.Text)
+			assert.Equal(t, tt.wantStatus, response.Status)
+			assert.Equal(t, "192.168.1.1", response.VoterID)
+			mockResponseRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateResponseUseCase_Refusals(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name      string
+		poll      *entity.Poll
+		text      string
+		responded bool
+		wantErr   error
+		wantRule  string
+	}{
+		{name: "choice poll", poll: &entity.Poll{Type: entity.PollTypeChoice}, text: "Go", wantErr: entity.ErrChoicePoll},
+		{name: "expired poll", poll: &entity.Poll{Type: entity.PollTypeText, ExpiresAt: &past}, text: "Go", wantErr: entity.ErrPollExpired},
+		{name: "already responded", poll: &entity.Poll{Type: entity.PollTypeText}, text: "Go", responded: true, wantErr: entity.ErrAlreadyVoted},
+		{name: "empty once sanitized", poll: &entity.Poll{Type: entity.PollTypeText}, text: "<br/> \u200b", wantRule: "required"},
+		{name: "too long", poll: &entity.Poll{Type: entity.PollTypeText}, text: strings.Repeat("é", entity.MaxResponseLength+1), wantRule: "max"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.poll.ID = uuid.New()
+			mockPollRepo := new(mocks.MockPollRepository)
+			mockResponseRepo := new(mocks.MockTextResponseRepository)
+			useCase := vote.NewCreateResponseUseCase(mockPollRepo, mockResponseRepo, nil)
+
+			mockPollRepo.On("GetByID", mock.Anything, tt.poll.ID).Return(tt.poll, nil)
+			mockResponseRepo.On("HasResponded", mock.Anything, tt.poll.ID, "192.168.1.1").Return(tt.responded, nil)
+			mockResponseRepo.On("HasResponded", mock.Anything, tt.poll.ID, privacy.Pseudonymize("192.168.1.1")).Return(false, nil).Maybe()
+
+			_, err := useCase.Execute(context.Background(), vote.CreateResponseInput{
+				PollID:  tt.poll.ID,
+				Text:    tt.text,
+				VoterID: "192.168.1.1",
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				var appErr *apperror.Error
+				require.ErrorAs(t, err, &appErr)
+				require.Len(t, appErr.Fields, 1)
+				assert.Equal(t, "text", appErr.Fields[0].Field)
+				assert.Equal(t, tt.wantRule, appErr.Fields[0].Rule)
+			}
+			mockResponseRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
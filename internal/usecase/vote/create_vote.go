@@ -3,81 +3,264 @@ package vote
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
 	"microservice-go-gin/internal/domain/entity"
 	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
 )
 
+// CreateVoteInput carries the raw identity of the voter; it is hashed before
+// being persisted when the IP privacy mode is enabled
 type CreateVoteInput struct {
 	PollID    uuid.UUID   `json:"poll_id" binding:"required"`
-	OptionIDs []uuid.UUID `json:"option_ids" binding:"required,min=1"`
+	OptionIDs []uuid.UUID `json:"option_ids"`
 	VoterID   string      `json:"-"`
 	IPAddress string      `json:"-"`
 	UserAgent string      `json:"-"`
+
+	// WriteIn is an option of the voter's own, for polls that allow
+	// write-ins; it counts as one more choice
+	WriteIn string `json:"write_in" example:"Zig"`
+}
+
+// CreateVoteOutput lists the options the ballot was cast for
+type CreateVoteOutput struct {
+	OptionIDs []uuid.UUID
+	// WriteIn is the option written in by the voter: a new one when
+	// WriteInCreated, else the existing option it duplicates
+	WriteIn        *entity.Option
+	WriteInCreated bool
+
+	// Waitlisted are the places of the voter in the waitlists of the full
+	// options of a sign-up sheet, which got no vote
+	Waitlisted []*entity.WaitlistEntry
 }
 
 type CreateVoteUseCase struct {
 	pollRepo repository.PollRepository
 	voteRepo repository.VoteRepository
+	ipHasher *privacy.IPHasher
 }
 
-func NewCreateVoteUseCase(pollRepo repository.PollRepository, voteRepo repository.VoteRepository) *CreateVoteUseCase {
+// NewCreateVoteUseCase creates the use case; ipHasher may be nil to keep
+// voter IP addresses in clear
+func NewCreateVoteUseCase(pollRepo repository.PollRepository, voteRepo repository.VoteRepository, ipHasher *privacy.IPHasher) *CreateVoteUseCase {
 	return &CreateVoteUseCase{
 		pollRepo: pollRepo,
 		voteRepo: voteRepo,
+		ipHasher: ipHasher,
 	}
 }
 
-func (uc *CreateVoteUseCase) Execute(ctx context.Context, input CreateVoteInput) error {
+func (uc *CreateVoteUseCase) Execute(ctx context.Context, input CreateVoteInput) (_ *CreateVoteOutput, err error) {
+	ctx, span := tracing.Start(ctx, "CreateVoteUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", input.PollID.String()),
+		attribute.Int("vote.options", len(input.OptionIDs)),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	writeIn := entity.NormalizeOption(input.WriteIn)
+	if err := validateChoices(input, writeIn); err != nil {
+		return nil, err
+	}
+	// Une option répétée ne compte qu'une fois : elle ne prend pas plusieurs places
+	input.OptionIDs = distinct(input.OptionIDs)
+
 	poll, err := uc.pollRepo.GetByID(ctx, input.PollID)
+	if errors.Is(err, entity.ErrPollNotFound) {
+		return nil, reject(ctx, input.PollID, metrics.ReasonPollNotFound, err)
+	}
 	if err != nil {
-		return errors.New("poll not found")
+		return nil, err
+	}
+
+	if poll.IsText() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonTextPoll, entity.ErrTextPoll)
+	}
+	if poll.IsNumeric() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonNumericPoll, entity.ErrNumericPoll)
+	}
+	if poll.IsSchedule() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonSchedulePoll, entity.ErrSchedulePoll)
+	}
+
+	// Les questions d'un questionnaire sont soumises ensemble, conditions comprises
+	if poll.SurveyID != nil {
+		return nil, reject(ctx, input.PollID, metrics.ReasonSurveyQuestion, entity.ErrAnswerThroughSurvey)
+	}
+	// Les questions d'un quiz sont minutées et notées : réponses via le quiz uniquement
+	if poll.QuizID != nil {
+		return nil, reject(ctx, input.PollID, metrics.ReasonQuizQuestion, entity.ErrAnswerThroughQuiz)
 	}
 
 	if poll.IsExpired() {
-		return errors.New("poll has expired")
+		return nil, reject(ctx, input.PollID, metrics.ReasonPollExpired, entity.ErrPollExpired)
 	}
 
 	if poll.RequireAuth && input.VoterID == "" {
-		return errors.New("authentication required to vote")
+		return nil, reject(ctx, input.PollID, metrics.ReasonAuthRequired, entity.ErrAuthRequired)
 	}
 
-	hasVoted, err := uc.voteRepo.HasVoted(ctx, input.PollID, input.VoterID)
+	hasVoted, err := uc.ipHasher.Recorded(ctx, poll.IPSalt, poll.ID, input.VoterID, uc.voteRepo.HasVoted)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if hasVoted {
-		return errors.New("you have already voted in this poll")
+		return nil, reject(ctx, input.PollID, metrics.ReasonAlreadyVoted, entity.ErrAlreadyVoted)
 	}
 
-	if !poll.MultiChoice && len(input.OptionIDs) > 1 {
-		return errors.New("only one option can be selected")
+	if writeIn != "" && !poll.AllowWriteIn {
+		return nil, reject(ctx, input.PollID, metrics.ReasonWriteIn, entity.ErrWriteInDisabled)
+	}
+
+	choices := len(input.OptionIDs)
+	if writeIn != "" {
+		choices++
+	}
+	if !poll.MultiChoice && choices > 1 {
+		return nil, reject(ctx, input.PollID, metrics.ReasonTooManyOptions, entity.ErrSingleChoice)
 	}
 
+	// Une option libre en attente de modération ne reçoit que le vote de son auteur, via write_in
 	validOptions := make(map[uuid.UUID]bool)
 	for _, option := range poll.Options {
-		validOptions[option.ID] = true
+		if !option.Pending {
+			validOptions[option.ID] = true
+		}
 	}
-
 	for _, optionID := range input.OptionIDs {
 		if !validOptions[optionID] {
-			return errors.New("invalid option selected")
+			return nil, reject(ctx, input.PollID, metrics.ReasonInvalidOption, entity.ErrInvalidOption)
 		}
+	}
 
-		vote := &entity.Vote{
+	output := &CreateVoteOutput{OptionIDs: input.OptionIDs}
+	newVote := func(optionID uuid.UUID) *entity.Vote {
+		return &entity.Vote{
 			PollID:    input.PollID,
 			OptionID:  optionID,
-			VoterID:   input.VoterID,
-			IPAddress: input.IPAddress,
+			VoterID:   uc.ipHasher.Identity(poll.IPSalt, input.VoterID),
+			IPAddress: uc.ipHasher.Identity(poll.IPSalt, input.IPAddress),
 			UserAgent: input.UserAgent,
 		}
+	}
+	votes := make([]*entity.Vote, 0, len(input.OptionIDs)+1)
+	for _, optionID := range input.OptionIDs {
+		votes = append(votes, newVote(optionID))
+	}
+	if writeIn != "" {
+		// Le vote pour l'option libre reçoit son identifiant à l'enregistrement
+		votes = append(votes, newVote(uuid.Nil))
+	}
 
-		if err := uc.voteRepo.Create(ctx, vote); err != nil {
-			return err
+	switch {
+	case writeIn != "":
+		// Une option déjà proposée, quelle que soit la casse, reçoit le vote
+		// au lieu d'être dupliquée. L'option est enregistrée avec le
+		// bulletin : un bulletin refusé ne laisse pas d'option orpheline.
+		var result *entity.WriteIn
+		result, err = uc.voteRepo.CreateWithWriteIn(ctx, &entity.Option{
+			PollID:  poll.ID,
+			Text:    writeIn,
+			Pending: poll.Moderated,
+		}, votes, poll.Waitlist)
+		if err == nil {
+			output.WriteIn, output.WriteInCreated, output.Waitlisted = result.Option, result.Created, result.Waitlisted
+			if !contains(output.OptionIDs, output.WriteIn.ID) {
+				output.OptionIDs = append(output.OptionIDs, output.WriteIn.ID)
+			}
+		}
+	case poll.HasCapacity():
+		// Feuille d'inscription : les places sont comptées sous verrou pour
+		// qu'aucun vote concurrent ne dépasse la capacité d'une option
+		output.Waitlisted, err = uc.voteRepo.CreateWithinCapacity(ctx, votes, poll.Waitlist)
+	default:
+		for _, vote := range votes {
+			if err = uc.voteRepo.Create(ctx, vote); err != nil {
+				break
+			}
 		}
 	}
+	switch {
+	case errors.Is(err, entity.ErrOptionLimit):
+		return nil, reject(ctx, input.PollID, metrics.ReasonOptionLimit, err)
+	case errors.Is(err, entity.ErrOptionPending):
+		return nil, reject(ctx, input.PollID, metrics.ReasonOptionPending, err)
+	case errors.Is(err, entity.ErrOptionFull):
+		return nil, reject(ctx, input.PollID, metrics.ReasonOptionFull, err)
+	case err != nil:
+		return nil, err
+	}
+
+	metrics.WaitlistEntries.Add(float64(len(output.Waitlisted)))
+	metrics.VotesCast.Inc()
+	slog.InfoContext(ctx, "vote recorded", "poll_id", input.PollID, "options", len(output.OptionIDs), "write_in", output.WriteInCreated, "waitlisted", len(output.Waitlisted))
+
+	return output, nil
+}
+
+// HasVoted reports whether voterID has already voted in the poll
+func (uc *CreateVoteUseCase) HasVoted(ctx context.Context, pollID uuid.UUID, voterID string) (bool, error) {
+	poll, err := uc.pollRepo.GetByID(ctx, pollID)
+	if err != nil {
+		return false, err
+	}
+	return uc.ipHasher.Recorded(ctx, poll.IPSalt, poll.ID, voterID, uc.voteRepo.HasVoted)
+}
+
+// reject records a refused ballot before returning err to the caller
+func reject(ctx context.Context, pollID uuid.UUID, reason string, err error) error {
+	metrics.VotesRejected.WithLabelValues(reason).Inc()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("vote.rejected_reason", reason))
+	slog.InfoContext(ctx, "vote rejected", "poll_id", pollID, "reason", reason)
+	return err
+}
 
+// validateChoices checks that the ballot selects an option or writes one in
+func validateChoices(input CreateVoteInput, writeIn string) error {
+	if input.WriteIn != "" && writeIn == "" {
+		return apperror.Validation(apperror.FieldError{
+			Field: "write_in", Rule: "required", Message: "write_in cannot be empty",
+		})
+	}
+	if utf8.RuneCountInString(writeIn) > 255 {
+		return apperror.Validation(apperror.FieldError{
+			Field: "write_in", Rule: "max", Param: "255", Message: "write_in must be no more than 255 characters long",
+		})
+	}
+	if len(input.OptionIDs) == 0 && writeIn == "" {
+		return apperror.Validation(apperror.FieldError{
+			Field: "option_ids", Rule: "required", Message: "option_ids is required",
+		})
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// distinct returns ids without their duplicates, in their first order
+func distinct(ids []uuid.UUID) []uuid.UUID {
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func contains(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
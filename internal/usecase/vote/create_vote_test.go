@@ -2,15 +2,17 @@ package vote_test
 
 import (
 	"context"
-	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
 	"microservice-go-gin/internal/domain/entity"
 	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/privacy"
 	"microservice-go-gin/internal/usecase/vote"
 )
 
@@ -178,11 +180,11 @@ func TestCreateVoteUseCase_Execute(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockPollRepo := new(mocks.MockPollRepository)
 			mockVoteRepo := new(mocks.MockVoteRepository)
-			useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo)
+			useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
 
 			if tt.mockPoll == nil {
 				mockPollRepo.On("GetByID", mock.Anything, pollID).
-					Return(nil, errors.New("poll not found"))
+					Return(nil, entity.ErrPollNotFound)
 			} else {
 				mockPollRepo.On("GetByID", mock.Anything, pollID).
 					Return(tt.mockPoll, nil)
@@ -191,6 +193,7 @@ func TestCreateVoteUseCase_Execute(t *testing.T) {
 				if tt.errMsg != "poll has expired" && tt.errMsg != "authentication required to vote" {
 					mockVoteRepo.On("HasVoted", mock.Anything, pollID, tt.input.VoterID).
 						Return(tt.hasVoted, nil)
+					mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize(tt.input.VoterID)).Return(false, nil).Maybe()
 				}
 
 				if !tt.wantErr {
@@ -205,7 +208,7 @@ func TestCreateVoteUseCase_Execute(t *testing.T) {
 				}
 			}
 
-			err := useCase.Execute(context.Background(), tt.input)
+			_, err := useCase.Execute(context.Background(), tt.input)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -220,6 +223,310 @@ func TestCreateVoteUseCase_Execute(t *testing.T) {
 	}
 }
 
+func TestCreateVoteUseCase_HashedIP(t *testing.T) {
+	pollID := uuid.New()
+	optionID := uuid.New()
+	poll := &entity.Poll{
+		ID:      pollID,
+		Title:   "Private Poll",
+		IPSalt:  "poll-salt",
+		Options: []entity.Option{{ID: optionID, Text: "Option 1"}},
+	}
+	previous := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"previous"}})
+	rotated := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"current", "previous"}})
+	rawIP := "2001:db8:1:2:3:4:5:6"
+
+	t.Run("stores the hash instead of the address", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, rotated)
+		stored := rotated.Identity("poll-salt", rawIP)
+
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(poll, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, mock.Anything).Return(false, nil)
+		mockVoteRepo.On("Create", mock.Anything, mock.MatchedBy(func(v *entity.Vote) bool {
+			return v.VoterID == stored && v.IPAddress == stored
+		})).Return(nil)
+
+		_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID:    pollID,
+			OptionIDs: []uuid.UUID{optionID},
+			VoterID:   rawIP,
+			IPAddress: rawIP,
+		})
+
+		assert.NoError(t, err)
+		mockVoteRepo.AssertNotCalled(t, "HasVoted", mock.Anything, pollID, rawIP)
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("detects ballots hashed with a previous key", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, rotated)
+
+		// Même réseau /64 que le bulletin déjà enregistré
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(poll, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, rotated.Identity("poll-salt", "2001:db8:1:2::9")).Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, previous.Identity("poll-salt", rawIP)).Return(true, nil)
+
+		hasVoted, err := useCase.HasVoted(context.Background(), pollID, "2001:db8:1:2::9")
+
+		assert.NoError(t, err)
+		assert.True(t, hasVoted)
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("detects ballots anonymized by retention", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+		// Sans clé, la rétention remplace l'adresse par son empreinte
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(poll, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, rawIP).Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize(rawIP)).Return(true, nil)
+
+		hasVoted, err := useCase.HasVoted(context.Background(), pollID, rawIP)
+
+		assert.NoError(t, err)
+		assert.True(t, hasVoted)
+		mockVoteRepo.AssertExpectations(t)
+	})
+}
+
+// Les questions d'un questionnaire ne se votent pas une à une
+func TestCreateVoteUseCase_SurveyQuestion(t *testing.T) {
+	surveyID := uuid.New()
+	question := &entity.Poll{ID: uuid.New(), SurveyID: &surveyID, Options: []entity.Option{{ID: uuid.New()}}}
+	mockPollRepo := new(mocks.MockPollRepository)
+	mockVoteRepo := new(mocks.MockVoteRepository)
+	useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+	mockPollRepo.On("GetByID", mock.Anything, question.ID).Return(question, nil)
+
+	_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+		PollID:    question.ID,
+		OptionIDs: []uuid.UUID{question.Options[0].ID},
+		VoterID:   "voter1",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrAnswerThroughSurvey)
+	mockVoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// Les questions d'un quiz se répondent via le quiz, chrono compris
+func TestCreateVoteUseCase_QuizQuestion(t *testing.T) {
+	quizID := uuid.New()
+	question := &entity.Poll{ID: uuid.New(), QuizID: &quizID, Options: []entity.Option{{ID: uuid.New(), Correct: true}}}
+	mockPollRepo := new(mocks.MockPollRepository)
+	mockVoteRepo := new(mocks.MockVoteRepository)
+	useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+	mockPollRepo.On("GetByID", mock.Anything, question.ID).Return(question, nil)
+
+	_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+		PollID:    question.ID,
+		OptionIDs: []uuid.UUID{question.Options[0].ID},
+		VoterID:   "voter1",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrAnswerThroughQuiz)
+	mockVoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateVoteUseCase_TextPoll(t *testing.T) {
+	textPoll := &entity.Poll{ID: uuid.New(), Type: entity.PollTypeText}
+	mockPollRepo := new(mocks.MockPollRepository)
+	mockVoteRepo := new(mocks.MockVoteRepository)
+	useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+	mockPollRepo.On("GetByID", mock.Anything, textPoll.ID).Return(textPoll, nil)
+
+	_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+		PollID:    textPoll.ID,
+		OptionIDs: []uuid.UUID{uuid.New()},
+		VoterID:   "voter1",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrTextPoll)
+	mockVoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateVoteUseCase_WriteIn(t *testing.T) {
+	pollID := uuid.New()
+	existingID := uuid.New()
+	newPoll := func(allowWriteIn, moderated bool) *entity.Poll {
+		return &entity.Poll{
+			ID:           pollID,
+			AllowWriteIn: allowWriteIn,
+			Moderated:    moderated,
+			MultiChoice:  true,
+			Options:      []entity.Option{{ID: existingID, Text: "Go"}},
+		}
+	}
+
+	t.Run("votes for the existing option it duplicates", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+		existing := &entity.Option{ID: existingID, PollID: pollID, Text: "Go"}
+
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(newPoll(true, false), nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, "voter1").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		mockVoteRepo.On("CreateWithWriteIn", mock.Anything, mock.MatchedBy(func(o *entity.Option) bool {
+			return o.Text == "go"
+		}), mock.MatchedBy(func(votes []*entity.Vote) bool {
+			return len(votes) == 2 && votes[0].OptionID == existingID && votes[1].OptionID == uuid.Nil
+		}), false).Return(&entity.WriteIn{Option: existing}, nil)
+
+		output, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID:    pollID,
+			OptionIDs: []uuid.UUID{existingID},
+			WriteIn:   "  go ",
+			VoterID:   "voter1",
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, output.WriteInCreated)
+		assert.Equal(t, []uuid.UUID{existingID}, output.OptionIDs)
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("adds a pending option on moderated polls", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+		created := &entity.Option{ID: uuid.New(), PollID: pollID, Text: "Zig lang", Pending: true}
+
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(newPoll(true, true), nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, "voter1").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		mockVoteRepo.On("CreateWithWriteIn", mock.Anything, mock.MatchedBy(func(o *entity.Option) bool {
+			return o.Text == "Zig lang" && o.Pending && o.PollID == pollID
+		}), mock.MatchedBy(func(votes []*entity.Vote) bool {
+			return len(votes) == 1 && votes[0].VoterID == "voter1"
+		}), false).Return(&entity.WriteIn{Option: created, Created: true}, nil)
+
+		output, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID:  pollID,
+			WriteIn: "Zig \t lang",
+			VoterID: "voter1",
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, output.WriteInCreated)
+		assert.Equal(t, created, output.WriteIn)
+		assert.Equal(t, []uuid.UUID{created.ID}, output.OptionIDs)
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects write-ins when the poll does not allow them", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(newPoll(false, false), nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, "voter1").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+
+		_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID:  pollID,
+			WriteIn: "Zig",
+			VoterID: "voter1",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrWriteInDisabled)
+		mockVoteRepo.AssertNotCalled(t, "CreateWithWriteIn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockVoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("casts no ballot when the option cap is reached", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(newPoll(true, false), nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, "voter1").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		mockVoteRepo.On("CreateWithWriteIn", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, entity.ErrOptionLimit)
+
+		_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID:    pollID,
+			OptionIDs: []uuid.UUID{existingID},
+			WriteIn:   "Zig",
+			VoterID:   "voter1",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrOptionLimit)
+		mockVoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a write-in matching the pending option of another voter", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(newPoll(true, true), nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, "voter2").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize("voter2")).Return(false, nil).Maybe()
+		mockVoteRepo.On("CreateWithWriteIn", mock.Anything, mock.Anything, mock.Anything, false).Return(nil, entity.ErrOptionPending)
+
+		_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID:  pollID,
+			WriteIn: "zig",
+			VoterID: "voter2",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrOptionPending)
+	})
+
+	t.Run("counts the write-in as a choice on single-choice polls", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+		singleChoice := newPoll(true, false)
+		singleChoice.MultiChoice = false
+
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(singleChoice, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, "voter1").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+
+		_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID:    pollID,
+			OptionIDs: []uuid.UUID{existingID},
+			WriteIn:   "Zig",
+			VoterID:   "voter1",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrSingleChoice)
+		mockVoteRepo.AssertNotCalled(t, "CreateWithWriteIn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a pending option chosen by ID", func(t *testing.T) {
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+		moderated := newPoll(true, true)
+		pendingID := uuid.New()
+		moderated.Options = append(moderated.Options, entity.Option{ID: pendingID, Text: "Zig", Pending: true})
+
+		mockPollRepo.On("GetByID", mock.Anything, pollID).Return(moderated, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, "voter2").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, pollID, privacy.Pseudonymize("voter2")).Return(false, nil).Maybe()
+
+		_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID:    pollID,
+			OptionIDs: []uuid.UUID{pendingID},
+			VoterID:   "voter2",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrInvalidOption)
+		mockVoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
@@ -231,4 +538,81 @@ func contains(slice []uuid.UUID, item uuid.UUID) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+func TestCreateVoteUseCase_SignUpSheet(t *testing.T) {
+	capacity := 20
+	newSheet := func(waitlist bool) *entity.Poll {
+		return &entity.Poll{
+			ID:       uuid.New(),
+			Title:    "Workshops",
+			Waitlist: waitlist,
+			Options:  []entity.Option{{ID: uuid.New(), Text: "Kubernetes 101", MaxVotes: &capacity}},
+		}
+	}
+
+	t.Run("a full option puts the voter on its waitlist", func(t *testing.T) {
+		sheet := newSheet(true)
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+		entry := &entity.WaitlistEntry{PollID: sheet.ID, OptionID: sheet.Options[0].ID, Rank: 3}
+
+		mockPollRepo.On("GetByID", mock.Anything, sheet.ID).Return(sheet, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, sheet.ID, "voter1").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, sheet.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		mockVoteRepo.On("CreateWithinCapacity", mock.Anything, mock.MatchedBy(func(votes []*entity.Vote) bool {
+			return len(votes) == 1 && votes[0].OptionID == sheet.Options[0].ID && votes[0].VoterID == "voter1"
+		}), true).Return([]*entity.WaitlistEntry{entry}, nil)
+
+		output, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID: sheet.ID, OptionIDs: []uuid.UUID{sheet.Options[0].ID}, VoterID: "voter1",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.WaitlistEntry{entry}, output.Waitlisted)
+		mockVoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("a full option turns the voter away without waitlist", func(t *testing.T) {
+		sheet := newSheet(false)
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+		mockPollRepo.On("GetByID", mock.Anything, sheet.ID).Return(sheet, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, sheet.ID, "voter1").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, sheet.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		mockVoteRepo.On("CreateWithinCapacity", mock.Anything, mock.Anything, false).Return(nil, entity.ErrOptionFull)
+
+		_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID: sheet.ID, OptionIDs: []uuid.UUID{sheet.Options[0].ID}, VoterID: "voter1",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrOptionFull)
+	})
+
+	t.Run("a repeated option takes one place", func(t *testing.T) {
+		sheet := newSheet(true)
+		sheet.MultiChoice = true
+		option := sheet.Options[0].ID
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewCreateVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+		mockPollRepo.On("GetByID", mock.Anything, sheet.ID).Return(sheet, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, sheet.ID, "voter1").Return(false, nil)
+		mockVoteRepo.On("HasVoted", mock.Anything, sheet.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		mockVoteRepo.On("CreateWithinCapacity", mock.Anything, mock.MatchedBy(func(votes []*entity.Vote) bool {
+			return len(votes) == 1 && votes[0].OptionID == option
+		}), true).Return(nil, nil)
+
+		output, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+			PollID: sheet.ID, OptionIDs: []uuid.UUID{option, option, option}, VoterID: "voter1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{option}, output.OptionIDs)
+		mockVoteRepo.AssertExpectations(t)
+	})
+}
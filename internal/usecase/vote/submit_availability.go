@@ -0,0 +1,184 @@
+package vote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// SlotAnswer is the answer of a participant to one time slot
+type SlotAnswer struct {
+	OptionID uuid.UUID `json:"option_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Answer   string    `json:"answer" example:"yes"`
+}
+
+// SubmitAvailabilityInput is the answer of a participant to every time
+// slot of a scheduling poll, under a public display name; the voter
+// identity is hashed like the one of a ballot
+type SubmitAvailabilityInput struct {
+	PollID       uuid.UUID    `json:"-"`
+	Name         string       `json:"name" binding:"required" example:"Alice"`
+	Availability []SlotAnswer `json:"availability" binding:"required"`
+	VoterID      string       `json:"-"`
+}
+
+// SubmitAvailabilityOutput is the recorded answers with the time slots of
+// the poll ranked again, to be pushed to the websocket subscribers
+type SubmitAvailabilityOutput struct {
+	Availabilities []*entity.Availability
+	Slots          []entity.SlotResult
+}
+
+type SubmitAvailabilityUseCase struct {
+	pollRepo         repository.PollRepository
+	availabilityRepo repository.AvailabilityRepository
+	ipHasher         *privacy.IPHasher
+}
+
+// NewSubmitAvailabilityUseCase creates the use case; ipHasher may be nil
+// to keep voter IP addresses in clear
+func NewSubmitAvailabilityUseCase(pollRepo repository.PollRepository, availabilityRepo repository.AvailabilityRepository, ipHasher *privacy.IPHasher) *SubmitAvailabilityUseCase {
+	return &SubmitAvailabilityUseCase{
+		pollRepo:         pollRepo,
+		availabilityRepo: availabilityRepo,
+		ipHasher:         ipHasher,
+	}
+}
+
+// Execute records the answers of a participant, who must answer yes,
+// if_need_be or no to each time slot of the poll exactly once
+func (uc *SubmitAvailabilityUseCase) Execute(ctx context.Context, input SubmitAvailabilityInput) (_ *SubmitAvailabilityOutput, err error) {
+	ctx, span := tracing.Start(ctx, "SubmitAvailabilityUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", input.PollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	poll, err := uc.pollRepo.GetByID(ctx, input.PollID)
+	if errors.Is(err, entity.ErrPollNotFound) {
+		return nil, reject(ctx, input.PollID, metrics.ReasonPollNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !poll.IsSchedule() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonNotSchedule, entity.ErrNotSchedulePoll)
+	}
+
+	if poll.IsExpired() {
+		return nil, reject(ctx, input.PollID, metrics.ReasonPollExpired, entity.ErrPollExpired)
+	}
+
+	if poll.RequireAuth && input.VoterID == "" {
+		return nil, reject(ctx, input.PollID, metrics.ReasonAuthRequired, entity.ErrAuthRequired)
+	}
+
+	answered, err := uc.ipHasher.Recorded(ctx, poll.IPSalt, poll.ID, input.VoterID, uc.availabilityRepo.HasAnswered)
+	if err != nil {
+		return nil, err
+	}
+	if answered {
+		return nil, reject(ctx, input.PollID, metrics.ReasonAlreadyVoted, entity.ErrAlreadyVoted)
+	}
+
+	name := entity.SanitizeResponse(input.Name)
+	if err := validateAvailability(poll, name, input.Availability); err != nil {
+		return nil, err
+	}
+
+	voterID := uc.ipHasher.Identity(poll.IPSalt, input.VoterID)
+	availabilities := make([]*entity.Availability, 0, len(input.Availability))
+	for _, answer := range input.Availability {
+		availabilities = append(availabilities, &entity.Availability{
+			PollID:   poll.ID,
+			OptionID: answer.OptionID,
+			Name:     name,
+			Answer:   answer.Answer,
+			VoterID:  voterID,
+		})
+	}
+	if err := uc.availabilityRepo.CreateAll(ctx, availabilities); err != nil {
+		return nil, err
+	}
+
+	answers, err := uc.availabilityRepo.ListByPoll(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Availabilities.Inc()
+	slog.InfoContext(ctx, "availability recorded", "poll_id", poll.ID, "slots", len(availabilities))
+
+	return &SubmitAvailabilityOutput{
+		Availabilities: availabilities,
+		Slots:          entity.RankSlots(poll, answers),
+	}, nil
+}
+
+// validateAvailability checks the display name and that every time slot
+// of the poll is answered once
+func validateAvailability(poll *entity.Poll, name string, answers []SlotAnswer) error {
+	var fields []apperror.FieldError
+	if name == "" {
+		fields = append(fields, apperror.FieldError{Field: "name", Rule: "required", Message: "name cannot be empty"})
+	}
+	if utf8.RuneCountInString(name) > entity.MaxNameLength {
+		fields = append(fields, apperror.FieldError{
+			Field: "name", Rule: "max", Param: strconv.Itoa(entity.MaxNameLength),
+			Message: "name must be no more than 50 characters long",
+		})
+	}
+
+	slots := make(map[uuid.UUID]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		slots[option.ID] = true
+	}
+	answered := make(map[uuid.UUID]bool, len(answers))
+	for i, answer := range answers {
+		switch answer.Answer {
+		case entity.AvailabilityYes, entity.AvailabilityIfNeedBe, entity.AvailabilityNo:
+		default:
+			fields = append(fields, apperror.FieldError{
+				Field: fmt.Sprintf("availability[%d].answer", i), Rule: "oneof", Param: "yes if_need_be no",
+				Message: "answer must be yes, if_need_be or no",
+			})
+		}
+		switch {
+		case !slots[answer.OptionID]:
+			fields = append(fields, apperror.FieldError{
+				Field: fmt.Sprintf("availability[%d].option_id", i), Rule: "invalid",
+				Message: "the poll has no such time slot",
+			})
+		case answered[answer.OptionID]:
+			fields = append(fields, apperror.FieldError{
+				Field: fmt.Sprintf("availability[%d].option_id", i), Rule: "unique",
+				Message: "each time slot can only be answered once",
+			})
+		}
+		answered[answer.OptionID] = true
+	}
+	if len(fields) == 0 && len(answered) != len(slots) {
+		fields = append(fields, apperror.FieldError{
+			Field: "availability", Rule: "len", Param: strconv.Itoa(len(slots)),
+			Message: fmt.Sprintf("answer each of the %d time slots of the poll", len(slots)),
+		})
+	}
+
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package vote_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/domain/apperror"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/vote"
+)
+
+func newSchedulePoll() *entity.Poll {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	options := make([]entity.Option, 2)
+	for i := range options {
+		startsAt := start.Add(time.Duration(i) * 24 * time.Hour)
+		endsAt := startsAt.Add(time.Hour)
+		options[i] = entity.Option{ID: uuid.New(), Order: i, StartsAt: &startsAt, EndsAt: &endsAt}
+	}
+	return &entity.Poll{
+		ID:      uuid.New(),
+		Title:   "Sprint review",
+		Type:    entity.PollTypeSchedule,
+		Options: options,
+	}
+}
+
+func TestSubmitAvailabilityUseCase_Execute(t *testing.T) {
+	t.Run("records an answer per slot and ranks the slots", func(t *testing.T) {
+		schedulePoll := newSchedulePoll()
+		pollRepo := new(mocks.MockPollRepository)
+		availabilityRepo := new(mocks.MockAvailabilityRepository)
+		useCase := vote.NewSubmitAvailabilityUseCase(pollRepo, availabilityRepo, nil)
+
+		first, second := schedulePoll.Options[0].ID, schedulePoll.Options[1].ID
+		pollRepo.On("GetByID", mock.Anything, schedulePoll.ID).Return(schedulePoll, nil)
+		availabilityRepo.On("HasAnswered", mock.Anything, schedulePoll.ID, "voter1").Return(false, nil)
+		availabilityRepo.On("HasAnswered", mock.Anything, schedulePoll.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+		availabilityRepo.On("CreateAll", mock.Anything, mock.MatchedBy(func(answers []*entity.Availability) bool {
+			return len(answers) == 2 && answers[0].Name == "Alice" && answers[0].VoterID == "voter1"
+		})).Return(nil)
+		availabilityRepo.On("ListByPoll", mock.Anything, schedulePoll.ID).Return([]*entity.Availability{
+			{OptionID: first, Name: "Alice", Answer: entity.AvailabilityIfNeedBe},
+			{OptionID: second, Name: "Alice", Answer: entity.AvailabilityYes},
+		}, nil)
+
+		output, err := useCase.Execute(context.Background(), vote.SubmitAvailabilityInput{
+			PollID: schedulePoll.ID,
+			Name:   "  <b>Alice</b> ",
+			Availability: []vote.SlotAnswer{
+				{OptionID: first, Answer: entity.AvailabilityIfNeedBe},
+				{OptionID: second, Answer: entity.AvailabilityYes},
+			},
+			VoterID: "voter1",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, output.Availabilities, 2)
+		require.Len(t, output.Slots, 2)
+		assert.Equal(t, second, output.Slots[0].OptionID)
+		assert.Equal(t, []string{"Alice"}, output.Slots[0].Yes)
+		availabilityRepo.AssertExpectations(t)
+	})
+
+	t.Run("one answer per participant", func(t *testing.T) {
+		schedulePoll := newSchedulePoll()
+		pollRepo := new(mocks.MockPollRepository)
+		availabilityRepo := new(mocks.MockAvailabilityRepository)
+		useCase := vote.NewSubmitAvailabilityUseCase(pollRepo, availabilityRepo, nil)
+
+		pollRepo.On("GetByID", mock.Anything, schedulePoll.ID).Return(schedulePoll, nil)
+		availabilityRepo.On("HasAnswered", mock.Anything, schedulePoll.ID, "voter1").Return(true, nil)
+		availabilityRepo.On("HasAnswered", mock.Anything, schedulePoll.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+
+		_, err := useCase.Execute(context.Background(), vote.SubmitAvailabilityInput{
+			PollID: schedulePoll.ID, Name: "Alice", VoterID: "voter1",
+		})
+
+		assert.ErrorIs(t, err, entity.ErrAlreadyVoted)
+		availabilityRepo.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects polls without time slots", func(t *testing.T) {
+		choicePoll := &entity.Poll{ID: uuid.New(), Type: entity.PollTypeChoice}
+		pollRepo := new(mocks.MockPollRepository)
+		useCase := vote.NewSubmitAvailabilityUseCase(pollRepo, new(mocks.MockAvailabilityRepository), nil)
+
+		pollRepo.On("GetByID", mock.Anything, choicePoll.ID).Return(choicePoll, nil)
+
+		_, err := useCase.Execute(context.Background(), vote.SubmitAvailabilityInput{PollID: choicePoll.ID, Name: "Alice"})
+
+		assert.ErrorIs(t, err, entity.ErrNotSchedulePoll)
+	})
+}
+
+func TestSubmitAvailabilityUseCase_Validation(t *testing.T) {
+	schedulePoll := newSchedulePoll()
+	first, second := schedulePoll.Options[0].ID, schedulePoll.Options[1].ID
+
+	tests := []struct {
+		name   string
+		input  vote.SubmitAvailabilityInput
+		fields []apperror.FieldError
+	}{
+		{
+			name: "every slot must be answered",
+			input: vote.SubmitAvailabilityInput{Name: "Alice", Availability: []vote.SlotAnswer{
+				{OptionID: first, Answer: entity.AvailabilityYes},
+			}},
+			fields: []apperror.FieldError{{Field: "availability", Rule: "len", Param: "2", Message: "answer each of the 2 time slots of the poll"}},
+		},
+		{
+			name: "unknown answer, unknown slot and duplicate slot",
+			input: vote.SubmitAvailabilityInput{Name: "Alice", Availability: []vote.SlotAnswer{
+				{OptionID: first, Answer: "maybe"},
+				{OptionID: uuid.New(), Answer: entity.AvailabilityNo},
+				{OptionID: first, Answer: entity.AvailabilityNo},
+				{OptionID: second, Answer: entity.AvailabilityNo},
+			}},
+			fields: []apperror.FieldError{
+				{Field: "availability[0].answer", Rule: "oneof", Param: "yes if_need_be no", Message: "answer must be yes, if_need_be or no"},
+				{Field: "availability[1].option_id", Rule: "invalid", Message: "the poll has no such time slot"},
+				{Field: "availability[2].option_id", Rule: "unique", Message: "each time slot can only be answered once"},
+			},
+		},
+		{
+			name: "a name made of markup is empty",
+			input: vote.SubmitAvailabilityInput{Name: "<br>", Availability: []vote.SlotAnswer{
+				{OptionID: first, Answer: entity.AvailabilityYes},
+				{OptionID: second, Answer: entity.AvailabilityNo},
+			}},
+			fields: []apperror.FieldError{{Field: "name", Rule: "required", Message: "name cannot be empty"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pollRepo := new(mocks.MockPollRepository)
+			availabilityRepo := new(mocks.MockAvailabilityRepository)
+			useCase := vote.NewSubmitAvailabilityUseCase(pollRepo, availabilityRepo, nil)
+
+			pollRepo.On("GetByID", mock.Anything, schedulePoll.ID).Return(schedulePoll, nil)
+			availabilityRepo.On("HasAnswered", mock.Anything, schedulePoll.ID, "voter1").Return(false, nil)
+			availabilityRepo.On("HasAnswered", mock.Anything, schedulePoll.ID, privacy.Pseudonymize("voter1")).Return(false, nil).Maybe()
+
+			tt.input.PollID = schedulePoll.ID
+			tt.input.VoterID = "voter1"
+			_, err := useCase.Execute(context.Background(), tt.input)
+
+			validation, ok := apperror.As(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.fields, validation.Fields)
+			availabilityRepo.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateVoteUseCase_RejectsSchedulePolls(t *testing.T) {
+	schedulePoll := newSchedulePoll()
+	pollRepo := new(mocks.MockPollRepository)
+	useCase := vote.NewCreateVoteUseCase(pollRepo, new(mocks.MockVoteRepository), nil)
+
+	pollRepo.On("GetByID", mock.Anything, schedulePoll.ID).Return(schedulePoll, nil)
+
+	_, err := useCase.Execute(context.Background(), vote.CreateVoteInput{
+		PollID: schedulePoll.ID, OptionIDs: []uuid.UUID{schedulePoll.Options[0].ID}, VoterID: "voter1",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrSchedulePoll)
+}
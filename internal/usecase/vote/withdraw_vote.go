@@ -0,0 +1,81 @@
+package vote
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository"
+	"microservice-go-gin/internal/infrastructure/metrics"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/infrastructure/tracing"
+)
+
+// WithdrawVoteInput carries the raw identity of the voter withdrawing their
+// ballot, matched against every identity it may have been stored as
+type WithdrawVoteInput struct {
+	PollID  uuid.UUID
+	VoterID string
+}
+
+type WithdrawVoteUseCase struct {
+	pollRepo repository.PollRepository
+	voteRepo repository.VoteRepository
+	ipHasher *privacy.IPHasher
+}
+
+// NewWithdrawVoteUseCase creates the use case; ipHasher may be nil when
+// voter IP addresses are kept in clear
+func NewWithdrawVoteUseCase(pollRepo repository.PollRepository, voteRepo repository.VoteRepository, ipHasher *privacy.IPHasher) *WithdrawVoteUseCase {
+	return &WithdrawVoteUseCase{
+		pollRepo: pollRepo,
+		voteRepo: voteRepo,
+		ipHasher: ipHasher,
+	}
+}
+
+// Execute withdraws the votes of the voter and their places in waitlists.
+// Only a sign-up sheet allows it: the freed places go to the first
+// waitlisted voters. Elsewhere a ballot stays cast.
+func (uc *WithdrawVoteUseCase) Execute(ctx context.Context, input WithdrawVoteInput) (_ *entity.Withdrawal, err error) {
+	ctx, span := tracing.Start(ctx, "WithdrawVoteUseCase.Execute", trace.WithAttributes(
+		attribute.String("poll.id", input.PollID.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	poll, err := uc.pollRepo.GetByID(ctx, input.PollID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Les réponses à un questionnaire sont indissociables
+	if poll.SurveyID != nil {
+		return nil, entity.ErrAnswerThroughSurvey
+	}
+	if poll.QuizID != nil {
+		return nil, entity.ErrAnswerThroughQuiz
+	}
+	if poll.IsExpired() {
+		return nil, entity.ErrPollExpired
+	}
+	if !poll.HasCapacity() {
+		return nil, entity.ErrNotSignUp
+	}
+	if input.VoterID == "" {
+		return nil, entity.ErrVoteNotFound
+	}
+
+	withdrawal, err := uc.voteRepo.Withdraw(ctx, poll.ID, uc.ipHasher.Candidates(poll.IPSalt, input.VoterID))
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.VotesWithdrawn.Inc()
+	metrics.WaitlistPromotions.Add(float64(len(withdrawal.Promoted)))
+	slog.InfoContext(ctx, "vote withdrawn", "poll_id", poll.ID, "options", len(withdrawal.OptionIDs), "promoted", len(withdrawal.Promoted))
+
+	return withdrawal, nil
+}
@@ -0,0 +1,73 @@
+package vote_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/domain/repository/mocks"
+	"microservice-go-gin/internal/infrastructure/privacy"
+	"microservice-go-gin/internal/usecase/vote"
+)
+
+func TestWithdrawVoteUseCase_Execute(t *testing.T) {
+	maxVotes := 10
+
+	t.Run("promotes the waitlisted voters of the freed options", func(t *testing.T) {
+		sheet := &entity.Poll{ID: uuid.New(), IPSalt: "poll-salt", Options: []entity.Option{{ID: uuid.New(), MaxVotes: &maxVotes}}}
+		hasher := privacy.NewIPHasher(config.PrivacyConfig{IPMode: privacy.IPModeHMAC, IPHashKeys: []string{"current", "previous"}})
+		mockPollRepo := new(mocks.MockPollRepository)
+		mockVoteRepo := new(mocks.MockVoteRepository)
+		useCase := vote.NewWithdrawVoteUseCase(mockPollRepo, mockVoteRepo, hasher)
+		promoted := &entity.Vote{PollID: sheet.ID, OptionID: sheet.Options[0].ID, VoterID: "voter2"}
+
+		// Le bulletin est retrouvé quelle que soit la clé qui l'a haché
+		mockPollRepo.On("GetByID", mock.Anything, sheet.ID).Return(sheet, nil)
+		mockVoteRepo.On("Withdraw", mock.Anything, sheet.ID, hasher.Candidates("poll-salt", "203.0.113.7")).
+			Return(&entity.Withdrawal{OptionIDs: []uuid.UUID{sheet.Options[0].ID}, Promoted: []*entity.Vote{promoted}}, nil)
+
+		withdrawal, err := useCase.Execute(context.Background(), vote.WithdrawVoteInput{PollID: sheet.ID, VoterID: "203.0.113.7"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.Vote{promoted}, withdrawal.Promoted)
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("refusals", func(t *testing.T) {
+		surveyID := uuid.New()
+		sheet := func() *entity.Poll {
+			return &entity.Poll{ID: uuid.New(), Options: []entity.Option{{ID: uuid.New(), MaxVotes: &maxVotes}}}
+		}
+		tests := []struct {
+			name    string
+			poll    *entity.Poll
+			voterID string
+			wantErr error
+		}{
+			{"expired poll", &entity.Poll{ID: uuid.New(), ExpiresAt: timePtr(time.Now().Add(-time.Hour))}, "voter1", entity.ErrPollExpired},
+			{"survey question", &entity.Poll{ID: uuid.New(), SurveyID: &surveyID}, "voter1", entity.ErrAnswerThroughSurvey},
+			{"plain choice poll", &entity.Poll{ID: uuid.New(), Options: []entity.Option{{ID: uuid.New()}, {ID: uuid.New()}}}, "voter1", entity.ErrNotSignUp},
+			{"unknown voter", sheet(), "", entity.ErrVoteNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockPollRepo := new(mocks.MockPollRepository)
+				mockVoteRepo := new(mocks.MockVoteRepository)
+				useCase := vote.NewWithdrawVoteUseCase(mockPollRepo, mockVoteRepo, nil)
+
+				mockPollRepo.On("GetByID", mock.Anything, tt.poll.ID).Return(tt.poll, nil)
+
+				_, err := useCase.Execute(context.Background(), vote.WithdrawVoteInput{PollID: tt.poll.ID, VoterID: tt.voterID})
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockVoteRepo.AssertNotCalled(t, "Withdraw", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"microservice-go-gin/internal/config"
 	"microservice-go-gin/internal/delivery/http/route"
 	"microservice-go-gin/internal/infrastructure/database"
 )
@@ -30,7 +31,7 @@ func setupBenchmarkRouter() *gin.Engine {
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
-	route.SetupRoutes(router, db, "http://localhost:8080")
+	route.SetupRoutes(router, database.NewResolver(db), "http://localhost:8080", &config.Config{}, nil)
 
 	return router
 }
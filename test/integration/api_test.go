@@ -6,19 +6,28 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"microservice-go-gin/internal/config"
+	"microservice-go-gin/internal/delivery/http/handler"
+	"microservice-go-gin/internal/delivery/http/problem"
 	"microservice-go-gin/internal/delivery/http/route"
+	"microservice-go-gin/internal/domain/apperror"
 	"microservice-go-gin/internal/domain/entity"
+	"microservice-go-gin/internal/infrastructure/cache"
 	"microservice-go-gin/internal/infrastructure/database"
 	"microservice-go-gin/internal/usecase/poll"
 )
 
+const adminKey = "test-admin-key"
+
 type APITestSuite struct {
 	suite.Suite
 	db     *gorm.DB
@@ -39,14 +48,26 @@ func (suite *APITestSuite) SetupSuite() {
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
 	suite.router = gin.New()
-	route.SetupRoutes(suite.router, db, "http://localhost:8080")
+	cfg := &config.Config{Admin: config.AdminConfig{APIKeys: []string{"ops:" + adminKey}}}
+	route.SetupRoutes(suite.router, database.NewResolver(db), "http://localhost:8080", cfg, nil)
 }
 
 func (suite *APITestSuite) TearDownTest() {
 	// Clean up database after each test
+	suite.db.Exec("DELETE FROM quiz_answers")
+	suite.db.Exec("DELETE FROM quiz_players")
 	suite.db.Exec("DELETE FROM votes")
+	suite.db.Exec("DELETE FROM waitlist_entries")
+	suite.db.Exec("DELETE FROM estimates")
+	suite.db.Exec("DELETE FROM availabilities")
+	suite.db.Exec("DELETE FROM text_responses")
 	suite.db.Exec("DELETE FROM options")
 	suite.db.Exec("DELETE FROM polls")
+	suite.db.Exec("DELETE FROM surveys")
+	suite.db.Exec("DELETE FROM quizzes")
+	suite.db.Exec("DELETE FROM bans")
+	suite.db.Exec("DELETE FROM audit_events")
+	suite.db.Exec("DELETE FROM api_keys")
 }
 
 func (suite *APITestSuite) TestCreatePoll() {
@@ -216,12 +237,15 @@ func (suite *APITestSuite) TestGetPollNotFound() {
 	suite.router.ServeHTTP(w, req)
 
 	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Equal(problem.ContentType, w.Header().Get("Content-Type"))
 
-	var response map[string]string
+	var response problem.Problem
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	suite.Require().NoError(err)
 
-	suite.Equal("poll not found", response["error"])
+	suite.Equal("poll_not_found", response.Code)
+	suite.Equal(http.StatusNotFound, response.Status)
+	suite.Equal("poll not found", response.Detail)
 }
 
 func (suite *APITestSuite) TestCreatePollValidation() {
@@ -242,6 +266,766 @@ func (suite *APITestSuite) TestCreatePollValidation() {
 	suite.router.ServeHTTP(w, req)
 
 	suite.Equal(http.StatusBadRequest, w.Code)
+
+	var response problem.Problem
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(apperror.CodeValidation, response.Code)
+	suite.Require().Len(response.Errors, 1)
+	suite.Equal("options", response.Errors[0].Field)
+	suite.Equal("required", response.Errors[0].Rule)
+}
+
+func (suite *APITestSuite) TestLocalizedErrors() {
+	body, _ := json.Marshal(map[string]interface{}{"title": "Go", "options": []string{"A", ""}})
+	req, _ := http.NewRequest("POST", "/api/v1/polls", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "fr-FR,fr;q=0.9,en;q=0.5")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Equal("fr", w.Header().Get("Content-Language"))
+	var response problem.Problem
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(apperror.CodeValidation, response.Code)
+	suite.Equal("certains champs sont invalides", response.Detail)
+	suite.Require().Len(response.Errors, 2)
+	suite.Equal("title", response.Errors[0].Field)
+	suite.Equal("le titre doit contenir au moins 3 caractères", response.Errors[0].Message)
+	suite.Equal("options[1]", response.Errors[1].Field)
+	suite.Equal("l'option 2 ne peut pas être vide", response.Errors[1].Message)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/polls/%s", uuid.New()), nil)
+	req.Header.Set("Accept-Language", "de")
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("en", w.Header().Get("Content-Language"))
+	suite.Equal("poll not found", response.Detail)
+}
+
+func (suite *APITestSuite) TestMultilingualPoll() {
+	body, _ := json.Marshal(map[string]interface{}{
+		"title":    "Favorite language?",
+		"options":  []string{"Go", "Other"},
+		"language": "en",
+		"translations": map[string]interface{}{
+			"fr": map[string]interface{}{"title": "Langage préféré ?", "options": []string{"Go", "Autre"}},
+		},
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/polls", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created poll.CreatePollOutput
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+
+	get := func(query, acceptLanguage string) entity.Poll {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/polls/%s%s", created.ID, query), nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var p entity.Poll
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &p))
+		suite.Equal(p.Locale, w.Header().Get("Content-Language"))
+		return p
+	}
+
+	english := get("", "de-DE")
+	suite.Equal("en", english.Locale)
+	suite.Equal("Other", english.Options[1].Text)
+
+	french := get("", "fr-CA,fr;q=0.9")
+	suite.Equal("fr", french.Locale)
+	suite.Equal("Langage préféré ?", french.Title)
+	suite.Equal("Autre", french.Options[1].Text)
+	suite.Equal(english.Options[1].ID, french.Options[1].ID)
+
+	suite.Equal("en", get("?lang=en", "fr").Locale)
+
+	// Un vote en français compte pour toutes les langues
+	body, _ = json.Marshal(map[string]interface{}{"option_ids": []string{french.Options[1].ID.String()}})
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/api/v1/polls/%s/vote", created.ID), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "198.51.100.30:1234"
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	suite.Equal(1, get("?lang=en", "").Options[1].VoteCount)
+	suite.Equal(1, get("?lang=fr", "").Options[1].VoteCount)
+}
+
+func (suite *APITestSuite) TestSurvey() {
+	const creatorIP = "198.51.100.40:1234"
+	send := func(method, url string, payload interface{}, remoteAddr string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req, _ := http.NewRequest(method, url, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/v1/surveys", map[string]interface{}{
+		"title": "Team offsite",
+		"questions": []map[string]interface{}{
+			{"title": "Are you coming?", "options": []string{"Yes", "No"}},
+			{"title": "Which month?", "options": []string{"June", "July"}, "multi_choice": true,
+				"show_if": map[string]interface{}{"question": 0, "options": []int{0}}},
+			{"title": "Any remark?", "options": []string{"None", "Some"}, "optional": true},
+		},
+	}, creatorIP)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		ID        uuid.UUID `json:"id"`
+		ShareURL  string    `json:"share_url"`
+		QRCodeURL string    `json:"qr_code_url"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	suite.Equal("http://localhost:8080/survey/"+created.ID.String(), created.ShareURL)
+	surveyURL := "/api/v1/surveys/" + created.ID.String()
+
+	var survey entity.Survey
+	w = send("GET", surveyURL, nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &survey))
+	suite.Require().Len(survey.Questions, 3)
+	coming, month := survey.Questions[0], survey.Questions[1]
+	suite.Equal("Which month?", month.Title)
+	suite.Equal("July", month.Options[1].Text)
+
+	// Une question de questionnaire ne se vote pas seule
+	w = send("POST", "/api/v1/polls/"+coming.ID.String()+"/vote", map[string]interface{}{
+		"option_ids": []string{coming.Options[0].ID.String()},
+	}, "198.51.100.41:1234")
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "survey_question")
+
+	// Refusée en bloc : le mois n'est pas demandé à qui ne vient pas
+	w = send("POST", surveyURL+"/responses", map[string]interface{}{
+		"answers": map[string][]string{
+			coming.ID.String(): {coming.Options[1].ID.String()},
+			month.ID.String():  {month.Options[0].ID.String()},
+		},
+	}, "198.51.100.41:1234")
+	suite.Require().Equal(http.StatusBadRequest, w.Code)
+	var p problem.Problem
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &p))
+	suite.Require().Len(p.Errors, 1)
+	suite.Equal("answers["+month.ID.String()+"]", p.Errors[0].Field)
+	suite.Equal("hidden", p.Errors[0].Rule)
+
+	answers := map[string]interface{}{
+		"answers": map[string][]string{
+			coming.ID.String(): {coming.Options[0].ID.String()},
+			month.ID.String():  {month.Options[0].ID.String(), month.Options[1].ID.String()},
+		},
+	}
+	w = send("POST", surveyURL+"/responses", answers, "198.51.100.41:1234")
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	w = send("POST", surveyURL+"/responses", answers, "198.51.100.41:1234")
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Contains(w.Body.String(), "already_responded")
+
+	w = send("GET", surveyURL+"/has-responded", nil, "198.51.100.41:1234")
+	suite.JSONEq(`{"has_responded":true}`, w.Body.String())
+
+	w = send("GET", surveyURL, nil, creatorIP)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &survey))
+	suite.Equal(1, survey.Questions[0].Options[0].VoteCount)
+	suite.Equal(1, survey.Questions[1].Options[1].VoteCount)
+	suite.Equal(0, survey.Questions[2].Options[0].VoteCount)
+
+	// Export combiné réservé au créateur
+	w = send("GET", surveyURL+"/export", nil, "198.51.100.41:1234")
+	suite.Equal(http.StatusForbidden, w.Code)
+	w = send("GET", surveyURL+"/export", nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), "question,question_id,title,option_id,option,votes")
+	suite.Contains(w.Body.String(), fmt.Sprintf("2,%s,Which month?,%s,July,1", month.ID, month.Options[1].ID))
+
+	w = send("GET", surveyURL+"/qr", nil, creatorIP)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("image/png", w.Header().Get("Content-Type"))
+
+	w = send("GET", "/api/v1/surveys/"+uuid.New().String(), nil, creatorIP)
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *APITestSuite) TestTextPoll() {
+	const creatorIP = "198.51.100.50:1234"
+	send := func(method, url string, payload interface{}, remoteAddr string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req, _ := http.NewRequest(method, url, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/v1/polls", map[string]interface{}{
+		"title": "What should we improve?", "type": "text", "options": []string{"A", "B"},
+	}, creatorIP)
+	suite.Require().Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "excluded")
+
+	w = send("POST", "/api/v1/polls", map[string]interface{}{
+		"title": "What should we improve?", "type": "text", "moderated": true,
+	}, creatorIP)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		ID uuid.UUID `json:"id"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	pollURL := "/api/v1/polls/" + created.ID.String()
+
+	w = send("POST", pollURL+"/vote", map[string]interface{}{"option_ids": []string{uuid.New().String()}}, "198.51.100.51:1234")
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "text_poll")
+
+	texts := []string{"Faster <b>builds</b>", "faster onboarding", "Des builds plus rapides"}
+	var ids []string
+	for i, text := range texts {
+		w = send("POST", pollURL+"/responses", map[string]string{"text": text}, fmt.Sprintf("198.51.100.%d:1234", 60+i))
+		suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+		var response entity.TextResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(entity.ResponsePending, response.Status)
+		ids = append(ids, response.ID.String())
+	}
+	w = send("POST", pollURL+"/responses", map[string]string{"text": "Again"}, "198.51.100.60:1234")
+	suite.Equal(http.StatusConflict, w.Code)
+	w = send("POST", pollURL+"/responses", map[string]string{"text": strings.Repeat("a", 501)}, "198.51.100.70:1234")
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	// File de modération réservée au créateur
+	w = send("GET", pollURL+"/responses?status=pending", nil, "198.51.100.60:1234")
+	suite.Equal(http.StatusForbidden, w.Code)
+	w = send("GET", pollURL+"/responses?status=pending", nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"total":3`)
+	suite.Contains(w.Body.String(), "Faster builds")
+
+	for i, status := range []string{"approved", "approved", "rejected"} {
+		w = send("PATCH", pollURL+"/responses/"+ids[i], map[string]string{"status": status}, creatorIP)
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	}
+	w = send("PATCH", pollURL+"/responses/"+ids[0], map[string]string{"status": "rejected"}, "198.51.100.60:1234")
+	suite.Equal(http.StatusForbidden, w.Code)
+
+	w = send("GET", pollURL+"/responses", nil, "198.51.100.60:1234")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"total":2`)
+	suite.NotContains(w.Body.String(), "rapides")
+
+	var poll entity.Poll
+	w = send("GET", pollURL, nil, "198.51.100.60:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	suite.Equal(entity.PollTypeText, poll.Type)
+	suite.Equal(2, poll.ResponseCount)
+	suite.Equal([]entity.Term{{Term: "faster", Count: 2}, {Term: "builds", Count: 1}, {Term: "onboarding", Count: 1}}, poll.Terms)
+}
+
+func (suite *APITestSuite) TestWriteIn() {
+	const creatorIP = "198.51.100.80:1234"
+	send := func(method, url string, payload interface{}, remoteAddr string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req, _ := http.NewRequest(method, url, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/v1/polls", map[string]interface{}{
+		"title": "Favourite language?", "options": []string{"Go", "Rust"}, "allow_write_in": true, "moderated": true,
+	}, creatorIP)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created entity.Poll
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	pollURL := "/api/v1/polls/" + created.ID.String()
+
+	// Une option existante, quelle que soit la casse, reçoit le vote
+	w = send("POST", pollURL+"/vote", map[string]string{"write_in": "  GO "}, "198.51.100.81:1234")
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	suite.Contains(w.Body.String(), `"text":"Go"`)
+
+	w = send("POST", pollURL+"/vote", map[string]string{"write_in": "Zig"}, "198.51.100.82:1234")
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var vote struct {
+		WriteIn entity.Option `json:"write_in"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &vote))
+	suite.True(vote.WriteIn.Pending)
+
+	// L'option en attente n'est visible que du créateur
+	w = send("GET", pollURL, nil, "198.51.100.81:1234")
+	suite.NotContains(w.Body.String(), "Zig")
+	w = send("GET", pollURL+"/write-ins", nil, "198.51.100.81:1234")
+	suite.Equal(http.StatusForbidden, w.Code)
+	w = send("GET", pollURL+"/write-ins", nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), "Zig")
+
+	w = send("PATCH", pollURL+"/options/"+vote.WriteIn.ID.String(), map[string]string{"status": "approved"}, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	w = send("PATCH", pollURL+"/options/"+vote.WriteIn.ID.String(), map[string]string{"status": "rejected"}, creatorIP)
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	var poll entity.Poll
+	w = send("GET", pollURL, nil, "198.51.100.81:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	suite.Require().Len(poll.Options, 3)
+	suite.Equal("Zig", poll.Options[2].Text)
+	suite.Equal(1, poll.Options[2].VoteCount)
+
+	// Sans allow_write_in, le texte libre est refusé
+	w = send("POST", "/api/v1/polls", map[string]interface{}{"title": "Closed list", "options": []string{"A", "B"}}, creatorIP)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	w = send("POST", "/api/v1/polls/"+created.ID.String()+"/vote", map[string]string{"write_in": "C"}, "198.51.100.81:1234")
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "write_in_disabled")
+}
+
+func (suite *APITestSuite) TestNumericPoll() {
+	const creatorIP = "198.51.100.90:1234"
+	send := func(method, url string, payload interface{}, remoteAddr string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req, _ := http.NewRequest(method, url, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/v1/polls", map[string]interface{}{
+		"title": "How many story points?", "type": "numeric",
+		"numeric": map[string]interface{}{"min": 0, "max": 20, "step": 1, "buckets": 4, "hide_until_reveal": true},
+	}, creatorIP)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		ID uuid.UUID `json:"id"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	pollURL := "/api/v1/polls/" + created.ID.String()
+
+	w = send("POST", pollURL+"/vote", map[string]interface{}{"option_ids": []string{uuid.New().String()}}, "198.51.100.91:1234")
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "numeric_poll")
+	w = send("POST", pollURL+"/estimates", map[string]float64{"estimate": 2.5}, "198.51.100.91:1234")
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), `"rule":"step"`)
+
+	for i, estimate := range []float64{1, 3, 5, 13} {
+		w = send("POST", pollURL+"/estimates", map[string]float64{"estimate": estimate}, fmt.Sprintf("198.51.100.%d:1234", 91+i))
+		suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	}
+	w = send("POST", pollURL+"/estimates", map[string]float64{"estimate": 8}, "198.51.100.91:1234")
+	suite.Equal(http.StatusConflict, w.Code)
+
+	// Planning poker : seul le nombre d'estimations est visible avant la révélation
+	var poll entity.Poll
+	w = send("GET", pollURL, nil, "198.51.100.91:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	suite.Equal(&entity.EstimateResults{Count: 4, Hidden: true}, poll.Estimates)
+
+	w = send("POST", pollURL+"/reveal", nil, "198.51.100.91:1234")
+	suite.Equal(http.StatusForbidden, w.Code)
+	w = send("POST", pollURL+"/reveal", nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	w = send("POST", pollURL+"/reveal", nil, creatorIP)
+	suite.Equal(http.StatusConflict, w.Code)
+
+	poll = entity.Poll{}
+	w = send("GET", pollURL, nil, "198.51.100.91:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	suite.Require().NotNil(poll.Estimates.Stats)
+	suite.Equal(5.5, poll.Estimates.Stats.Mean)
+	suite.Equal(4.0, poll.Estimates.Stats.Median)
+	suite.Equal([]entity.Bucket{
+		{From: 0, To: 5, Count: 2}, {From: 5, To: 10, Count: 1}, {From: 10, To: 15, Count: 1}, {From: 15, To: 20, Count: 0},
+	}, poll.Estimates.Stats.Histogram)
+}
+
+func (suite *APITestSuite) TestSchedulePoll() {
+	send := func(method, url string, payload interface{}, remoteAddr string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req, _ := http.NewRequest(method, url, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/v1/polls", map[string]interface{}{
+		"title": "Sprint review", "type": "schedule", "time_zone": "Europe/Paris",
+		"slots": []map[string]string{
+			{"starts_at": "2024-01-15T10:00:00+01:00", "ends_at": "2024-01-15T11:00:00+01:00"},
+			{"starts_at": "2024-01-16T14:00:00Z", "ends_at": "2024-01-16T15:30:00Z"},
+		},
+	}, "198.51.100.100:1234")
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		ID uuid.UUID `json:"id"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	pollURL := "/api/v1/polls/" + created.ID.String()
+
+	var poll entity.Poll
+	w = send("GET", pollURL, nil, "198.51.100.101:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	suite.Require().Len(poll.Options, 2)
+	monday, tuesday := poll.Options[0].ID, poll.Options[1].ID
+	suite.Equal("Tue 16 Jan 2024 15:00–16:30 CET", poll.Options[1].Text)
+
+	w = send("GET", pollURL+"/calendar.ics", nil, "198.51.100.101:1234")
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Contains(w.Body.String(), "no_available_slot")
+	w = send("POST", pollURL+"/vote", map[string]interface{}{"option_ids": []string{monday.String()}}, "198.51.100.101:1234")
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "schedule_poll")
+	w = send("POST", pollURL+"/availability", map[string]interface{}{
+		"name": "Alice", "availability": []map[string]string{{"option_id": monday.String(), "answer": "yes"}},
+	}, "198.51.100.101:1234")
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), `"rule":"len"`)
+
+	answers := map[string][2]string{"Alice": {"yes", "if_need_be"}, "Bob": {"no", "yes"}, "Chloé": {"if_need_be", "yes"}}
+	i := 0
+	for name, answer := range answers {
+		w = send("POST", pollURL+"/availability", map[string]interface{}{
+			"name": name, "availability": []map[string]string{
+				{"option_id": monday.String(), "answer": answer[0]},
+				{"option_id": tuesday.String(), "answer": answer[1]},
+			},
+		}, fmt.Sprintf("198.51.100.%d:1234", 101+i))
+		suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+		i++
+	}
+	w = send("POST", pollURL+"/availability", map[string]interface{}{
+		"name": "Alice again", "availability": []map[string]string{
+			{"option_id": monday.String(), "answer": "yes"},
+			{"option_id": tuesday.String(), "answer": "yes"},
+		},
+	}, "198.51.100.101:1234")
+	suite.Equal(http.StatusConflict, w.Code)
+
+	// Mardi : trois disponibles dont deux sans réserve, lundi : deux seulement
+	poll = entity.Poll{}
+	w = send("GET", pollURL, nil, "198.51.100.101:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	suite.Require().Len(poll.Slots, 2)
+	suite.Equal(tuesday, poll.Slots[0].OptionID)
+	suite.ElementsMatch([]string{"Bob", "Chloé"}, poll.Slots[0].Yes)
+	suite.Equal([]string{"Alice"}, poll.Slots[0].IfNeedBe)
+	suite.Equal(monday, poll.Slots[1].OptionID)
+	suite.Equal([]string{"Bob"}, poll.Slots[1].No)
+
+	w = send("GET", pollURL+"/calendar.ics", nil, "198.51.100.101:1234")
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	suite.Equal("text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+	suite.Contains(w.Body.String(), "\r\nDTSTART:20240116T140000Z\r\n")
+	suite.Contains(w.Body.String(), "\r\nDTEND:20240116T153000Z\r\n")
+	suite.Contains(w.Body.String(), "\r\nSUMMARY:Sprint review\r\n")
+}
+
+func (suite *APITestSuite) TestSignUpSheet() {
+	send := func(method, url string, payload interface{}, remoteAddr string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req, _ := http.NewRequest(method, url, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/v1/polls", map[string]interface{}{
+		"title": "Workshops", "options": []string{"Kubernetes 101", "Go tour"},
+		"max_votes": []int{1, 0}, "waitlist": true,
+	}, "198.51.100.100:1234")
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		ID uuid.UUID `json:"id"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	pollURL := "/api/v1/polls/" + created.ID.String()
+
+	var poll entity.Poll
+	w = send("GET", pollURL, nil, "198.51.100.101:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	workshop := poll.Options[0].ID
+	suite.Require().NotNil(poll.Options[0].Remaining)
+	suite.Equal(1, *poll.Options[0].Remaining)
+	suite.Nil(poll.Options[1].MaxVotes)
+
+	// Une option répétée ne réserve pas plusieurs places
+	w = send("POST", pollURL+"/vote", map[string]interface{}{"option_ids": []string{workshop.String(), workshop.String()}}, "198.51.100.101:1234")
+	suite.Require().Equal(http.StatusBadRequest, w.Code)
+	var p problem.Problem
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &p))
+	suite.Require().Len(p.Errors, 1)
+	suite.Equal("option_ids", p.Errors[0].Field)
+	suite.Equal("unique", p.Errors[0].Rule)
+
+	w = send("POST", pollURL+"/vote", map[string]interface{}{"option_ids": []string{workshop.String()}}, "198.51.100.101:1234")
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	suite.NotContains(w.Body.String(), "waitlisted")
+
+	// Atelier complet : les suivants passent en liste d'attente, dans l'ordre
+	for i, remoteAddr := range []string{"198.51.100.102:1234", "198.51.100.103:1234"} {
+		w = send("POST", pollURL+"/vote", map[string]interface{}{"option_ids": []string{workshop.String()}}, remoteAddr)
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+		var response handler.VoteResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Require().Len(response.Waitlisted, 1)
+		suite.Equal(i+1, response.Waitlisted[0].Rank)
+	}
+	w = send("POST", pollURL+"/vote", map[string]interface{}{"option_ids": []string{workshop.String()}}, "198.51.100.102:1234")
+	suite.Equal(http.StatusConflict, w.Code)
+
+	poll = entity.Poll{}
+	w = send("GET", pollURL, nil, "198.51.100.101:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	suite.Equal(1, poll.Options[0].VoteCount)
+	suite.Equal(0, *poll.Options[0].Remaining)
+	suite.Equal(2, poll.Options[0].Waitlisted)
+
+	// Le premier inscrit se désiste : la première personne en attente prend sa place
+	w = send("DELETE", pollURL+"/vote", nil, "198.51.100.101:1234")
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	suite.JSONEq(`{"message":"vote withdrawn successfully","promoted":1}`, w.Body.String())
+	w = send("DELETE", pollURL+"/vote", nil, "198.51.100.101:1234")
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Contains(w.Body.String(), "vote_not_found")
+
+	var votes []entity.Vote
+	suite.Require().NoError(suite.db.Where("poll_id = ?", created.ID).Find(&votes).Error)
+	suite.Require().Len(votes, 1)
+	suite.Equal("198.51.100.102", votes[0].VoterID)
+
+	poll = entity.Poll{}
+	w = send("GET", pollURL, nil, "198.51.100.101:1234")
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &poll))
+	suite.Equal(1, poll.Options[0].VoteCount)
+	suite.Equal(1, poll.Options[0].Waitlisted)
+}
+
+func (suite *APITestSuite) TestQuiz() {
+	const creatorIP = "198.51.100.120:1234"
+	// Toute la salle joue derrière la même adresse IP
+	const roomIP = "198.51.100.121:1234"
+	send := func(method, url string, payload interface{}, remoteAddr string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		if payload != nil {
+			json.NewEncoder(&body).Encode(payload)
+		}
+		req, _ := http.NewRequest(method, url, &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/v1/quizzes", map[string]interface{}{
+		"title": "Onboarding quiz",
+		"questions": []map[string]interface{}{
+			{"title": "Which command formats Go code?", "options": []string{"go vet", "gofmt"}, "correct": []int{1}},
+			{"title": "Which are Go keywords?", "options": []string{"defer", "select", "lambda"}, "correct": []int{0, 1}, "time_limit": 30},
+		},
+	}, creatorIP)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		ID       uuid.UUID `json:"id"`
+		ShareURL string    `json:"share_url"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	suite.Equal("http://localhost:8080/quiz/"+created.ID.String(), created.ShareURL)
+	quizURL := "/api/v1/quizzes/" + created.ID.String()
+
+	var quiz entity.Quiz
+	w = send("GET", quizURL, nil, roomIP)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &quiz))
+	suite.Nil(quiz.CurrentQuestion)
+	suite.Empty(quiz.Questions)
+
+	join := func(nickname string) uuid.UUID {
+		w := send("POST", quizURL+"/players", map[string]string{"nickname": nickname}, roomIP)
+		suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+		var player entity.QuizPlayer
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &player))
+		return player.ID
+	}
+	ada, grace := join("Ada"), join("Grace")
+	w = send("POST", quizURL+"/players", map[string]string{"nickname": "ada"}, roomIP)
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Contains(w.Body.String(), "nickname_taken")
+
+	w = send("POST", quizURL+"/next", nil, roomIP)
+	suite.Equal(http.StatusForbidden, w.Code)
+	suite.Contains(w.Body.String(), "not_quiz_creator")
+
+	w = send("POST", quizURL+"/next", nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var step struct {
+		Ended       *entity.QuestionResult `json:"ended"`
+		Started     *entity.QuestionStart  `json:"started"`
+		Leaderboard *entity.Leaderboard    `json:"leaderboard"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &step))
+	suite.Require().NotNil(step.Started)
+	suite.Nil(step.Ended)
+	first := step.Started.Question
+	suite.Equal(20, step.Started.TimeLimit)
+	suite.NotContains(w.Body.String(), `"correct":true`)
+
+	// Ni le quiz ni le sondage de la question ne trahissent la bonne réponse
+	w = send("GET", quizURL, nil, roomIP)
+	suite.NotContains(w.Body.String(), `"correct":true`)
+	w = send("GET", "/api/v1/polls/"+first.ID.String(), nil, roomIP)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.NotContains(w.Body.String(), `"correct":true`)
+	w = send("POST", "/api/v1/polls/"+first.ID.String()+"/vote", map[string]interface{}{
+		"option_ids": []string{first.Options[1].ID.String()},
+	}, roomIP)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "quiz_question")
+
+	answer := func(player uuid.UUID, option int) *httptest.ResponseRecorder {
+		return send("POST", quizURL+"/answers", map[string]interface{}{
+			"player_id": player, "question_id": first.ID, "option_ids": []uuid.UUID{first.Options[option].ID},
+		}, roomIP)
+	}
+	w = answer(ada, 1)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	suite.NotContains(w.Body.String(), "points")
+	w = answer(grace, 0)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	w = answer(ada, 0)
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Contains(w.Body.String(), "already_answered")
+	w = answer(uuid.New(), 1)
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	// Tant que la question est ouverte, le classement ignore ses points
+	var board entity.Leaderboard
+	w = send("GET", quizURL+"/leaderboard", nil, roomIP)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &board))
+	suite.Require().Len(board.Entries, 2)
+	suite.Equal(0, board.Entries[0].Score)
+
+	w = send("POST", quizURL+"/close", nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	step.Ended, step.Started, step.Leaderboard = nil, nil, nil
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &step))
+	suite.Require().NotNil(step.Ended)
+	suite.Equal([]uuid.UUID{first.Options[1].ID}, step.Ended.Correct)
+	suite.Equal(2, step.Ended.Answers)
+	suite.Equal(1, step.Ended.CorrectAnswers)
+	suite.Require().Len(step.Leaderboard.Entries, 2)
+	suite.Equal("Ada", step.Leaderboard.Entries[0].Nickname)
+	suite.Greater(step.Leaderboard.Entries[0].Score, 500)
+	suite.Equal(0, step.Leaderboard.Entries[1].Score)
+	suite.False(step.Leaderboard.Final)
+
+	// Hors délai : refusée même si le joueur n'a pas encore vu la clôture
+	w = send("POST", quizURL+"/players", map[string]string{"nickname": "Linus"}, roomIP)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var linus entity.QuizPlayer
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &linus))
+	w = answer(linus.ID, 1)
+	suite.Equal(http.StatusGone, w.Code)
+	suite.Contains(w.Body.String(), "question_closed")
+	w = send("POST", quizURL+"/close", nil, creatorIP)
+	suite.Equal(http.StatusGone, w.Code)
+
+	w = send("POST", quizURL+"/next", nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	step.Ended, step.Started, step.Leaderboard = nil, nil, nil
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &step))
+	suite.Require().NotNil(step.Started)
+	suite.Equal(1, step.Started.Question.Position)
+	suite.True(step.Started.Question.MultiChoice)
+	suite.Equal(30, step.Started.TimeLimit)
+
+	w = send("GET", quizURL, nil, roomIP)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &quiz))
+	suite.Require().Len(quiz.Questions, 2)
+	suite.True(quiz.Questions[0].Options[1].Correct)
+	suite.False(quiz.Questions[1].Options[0].Correct)
+
+	// Suivant après la dernière question : fin du quiz et classement final
+	w = send("POST", quizURL+"/next", nil, creatorIP)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	step.Ended, step.Started, step.Leaderboard = nil, nil, nil
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &step))
+	suite.NotNil(step.Ended)
+	suite.Nil(step.Started)
+	suite.Require().NotNil(step.Leaderboard)
+	suite.True(step.Leaderboard.Final)
+	suite.Len(step.Leaderboard.Entries, 3)
+
+	w = send("POST", quizURL+"/next", nil, creatorIP)
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Contains(w.Body.String(), "quiz_finished")
+	w = send("POST", quizURL+"/players", map[string]string{"nickname": "Ken"}, roomIP)
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *APITestSuite) TestVoteErrors() {
+	expired := time.Now().Add(-time.Hour)
+	closed := &entity.Poll{Title: "Closed poll", ExpiresAt: &expired, Options: []entity.Option{{Text: "A"}, {Text: "B"}}}
+	suite.Require().NoError(suite.db.Create(closed).Error)
+
+	vote := func(pollID uuid.UUID, optionID uuid.UUID) problem.Problem {
+		body, _ := json.Marshal(map[string]interface{}{"option_ids": []string{optionID.String()}})
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/polls/%s/vote", pollID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "198.51.100.20:1234"
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		var response problem.Problem
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(w.Code, response.Status)
+		return response
+	}
+
+	missing := vote(uuid.New(), uuid.New())
+	suite.Equal(http.StatusNotFound, missing.Status)
+	suite.Equal("poll_not_found", missing.Code)
+
+	gone := vote(closed.ID, closed.Options[0].ID)
+	suite.Equal(http.StatusGone, gone.Status)
+	suite.Equal("poll_expired", gone.Code)
 }
 
 func (suite *APITestSuite) TestHealthCheck() {
@@ -261,6 +1045,284 @@ func (suite *APITestSuite) TestHealthCheck() {
 	suite.Equal("QuickPoll API", response["service"])
 }
 
+func (suite *APITestSuite) TestLiveness() {
+	req, err := http.NewRequest("GET", "/livez", nil)
+	suite.Require().NoError(err)
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *APITestSuite) TestReadiness() {
+	var response handler.HealthResponse
+
+	// The hub goroutine records its first heartbeat asynchronously
+	suite.Eventually(func() bool {
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+		response = handler.HealthResponse{}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code == http.StatusOK
+	}, 2*time.Second, 20*time.Millisecond)
+
+	suite.Equal("ready", response.Status)
+	suite.Equal("up", response.Checks["database"].Status)
+	suite.Equal("up", response.Checks["migration"].Status)
+	suite.Equal("up", response.Checks["websocket"].Status)
+	suite.NotContains(response.Checks, "redis")
+}
+
+func (suite *APITestSuite) TestReadinessDuringShutdown() {
+	// Use a dedicated router so the shared one stays ready for the other tests
+	router := gin.New()
+	services := route.SetupRoutes(router, database.NewResolver(suite.db), "http://localhost:8080", &config.Config{}, nil)
+	services.Health.MarkShuttingDown()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	suite.Equal(http.StatusServiceUnavailable, w.Code)
+
+	var response handler.HealthResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("shutting_down", response.Status)
+}
+
+func (suite *APITestSuite) TestAdminRequiresCredentials() {
+	for _, key := range []string{"", "wrong-key"} {
+		req, err := http.NewRequest("GET", "/api/v1/admin/polls", nil)
+		suite.Require().NoError(err)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		suite.Equal(http.StatusUnauthorized, w.Code)
+	}
+}
+
+func (suite *APITestSuite) TestAdminBanBlocksVoteAndIsAudited() {
+	poll := &entity.Poll{
+		Title:   "Banned voter",
+		Options: []entity.Option{{Text: "Option 1"}, {Text: "Option 2"}},
+	}
+	suite.Require().NoError(suite.db.Create(poll).Error)
+
+	banData, err := json.Marshal(map[string]interface{}{"kind": "ip", "value": "192.0.2.1", "reason": "flooding"})
+	suite.Require().NoError(err)
+	req, err := http.NewRequest("POST", "/api/v1/admin/bans", bytes.NewBuffer(banData))
+	suite.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", adminKey)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+
+	voteData, err := json.Marshal(map[string]interface{}{"option_ids": []string{poll.Options[0].ID.String()}})
+	suite.Require().NoError(err)
+	req, err = http.NewRequest("POST", fmt.Sprintf("/api/v1/polls/%s/vote", poll.ID), bytes.NewBuffer(voteData))
+	suite.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "192.0.2.1:40000"
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Equal(http.StatusForbidden, w.Code)
+
+	var event entity.AuditEvent
+	suite.Require().NoError(suite.db.Where("action = ?", entity.AuditBanCreated).First(&event).Error)
+	suite.Equal("api-key:ops", event.Actor)
+}
+
+func (suite *APITestSuite) TestAdminClosePoll() {
+	poll := &entity.Poll{
+		Title:   "Abusive poll",
+		Options: []entity.Option{{Text: "Option 1"}, {Text: "Option 2"}},
+	}
+	suite.Require().NoError(suite.db.Create(poll).Error)
+
+	for _, want := range []int{http.StatusNoContent, http.StatusConflict} {
+		req, err := http.NewRequest("POST", fmt.Sprintf("/api/v1/admin/polls/%s/close", poll.ID), nil)
+		suite.Require().NoError(err)
+		req.Header.Set("X-API-Key", adminKey)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		suite.Equal(want, w.Code)
+	}
+
+	var reloaded entity.Poll
+	suite.Require().NoError(suite.db.First(&reloaded, "id = ?", poll.ID).Error)
+	suite.True(reloaded.IsExpired())
+}
+
+func (suite *APITestSuite) TestPollHistory() {
+	send := func(method, path string, body interface{}, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			var err error
+			payload, err = json.Marshal(body)
+			suite.Require().NoError(err)
+		}
+		req, err := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		suite.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("POST", "/api/v1/polls", map[string]interface{}{
+		"title":   "Audited poll",
+		"options": []string{"Go", "Rust"},
+	}, "198.51.100.7:40000", nil)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var created poll.CreatePollOutput
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	pollPath := "/api/v1/polls/" + created.ID.String()
+
+	w = send("PATCH", pollPath, map[string]interface{}{"title": "Renamed poll"}, "198.51.100.8:40000", nil)
+	suite.Equal(http.StatusForbidden, w.Code)
+	w = send("PATCH", pollPath, map[string]interface{}{"title": "Renamed poll"}, "198.51.100.7:40000", nil)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	w = send("GET", pollPath+"/history", nil, "198.51.100.8:40000", nil)
+	suite.Equal(http.StatusForbidden, w.Code)
+	w = send("GET", pollPath+"/history", nil, "198.51.100.7:40000", nil)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var page poll.HistoryPage
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &page))
+	suite.Require().Len(page.Entries, 2)
+	suite.Equal(entity.AuditPollCreated, page.Entries[0].Action)
+	suite.Equal(entity.AuditPollUpdated, page.Entries[1].Action)
+	suite.JSONEq(`{"title":{"from":"Audited poll","to":"Renamed poll"}}`, string(page.Entries[1].Changes))
+
+	// Les administrateurs voient aussi l'historique d'un sondage supprimé
+	w = send("DELETE", "/api/v1/admin/polls/"+created.ID.String(), nil, "", map[string]string{"X-API-Key": adminKey})
+	suite.Require().Equal(http.StatusNoContent, w.Code)
+	w = send("GET", "/api/v1/admin/polls/"+created.ID.String()+"/history", nil, "", map[string]string{"X-API-Key": adminKey})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &page))
+	suite.Require().Len(page.Entries, 3)
+	suite.Equal(entity.AuditPollDeleted, page.Entries[2].Action)
+	suite.Equal("api-key:ops", page.Entries[2].Actor)
+}
+
+func (suite *APITestSuite) TestAPIKeyCreatesPolls() {
+	send := func(method, path string, body interface{}, headers map[string]string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			var err error
+			payload, err = json.Marshal(body)
+			suite.Require().NoError(err)
+		}
+		req, err := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		suite.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+	admin := map[string]string{"X-API-Key": adminKey}
+	newPoll := map[string]interface{}{"title": "Sprint 42 retro", "options": []string{"Good", "Bad"}}
+
+	w := send("POST", "/api/v1/admin/api-keys", map[string]interface{}{
+		"name":   "retro-bot",
+		"scopes": []string{"polls:write"},
+	}, admin)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var key struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &key))
+	bearer := map[string]string{"Authorization": "Bearer " + key.Key}
+
+	w = send("POST", "/api/v1/polls", newPoll, bearer)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var created poll.CreatePollOutput
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+
+	var stored entity.Poll
+	suite.Require().NoError(suite.db.First(&stored, "id = ?", created.ID).Error)
+	suite.Equal("apikey:"+key.ID, stored.CreatedBy)
+
+	// Sans le scope export, la clé ne peut pas exporter ses propres sondages
+	w = send("GET", "/api/v1/polls/"+created.ID.String()+"/export", nil, bearer)
+	suite.Equal(http.StatusForbidden, w.Code)
+	w = send("GET", "/api/v1/polls/"+created.ID.String()+"/history", nil, bearer)
+	suite.Equal(http.StatusOK, w.Code)
+
+	w = send("DELETE", "/api/v1/admin/api-keys/"+key.ID, nil, admin)
+	suite.Require().Equal(http.StatusNoContent, w.Code)
+	w = send("POST", "/api/v1/polls", newPoll, bearer)
+	suite.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (suite *APITestSuite) TestIdempotentRetries() {
+	// Routeur dédié : l'idempotence n'est active qu'avec un cache
+	store := cache.NewMemoryCache()
+	defer store.Close()
+	router := gin.New()
+	route.SetupRoutes(router, database.NewResolver(suite.db), "http://localhost:8080", &config.Config{}, store)
+
+	send := func(path, key string, body interface{}) *httptest.ResponseRecorder {
+		payload, err := json.Marshal(body)
+		suite.Require().NoError(err)
+		req, err := http.NewRequest("POST", path, bytes.NewBuffer(payload))
+		suite.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		req.RemoteAddr = "198.51.100.20:40000"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	newPoll := map[string]interface{}{"title": "Retried poll", "options": []string{"A", "B"}}
+
+	first := send("/api/v1/polls", "create-1", newPoll)
+	suite.Require().Equal(http.StatusCreated, first.Code)
+	retry := send("/api/v1/polls", "create-1", newPoll)
+	suite.Equal(http.StatusCreated, retry.Code)
+	suite.Equal("true", retry.Header().Get("Idempotent-Replayed"))
+	suite.JSONEq(first.Body.String(), retry.Body.String())
+
+	var count int64
+	suite.Require().NoError(suite.db.Model(&entity.Poll{}).Where("title = ?", "Retried poll").Count(&count).Error)
+	suite.Equal(int64(1), count)
+
+	// Même clé, autre corps
+	w := send("/api/v1/polls", "create-1", map[string]interface{}{"title": "Other poll", "options": []string{"A", "B"}})
+	suite.Equal(http.StatusConflict, w.Code)
+
+	// Un vote renvoyé reçoit la réponse du premier essai, pas « déjà voté »
+	var created poll.CreatePollOutput
+	suite.Require().NoError(json.Unmarshal(first.Body.Bytes(), &created))
+	var stored entity.Poll
+	suite.Require().NoError(suite.db.Preload("Options").First(&stored, "id = ?", created.ID).Error)
+	vote := map[string]interface{}{"option_ids": []string{stored.Options[0].ID.String()}}
+	votePath := fmt.Sprintf("/api/v1/polls/%s/vote", created.ID)
+
+	first = send(votePath, "vote-1", vote)
+	suite.Require().Equal(http.StatusOK, first.Code)
+	retry = send(votePath, "vote-1", vote)
+	suite.Equal(http.StatusOK, retry.Code)
+	suite.Equal("true", retry.Header().Get("Idempotent-Replayed"))
+	w = send(votePath, "vote-2", vote)
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Empty(w.Header().Get("Idempotent-Replayed"))
+}
+
 func TestAPITestSuite(t *testing.T) {
 	suite.Run(t, new(APITestSuite))
 }
\ No newline at end of file